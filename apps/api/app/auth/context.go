@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+// WithUserID は認証済みユーザーのIDを ctx に設定する
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, contextKey{}, userID)
+}
+
+// UserIDFromContext は ctx に設定された認証済みユーザーのIDを返す
+// 未認証（バックグラウンドジョブなど）の場合は false を返す
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(contextKey{}).(int)
+	return userID, ok
+}
+
+type scopesContextKey struct{}
+
+// WithScopes はAPIキーで認証したリクエストに許可されたスコープを ctx に設定する
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesContextKey{}, scopes)
+}
+
+// ScopesFromContext は ctx に設定されたスコープを返す
+// スコープの制限がない（JWTで認証したユーザーなど）場合は false を返す
+func ScopesFromContext(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesContextKey{}).([]string)
+	return scopes, ok
+}
+
+// HasScope は ctx で scope の操作が許可されているかを返す
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, restricted := ScopesFromContext(ctx)
+	if !restricted {
+		return true
+	}
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type apiKeyIDContextKey struct{}
+
+// WithAPIKeyID はリクエストの認証に使ったAPIキーのIDを ctx に設定する
+func WithAPIKeyID(ctx context.Context, apiKeyID int) context.Context {
+	return context.WithValue(ctx, apiKeyIDContextKey{}, apiKeyID)
+}
+
+// APIKeyIDFromContext は ctx に設定されたAPIキーのIDを返す
+// APIキー以外（JWTなど）で認証した場合は false を返す
+func APIKeyIDFromContext(ctx context.Context) (int, bool) {
+	apiKeyID, ok := ctx.Value(apiKeyIDContextKey{}).(int)
+	return apiKeyID, ok
+}
+
+type workspaceIDContextKey struct{}
+
+// WithWorkspaceID はリクエストで操作するワークスペースのIDを ctx に設定する
+func WithWorkspaceID(ctx context.Context, workspaceID int) context.Context {
+	return context.WithValue(ctx, workspaceIDContextKey{}, workspaceID)
+}
+
+// WorkspaceIDFromContext は ctx に設定されたワークスペースのIDを返す
+// 設定されていない場合は false を返す（ワークスペース単位のデータにはアクセスできない）
+func WorkspaceIDFromContext(ctx context.Context) (int, bool) {
+	workspaceID, ok := ctx.Value(workspaceIDContextKey{}).(int)
+	return workspaceID, ok
+}
@@ -0,0 +1,291 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 署名アルゴリズム
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+	ErrUnknownKey   = errors.New("unknown signing key")
+)
+
+// Key はJWTの検証鍵（HS256 は共有シークレット、RS256 は公開鍵）
+type Key struct {
+	ID        string
+	Algorithm string
+	secret    []byte
+	publicKey *rsa.PublicKey
+}
+
+// NewHS256Key はHS256の共有シークレットから検証鍵を作成する
+func NewHS256Key(id string, secret []byte) (Key, error) {
+	// RFC 7518 3.2: ハッシュ長以上の鍵長が必要
+	if len(secret) < sha256.Size {
+		return Key{}, fmt.Errorf("HS256 secret for key %q must be at least %d bytes", id, sha256.Size)
+	}
+	return Key{ID: id, Algorithm: AlgHS256, secret: secret}, nil
+}
+
+// NewRS256Key はPEM形式のRSA公開鍵から検証鍵を作成する
+func NewRS256Key(id string, pemBytes []byte) (Key, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return Key{}, fmt.Errorf("RS256 key %q is not PEM encoded", id)
+	}
+
+	var publicKey *rsa.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return Key{}, fmt.Errorf("failed to parse RS256 key %q: %w", id, err)
+		}
+		rsaKey, ok := parsed.(*rsa.PublicKey)
+		if !ok {
+			return Key{}, fmt.Errorf("RS256 key %q is not an RSA public key", id)
+		}
+		publicKey = rsaKey
+	case "RSA PUBLIC KEY":
+		parsed, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return Key{}, fmt.Errorf("failed to parse RS256 key %q: %w", id, err)
+		}
+		publicKey = parsed
+	default:
+		return Key{}, fmt.Errorf("RS256 key %q has unsupported PEM type %q", id, block.Type)
+	}
+
+	if publicKey.N.BitLen() < 2048 {
+		return Key{}, fmt.Errorf("RS256 key %q must be at least 2048 bits", id)
+	}
+	return Key{ID: id, Algorithm: AlgRS256, publicKey: publicKey}, nil
+}
+
+// NewRS256PublicKey は *rsa.PublicKey から検証鍵を作成する（JWKSなどPEM以外で取得した鍵用）
+func NewRS256PublicKey(id string, publicKey *rsa.PublicKey) Key {
+	return Key{ID: id, Algorithm: AlgRS256, publicKey: publicKey}
+}
+
+func (k Key) verify(signingInput, signature []byte) bool {
+	switch k.Algorithm {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(signingInput)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case AlgRS256:
+		digest := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(k.publicKey, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
+
+// Claims はJWTのペイロードのうち検証に使う項目
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  Audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	IssuedAt  int64    `json:"iat"`
+}
+
+// UserID は sub クレームをユーザーIDとして返す
+func (c *Claims) UserID() (int, error) {
+	userID, err := strconv.Atoi(c.Subject)
+	if err != nil || userID <= 0 {
+		return 0, fmt.Errorf("%w: sub must be a positive user id", ErrInvalidToken)
+	}
+	return userID, nil
+}
+
+// Audience は文字列と文字列配列の両方を受け付ける aud クレーム
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+func (a Audience) contains(audience string) bool {
+	for _, v := range a {
+		if v == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// JWTVerifier は設定された鍵セットでJWTを検証する
+type JWTVerifier struct {
+	keys     []Key
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// JWTVerifierOption は JWTVerifier の検証条件を設定する
+type JWTVerifierOption func(*JWTVerifier)
+
+// WithIssuer は iss クレームが issuer と一致することを要求する
+func WithIssuer(issuer string) JWTVerifierOption {
+	return func(v *JWTVerifier) { v.issuer = issuer }
+}
+
+// WithAudience は aud クレームに audience が含まれることを要求する
+func WithAudience(audience string) JWTVerifierOption {
+	return func(v *JWTVerifier) { v.audience = audience }
+}
+
+// WithLeeway は exp / nbf の検証で許容する時計のずれを設定する
+func WithLeeway(leeway time.Duration) JWTVerifierOption {
+	return func(v *JWTVerifier) { v.leeway = leeway }
+}
+
+// WithClock は現在時刻の取得方法を差し替える（テスト用）
+func WithClock(now func() time.Time) JWTVerifierOption {
+	return func(v *JWTVerifier) { v.now = now }
+}
+
+func NewJWTVerifier(keys []Key, opts ...JWTVerifierOption) *JWTVerifier {
+	v := &JWTVerifier{
+		keys: keys,
+		now:  time.Now,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Type      string `json:"typ"`
+}
+
+// Verify はトークンの署名と exp / nbf / iss / aud を検証し、クレームを返す
+func (v *JWTVerifier) Verify(token string) (*Claims, error) {
+	return v.VerifyWithClaims(token, nil)
+}
+
+// VerifyWithClaims は Verify と同様に検証し、extra が nil でなければペイロードを extra にもデコードする
+// OIDC の ID トークンの nonce や email など、Claims にない項目を取り出すために使う
+func (v *JWTVerifier) VerifyWithClaims(token string, extra interface{}) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidToken)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+
+	// alg は鍵側で固定し、トークンの alg と一致する鍵でのみ検証する（alg: none や HS/RS の取り違えを防ぐ）
+	keys := v.candidateKeys(header)
+	if len(keys) == 0 {
+		return nil, ErrUnknownKey
+	}
+	signingInput := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range keys {
+		if key.verify(signingInput, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	if err := v.validateClaims(&claims); err != nil {
+		return nil, err
+	}
+	if extra != nil {
+		if err := decodeSegment(parts[1], extra); err != nil {
+			return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+		}
+	}
+	return &claims, nil
+}
+
+func (v *JWTVerifier) candidateKeys(header jwtHeader) []Key {
+	var keys []Key
+	for _, key := range v.keys {
+		if key.Algorithm != header.Algorithm {
+			continue
+		}
+		// kid が指定されている場合はその鍵のみ、未指定の場合は同じアルゴリズムの全ての鍵を試す
+		if header.KeyID != "" && key.ID != header.KeyID {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (v *JWTVerifier) validateClaims(claims *Claims) error {
+	now := v.now()
+	// exp は必須（失効しないトークンは受け付けない）
+	if claims.ExpiresAt == 0 {
+		return fmt.Errorf("%w: exp is required", ErrInvalidToken)
+	}
+	if !now.Before(time.Unix(claims.ExpiresAt, 0).Add(v.leeway)) {
+		return ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Add(v.leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return fmt.Errorf("%w: token not yet valid", ErrInvalidToken)
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.audience != "" && !claims.Audience.contains(v.audience) {
+		return fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// JWTSigner はログインしたユーザーのアクセストークン（HS256 のJWT）を発行する
+type JWTSigner struct {
+	key      Key
+	issuer   string
+	audience string
+	ttl      time.Duration
+}
+
+// NewJWTSigner は key で署名し、ttl の間有効なアクセストークンを発行する JWTSigner を作成する
+// issuer / audience を指定した場合は iss / aud クレームに含める（JWTVerifier の検証条件と合わせる）
+func NewJWTSigner(key Key, issuer, audience string, ttl time.Duration) (*JWTSigner, error) {
+	if key.Algorithm != AlgHS256 {
+		return nil, fmt.Errorf("JWT signing key %q must be HS256", key.ID)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("access token ttl must be positive")
+	}
+	return &JWTSigner{key: key, issuer: issuer, audience: audience, ttl: ttl}, nil
+}
+
+// IssueAccessToken は userID を sub に持つアクセストークンと、その有効期限を返す
+func (s *JWTSigner) IssueAccessToken(userID int, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(s.ttl)
+	claims := Claims{
+		Subject:   strconv.Itoa(userID),
+		Issuer:    s.issuer,
+		ExpiresAt: expiresAt.Unix(),
+		IssuedAt:  now.Unix(),
+	}
+	if s.audience != "" {
+		claims.Audience = Audience{s.audience}
+	}
+
+	header, err := encodeSegment(jwtHeader{Algorithm: AlgHS256, KeyID: s.key.ID, Type: "JWT"})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	payload, err := encodeSegment(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	signingInput := header + "." + payload
+	mac := hmac.New(sha256.New, s.key.secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), expiresAt, nil
+}
+
+func encodeSegment(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
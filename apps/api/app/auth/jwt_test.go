@@ -0,0 +1,261 @@
+package auth_test
+
+import (
+	"api/app/auth"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSecret は HS256 の最小の鍵長（32バイト）のシークレット
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// segment は v を JWT のヘッダーまたはペイロードの形式にエンコードする
+func segment(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signHS256 は header と claims を secret で HS256 署名したトークンを返す
+func signHS256(t *testing.T, secret []byte, header, claims map[string]interface{}) string {
+	t.Helper()
+	signingInput := segment(t, header) + "." + segment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signRS256 は header と claims を privateKey で RS256 署名したトークンを返す
+func signRS256(t *testing.T, privateKey *rsa.PrivateKey, header, claims map[string]interface{}) string {
+	t.Helper()
+	signingInput := segment(t, header) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestNewHS256Key(t *testing.T) {
+	_, err := auth.NewHS256Key("short", testSecret[:31])
+	assert.Error(t, err)
+
+	key, err := auth.NewHS256Key("ok", testSecret)
+	require.NoError(t, err)
+	assert.Equal(t, auth.AlgHS256, key.Algorithm)
+}
+
+func TestJWTVerifier(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	hsKey, err := auth.NewHS256Key("hs", testSecret)
+	require.NoError(t, err)
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	rsKey, err := auth.NewRS256Key("rs", publicPEM)
+	require.NoError(t, err)
+
+	// claims は now を基準にした有効なクレームに overrides を上書きしたものを返す（値が nil の項目は削除する）
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub": "42",
+			"iss": "https://issuer.example.com",
+			"aud": "todo-api",
+			"exp": now.Add(time.Minute).Unix(),
+			"iat": now.Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+	hsHeader := map[string]interface{}{"alg": "HS256", "typ": "JWT", "kid": "hs"}
+	rsHeader := map[string]interface{}{"alg": "RS256", "typ": "JWT", "kid": "rs"}
+
+	tests := []struct {
+		name    string
+		keys    []auth.Key
+		opts    []auth.JWTVerifierOption
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "Valid HS256 token",
+			keys:  []auth.Key{hsKey},
+			token: signHS256(t, testSecret, hsHeader, claims(nil)),
+		},
+		{
+			name:  "Valid RS256 token",
+			keys:  []auth.Key{hsKey, rsKey},
+			token: signRS256(t, privateKey, rsHeader, claims(nil)),
+		},
+		{
+			name:  "Token without kid tries every key of the algorithm",
+			keys:  []auth.Key{hsKey},
+			token: signHS256(t, testSecret, map[string]interface{}{"alg": "HS256"}, claims(nil)),
+		},
+		{
+			// RS256 の公開鍵を HS256 のシークレットとして署名したトークン
+			name:    "HS256 token signed with the RS256 public key",
+			keys:    []auth.Key{rsKey},
+			token:   signHS256(t, publicPEM, map[string]interface{}{"alg": "HS256", "kid": "rs"}, claims(nil)),
+			wantErr: auth.ErrUnknownKey,
+		},
+		{
+			name:    "HS256 token signed with the RS256 public key when an HS256 key is also configured",
+			keys:    []auth.Key{hsKey, rsKey},
+			token:   signHS256(t, publicPEM, map[string]interface{}{"alg": "HS256"}, claims(nil)),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "alg none",
+			keys:    []auth.Key{hsKey},
+			token:   segment(t, map[string]interface{}{"alg": "none"}) + "." + segment(t, claims(nil)) + ".",
+			wantErr: auth.ErrUnknownKey,
+		},
+		{
+			name:    "Unknown kid",
+			keys:    []auth.Key{hsKey},
+			token:   signHS256(t, testSecret, map[string]interface{}{"alg": "HS256", "kid": "rotated-out"}, claims(nil)),
+			wantErr: auth.ErrUnknownKey,
+		},
+		{
+			// kid の鍵のアルゴリズムとトークンの alg が一致しない
+			name:    "kid of a key with another algorithm",
+			keys:    []auth.Key{hsKey, rsKey},
+			token:   signHS256(t, testSecret, map[string]interface{}{"alg": "HS256", "kid": "rs"}, claims(nil)),
+			wantErr: auth.ErrUnknownKey,
+		},
+		{
+			name:    "Signature by another secret",
+			keys:    []auth.Key{hsKey},
+			token:   signHS256(t, []byte("another-secret-another-secret-00"), hsHeader, claims(nil)),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Malformed token",
+			keys:    []auth.Key{hsKey},
+			token:   "not-a-jwt",
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Missing exp",
+			keys:    []auth.Key{hsKey},
+			token:   signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"exp": nil})),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Expired",
+			keys:    []auth.Key{hsKey},
+			token:   signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"exp": now.Unix()})),
+			wantErr: auth.ErrTokenExpired,
+		},
+		{
+			name:  "Expired within the leeway",
+			keys:  []auth.Key{hsKey},
+			opts:  []auth.JWTVerifierOption{auth.WithLeeway(30 * time.Second)},
+			token: signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"exp": now.Add(-10 * time.Second).Unix()})),
+		},
+		{
+			name:    "Not yet valid",
+			keys:    []auth.Key{hsKey},
+			token:   signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"nbf": now.Add(time.Minute).Unix()})),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "nbf in the past",
+			keys:  []auth.Key{hsKey},
+			token: signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"nbf": now.Add(-time.Minute).Unix()})),
+		},
+		{
+			name:  "Matching iss and aud",
+			keys:  []auth.Key{hsKey},
+			opts:  []auth.JWTVerifierOption{auth.WithIssuer("https://issuer.example.com"), auth.WithAudience("todo-api")},
+			token: signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"aud": []string{"other", "todo-api"}})),
+		},
+		{
+			name:    "iss mismatch",
+			keys:    []auth.Key{hsKey},
+			opts:    []auth.JWTVerifierOption{auth.WithIssuer("https://issuer.example.com")},
+			token:   signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"iss": "https://evil.example.com"})),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "aud mismatch",
+			keys:    []auth.Key{hsKey},
+			opts:    []auth.JWTVerifierOption{auth.WithAudience("todo-api")},
+			token:   signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"aud": []string{"other-api"}})),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Missing aud",
+			keys:    []auth.Key{hsKey},
+			opts:    []auth.JWTVerifierOption{auth.WithAudience("todo-api")},
+			token:   signHS256(t, testSecret, hsHeader, claims(map[string]interface{}{"aud": nil})),
+			wantErr: auth.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]auth.JWTVerifierOption{auth.WithClock(func() time.Time { return now })}, tt.opts...)
+			verifier := auth.NewJWTVerifier(tt.keys, opts...)
+
+			got, err := verifier.Verify(tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			userID, err := got.UserID()
+			require.NoError(t, err)
+			assert.Equal(t, 42, userID)
+		})
+	}
+}
+
+func TestJWTSigner(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	key, err := auth.NewHS256Key("hs", testSecret)
+	require.NoError(t, err)
+	signer, err := auth.NewJWTSigner(key, "https://issuer.example.com", "todo-api", 15*time.Minute)
+	require.NoError(t, err)
+
+	token, expiresAt, err := signer.IssueAccessToken(42, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(15*time.Minute), expiresAt)
+
+	verifier := auth.NewJWTVerifier([]auth.Key{key},
+		auth.WithIssuer("https://issuer.example.com"),
+		auth.WithAudience("todo-api"),
+		auth.WithClock(func() time.Time { return now.Add(14 * time.Minute) }),
+	)
+	claims, err := verifier.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "42", claims.Subject)
+
+	// 有効期限を過ぎたトークンは受け付けない
+	expired := auth.NewJWTVerifier([]auth.Key{key}, auth.WithClock(func() time.Time { return expiresAt }))
+	_, err = expired.Verify(token)
+	assert.ErrorIs(t, err, auth.ErrTokenExpired)
+}
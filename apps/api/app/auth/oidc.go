@@ -0,0 +1,361 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrOIDCDiscovery = errors.New("oidc discovery failed")
+	ErrOIDCExchange  = errors.New("oidc code exchange failed")
+)
+
+// JWKS の再取得の最短間隔（未知の kid のトークンで IdP に負荷をかけないようにする）
+const jwksRefreshInterval = time.Minute
+
+// OIDCConfig は OpenID Connect のクライアント設定
+type OIDCConfig struct {
+	// Issuer は IdP の issuer（{Issuer}/.well-known/openid-configuration から設定を取得する）
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL は認可後に IdP がリダイレクトする URL（IdP に登録したものと一致させる）
+	RedirectURL string
+	Scopes      []string
+	// Leeway は exp の検証で許容する時計のずれ
+	Leeway time.Duration
+}
+
+// OIDCProvider は OpenID Connect の認可コードフロー（PKCE）で IdP と通信する
+// ディスカバリーの結果と JWKS はキャッシュし、未知の kid のトークンを受け取った場合のみ JWKS を再取得する
+type OIDCProvider struct {
+	config     OIDCConfig
+	httpClient *http.Client
+
+	mu            sync.Mutex
+	metadata      *oidcMetadata
+	keys          []Key
+	keysFetchedAt time.Time
+}
+
+// OIDCOption は OIDCProvider の設定を変更する
+type OIDCOption func(*OIDCProvider)
+
+// WithOIDCHTTPClient は IdP との通信に使う HTTP クライアントを差し替える
+func WithOIDCHTTPClient(client *http.Client) OIDCOption {
+	return func(p *OIDCProvider) { p.httpClient = client }
+}
+
+func NewOIDCProvider(config OIDCConfig, opts ...OIDCOption) *OIDCProvider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	p := &OIDCProvider{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// oidcMetadata は OpenID Provider Metadata のうち利用する項目
+type oidcMetadata struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	JWKSURI                       string   `json:"jwks_uri"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+// IDTokenClaims は ID トークンのクレームのうちユーザーの識別とプロビジョニングに使う項目
+type IDTokenClaims struct {
+	Issuer        string    `json:"iss"`
+	Subject       string    `json:"sub"`
+	Email         string    `json:"email"`
+	EmailVerified BoolClaim `json:"email_verified"`
+	Name          string    `json:"name"`
+	Nonce         string    `json:"nonce"`
+	// azp は aud が複数の場合にトークンを要求したクライアントを示す
+	AuthorizedParty string `json:"azp"`
+}
+
+// BoolClaim は真偽値と文字列（"true" / "false"）の両方を受け付けるクレーム（文字列で返す IdP があるため）
+type BoolClaim bool
+
+func (b *BoolClaim) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = BoolClaim(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*b = BoolClaim(text == "true")
+	return nil
+}
+
+// Issuer は設定された issuer を返す（ユーザーの外部IDは issuer と sub の組で識別する）
+func (p *OIDCProvider) Issuer() string {
+	return p.config.Issuer
+}
+
+// AuthCodeURL は IdP の認可エンドポイントの URL を返す
+// codeVerifier から S256 の code_challenge を作成し、トークン取得時に同じ codeVerifier を送る
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid authorization_endpoint", ErrOIDCDiscovery)
+	}
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// Exchange は認可コードをトークンエンドポイントで交換し、ID トークンを返す（検証は VerifyIDToken で行う）
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.config.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrOIDCExchange, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// client_secret_basic（RFC 6749 2.3.1 に従い URL エンコードする）
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.fetchJSON(req, &token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrOIDCExchange, err)
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("%w: status %d: %s %s", ErrOIDCExchange, status, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return "", fmt.Errorf("%w: id_token is missing", ErrOIDCExchange)
+	}
+	return token.IDToken, nil
+}
+
+// VerifyIDToken は ID トークンの署名を JWKS で検証し、iss / aud / exp / nonce を確認してクレームを返す
+func (p *OIDCProvider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	keys, err := p.signingKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims IDTokenClaims
+	verified, err := p.verifier(keys).VerifyWithClaims(rawIDToken, &claims)
+	if errors.Is(err, ErrUnknownKey) {
+		// IdP が署名鍵をローテーションした場合に備えて JWKS を取り直す
+		if keys, err = p.signingKeys(ctx, true); err != nil {
+			return nil, err
+		}
+		verified, err = p.verifier(keys).VerifyWithClaims(rawIDToken, &claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if verified.Subject == "" {
+		return nil, fmt.Errorf("%w: sub is required", ErrInvalidToken)
+	}
+	if len(verified.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("%w: unexpected azp", ErrInvalidToken)
+	}
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+	return &claims, nil
+}
+
+func (p *OIDCProvider) verifier(keys []Key) *JWTVerifier {
+	return NewJWTVerifier(keys,
+		WithIssuer(p.config.Issuer),
+		WithAudience(p.config.ClientID),
+		WithLeeway(p.config.Leeway),
+	)
+}
+
+// discover は {issuer}/.well-known/openid-configuration から IdP の設定を取得する（成功した結果はキャッシュする）
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	wellKnown := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCDiscovery, err)
+	}
+	var metadata oidcMetadata
+	status, err := p.fetchJSON(req, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCDiscovery, err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrOIDCDiscovery, status)
+	}
+
+	// OpenID Connect Discovery 4.3: issuer は設定値と完全に一致しなければならない
+	if metadata.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match %q", ErrOIDCDiscovery, metadata.Issuer, p.config.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: required endpoints are missing", ErrOIDCDiscovery)
+	}
+	if len(metadata.CodeChallengeMethodsSupported) > 0 && !containsString(metadata.CodeChallengeMethodsSupported, "S256") {
+		return nil, fmt.Errorf("%w: PKCE S256 is not supported", ErrOIDCDiscovery)
+	}
+
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// signingKeys はキャッシュした JWKS を返す（refresh の場合は最短間隔を空けて再取得する）
+func (p *OIDCProvider) signingKeys(ctx context.Context, refresh bool) ([]Key, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys != nil && (!refresh || time.Since(p.keysFetchedAt) < jwksRefreshInterval) {
+		return p.keys, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCDiscovery, err)
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	status, err := p.fetchJSON(req, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch jwks: %v", ErrOIDCDiscovery, err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%w: failed to fetch jwks: status %d", ErrOIDCDiscovery, status)
+	}
+
+	keys := []Key{}
+	for _, jwk := range jwks.Keys {
+		// 署名用の RSA 鍵のみ使う（暗号化用の鍵や未対応のアルゴリズムは無視する）
+		if key, ok := jwk.rs256Key(); ok {
+			keys = append(keys, key)
+		}
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	return p.keys, nil
+}
+
+// fetchJSON はリクエストを送ってレスポンスを v にデコードし、ステータスコードを返す
+func (p *OIDCProvider) fetchJSON(req *http.Request, v interface{}) (int, error) {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// jsonWebKey は JWKS の鍵（RFC 7517）のうち RSA 公開鍵に必要な項目
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+func (k jsonWebKey) rs256Key() (Key, bool) {
+	if k.KeyType != "RSA" || (k.Use != "" && k.Use != "sig") || (k.Algorithm != "" && k.Algorithm != AlgRS256) {
+		return Key{}, false
+	}
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return Key{}, false
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return Key{}, false
+	}
+
+	publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	if publicKey.N.BitLen() < 2048 {
+		return Key{}, false
+	}
+	return NewRS256PublicKey(k.KeyID, publicKey), true
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2id のパラメータ（OWASP Password Storage Cheat Sheet の推奨値）
+// 変更してもハッシュにパラメータを含めるため、既存のハッシュはそのまま検証できる
+const (
+	argon2Memory  = 64 * 1024 // KiB
+	argon2Time    = 1
+	argon2Threads = 4
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+// DefaultPasswordHashConcurrency は同時に実行する argon2id の計算の数の初期値
+const DefaultPasswordHashConcurrency = 4
+
+var ErrInvalidPasswordHash = errors.New("invalid password hash")
+
+// passwordHashSlots は同時に実行する argon2id の計算の数を制限する
+// 1回の計算で argon2Memory（64MiB）のメモリを使うため、同時のログインでメモリを使い果たさないようにする
+var passwordHashSlots = make(chan struct{}, DefaultPasswordHashConcurrency)
+
+// SetPasswordHashConcurrency は同時に実行する argon2id の計算の数の上限を設定する（起動時に呼び出す）
+func SetPasswordHashConcurrency(n int) {
+	passwordHashSlots = make(chan struct{}, max(n, 1))
+}
+
+// idKey は同時に実行する数を制限して argon2id の鍵を計算する（上限に達している場合は空くまで待つ）
+func idKey(password, salt []byte, time, memory uint32, threads uint8, keyLen uint32) []byte {
+	slots := passwordHashSlots
+	slots <- struct{}{}
+	defer func() { <-slots }()
+	return argon2.IDKey(password, salt, time, memory, threads, keyLen)
+}
+
+// HashPassword はパスワードを argon2id でハッシュ化し、PHC 形式の文字列を返す
+// 例: $argon2id$v=19$m=65536,t=1,p=4$<salt>$<hash>
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := idKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword はパスワードが HashPassword で作成したハッシュと一致するかを返す
+func VerifyPassword(password, encoded string) (bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return false, ErrInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, ErrInvalidPasswordHash
+	}
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil || memory == 0 || time == 0 || threads == 0 {
+		return false, ErrInvalidPasswordHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, ErrInvalidPasswordHash
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(expected) == 0 {
+		return false, ErrInvalidPasswordHash
+	}
+
+	key := idKey([]byte(password), salt, time, memory, threads, uint32(len(expected)))
+	return subtle.ConstantTimeCompare(key, expected) == 1, nil
+}
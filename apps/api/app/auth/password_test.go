@@ -0,0 +1,47 @@
+package auth_test
+
+import (
+	"api/app/auth"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassword(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse battery staple")
+	require.NoError(t, err)
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=65536,t=1,p=4\$`, hash)
+
+	matched, err := auth.VerifyPassword("correct horse battery staple", hash)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = auth.VerifyPassword("wrong", hash)
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	_, err = auth.VerifyPassword("x", "$argon2id$v=19$m=0,t=1,p=4$c2FsdA$aGFzaA")
+	assert.ErrorIs(t, err, auth.ErrInvalidPasswordHash)
+}
+
+func TestSetPasswordHashConcurrency(t *testing.T) {
+	defer auth.SetPasswordHashConcurrency(auth.DefaultPasswordHashConcurrency)
+
+	// 上限を超えた計算は空くまで待ち、全て完了する
+	auth.SetPasswordHashConcurrency(1)
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = auth.HashPassword("password")
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+}
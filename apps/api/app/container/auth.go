@@ -0,0 +1,99 @@
+package container
+
+import (
+	"api/app/auth"
+	"api/config"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// NewJWTVerifier は設定された鍵セットからJWTの検証器を初期化
+func NewJWTVerifier(cfg *config.Config) (*auth.JWTVerifier, error) {
+	var keys []auth.Key
+	for _, kid := range sortedKeys(cfg.JWTHS256Secrets) {
+		key, err := auth.NewHS256Key(kid, []byte(cfg.JWTHS256Secrets[kid]))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	for _, kid := range sortedKeys(cfg.JWTRS256PublicKeyFiles) {
+		pemBytes, err := os.ReadFile(cfg.JWTRS256PublicKeyFiles[kid])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read RS256 public key %q: %w", kid, err)
+		}
+		key, err := auth.NewRS256Key(kid, pemBytes)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	// 鍵が1つもない場合は全てのトークンを拒否する（認証なしで公開しない）
+	if len(keys) == 0 {
+		log.Println("No JWT keys configured: all authenticated endpoints will return 401")
+	}
+
+	return auth.NewJWTVerifier(keys,
+		auth.WithIssuer(cfg.JWTIssuer),
+		auth.WithAudience(cfg.JWTAudience),
+		auth.WithLeeway(cfg.JWTLeeway),
+	), nil
+}
+
+// NewJWTSigner はパスワードログインで発行するアクセストークンの署名器を初期化
+// JWT_SIGNING_KEY_ID が未設定の場合は nil を返す（パスワードでログインできない）
+func NewJWTSigner(cfg *config.Config) (*auth.JWTSigner, error) {
+	if cfg.JWTSigningKeyID == "" {
+		log.Println("No JWT signing key configured: password login is disabled")
+		return nil, nil
+	}
+
+	// 検証器と同じ鍵で署名し、発行したトークンをそのまま Auth ミドルウェアで検証できるようにする
+	secret, ok := cfg.JWTHS256Secrets[cfg.JWTSigningKeyID]
+	if !ok {
+		return nil, fmt.Errorf("JWT signing key %q is not in JWT_HS256_SECRETS", cfg.JWTSigningKeyID)
+	}
+	key, err := auth.NewHS256Key(cfg.JWTSigningKeyID, []byte(secret))
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewJWTSigner(key, cfg.JWTIssuer, cfg.JWTAudience, cfg.AccessTokenTTL)
+}
+
+// NewOIDCProvider は OpenID Connect でログインする IdP を初期化
+// OIDC_ISSUER が未設定の場合は nil を返す（OpenID Connect でログインできない）
+func NewOIDCProvider(cfg *config.Config) (*auth.OIDCProvider, error) {
+	if cfg.OIDCIssuer == "" {
+		return nil, nil
+	}
+	if cfg.OIDCClientID == "" {
+		return nil, fmt.Errorf("OIDC_CLIENT_ID is required when OIDC_ISSUER is set")
+	}
+
+	redirectURL := cfg.OIDCRedirectURL
+	if redirectURL == "" {
+		redirectURL = strings.TrimRight(cfg.DashboardClientURL, "/") + "/auth/callback"
+	}
+	// ディスカバリーは最初のログインで行う（IdP が停止していても API は起動する）
+	return auth.NewOIDCProvider(auth.OIDCConfig{
+		Issuer:       cfg.OIDCIssuer,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       cfg.OIDCScopes,
+		Leeway:       cfg.JWTLeeway,
+	}), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
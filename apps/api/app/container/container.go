@@ -24,7 +24,7 @@ func NewMockContainer(db *sqlx.DB) *Container {
 	notificationClient := &extMock.MockNotificationClient{}
 
 	return &Container{
-		TodoUsecase: usecase.NewTodoUsecase(todoRepo, notificationClient),
+		TodoUsecase: usecase.NewTodoUsecase(todoRepo, repository.NewTagRepository(db), repository.NewProjectRepository(db), repository.NewTodoEventRepository(db), repository.NewTxManager(db), notificationClient),
 	}
 }
 
@@ -39,6 +39,6 @@ func NewContainer(db *sqlx.DB, cfg *config.Config) *Container {
 	)
 
 	return &Container{
-		TodoUsecase: usecase.NewTodoUsecase(todoRepo, notificationClient),
+		TodoUsecase: usecase.NewTodoUsecase(todoRepo, repository.NewTagRepository(db), repository.NewProjectRepository(db), repository.NewTodoEventRepository(db), repository.NewTxManager(db), notificationClient),
 	}
 }
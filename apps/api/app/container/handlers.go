@@ -1,37 +1,94 @@
 package container
 
 import (
+	"api/app/auth"
 	"api/app/external"
 	extMock "api/app/external/mock"
+	"api/app/middleware"
 	"api/app/presentation/handler"
+	"api/app/scheduler"
 	"api/app/usecase"
 	"api/config"
 	"api/repository"
+	"strings"
 
+	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 )
 
 // Handlers は全てのハンドラーを管理する構造体
 type Handlers struct {
-	Health *handler.HealthHandler
-	Simple *handler.SimpleHandler
-	Todo   *handler.TodoHandler
+	Health        *handler.HealthHandler
+	Simple        *handler.SimpleHandler
+	Todo          *handler.TodoHandler
+	TodoImport    *handler.TodoImportHandler
+	Tag           *handler.TagHandler
+	Trash         *handler.TrashHandler
+	Project       *handler.ProjectHandler
+	ProjectMember *handler.ProjectMemberHandler
+	Workspace     *handler.WorkspaceHandler
+	User          *handler.UserHandler
+	APIKey        *handler.APIKeyHandler
+	Login         *handler.AuthHandler
+	// OIDC は OpenID Connect のログイン（OIDC_ISSUER が未設定の場合は nil）
+	OIDC *handler.OIDCHandler
+	// Auth は認証が必要なルートに適用するミドルウェア
+	Auth gin.HandlerFunc
+	// WorkspaceScope はワークスペース単位のデータ（Todo・プロジェクト・タグ）のルートに適用するミドルウェア（Auth の後に適用する）
+	WorkspaceScope gin.HandlerFunc
+	// RateLimit はAPIに適用するレート制限のミドルウェア（無効にした場合は nil）
+	RateLimit gin.HandlerFunc
+	// IPRateLimit は認証が必要なルートに Auth の前に適用するIPアドレスごとのレート制限のミドルウェア（無効にした場合は nil）
+	IPRateLimit gin.HandlerFunc
+	// LoginRateLimit はログイン・パスワードの再設定に適用するIPアドレスごと・メールアドレスごとのレート制限のミドルウェア（無効にした場合は nil）
+	LoginRateLimit gin.HandlerFunc
+	// Idempotency は Idempotency-Key ヘッダーが付いた POST リクエストを1回だけ処理するミドルウェア（Auth の後に、Todoの作成のルートにのみ適用する）
+	Idempotency gin.HandlerFunc
 }
 
 // Infrastructure はインフラストラクチャレイヤーの依存性を管理
 type Infrastructure struct {
 	DB                 *sqlx.DB
 	NotificationClient external.NotificationClient
+	// TokenIssuer はログインで発行するアクセストークンの署名器（未設定の場合は nil）
+	TokenIssuer usecase.AccessTokenIssuer
+	// IdentityProvider は OpenID Connect の IdP（未設定の場合は nil）
+	IdentityProvider usecase.IdentityProvider
 }
 
 // Domain はドメインレイヤーの依存性を管理
 type Domain struct {
-	TodoRepository repository.TodoRepository
+	TodoRepository               repository.TodoRepository
+	TagRepository                repository.TagRepository
+	ProjectRepository            repository.ProjectRepository
+	ProjectInvitationRepository  repository.ProjectInvitationRepository
+	WorkspaceRepository          repository.WorkspaceRepository
+	TodoEventRepository          repository.TodoEventRepository
+	UserRepository               repository.UserRepository
+	APIKeyRepository             repository.APIKeyRepository
+	RefreshTokenRepository       repository.RefreshTokenRepository
+	PasswordResetTokenRepository repository.PasswordResetTokenRepository
+	UserIdentityRepository       repository.UserIdentityRepository
+	OIDCAuthRequestRepository    repository.OIDCAuthRequestRepository
+	TodoImportJobRepository      repository.TodoImportJobRepository
+	TxManager                    repository.TxManager
 }
 
 // Application はアプリケーションレイヤーの依存性を管理
 type Application struct {
-	TodoUsecase usecase.TodoUsecase
+	TodoUsecase          usecase.TodoUsecase
+	TodoImportUsecase    usecase.TodoImportUsecase
+	TagUsecase           usecase.TagUsecase
+	TrashUsecase         usecase.TrashUsecase
+	ProjectUsecase       usecase.ProjectUsecase
+	ProjectMemberUsecase usecase.ProjectMemberUsecase
+	WorkspaceUsecase     usecase.WorkspaceUsecase
+	ReminderUsecase      usecase.ReminderUsecase
+	UserUsecase          usecase.UserUsecase
+	APIKeyUsecase        usecase.APIKeyUsecase
+	AuthUsecase          usecase.AuthUsecase
+	// OIDCUsecase は IdP が設定されている場合のみ作成する
+	OIDCUsecase usecase.OIDCUsecase
 }
 
 // NewInfrastructure はインフラストラクチャレイヤーを初期化
@@ -55,26 +112,128 @@ func NewInfrastructure(db *sqlx.DB, cfg *config.Config) *Infrastructure {
 // NewDomain はドメインレイヤーを初期化
 func NewDomain(infra *Infrastructure) *Domain {
 	return &Domain{
-		TodoRepository: repository.NewTodoRepository(infra.DB),
+		TodoRepository:               repository.NewTodoRepository(infra.DB),
+		TagRepository:                repository.NewTagRepository(infra.DB),
+		ProjectRepository:            repository.NewProjectRepository(infra.DB),
+		ProjectInvitationRepository:  repository.NewProjectInvitationRepository(infra.DB),
+		WorkspaceRepository:          repository.NewWorkspaceRepository(infra.DB),
+		TodoEventRepository:          repository.NewTodoEventRepository(infra.DB),
+		UserRepository:               repository.NewUserRepository(infra.DB),
+		APIKeyRepository:             repository.NewAPIKeyRepository(infra.DB),
+		RefreshTokenRepository:       repository.NewRefreshTokenRepository(infra.DB),
+		PasswordResetTokenRepository: repository.NewPasswordResetTokenRepository(infra.DB),
+		UserIdentityRepository:       repository.NewUserIdentityRepository(infra.DB),
+		OIDCAuthRequestRepository:    repository.NewOIDCAuthRequestRepository(infra.DB),
+		TodoImportJobRepository:      repository.NewTodoImportJobRepository(infra.DB),
+		TxManager:                    repository.NewTxManager(infra.DB),
 	}
 }
 
 // NewApplication はアプリケーションレイヤーを初期化
-func NewApplication(domain *Domain, infra *Infrastructure) *Application {
-	return &Application{
-		TodoUsecase: usecase.NewTodoUsecase(domain.TodoRepository, infra.NotificationClient),
+func NewApplication(domain *Domain, infra *Infrastructure, cfg *config.Config) *Application {
+	app := &Application{
+		TodoUsecase:          usecase.NewTodoUsecase(domain.TodoRepository, domain.TagRepository, domain.ProjectRepository, domain.TodoEventRepository, domain.TxManager, infra.NotificationClient),
+		TagUsecase:           usecase.NewTagUsecase(domain.TagRepository, domain.TodoRepository, domain.TxManager),
+		TrashUsecase:         usecase.NewTrashUsecase(domain.TodoRepository, domain.TagRepository, domain.ProjectRepository, domain.TodoEventRepository, domain.WorkspaceRepository, domain.TxManager, cfg.TrashRetention),
+		ProjectUsecase:       usecase.NewProjectUsecase(domain.ProjectRepository, domain.TodoRepository, domain.TodoEventRepository, domain.TxManager),
+		ProjectMemberUsecase: usecase.NewProjectMemberUsecase(domain.ProjectRepository, domain.ProjectInvitationRepository, domain.UserRepository, domain.WorkspaceRepository, domain.TxManager, infra.NotificationClient),
+		WorkspaceUsecase:     usecase.NewWorkspaceUsecase(domain.WorkspaceRepository, domain.UserRepository, domain.TxManager),
+		ReminderUsecase:      usecase.NewReminderUsecase(domain.TodoRepository, domain.WorkspaceRepository, infra.NotificationClient, cfg.ReminderLeadTime),
+		UserUsecase:          usecase.NewUserUsecase(domain.UserRepository),
+		APIKeyUsecase:        usecase.NewAPIKeyUsecase(domain.APIKeyRepository),
+		AuthUsecase: usecase.NewAuthUsecase(domain.UserRepository, domain.RefreshTokenRepository, domain.PasswordResetTokenRepository, domain.TxManager, infra.TokenIssuer, infra.NotificationClient, usecase.AuthSettings{
+			RefreshTokenTTL:  cfg.RefreshTokenTTL,
+			PasswordResetTTL: cfg.PasswordResetTTL,
+			PasswordResetURL: strings.TrimRight(cfg.DashboardClientURL, "/") + "/reset-password",
+		}),
 	}
+	app.TodoImportUsecase = usecase.NewTodoImportUsecase(app.TodoUsecase, domain.TodoImportJobRepository, domain.WorkspaceRepository)
+	if infra.IdentityProvider != nil {
+		app.OIDCUsecase = usecase.NewOIDCUsecase(domain.UserRepository, domain.UserIdentityRepository, domain.OIDCAuthRequestRepository, domain.TxManager, infra.IdentityProvider, app.AuthUsecase)
+	}
+	return app
 }
 
 // InitializeHandlers は全ハンドラーを初期化
-func InitializeHandlers(db *sqlx.DB, cfg *config.Config) *Handlers {
+func InitializeHandlers(db *sqlx.DB, cfg *config.Config) (*Handlers, error) {
+	verifier, err := NewJWTVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+	rateLimit, ipRateLimit, loginRateLimit, err := NewRateLimiter(db, cfg)
+	if err != nil {
+		return nil, err
+	}
+	auth.SetPasswordHashConcurrency(cfg.PasswordHashConcurrency)
+	signer, err := NewJWTSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	oidcProvider, err := NewOIDCProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	infra := NewInfrastructure(db, cfg)
+	// nil の *auth.JWTSigner をインターフェースに入れると nil 判定できないため、設定されている場合のみ渡す
+	if signer != nil {
+		infra.TokenIssuer = signer
+	}
+	if oidcProvider != nil {
+		infra.IdentityProvider = oidcProvider
+	}
 	domain := NewDomain(infra)
-	app := NewApplication(domain, infra)
+	app := NewApplication(domain, infra, cfg)
 
-	return &Handlers{
-		Health: handler.NewHealthHandler(),
-		Simple: handler.NewSimpleHandler(),
-		Todo:   handler.NewTodoHandler(app.TodoUsecase),
+	handlers := &Handlers{
+		Health:         handler.NewHealthHandler(),
+		Simple:         handler.NewSimpleHandler(),
+		Todo:           handler.NewTodoHandler(app.TodoUsecase),
+		TodoImport:     handler.NewTodoImportHandler(app.TodoUsecase, app.TodoImportUsecase),
+		Tag:            handler.NewTagHandler(app.TagUsecase),
+		Trash:          handler.NewTrashHandler(app.TrashUsecase),
+		Project:        handler.NewProjectHandler(app.ProjectUsecase),
+		ProjectMember:  handler.NewProjectMemberHandler(app.ProjectMemberUsecase),
+		Workspace:      handler.NewWorkspaceHandler(app.WorkspaceUsecase),
+		User:           handler.NewUserHandler(app.UserUsecase),
+		APIKey:         handler.NewAPIKeyHandler(app.APIKeyUsecase),
+		Login:          handler.NewAuthHandler(app.AuthUsecase),
+		Auth:           middleware.Auth(verifier, app.APIKeyUsecase),
+		WorkspaceScope: middleware.Workspace(app.WorkspaceUsecase),
+		RateLimit:      rateLimit,
+		IPRateLimit:    ipRateLimit,
+		LoginRateLimit: loginRateLimit,
+		Idempotency:    middleware.Idempotency(repository.NewIdempotencyKeyRepository(db), cfg.IdempotencyKeyTTL),
+	}
+	if app.OIDCUsecase != nil {
+		handlers.OIDC = handler.NewOIDCHandler(app.OIDCUsecase)
 	}
-}
\ No newline at end of file
+	return handlers, nil
+}
+
+// InitializeReminderScheduler はリマインド送信のバックグラウンドジョブを初期化
+func InitializeReminderScheduler(db *sqlx.DB, cfg *config.Config) *scheduler.ReminderScheduler {
+	infra := NewInfrastructure(db, cfg)
+	domain := NewDomain(infra)
+	app := NewApplication(domain, infra, cfg)
+
+	return scheduler.NewReminderScheduler(app.ReminderUsecase, cfg.ReminderInterval)
+}
+
+// InitializeTrashPurgeScheduler はゴミ箱を自動で空にするバックグラウンドジョブを初期化
+func InitializeTrashPurgeScheduler(db *sqlx.DB, cfg *config.Config) *scheduler.TrashPurgeScheduler {
+	infra := NewInfrastructure(db, cfg)
+	domain := NewDomain(infra)
+	app := NewApplication(domain, infra, cfg)
+
+	return scheduler.NewTrashPurgeScheduler(app.TrashUsecase, cfg.TrashPurgeInterval)
+}
+
+// InitializeTodoImportScheduler はTodoのインポートを実行するバックグラウンドジョブを初期化
+func InitializeTodoImportScheduler(db *sqlx.DB, cfg *config.Config) *scheduler.TodoImportScheduler {
+	infra := NewInfrastructure(db, cfg)
+	domain := NewDomain(infra)
+	app := NewApplication(domain, infra, cfg)
+
+	return scheduler.NewTodoImportScheduler(app.TodoImportUsecase, cfg.TodoImportInterval)
+}
@@ -0,0 +1,39 @@
+package container
+
+import (
+	"api/app/middleware"
+	"api/config"
+	"api/repository"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+)
+
+// NewRateLimiter は設定されたストアでレート制限のミドルウェア（ルート・呼び出し元ごと、認証前のIPアドレスごと、ログインの試行）を初期化
+// 無効にした場合は nil を返す
+func NewRateLimiter(db *sqlx.DB, cfg *config.Config) (rateLimit gin.HandlerFunc, ipRateLimit gin.HandlerFunc, loginRateLimit gin.HandlerFunc, err error) {
+	if !cfg.RateLimitEnabled {
+		return nil, nil, nil, nil
+	}
+
+	var store middleware.RateLimitStore
+	switch cfg.RateLimitStore {
+	case "memory":
+		store = middleware.NewMemoryRateLimitStore()
+	case "postgres":
+		// 満杯に戻ったバケットは削除しても結果が変わらないため、最も長い Period を過ぎたら削除する
+		idleTTL := max(cfg.RateLimitDefault.Period, cfg.RateLimitPerIP.Period, cfg.RateLimitLoginPerIP.Period, cfg.RateLimitLoginPerEmail.Period)
+		for _, limit := range cfg.RateLimitRoutes {
+			idleTTL = max(idleTTL, limit.Period)
+		}
+		store = middleware.NewPostgresRateLimitStore(repository.NewRateLimitRepository(db), idleTTL)
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown rate limit store %q: expected memory or postgres", cfg.RateLimitStore)
+	}
+
+	return middleware.RateLimit(store, cfg.RateLimitDefault, cfg.RateLimitRoutes),
+		middleware.IPRateLimit(store, cfg.RateLimitPerIP),
+		middleware.LoginRateLimit(store, cfg.RateLimitLoginPerIP, cfg.RateLimitLoginPerEmail),
+		nil
+}
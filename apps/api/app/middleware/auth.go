@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyAuthenticator はAPIキーを検証し、有効なキーを返す（usecase.APIKeyUsecase が実装する）
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error)
+}
+
+// Auth は Authorization: Bearer <JWT> またはAPIキーを検証し、認証済みユーザーIDをリクエストの context に設定する
+// APIキーは X-API-Key ヘッダー、または Authorization: Bearer tdk_... で指定する
+// 検証に失敗した場合は 401 を返して後続のハンドラーを実行しない
+func Auth(verifier *auth.JWTVerifier, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			token, ok = apiKey, true
+		}
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer`)
+			response.UnauthorizedError(c, "認証が必要です")
+			c.Abort()
+			return
+		}
+
+		if strings.HasPrefix(token, models.APIKeyPrefix) {
+			authenticateAPIKey(c, apiKeys, token)
+			return
+		}
+
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			if errors.Is(err, auth.ErrTokenExpired) {
+				response.UnauthorizedError(c, "トークンの有効期限が切れています")
+			} else {
+				response.UnauthorizedError(c, "トークンが無効です")
+			}
+			c.Abort()
+			return
+		}
+
+		userID, err := claims.UserID()
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			response.UnauthorizedError(c, "トークンが無効です")
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithUserID(c.Request.Context(), userID))
+		c.Next()
+	}
+}
+
+// authenticateAPIKey はAPIキーを検証し、キーの所有者とスコープを context に設定する
+func authenticateAPIKey(c *gin.Context, apiKeys APIKeyAuthenticator, rawKey string) {
+	if apiKeys == nil {
+		c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+		response.UnauthorizedError(c, "APIキーが無効です")
+		c.Abort()
+		return
+	}
+
+	key, err := apiKeys.Authenticate(c.Request.Context(), rawKey)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAPIKey) {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			response.UnauthorizedError(c, "APIキーが無効または期限切れです")
+		} else {
+			response.InternalServerError(c, "APIキーの検証に失敗しました")
+		}
+		c.Abort()
+		return
+	}
+
+	ctx := auth.WithUserID(c.Request.Context(), key.UserID)
+	ctx = auth.WithScopes(ctx, key.Scopes)
+	ctx = auth.WithAPIKeyID(ctx, key.ID)
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+// RequireScopes はAPIキーで認証したリクエストに、参照系（GET・HEAD）は read、それ以外は write のスコープを要求する
+// JWTで認証したユーザーはスコープの制限を受けない
+func RequireScopes(read, write models.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := write
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = read
+		}
+		if !auth.HasScope(c.Request.Context(), string(scope)) {
+			response.ForbiddenError(c, "APIキーに "+string(scope)+" の権限がありません")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireUser はAPIキーでの認証を拒否し、ユーザー本人（JWT）による操作のみを許可する
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, restricted := auth.ScopesFromContext(c.Request.Context()); restricted {
+			response.ForbiddenError(c, "この操作はAPIキーでは実行できません")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// bearerToken は Authorization ヘッダーから Bearer トークンを取り出す
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,147 @@
+package middleware_test
+
+import (
+	"api/app/auth"
+	"api/app/middleware"
+	"api/app/models"
+	"api/app/usecase"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPIKeys は validKey のみを有効なAPIキーとして扱う APIKeyAuthenticator
+type fakeAPIKeys struct {
+	validKey string
+}
+
+func (f fakeAPIKeys) Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error) {
+	if rawKey != f.validKey {
+		return nil, usecase.ErrInvalidAPIKey
+	}
+	return &models.APIKey{ID: 7, UserID: 99, Scopes: []string{string(models.ScopeTodosRead)}}, nil
+}
+
+func TestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	key, err := auth.NewHS256Key("hs", []byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	signer, err := auth.NewJWTSigner(key, "", "", 15*time.Minute)
+	require.NoError(t, err)
+	verifier := auth.NewJWTVerifier([]auth.Key{key}, auth.WithClock(func() time.Time { return now }))
+	validToken, _, err := signer.IssueAccessToken(42, now)
+	require.NoError(t, err)
+	expiredToken, _, err := signer.IssueAccessToken(42, now.Add(-time.Hour))
+	require.NoError(t, err)
+	apiKey := models.APIKeyPrefix + "valid"
+
+	r := gin.New()
+	r.Use(middleware.Auth(verifier, fakeAPIKeys{validKey: apiKey}))
+	r.GET("/me", func(c *gin.Context) {
+		userID, _ := auth.UserIDFromContext(c.Request.Context())
+		scopes, _ := auth.ScopesFromContext(c.Request.Context())
+		c.String(http.StatusOK, strconv.Itoa(userID)+" "+strings.Join(scopes, ","))
+	})
+
+	tests := []struct {
+		name            string
+		headers         map[string]string
+		wantStatus      int
+		wantBody        string
+		wantWWWAuth     string
+		wantMessagePart string
+	}{
+		{
+			name:       "Bearer token",
+			headers:    map[string]string{"Authorization": "Bearer " + validToken},
+			wantStatus: http.StatusOK,
+			wantBody:   "42 ",
+		},
+		{
+			name:       "Scheme is case-insensitive",
+			headers:    map[string]string{"Authorization": "bearer " + validToken},
+			wantStatus: http.StatusOK,
+			wantBody:   "42 ",
+		},
+		{
+			name:        "Missing header",
+			wantStatus:  http.StatusUnauthorized,
+			wantWWWAuth: "Bearer",
+		},
+		{
+			name:        "Other scheme",
+			headers:     map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			wantStatus:  http.StatusUnauthorized,
+			wantWWWAuth: "Bearer",
+		},
+		{
+			name:        "Empty bearer token",
+			headers:     map[string]string{"Authorization": "Bearer   "},
+			wantStatus:  http.StatusUnauthorized,
+			wantWWWAuth: "Bearer",
+		},
+		{
+			name:            "Invalid token",
+			headers:         map[string]string{"Authorization": "Bearer " + validToken + "x"},
+			wantStatus:      http.StatusUnauthorized,
+			wantWWWAuth:     `Bearer error="invalid_token"`,
+			wantMessagePart: "トークンが無効です",
+		},
+		{
+			name:            "Expired token",
+			headers:         map[string]string{"Authorization": "Bearer " + expiredToken},
+			wantStatus:      http.StatusUnauthorized,
+			wantWWWAuth:     `Bearer error="invalid_token"`,
+			wantMessagePart: "有効期限",
+		},
+		{
+			name:       "API key in X-API-Key",
+			headers:    map[string]string{"X-API-Key": apiKey},
+			wantStatus: http.StatusOK,
+			wantBody:   "99 todos:read",
+		},
+		{
+			name:       "API key as a bearer token",
+			headers:    map[string]string{"Authorization": "Bearer " + apiKey},
+			wantStatus: http.StatusOK,
+			wantBody:   "99 todos:read",
+		},
+		{
+			name:            "Invalid API key",
+			headers:         map[string]string{"X-API-Key": models.APIKeyPrefix + "revoked"},
+			wantStatus:      http.StatusUnauthorized,
+			wantWWWAuth:     `Bearer error="invalid_token"`,
+			wantMessagePart: "APIキー",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantWWWAuth, w.Header().Get("WWW-Authenticate"))
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+			if tt.wantMessagePart != "" {
+				assert.Contains(t, w.Body.String(), tt.wantMessagePart)
+			}
+		})
+	}
+}
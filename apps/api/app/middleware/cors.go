@@ -43,8 +43,10 @@ func CORS(cfg *config.Config) gin.HandlerFunc {
 			}
 		}
 
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Workspace-ID, If-Match, If-None-Match, Idempotency-Key")
+		// 楽観的排他制御の ETag と、再送したリクエストかどうかをブラウザからも読めるようにする
+		c.Header("Access-Control-Expose-Headers", "ETag, Idempotent-Replayed, Content-Disposition")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
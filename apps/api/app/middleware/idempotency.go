@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"api/app/models"
+	"api/app/presentation/response"
+	"api/repository"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader は POST リクエストを安全に再送するためのキーを指定するヘッダー
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencySweepInterval は有効期限が切れたキーを削除する間隔
+const idempotencySweepInterval = 10 * time.Minute
+
+// MaxIdempotentRequestBodySize は Idempotency-Key 付きのリクエストで比較のために読み込む本文の上限
+const MaxIdempotentRequestBodySize = 1 << 20
+
+type idempotency struct {
+	repo repository.IdempotencyKeyRepository
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// IdempotencyOption は Idempotency の設定を変更する
+type IdempotencyOption func(*idempotency)
+
+// WithIdempotencyClock は現在時刻の取得方法を差し替える（テスト用）
+func WithIdempotencyClock(now func() time.Time) IdempotencyOption {
+	return func(m *idempotency) {
+		m.now = now
+	}
+}
+
+// Idempotency は Idempotency-Key ヘッダーが付いた POST リクエストを1回だけ処理する
+// 最初のリクエストのレスポンスを ttl の間保存し、同じキーで再送されたリクエストにはハンドラーを実行せずに保存したレスポンスを返す
+// キーは呼び出し元（APIキー、JWTのユーザー、未認証の場合はIPアドレス）ごとに区別するため、Auth の後に適用する
+// レスポンスの本文をそのままDBに保存するため、秘密情報（作成したAPIキーなど）を返すルートには適用しない
+// 同じキーで内容の異なるリクエストは 422、最初のリクエストの処理中に再送されたリクエストは 409 を返す
+func Idempotency(repo repository.IdempotencyKeyRepository, ttl time.Duration, opts ...IdempotencyOption) gin.HandlerFunc {
+	m := &idempotency{
+		repo: repo,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m.handle
+}
+
+func (m *idempotency) handle(c *gin.Context) {
+	key := c.GetHeader(IdempotencyKeyHeader)
+	// ファイルのアップロードは本文が大きく、メモリに読み込んで比較できないため対象にしない
+	if c.Request.Method != http.MethodPost || key == "" || strings.HasPrefix(c.ContentType(), "multipart/") {
+		c.Next()
+		return
+	}
+	if !isValidIdempotencyKey(key) {
+		response.InvalidRequestError(c, fmt.Sprintf("Idempotency-Key は%d文字以内の英数字・記号で指定してください", models.MaxIdempotencyKeyLength))
+		c.Abort()
+		return
+	}
+
+	// リクエストの内容を比較するため本文を読み込み、ハンドラーでも読めるように戻す
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxIdempotentRequestBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.RequestTooLargeError(c, fmt.Sprintf("Idempotency-Key を付けたリクエストの本文は%dMB以下にしてください", MaxIdempotentRequestBodySize>>20))
+		} else {
+			response.InvalidRequestError(c, "リクエストの本文を読み込めませんでした")
+		}
+		c.Abort()
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	scope := rateLimitCaller(c)
+	requestHash := hashIdempotentRequest(c, body)
+	now := m.now()
+	m.sweep(now)
+
+	existing, claimed, err := m.repo.Claim(scope, key, requestHash, now, now.Add(m.ttl))
+	if err != nil {
+		// 判定できないまま処理すると重複して作成される可能性があるため、再送を促す
+		fmt.Printf("Failed to claim idempotency key: %v\n", err)
+		response.InternalServerError(c, "リクエストの処理に失敗しました。しばらくしてから再度お試しください")
+		c.Abort()
+		return
+	}
+	if !claimed {
+		replayIdempotentResponse(c, existing, requestHash)
+		c.Abort()
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+
+	completed := false
+	defer func() {
+		// サーバーエラーやパニックで処理が終わらなかった場合は、同じキーで再実行できるようにする
+		if completed {
+			return
+		}
+		if err := m.repo.Release(scope, key); err != nil {
+			fmt.Printf("Failed to release idempotency key: %v\n", err)
+		}
+	}()
+
+	c.Next()
+
+	status := c.Writer.Status()
+	if status >= http.StatusInternalServerError {
+		return
+	}
+	if err := m.repo.Complete(scope, key, status, c.Writer.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+		fmt.Printf("Failed to save idempotent response: %v\n", err)
+		return
+	}
+	completed = true
+}
+
+// replayIdempotentResponse は同じキーで登録済みのリクエストに対するレスポンスを返す
+func replayIdempotentResponse(c *gin.Context, existing *models.IdempotencyKey, requestHash string) {
+	if existing != nil && existing.RequestHash != requestHash {
+		response.BusinessRuleError(c, "Idempotency-Key は内容の異なるリクエストで使用されています")
+		return
+	}
+	if existing == nil || !existing.Completed() {
+		response.ConflictError(c, "同じ Idempotency-Key のリクエストを処理中です。しばらくしてから再度お試しください")
+		return
+	}
+
+	contentType := ""
+	if existing.ResponseContentType != nil {
+		contentType = *existing.ResponseContentType
+	}
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(*existing.ResponseStatus, contentType, existing.ResponseBody)
+}
+
+// hashIdempotentRequest はメソッド・パス・ワークスペース・本文から同じ内容のリクエストかを判定するハッシュを作る
+func hashIdempotentRequest(c *gin.Context, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n", c.Request.Method, c.Request.URL.RequestURI(), c.GetHeader(WorkspaceHeader))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isValidIdempotencyKey はキーが空白・制御文字を含まない印字可能なASCII文字列かどうかを返す
+func isValidIdempotencyKey(key string) bool {
+	if len(key) > models.MaxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] <= ' ' || key[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// sweep はインスタンスごとに idempotencySweepInterval に1回、有効期限が切れたキーを削除する
+func (m *idempotency) sweep(now time.Time) {
+	m.mu.Lock()
+	if now.Sub(m.lastSweep) < idempotencySweepInterval {
+		m.mu.Unlock()
+		return
+	}
+	m.lastSweep = now
+	m.mu.Unlock()
+
+	// 削除に失敗してもリクエストの処理は続ける
+	if _, err := m.repo.DeleteExpired(now); err != nil {
+		fmt.Printf("Failed to delete expired idempotency keys: %v\n", err)
+	}
+}
+
+// responseRecorder はクライアントに書き込んだレスポンスの本文を保存用に記録する
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,137 @@
+package middleware_test
+
+import (
+	"api/app/middleware"
+	"api/repository"
+	"api/test"
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	r := gin.New()
+	r.Use(middleware.Idempotency(repository.NewIdempotencyKeyRepository(db), time.Hour, middleware.WithIdempotencyClock(func() time.Time { return now })))
+
+	// send は key を付けて本文 body を POST する
+	send := func(path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	created := 0
+	r.POST("/todos", func(c *gin.Context) {
+		created++
+		c.JSON(http.StatusCreated, gin.H{"id": created})
+	})
+
+	t.Run("Replays the stored response", func(t *testing.T) {
+		first := send("/todos", "replay", `{"title":"a"}`)
+		require.Equal(t, http.StatusCreated, first.Code)
+
+		second := send("/todos", "replay", `{"title":"a"}`)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("Different payload with the same key is rejected", func(t *testing.T) {
+		require.Equal(t, http.StatusCreated, send("/todos", "payload", `{"title":"a"}`).Code)
+
+		w := send("/todos", "payload", `{"title":"b"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Duplicate while the first request is in flight is rejected", func(t *testing.T) {
+		var duplicate *httptest.ResponseRecorder
+		r.POST("/slow", func(c *gin.Context) {
+			// 最初のリクエストの処理中に同じキーで再送する
+			duplicate = send("/slow", "in-flight", `{}`)
+			c.JSON(http.StatusCreated, gin.H{})
+		})
+
+		w := send("/slow", "in-flight", `{}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		require.NotNil(t, duplicate)
+		assert.Equal(t, http.StatusConflict, duplicate.Code)
+	})
+
+	t.Run("Server error releases the key", func(t *testing.T) {
+		attempts := 0
+		r.POST("/flaky", func(c *gin.Context) {
+			attempts++
+			if attempts == 1 {
+				c.JSON(http.StatusInternalServerError, gin.H{})
+				return
+			}
+			c.JSON(http.StatusCreated, gin.H{"attempt": attempts})
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, send("/flaky", "flaky", `{}`).Code)
+		w := send("/flaky", "flaky", `{}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("Expired key is processed again", func(t *testing.T) {
+		before := created
+		require.Equal(t, http.StatusCreated, send("/todos", "ttl", `{"title":"a"}`).Code)
+
+		now = now.Add(59 * time.Minute)
+		assert.Equal(t, "true", send("/todos", "ttl", `{"title":"a"}`).Header().Get("Idempotent-Replayed"))
+
+		now = now.Add(2 * time.Minute)
+		w := send("/todos", "ttl", `{"title":"a"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, before+2, created)
+	})
+
+	t.Run("Body over the limit is rejected", func(t *testing.T) {
+		body := fmt.Sprintf(`{"title":"%s"}`, strings.Repeat("a", middleware.MaxIdempotentRequestBodySize))
+		w := send("/todos", "large", body)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("Multipart uploads are not handled", func(t *testing.T) {
+		uploads := 0
+		r.POST("/upload", func(c *gin.Context) {
+			uploads++
+			c.JSON(http.StatusCreated, gin.H{})
+		})
+
+		for i := 0; i < 2; i++ {
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			require.NoError(t, mw.WriteField("name", "todos.csv"))
+			require.NoError(t, mw.Close())
+			req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			req.Header.Set(middleware.IdempotencyKeyHeader, "upload")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusCreated, w.Code)
+		}
+		assert.Equal(t, 2, uploads)
+	})
+}
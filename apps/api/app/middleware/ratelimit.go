@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"api/app/auth"
+	"api/app/presentation/response"
+	"api/config"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TokenBucket はレート制限のバケットの容量と回復速度
+type TokenBucket struct {
+	Capacity   float64 // 連続で呼び出せる回数
+	RefillRate float64 // 1秒あたりに回復するトークン数
+}
+
+// newTokenBucket は Period あたり Requests 回まで呼び出せるバケットを作成する
+func newTokenBucket(limit config.RateLimit) TokenBucket {
+	return TokenBucket{
+		Capacity:   float64(limit.Requests),
+		RefillRate: float64(limit.Requests) / limit.Period.Seconds(),
+	}
+}
+
+// RateLimitStore はトークンバケットの状態を保存する
+type RateLimitStore interface {
+	// Take は key のバケットを now まで補充してトークンを1つ取り出し、取り出せたかどうかと残りのトークン数を返す
+	Take(ctx context.Context, key string, bucket TokenBucket, now time.Time) (bool, float64, error)
+}
+
+// RateLimitOption は RateLimit の設定を変更する
+type RateLimitOption func(*rateLimiter)
+
+// WithRateLimitClock は現在時刻の取得方法を差し替える（テスト用）
+func WithRateLimitClock(now func() time.Time) RateLimitOption {
+	return func(l *rateLimiter) {
+		l.now = now
+	}
+}
+
+type rateLimiter struct {
+	store    RateLimitStore
+	fallback config.RateLimit
+	routes   config.RateLimitRoutes
+	now      func() time.Time
+	// key はバケットを区別するキーを返す
+	key func(c *gin.Context, route string) string
+}
+
+// RateLimit はルートと呼び出し元（ユーザー、APIキー、IPアドレス）ごとにトークンバケットでリクエスト数を制限する
+// ルートの上限は routes の "メソッド ルート"（例: "POST /api/v1/todos/bulk"）で指定し、指定がないルートは fallback を使う
+// 呼び出し元を認証済みユーザーで区別するため、Auth の後に適用する
+// 上限を超えた場合は 429 と Retry-After を返して後続のハンドラーを実行しない
+func RateLimit(store RateLimitStore, fallback config.RateLimit, routes config.RateLimitRoutes, opts ...RateLimitOption) gin.HandlerFunc {
+	l := &rateLimiter{
+		store:    store,
+		fallback: fallback,
+		routes:   routes,
+		now:      time.Now,
+		key: func(c *gin.Context, route string) string {
+			return route + " " + rateLimitCaller(c)
+		},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l.handle
+}
+
+// IPRateLimit はルートに関わらずIPアドレスごとにトークンバケットでリクエスト数を制限する
+// 不正なJWTやAPIキーを大量に送るリクエストも制限するため、Auth の前に適用する
+func IPRateLimit(store RateLimitStore, limit config.RateLimit, opts ...RateLimitOption) gin.HandlerFunc {
+	l := &rateLimiter{
+		store:    store,
+		fallback: limit,
+		now:      time.Now,
+		key: func(c *gin.Context, _ string) string {
+			return "* ip:" + c.ClientIP()
+		},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l.handle
+}
+
+// MaxLoginRequestBodySize はログインのレート制限でメールアドレスを読み取る本文の上限
+const MaxLoginRequestBodySize = 64 << 10
+
+// LoginRateLimit はメールアドレスを指定する認証のルート（ログイン・パスワードの再設定）を、
+// ルートごとにIPアドレスごと、メールアドレスごとのトークンバケットでリクエスト数を制限する
+// 1つのアカウントへの複数のIPアドレスからのパスワードの総当たり、argon2id の計算によるメモリ・CPUの消費、
+// 再設定メールの大量送信を防ぐ
+// メールアドレスは本文の email から読み取り、前後の空白を除いて小文字にしたもののハッシュをキーにする
+func LoginRateLimit(store RateLimitStore, perIP config.RateLimit, perEmail config.RateLimit, opts ...RateLimitOption) gin.HandlerFunc {
+	ipLimiter := &rateLimiter{store: store, fallback: perIP, now: time.Now}
+	emailLimiter := &rateLimiter{store: store, fallback: perEmail, now: time.Now}
+	for _, opt := range opts {
+		opt(ipLimiter)
+		opt(emailLimiter)
+	}
+
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		if !ipLimiter.allow(c, route+" ip:"+c.ClientIP(), perIP) {
+			return
+		}
+
+		// リクエストの内容からメールアドレスを読み取り、ハンドラーでも読めるように戻す
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxLoginRequestBodySize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				response.RequestTooLargeError(c, fmt.Sprintf("リクエストの本文は%dKB以下にしてください", MaxLoginRequestBodySize>>10))
+			} else {
+				response.InvalidRequestError(c, "リクエストの本文を読み込めませんでした")
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// メールアドレスを読み取れない場合はハンドラーのバリデーションで拒否される（パスワードは検証しない）
+		if email := loginEmail(body); email != "" && !emailLimiter.allow(c, route+" email:"+email, perEmail) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// loginEmail はリクエストの本文から正規化したメールアドレスのハッシュを返す（読み取れない場合は空文字）
+// メールアドレスをそのままストアに保存しないようにハッシュにする
+func loginEmail(body []byte) string {
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
+
+func (l *rateLimiter) handle(c *gin.Context) {
+	route := c.Request.Method + " " + c.FullPath()
+	limit, ok := l.routes[route]
+	if !ok {
+		limit = l.fallback
+	}
+	if l.allow(c, l.key(c, route), limit) {
+		c.Next()
+	}
+}
+
+// allow は key のバケットからトークンを1つ取り出し、レート制限のヘッダーを設定する
+// 上限を超えた場合は 429 を返して後続のハンドラーを中止し、false を返す
+func (l *rateLimiter) allow(c *gin.Context, key string, limit config.RateLimit) bool {
+	bucket := newTokenBucket(limit)
+
+	allowed, tokens, err := l.store.Take(c.Request.Context(), key, bucket, l.now())
+	if err != nil {
+		// 制限の判定に失敗してもAPIは利用できるようにする
+		fmt.Printf("Failed to check rate limit: %v\n", err)
+		return true
+	}
+
+	remaining := math.Max(math.Floor(tokens), 0)
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(int(remaining)))
+	// バケットが満杯に戻るまでの秒数
+	c.Header("X-RateLimit-Reset", strconv.Itoa(secondsUntil(bucket.Capacity-tokens, bucket.RefillRate)))
+
+	if !allowed {
+		// 次のトークンが回復するまでの秒数
+		c.Header("Retry-After", strconv.Itoa(max(secondsUntil(1-tokens, bucket.RefillRate), 1)))
+		response.RateLimitedError(c, "リクエスト数が上限を超えました。しばらくしてから再度お試しください")
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// rateLimitCaller はリクエストの呼び出し元を表すキーを返す
+// APIキーはキーごと、JWTはユーザーごと、未認証の場合はIPアドレスごとに制限する
+func rateLimitCaller(c *gin.Context) string {
+	ctx := c.Request.Context()
+	if apiKeyID, ok := auth.APIKeyIDFromContext(ctx); ok {
+		return "key:" + strconv.Itoa(apiKeyID)
+	}
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		return "user:" + strconv.Itoa(userID)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// secondsUntil は rate の速度で tokens 個回復するまでの秒数（切り上げ）を返す
+func secondsUntil(tokens float64, rate float64) int {
+	if tokens <= 0 {
+		return 0
+	}
+	return int(math.Ceil(tokens / rate))
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"api/repository"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// rateLimitSweepInterval はしばらく使われていないバケットを削除する間隔
+const rateLimitSweepInterval = time.Minute
+
+// MemoryRateLimitStore はプロセス内にトークンバケットを保存する（単一インスタンス用）
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time // 満杯に戻る時刻（これ以降は削除しても結果が変わらない）
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, bucket TokenBucket, now time.Time) (bool, float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: bucket.Capacity, updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	// 前回から経過した時間の分だけ補充する
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens = min(bucket.Capacity, b.tokens+elapsed.Seconds()*bucket.RefillRate)
+		b.updatedAt = now
+	}
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.fullAt = b.updatedAt.Add(time.Duration((bucket.Capacity - b.tokens) / bucket.RefillRate * float64(time.Second)))
+	return allowed, b.tokens, nil
+}
+
+// sweep は満杯に戻ったバケットを削除する（呼び出し元で mu をロックすること）
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// PostgresRateLimitStore はトークンバケットを Postgres に保存する（複数のレプリカで上限を共有する）
+type PostgresRateLimitStore struct {
+	repo repository.RateLimitRepository
+	// idleTTL より長く使われていないバケットを削除する
+	idleTTL time.Duration
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewPostgresRateLimitStore は idleTTL より長く使われていないバケットを定期的に削除する PostgresRateLimitStore を作成する
+// idleTTL はルートの上限の Period のうち最も長いもの以上にする
+func NewPostgresRateLimitStore(repo repository.RateLimitRepository, idleTTL time.Duration) *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{
+		repo:    repo,
+		idleTTL: idleTTL,
+	}
+}
+
+func (s *PostgresRateLimitStore) Take(ctx context.Context, key string, bucket TokenBucket, now time.Time) (bool, float64, error) {
+	s.sweep(now)
+	return s.repo.Take(key, bucket.Capacity, bucket.RefillRate, now)
+}
+
+// sweep はインスタンスごとに rateLimitSweepInterval に1回、使われていないバケットを削除する
+func (s *PostgresRateLimitStore) sweep(now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = now
+	s.mu.Unlock()
+
+	// 削除に失敗しても制限の判定は続ける
+	if _, err := s.repo.DeleteIdle(now.Add(-s.idleTTL)); err != nil {
+		fmt.Printf("Failed to delete idle rate limit buckets: %v\n", err)
+	}
+}
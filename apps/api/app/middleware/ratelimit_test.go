@@ -0,0 +1,215 @@
+package middleware_test
+
+import (
+	"api/app/middleware"
+	"api/config"
+	"api/repository"
+	"api/test"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// newRouter は時刻を now で固定したレート制限を適用したルーターを作成する
+	newRouter := func(now *time.Time, limiter func(opt middleware.RateLimitOption) gin.HandlerFunc) *gin.Engine {
+		r := gin.New()
+		r.Use(limiter(middleware.WithRateLimitClock(func() time.Time { return *now })))
+		r.GET("/todos", func(c *gin.Context) { c.Status(http.StatusOK) })
+		r.POST("/todos/bulk", func(c *gin.Context) { c.Status(http.StatusOK) })
+		return r
+	}
+	// send は ip から method path を呼び出す
+	send := func(r *gin.Engine, method, path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":12345"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// 2秒に2回（1秒に1トークン回復する）
+	fallback := config.RateLimit{Requests: 2, Period: 2 * time.Second}
+
+	t.Run("Tokens refill over time", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		r := newRouter(&now, func(opt middleware.RateLimitOption) gin.HandlerFunc {
+			return middleware.RateLimit(middleware.NewMemoryRateLimitStore(), fallback, nil, opt)
+		})
+
+		first := send(r, http.MethodGet, "/todos", "192.0.2.1")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "2", first.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "1", first.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "1", first.Header().Get("X-RateLimit-Reset"))
+
+		second := send(r, http.MethodGet, "/todos", "192.0.2.1")
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, "0", second.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "2", second.Header().Get("X-RateLimit-Reset"))
+
+		limited := send(r, http.MethodGet, "/todos", "192.0.2.1")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+		assert.Contains(t, limited.Body.String(), "RATE_LIMITED")
+		assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+		assert.Equal(t, "0", limited.Header().Get("X-RateLimit-Remaining"))
+
+		// 1秒後に1トークン回復する
+		now = now.Add(time.Second)
+		assert.Equal(t, http.StatusOK, send(r, http.MethodGet, "/todos", "192.0.2.1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(r, http.MethodGet, "/todos", "192.0.2.1").Code)
+
+		// 容量より多くは回復しない
+		now = now.Add(time.Hour)
+		assert.Equal(t, "1", send(r, http.MethodGet, "/todos", "192.0.2.1").Header().Get("X-RateLimit-Remaining"))
+	})
+
+	t.Run("Routes and callers have their own buckets", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		routes := config.RateLimitRoutes{"POST /todos/bulk": {Requests: 1, Period: time.Minute}}
+		r := newRouter(&now, func(opt middleware.RateLimitOption) gin.HandlerFunc {
+			return middleware.RateLimit(middleware.NewMemoryRateLimitStore(), fallback, routes, opt)
+		})
+
+		bulk := send(r, http.MethodPost, "/todos/bulk", "192.0.2.1")
+		assert.Equal(t, http.StatusOK, bulk.Code)
+		assert.Equal(t, "1", bulk.Header().Get("X-RateLimit-Limit"))
+		limited := send(r, http.MethodPost, "/todos/bulk", "192.0.2.1")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+		assert.Equal(t, "60", limited.Header().Get("Retry-After"))
+
+		// 設定のないルートは fallback の上限で、別に数える
+		list := send(r, http.MethodGet, "/todos", "192.0.2.1")
+		assert.Equal(t, http.StatusOK, list.Code)
+		assert.Equal(t, "2", list.Header().Get("X-RateLimit-Limit"))
+		// 別のIPアドレスは別に数える
+		assert.Equal(t, http.StatusOK, send(r, http.MethodPost, "/todos/bulk", "192.0.2.2").Code)
+	})
+
+	t.Run("IP rate limit is shared across routes", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		r := newRouter(&now, func(opt middleware.RateLimitOption) gin.HandlerFunc {
+			return middleware.IPRateLimit(middleware.NewMemoryRateLimitStore(), fallback, opt)
+		})
+
+		assert.Equal(t, http.StatusOK, send(r, http.MethodGet, "/todos", "192.0.2.1").Code)
+		assert.Equal(t, http.StatusOK, send(r, http.MethodPost, "/todos/bulk", "192.0.2.1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(r, http.MethodGet, "/todos", "192.0.2.1").Code)
+		assert.Equal(t, http.StatusOK, send(r, http.MethodGet, "/todos", "192.0.2.2").Code)
+	})
+
+	t.Run("Login is limited per IP address and per email", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		perIP := config.RateLimit{Requests: 3, Period: time.Minute}
+		perEmail := config.RateLimit{Requests: 2, Period: time.Minute}
+		limiter := middleware.LoginRateLimit(middleware.NewMemoryRateLimitStore(), perIP, perEmail,
+			middleware.WithRateLimitClock(func() time.Time { return now }))
+		echo := func(c *gin.Context) {
+			// ハンドラーでも本文を読める
+			var req struct {
+				Email string `json:"email"`
+			}
+			require.NoError(t, c.ShouldBindJSON(&req))
+			c.String(http.StatusOK, req.Email)
+		}
+		r := gin.New()
+		r.POST("/auth/login", limiter, echo)
+		r.POST("/auth/password-reset", limiter, echo)
+		post := func(path, ip, email string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"email":"`+email+`","password":"x"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.RemoteAddr = ip + ":12345"
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			return w
+		}
+		login := func(ip, email string) *httptest.ResponseRecorder {
+			return post("/auth/login", ip, email)
+		}
+
+		first := login("192.0.2.1", "alice@example.com")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "alice@example.com", first.Body.String())
+		// 大文字・前後の空白を除いて同じメールアドレスとして数える
+		assert.Equal(t, http.StatusOK, login("192.0.2.2", " Alice@Example.com ").Code)
+		limited := login("192.0.2.3", "ALICE@example.com")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+		assert.Equal(t, "30", limited.Header().Get("Retry-After"))
+
+		// 別のメールアドレスでも同じIPアドレスからは上限まで
+		assert.Equal(t, http.StatusOK, login("192.0.2.1", "bob@example.com").Code)
+		assert.Equal(t, http.StatusOK, login("192.0.2.1", "carol@example.com").Code)
+		assert.Equal(t, http.StatusTooManyRequests, login("192.0.2.1", "dave@example.com").Code)
+		// IPアドレスで制限されたリクエストはメールアドレスの上限を消費しない
+		assert.Equal(t, http.StatusOK, login("192.0.2.4", "dave@example.com").Code)
+		assert.Equal(t, http.StatusOK, login("192.0.2.4", "dave@example.com").Code)
+
+		// パスワードの再設定はログインとは別に数える
+		assert.Equal(t, http.StatusOK, post("/auth/password-reset", "192.0.2.5", "alice@example.com").Code)
+		assert.Equal(t, http.StatusOK, post("/auth/password-reset", "192.0.2.6", "alice@example.com").Code)
+		assert.Equal(t, http.StatusTooManyRequests, post("/auth/password-reset", "192.0.2.7", "Alice@example.com").Code)
+	})
+}
+
+func TestPostgresRateLimitStore(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	repo := repository.NewRateLimitRepository(db)
+	store := middleware.NewPostgresRateLimitStore(repo, time.Minute)
+	bucket := middleware.TokenBucket{Capacity: 2, RefillRate: 1}
+
+	t.Run("Bucket is created and updated in place", func(t *testing.T) {
+		allowed, tokens, err := store.Take(ctx, "upsert", bucket, now)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		assert.InDelta(t, 1, tokens, 1e-9)
+
+		allowed, tokens, err = store.Take(ctx, "upsert", bucket, now)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		assert.InDelta(t, 0, tokens, 1e-9)
+
+		// トークンが足りない場合は取り出さずに残りを返す
+		allowed, tokens, err = store.Take(ctx, "upsert", bucket, now.Add(500*time.Millisecond))
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.InDelta(t, 0.5, tokens, 1e-9)
+
+		allowed, tokens, err = store.Take(ctx, "upsert", bucket, now.Add(1500*time.Millisecond))
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		assert.InDelta(t, 0.5, tokens, 1e-9)
+
+		// 別のレプリカの時計が遅れていても回復量は負にならない
+		allowed, tokens, err = store.Take(ctx, "upsert", bucket, now)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.InDelta(t, 0.5, tokens, 1e-9)
+	})
+
+	t.Run("Idle buckets are deleted", func(t *testing.T) {
+		_, _, err := repo.Take("idle", 2, 1, now)
+		require.NoError(t, err)
+
+		deleted, err := repo.DeleteIdle(now.Add(time.Second))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+
+		// 削除されたバケットは満杯から始まる
+		_, tokens, err := repo.Take("idle", 2, 1, now.Add(2*time.Second))
+		require.NoError(t, err)
+		assert.InDelta(t, 1, tokens, 1e-9)
+	})
+}
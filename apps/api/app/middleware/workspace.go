@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"api/app/auth"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceHeader はリクエストで操作するワークスペースを指定するヘッダー
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceResolver は認証済みユーザーが操作するワークスペースを決める（usecase.WorkspaceUsecase が実装する）
+type WorkspaceResolver interface {
+	ResolveWorkspace(ctx context.Context, requestedID *int) (int, error)
+}
+
+// Workspace は X-Workspace-ID ヘッダーのワークスペースをリクエストの context に設定する（Auth の後に適用する）
+// ヘッダーを省略した場合は、ユーザーが所属するワークスペースが1つだけのときにそれを使う
+// 決められない場合や所属していない場合は後続のハンドラーを実行しない
+func Workspace(resolver WorkspaceResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestedID *int
+		if header := strings.TrimSpace(c.GetHeader(WorkspaceHeader)); header != "" {
+			id, err := strconv.Atoi(header)
+			if err != nil || id <= 0 {
+				response.InvalidRequestError(c, WorkspaceHeader+" ヘッダーの形式が正しくありません")
+				c.Abort()
+				return
+			}
+			requestedID = &id
+		}
+
+		workspaceID, err := resolver.ResolveWorkspace(c.Request.Context(), requestedID)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWorkspaceRequired):
+				response.InvalidRequestError(c, WorkspaceHeader+" ヘッダーでワークスペースを指定してください")
+			case errors.Is(err, usecase.ErrForbidden):
+				response.ForbiddenError(c, "指定されたワークスペースのメンバーではありません")
+			case errors.Is(err, usecase.ErrInvalidInput):
+				response.InvalidRequestError(c, WorkspaceHeader+" ヘッダーの形式が正しくありません")
+			default:
+				response.InternalServerError(c, "ワークスペースの確認に失敗しました")
+			}
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithWorkspaceID(c.Request.Context(), workspaceID))
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIKeyScope はAPIキーに許可する操作の範囲
+// @enum todos:read,todos:write
+type APIKeyScope string
+
+const (
+	ScopeTodosRead  APIKeyScope = "todos:read"
+	ScopeTodosWrite APIKeyScope = "todos:write"
+)
+
+const (
+	// APIKeyPrefix はAPIキーの先頭に付ける識別子（JWTと区別するため）
+	APIKeyPrefix        = "tdk_"
+	MaxAPIKeyNameLength = 100
+	// 有効期限を省略した場合の有効期間
+	DefaultAPIKeyTTL = 90 * 24 * time.Hour
+	// 有効期限に指定できる最長の期間
+	MaxAPIKeyTTL = 365 * 24 * time.Hour
+)
+
+// APIKey はCIやスクリプトなどのマシンクライアント用のAPIキー
+// キー本体は保存せず、SHA-256 のハッシュのみを保存する
+type APIKey struct {
+	ID     int    `db:"id"`
+	UserID int    `db:"user_id"`
+	Name   string `db:"name"`
+	// キーの先頭部分（検索と一覧での識別に使う）
+	Prefix     string         `db:"prefix"`
+	KeyHash    string         `db:"key_hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	ExpiresAt  time.Time      `db:"expires_at"`
+	LastUsedAt *time.Time     `db:"last_used_at"`
+	RevokedAt  *time.Time     `db:"revoked_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+// HasScope は scope が許可されているかを返す
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive は now の時点でAPIキーが有効（失効・期限切れでない）かを返す
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	MinPasswordLength = 8
+	// argon2id は長さに制限はないが、極端に長い入力でハッシュ計算の負荷が増えないよう制限する
+	MaxPasswordLength = 128
+)
+
+// AuthTokens はログインとトークンの更新で発行するトークン
+type AuthTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// RefreshToken はアクセストークンを再発行するためのトークン
+// 使用するたびに新しいトークンに置き換え（ローテーション）、同じログインで発行したトークンは FamilyID が同じになる
+// トークン本体は保存せず、SHA-256 のハッシュのみを保存する
+type RefreshToken struct {
+	ID        int        `db:"id"`
+	UserID    int        `db:"user_id"`
+	FamilyID  string     `db:"family_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// IsActive は now の時点でリフレッシュトークンが使用可能（未使用・未失効・期限内）かを返す
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// PasswordResetToken はパスワードリセット用の1回限りのトークン
+type PasswordResetToken struct {
+	ID        int        `db:"id"`
+	UserID    int        `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// IsActive は now の時点でトークンが使用可能（未使用・期限内）かを返す
+func (t *PasswordResetToken) IsActive(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
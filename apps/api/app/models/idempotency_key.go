@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// MaxIdempotencyKeyLength は Idempotency-Key の最大文字数
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey は Idempotency-Key を付けて送られたリクエストと、そのレスポンス
+type IdempotencyKey struct {
+	Scope       string `db:"scope"`
+	Key         string `db:"key"`
+	RequestHash string `db:"request_hash"`
+	// 最初のリクエストを処理している間は nil
+	ResponseStatus      *int      `db:"response_status"`
+	ResponseContentType *string   `db:"response_content_type"`
+	ResponseBody        []byte    `db:"response_body"`
+	CreatedAt           time.Time `db:"created_at"`
+	ExpiresAt           time.Time `db:"expires_at"`
+}
+
+// Completed は最初のリクエストの処理が終わり、レスポンスが保存されているかどうかを返す
+func (k *IdempotencyKey) Completed() bool {
+	return k.ResponseStatus != nil
+}
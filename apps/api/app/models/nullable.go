@@ -0,0 +1,77 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable は部分更新（JSON Merge Patch, RFC 7396）の1項目を表す
+// 未指定（Set が false）・null の指定（Null が true）・値の指定（Value）を区別する
+type Nullable[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// NewNullable は value を指定した Nullable を返す
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T]{Set: true, Value: value}
+}
+
+// NullOf は null を指定した Nullable を返す
+func NullOf[T any]() Nullable[T] {
+	return Nullable[T]{Set: true, Null: true}
+}
+
+// NullableFromPtr は nil の場合は未指定、それ以外は値を指定した Nullable を返す
+func NullableFromPtr[T any](value *T) Nullable[T] {
+	if value == nil {
+		return Nullable[T]{}
+	}
+	return NewNullable(*value)
+}
+
+// IsNull は null が指定されたかどうかを返す
+func (n Nullable[T]) IsNull() bool {
+	return n.Set && n.Null
+}
+
+// HasValue は値が指定されたかどうかを返す
+func (n Nullable[T]) HasValue() bool {
+	return n.Set && !n.Null
+}
+
+// Ptr は値が指定された場合はそのポインタ、未指定・null の場合は nil を返す
+func (n Nullable[T]) Ptr() *T {
+	if !n.HasValue() {
+		return nil
+	}
+	value := n.Value
+	return &value
+}
+
+// OrZero は未指定の場合は nil、null の場合はゼロ値、値が指定された場合はその値のポインタを返す
+// NULL を持てない列（空文字で値を消す列）の更新に使う
+func (n Nullable[T]) OrZero() *T {
+	if !n.Set {
+		return nil
+	}
+	var value T
+	if !n.Null {
+		value = n.Value
+	}
+	return &value
+}
+
+// UnmarshalJSON はキーが存在する場合のみ呼ばれるため、呼ばれた時点で指定済みとして扱う
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		n.Null = true
+		var zero T
+		n.Value = zero
+		return nil
+	}
+	n.Null = false
+	return json.Unmarshal(data, &n.Value)
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// DefaultOccurrenceLimit は繰り返しのプレビューで limit 未指定時に返す件数
+	DefaultOccurrenceLimit = 10
+	// MaxOccurrenceLimit は繰り返しのプレビューで返す最大件数
+	MaxOccurrenceLimit = 100
+)
+
+// OccurrenceQuery は繰り返しTodoの今後の期限を取得する条件
+type OccurrenceQuery struct {
+	Limit int
+	// 曜日・時刻を計算するタイムゾーン（空の場合はTodoに保存したタイムゾーン）
+	Timezone string
+	// この日時より後の期限を返す（ゼロ値の場合は現在時刻）
+	After time.Time
+}
+
+// Occurrence は繰り返しTodoの1回分の期限とリマインド日時
+type Occurrence struct {
+	DueAt    time.Time
+	RemindAt *time.Time
+}
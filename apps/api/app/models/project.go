@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// MaxProjectNameLength はプロジェクト名の最大文字数
+	MaxProjectNameLength = 100
+	// DefaultProjectColor はカラー未指定時のプロジェクトの色
+	DefaultProjectColor = "#808080"
+)
+
+type Project struct {
+	ID        int       `db:"id"`
+	OwnerID   int       `db:"owner_id"` // 作成したユーザー（権限はメンバーのロールで判定する）
+	Name      string    `db:"name"`
+	Color     string    `db:"color"`
+	Archived  bool      `db:"archived"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	// プロジェクト内のTodo（サブタスクを含み、ゴミ箱内を除く）の件数
+	OpenTodoCount      int `db:"open_todo_count"`
+	CompletedTodoCount int `db:"completed_todo_count"`
+
+	// 取得したユーザーのロール
+	Role ProjectRole `db:"role"`
+}
+
+// ProjectUpdate はプロジェクトの更新内容（nil の項目は更新しない）
+type ProjectUpdate struct {
+	Name     *string
+	Color    *string
+	Archived *bool
+}
+
+// ProjectListQuery はプロジェクト一覧取得の条件
+type ProjectListQuery struct {
+	// MemberID が指定された場合はそのユーザーがメンバーになっているプロジェクトのみを対象にする
+	MemberID *int
+	// IncludeArchived が true の場合はアーカイブ済みのプロジェクトも含める
+	IncludeArchived bool
+}
+
+// ProjectDeleteMode はプロジェクト削除時のTodoの扱い
+// @enum inbox,cascade
+type ProjectDeleteMode string
+
+const (
+	// ProjectDeleteMoveToInbox はプロジェクトのTodoをインボックス（プロジェクト未所属）に移動する
+	ProjectDeleteMoveToInbox ProjectDeleteMode = "inbox"
+	// ProjectDeleteCascade はプロジェクトのTodoをサブタスクごとゴミ箱に移動する
+	ProjectDeleteCascade ProjectDeleteMode = "cascade"
+)
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+)
+
+// ProjectRole はプロジェクトのメンバーのロール
+// @enum owner,editor,viewer
+type ProjectRole string
+
+const (
+	// ProjectRoleOwner はメンバー・プロジェクトを管理できる
+	ProjectRoleOwner ProjectRole = "owner"
+	// ProjectRoleEditor はTodoを作成・更新・削除できる
+	ProjectRoleEditor ProjectRole = "editor"
+	// ProjectRoleViewer はTodoを閲覧のみできる
+	ProjectRoleViewer ProjectRole = "viewer"
+)
+
+// IsValid は定義済みのロールかどうかを返す
+func (r ProjectRole) IsValid() bool {
+	return r.rank() > 0
+}
+
+// Includes はロールが required の権限を含むかどうかを返す（owner > editor > viewer）
+func (r ProjectRole) Includes(required ProjectRole) bool {
+	return r.IsValid() && r.rank() >= required.rank()
+}
+
+func (r ProjectRole) rank() int {
+	switch r {
+	case ProjectRoleViewer:
+		return 1
+	case ProjectRoleEditor:
+		return 2
+	case ProjectRoleOwner:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type ProjectMember struct {
+	ProjectID int         `db:"project_id"`
+	UserID    int         `db:"user_id"`
+	Role      ProjectRole `db:"role"`
+	CreatedAt time.Time   `db:"created_at"`
+	UpdatedAt time.Time   `db:"updated_at"`
+
+	// メンバーのユーザー情報
+	UserName  string `db:"user_name"`
+	UserEmail string `db:"user_email"`
+}
+
+// InvitationStatus はプロジェクトへの招待の状態
+// @enum pending,accepted,declined,revoked
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+	InvitationRevoked  InvitationStatus = "revoked"
+)
+
+type ProjectInvitation struct {
+	ID          int              `db:"id"`
+	ProjectID   int              `db:"project_id"`
+	InviterID   *int             `db:"inviter_id"`
+	InviteeID   int              `db:"invitee_id"`
+	Role        ProjectRole      `db:"role"`
+	Status      InvitationStatus `db:"status"`
+	CreatedAt   time.Time        `db:"created_at"`
+	RespondedAt *time.Time       `db:"responded_at"`
+
+	// 招待されたプロジェクトの名前
+	ProjectName string `db:"project_name"`
+}
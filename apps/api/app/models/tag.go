@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// MaxTagNameLength はタグ名の最大文字数
+const MaxTagNameLength = 50
+
+type Tag struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -15,12 +15,73 @@ const (
 )
 
 type Todo struct {
-	ID          int       `db:"id"`
-	Title       string    `db:"title"`
-	Description string    `db:"description"`
-	Completed   bool      `db:"completed"`
+	ID          int          `db:"id"`
+	Title       string       `db:"title"`
+	Description string       `db:"description"`
+	Completed   bool         `db:"completed"`
 	Priority    TodoPriority `db:"priority"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ParentID    *int         `db:"parent_id"`
+	OwnerID     *int         `db:"owner_id"`
+	ProjectID   *int         `db:"project_id"` // nil の場合はインボックス（プロジェクト未所属）
+	DueAt       *time.Time   `db:"due_at"`
+	RemindAt    *time.Time   `db:"remind_at"`
+	RemindedAt  *time.Time   `db:"reminded_at"`
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+	// ゴミ箱に移動した日時（nil の場合は通常のTodo）
+	DeletedAt *time.Time `db:"deleted_at"`
+	// 更新のたびに1ずつ増えるバージョン（楽観的排他制御に使う）
+	Version int `db:"version"`
+
+	// 繰り返し設定（RFC 5545 の RRULE、nil の場合は繰り返さない）
+	RecurrenceRule *string `db:"recurrence_rule"`
+	// 繰り返しの曜日・時刻を計算するタイムゾーン（IANA のタイムゾーン名）
+	RecurrenceTimezone *string `db:"recurrence_timezone"`
+	// 繰り返しの起点となる最初の期限（COUNT・UNTIL はここから数える）
+	RecurrenceStart *time.Time `db:"recurrence_start"`
+	// 完了時に自動生成した次回のTodoのID
+	NextOccurrenceID *int `db:"next_occurrence_id"`
+
+	// ツリー表示時に読み込んだ子Todo
+	Children []Todo `db:"-"`
+
+	// Todoに付与されたタグ
+	// 作成・更新時は Name のみを指定し、nil の場合は変更しない（空スライスの場合は全て外す）
+	Tags []Tag `db:"-"`
 }
 
+// TodoUpdate はTodoの更新内容（空文字・nil・未指定の項目は更新しない）
+type TodoUpdate struct {
+	Title       string
+	Description *string // 空文字の場合は説明を消す
+	Priority    TodoPriority
+	Completed   *bool
+	ParentID    Nullable[int] // null の場合はルートTodoにする
+	ProjectID   Nullable[int] // null の場合はインボックスに移動する
+	DueAt       Nullable[time.Time]
+	RemindAt    Nullable[time.Time]
+
+	// 繰り返し設定は3項目をまとめて更新する
+	RecurrenceRule     *string
+	RecurrenceTimezone *string
+	RecurrenceStart    *time.Time
+}
+
+// TodoPatch は JSON Merge Patch（RFC 7396）によるTodoの部分更新
+// 未指定の項目は変更せず、null を指定した項目は値を消す（タイトル・優先度・完了状態は null にできない）
+type TodoPatch struct {
+	Title       Nullable[string]
+	Description Nullable[string]
+	Priority    Nullable[TodoPriority]
+	Completed   Nullable[bool]
+	ParentID    Nullable[int]
+	ProjectID   Nullable[int]
+	DueAt       Nullable[time.Time]
+	RemindAt    Nullable[time.Time]
+	// null の場合は全てのタグを外す
+	Tags Nullable[[]string]
+	// null の場合は繰り返しを解除する
+	RecurrenceRule Nullable[string]
+	// null の場合は UTC で計算する
+	RecurrenceTimezone Nullable[string]
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// TodoEventType はTodoの変更履歴の種類
+// @enum created,updated,completed,reopened,deleted,restored
+type TodoEventType string
+
+const (
+	TodoEventCreated   TodoEventType = "created"
+	TodoEventUpdated   TodoEventType = "updated"
+	TodoEventCompleted TodoEventType = "completed"
+	TodoEventReopened  TodoEventType = "reopened"
+	TodoEventDeleted   TodoEventType = "deleted"
+	TodoEventRestored  TodoEventType = "restored"
+)
+
+// FieldChange は1項目の変更前後の値
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// TodoChanges は項目名ごとの変更内容（todo_events.changes に JSONB で保存する）
+type TodoChanges map[string]FieldChange
+
+// Value は JSONB として保存する（lib/pq は []byte を bytea として送信するため文字列で渡す）
+func (c TodoChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *TodoChanges) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*c = TodoChanges{}
+		return nil
+	default:
+		return errors.New("unsupported type for TodoChanges")
+	}
+	return json.Unmarshal(b, c)
+}
+
+// TodoEvent はTodoの変更履歴の1件
+type TodoEvent struct {
+	ID        int           `db:"id"`
+	TodoID    int           `db:"todo_id"`
+	Type      TodoEventType `db:"event_type"`
+	ActorID   *int          `db:"actor_id"` // nil の場合はシステム（バックグラウンドジョブなど）による変更
+	Changes   TodoChanges   `db:"changes"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+// TodoEventQuery は変更履歴取得の条件（新しい順）
+type TodoEventQuery struct {
+	Limit  int
+	Cursor *TodoEventCursor
+}
+
+// TodoEventPage は変更履歴の1ページ分の結果
+type TodoEventPage struct {
+	Events     []TodoEvent
+	NextCursor *TodoEventCursor
+	HasMore    bool
+}
+
+// TodoEventCursor は変更履歴のページネーション位置（最後に返した履歴のID）を表す
+type TodoEventCursor struct {
+	ID int
+}
+
+// Encode はカーソルをクライアントに返す不透明な文字列に変換する
+func (c *TodoEventCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(c.ID)))
+}
+
+// DecodeTodoEventCursor は Encode で作成した文字列をカーソルに戻す
+func DecodeTodoEventCursor(s string) (*TodoEventCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.Atoi(string(b))
+	if err != nil || id <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &TodoEventCursor{ID: id}, nil
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MaxTodoImportRows は1回のインポートで登録できる最大行数
+const MaxTodoImportRows = 10000
+
+// TodoImportRow はインポートするファイルの1行（ジョブの payload として JSONB に保存する）
+type TodoImportRow struct {
+	// ファイル内の行番号（1始まり、CSVの見出し行を含む）
+	Line        int          `json:"line"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Priority    TodoPriority `json:"priority"`
+	DueAt       *time.Time   `json:"due_at"`
+	RemindAt    *time.Time   `json:"remind_at"`
+	ParentID    *int         `json:"parent_id"`
+	ProjectID   *int         `json:"project_id"`
+	Tags        []string     `json:"tags"`
+	// 繰り返し設定（nil の場合は繰り返さない）
+	RecurrenceRule     *string `json:"recurrence_rule"`
+	RecurrenceTimezone *string `json:"timezone"`
+}
+
+// Todo は行の内容から作成するTodoを返す
+func (r TodoImportRow) Todo() *Todo {
+	tags := make([]Tag, len(r.Tags))
+	for i, name := range r.Tags {
+		tags[i] = Tag{Name: name}
+	}
+	return &Todo{
+		Title:              r.Title,
+		Description:        r.Description,
+		Priority:           r.Priority,
+		DueAt:              r.DueAt,
+		RemindAt:           r.RemindAt,
+		ParentID:           r.ParentID,
+		ProjectID:          r.ProjectID,
+		Tags:               tags,
+		RecurrenceRule:     r.RecurrenceRule,
+		RecurrenceTimezone: r.RecurrenceTimezone,
+	}
+}
+
+type TodoImportRows []TodoImportRow
+
+func (r TodoImportRows) Value() (driver.Value, error) {
+	return jsonbValue(r, "[]")
+}
+
+func (r *TodoImportRows) Scan(src interface{}) error {
+	return scanJSONB(src, r)
+}
+
+// TodoImportRowError はインポートできない行の項目とエラーメッセージ
+type TodoImportRowError struct {
+	Line int `json:"line"`
+	// 行全体のエラーの場合は空文字
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type TodoImportRowErrors []TodoImportRowError
+
+func (e TodoImportRowErrors) Value() (driver.Value, error) {
+	return jsonbValue(e, "[]")
+}
+
+func (e *TodoImportRowErrors) Scan(src interface{}) error {
+	return scanJSONB(src, e)
+}
+
+// TodoImportResult は同期的に実行したインポート（ドライランを含む）の結果
+type TodoImportResult struct {
+	TotalRows    int
+	ImportedRows int
+	// エラーがある場合は1行も登録しない（登録中にエラーが発生した場合を除く）
+	Errors []TodoImportRowError
+}
+
+type TodoImportJobStatus string
+
+const (
+	TodoImportJobPending   TodoImportJobStatus = "pending"
+	TodoImportJobRunning   TodoImportJobStatus = "running"
+	TodoImportJobSucceeded TodoImportJobStatus = "succeeded"
+	TodoImportJobFailed    TodoImportJobStatus = "failed"
+)
+
+// TodoImportJob は非同期で実行するインポート
+type TodoImportJob struct {
+	ID          int                 `db:"id"`
+	WorkspaceID int                 `db:"workspace_id"`
+	UserID      *int                `db:"user_id"`
+	Status      TodoImportJobStatus `db:"status"`
+	// 完了したジョブでは nil
+	Payload      TodoImportRows      `db:"payload"`
+	TotalRows    int                 `db:"total_rows"`
+	ImportedRows int                 `db:"imported_rows"`
+	Errors       TodoImportRowErrors `db:"errors"`
+	CreatedAt    time.Time           `db:"created_at"`
+	StartedAt    *time.Time          `db:"started_at"`
+	HeartbeatAt  *time.Time          `db:"heartbeat_at"`
+	FinishedAt   *time.Time          `db:"finished_at"`
+	// ClaimToken はジョブを取得したワーカーを表す（ClaimNext で取得した場合のみ設定される）
+	ClaimToken *string `db:"claim_token"`
+}
+
+// jsonbValue は v を JSONB として保存する（lib/pq は []byte を bytea として送信するため文字列で渡す）
+func jsonbValue(v interface{}, empty string) (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if string(b) == "null" {
+		return empty, nil
+	}
+	return string(b), nil
+}
+
+func scanJSONB(src interface{}, dest interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for JSONB: %T", src)
+	}
+}
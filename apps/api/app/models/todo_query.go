@@ -0,0 +1,217 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTodoListLimit は limit 未指定時の取得件数
+	DefaultTodoListLimit = 20
+	// MaxTodoListLimit は1ページで取得できる最大件数
+	MaxTodoListLimit = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidSort   = errors.New("invalid sort")
+)
+
+// TodoSortField は一覧の並び替えに使用できる項目
+type TodoSortField string
+
+const (
+	TodoSortCreatedAt TodoSortField = "created_at"
+	TodoSortUpdatedAt TodoSortField = "updated_at"
+	TodoSortPriority  TodoSortField = "priority"
+	TodoSortTitle     TodoSortField = "title"
+)
+
+// TodoSortFields は並び替え可能な項目のホワイトリスト
+var TodoSortFields = []TodoSortField{
+	TodoSortCreatedAt,
+	TodoSortUpdatedAt,
+	TodoSortPriority,
+	TodoSortTitle,
+}
+
+// TodoSort は並び替えの1キー
+type TodoSort struct {
+	Field TodoSortField
+	Desc  bool
+}
+
+// DefaultTodoSort は sort 未指定時の並び順（新しい順）
+var DefaultTodoSort = []TodoSort{{Field: TodoSortCreatedAt, Desc: true}}
+
+// TodoCursor はキーセットページネーションの位置（最後に返したTodo）を表す
+// 並び替えキーの値を全て保持し、どの並び順でも続きから取得できるようにする
+type TodoCursor struct {
+	Sort      string       `json:"sort"`
+	ID        int          `json:"id"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	Priority  TodoPriority `json:"priority,omitempty"`
+	Title     string       `json:"title,omitempty"`
+}
+
+// TodoListQuery はTodo一覧取得の条件
+type TodoListQuery struct {
+	Limit  int
+	Cursor *TodoCursor
+
+	// UserID が指定された場合はそのユーザーがアクセスできるTodo（自分のインボックスのTodoと、メンバーになっているプロジェクトのTodo）のみを対象にする
+	UserID *int
+	// ProjectID が指定された場合はそのプロジェクトのTodoのみを対象にする
+	ProjectID *int
+
+	// フィルタ（nil・空の場合は条件なし）
+	Completed     *bool
+	Priorities    []TodoPriority
+	CreatedAfter  *time.Time // created_at >= CreatedAfter
+	CreatedBefore *time.Time // created_at < CreatedBefore
+	Tags          []string   // 指定した全てのタグが付いたTodo
+
+	Sort []TodoSort
+
+	// RootsOnly は親を持たないTodoのみを対象にする（ツリー表示用）
+	RootsOnly bool
+}
+
+// TodoPage はTodo一覧の1ページ分の結果
+type TodoPage struct {
+	Todos      []Todo
+	NextCursor *TodoCursor
+	HasMore    bool
+}
+
+// TodoSearchQuery は全文検索の条件
+type TodoSearchQuery struct {
+	Q     string
+	Limit int
+
+	// UserID が指定された場合はそのユーザーがアクセスできるTodo（自分のインボックスのTodoと、メンバーになっているプロジェクトのTodo）のみを対象にする
+	UserID *int
+}
+
+// TodoSearchResult は全文検索の1件分の結果
+type TodoSearchResult struct {
+	Todo
+	Rank float64 `db:"rank"`
+
+	// 検索語を <mark> で囲んだタイトル・説明の抜粋（HTMLエスケープ済み）
+	TitleHighlight     string `db:"-"`
+	DescriptionSnippet string `db:"-"`
+}
+
+// SortOrDefault は指定された並び順、未指定ならデフォルトの並び順を返す
+func (q TodoListQuery) SortOrDefault() []TodoSort {
+	if len(q.Sort) == 0 {
+		return DefaultTodoSort
+	}
+	return q.Sort
+}
+
+// IsValidTodoSortField はホワイトリストに含まれる項目かどうかを返す
+func IsValidTodoSortField(field TodoSortField) bool {
+	for _, f := range TodoSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTodoSort は "priority,-created_at" 形式の文字列を並び順に変換する
+// 先頭に "-" が付いた項目は降順になる
+func ParseTodoSort(s string) ([]TodoSort, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var sorts []TodoSort
+	seen := map[TodoSortField]bool{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		sort := TodoSort{}
+		if strings.HasPrefix(part, "-") {
+			sort.Desc = true
+			part = part[1:]
+		}
+		sort.Field = TodoSortField(part)
+
+		if !IsValidTodoSortField(sort.Field) || seen[sort.Field] {
+			return nil, ErrInvalidSort
+		}
+		seen[sort.Field] = true
+		sorts = append(sorts, sort)
+	}
+
+	return sorts, nil
+}
+
+// FormatTodoSort は並び順を ParseTodoSort で解釈できる文字列に戻す
+func FormatTodoSort(sorts []TodoSort) string {
+	parts := make([]string, len(sorts))
+	for i, sort := range sorts {
+		parts[i] = string(sort.Field)
+		if sort.Desc {
+			parts[i] = "-" + parts[i]
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// PriorityRank は優先度を並び替え用の数値に変換する（low < medium < high）
+func PriorityRank(priority TodoPriority) int {
+	switch priority {
+	case PriorityLow:
+		return 1
+	case PriorityHigh:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// NewTodoCursor は指定したTodoの直後から取得するためのカーソルを作成する
+func NewTodoCursor(todo Todo, sorts []TodoSort) *TodoCursor {
+	return &TodoCursor{
+		Sort:      FormatTodoSort(sorts),
+		ID:        todo.ID,
+		CreatedAt: todo.CreatedAt,
+		UpdatedAt: todo.UpdatedAt,
+		Priority:  todo.Priority,
+		Title:     todo.Title,
+	}
+}
+
+// Encode はカーソルをクライアントに返す不透明な文字列に変換する
+func (c *TodoCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeTodoCursor は Encode で作成した文字列をカーソルに戻す
+func DecodeTodoCursor(s string) (*TodoCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor TodoCursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.ID <= 0 || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := ParseTodoSort(cursor.Sort); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// TrashListQuery はゴミ箱一覧取得の条件
+type TrashListQuery struct {
+	Limit  int
+	Cursor *TrashCursor
+
+	// UserID が指定された場合はそのユーザーがアクセスできるTodo（自分のインボックスのTodoと、メンバーになっているプロジェクトのTodo）のみを対象にする
+	UserID *int
+}
+
+// TrashPage はゴミ箱一覧の1ページ分の結果
+type TrashPage struct {
+	Todos      []Todo
+	NextCursor *TrashCursor
+	HasMore    bool
+}
+
+// TrashCursor はゴミ箱一覧（削除日時の新しい順）のページネーション位置を表す
+type TrashCursor struct {
+	DeletedAt time.Time `json:"deleted_at"`
+	ID        int       `json:"id"`
+}
+
+// NewTrashCursor は指定したTodoの直後から取得するためのカーソルを作成する
+func NewTrashCursor(todo Todo) *TrashCursor {
+	cursor := &TrashCursor{ID: todo.ID}
+	if todo.DeletedAt != nil {
+		cursor.DeletedAt = *todo.DeletedAt
+	}
+	return cursor
+}
+
+// Encode はカーソルをクライアントに返す不透明な文字列に変換する
+func (c *TrashCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeTrashCursor は Encode で作成した文字列をカーソルに戻す
+func DecodeTrashCursor(s string) (*TrashCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor TrashCursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.ID <= 0 || cursor.DeletedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	MaxUserNameLength  = 100
+	MaxUserEmailLength = 255
+)
+
+type User struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	Email     string    `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	// EmailVerifiedAt はメールアドレスの所有を確認した日時（未確認の場合は nil）
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+}
+
+// UserUpdate はユーザーの更新内容（空文字の項目は更新しない）
+type UserUpdate struct {
+	Name  string
+	Email string
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// OIDCLoginTTL は認可リクエストを開始してからコールバックまでに許容する時間
+const OIDCLoginTTL = 10 * time.Minute
+
+// UserIdentity は外部の IdP（OpenID Connect）のアカウントとユーザーの紐付け
+type UserIdentity struct {
+	ID          int        `db:"id"`
+	UserID      int        `db:"user_id"`
+	Issuer      string     `db:"issuer"`
+	Subject     string     `db:"subject"`
+	Email       *string    `db:"email"`
+	LastLoginAt *time.Time `db:"last_login_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// OIDCAuthRequest は認可リクエストの開始時に保存し、コールバックで検証する値
+// state は SHA-256 のハッシュのみを保存する
+type OIDCAuthRequest struct {
+	StateHash    string    `db:"state_hash"`
+	Nonce        string    `db:"nonce"`
+	CodeVerifier string    `db:"code_verifier"`
+	ExpiresAt    time.Time `db:"expires_at"`
+	CreatedAt    time.Time `db:"created_at"`
+	// LinkUserID はログイン済みのユーザーが IdP のアカウントを紐付ける場合のユーザー（ログインの場合は nil）
+	LinkUserID *int `db:"link_user_id"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// MaxWorkspaceNameLength はワークスペース名の最大文字数
+const MaxWorkspaceNameLength = 100
+
+// WorkspaceRole はワークスペースのメンバーのロール
+// @enum owner,member
+type WorkspaceRole string
+
+const (
+	// WorkspaceRoleOwner はメンバーを追加できる
+	WorkspaceRoleOwner WorkspaceRole = "owner"
+	// WorkspaceRoleMember はワークスペース内のTodo・プロジェクト・タグを利用できる
+	WorkspaceRoleMember WorkspaceRole = "member"
+)
+
+// IsValid は定義済みのロールかどうかを返す
+func (r WorkspaceRole) IsValid() bool {
+	return r == WorkspaceRoleOwner || r == WorkspaceRoleMember
+}
+
+// Workspace はTodo・プロジェクト・タグを分離する単位（チーム）
+type Workspace struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	// 取得したユーザーのロール
+	Role WorkspaceRole `db:"role"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int           `db:"workspace_id"`
+	UserID      int           `db:"user_id"`
+	Role        WorkspaceRole `db:"role"`
+	CreatedAt   time.Time     `db:"created_at"`
+
+	// メンバーのユーザー情報
+	UserName  string `db:"user_name"`
+	UserEmail string `db:"user_email"`
+}
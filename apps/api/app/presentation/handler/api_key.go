@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	apiKeyUsecase usecase.APIKeyUsecase
+}
+
+func NewAPIKeyHandler(apiKeyUsecase usecase.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUsecase: apiKeyUsecase,
+	}
+}
+
+// GetAPIKeys retrieves the API keys of the authenticated user
+// @Summary Get API keys
+// @Description Get the authenticated user's API keys (including revoked ones), newest first. The key itself is never returned.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Success 200 {object} handler.APIResponse{data=[]response.APIKeyResponse}
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyUsecase.GetAPIKeys(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "APIキーを管理する権限がありません")
+			return
+		}
+		response.InternalServerError(c, "APIキー一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "APIキー一覧を正常に取得しました",
+		"data":    response.ToAPIKeyResponses(keys),
+	})
+}
+
+// CreateAPIKey issues a new API key
+// @Summary Create an API key
+// @Description Issue an API key for machine-to-machine clients. The key is only returned in this response; store it securely.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param apiKey body request.CreateAPIKeyRequest true "Create API key request"
+// @Success 201 {object} handler.APIResponse{data=response.CreatedAPIKeyResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewCreateAPIKeyRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	key, rawKey, err := h.apiKeyUsecase.CreateAPIKey(c.Request.Context(), req.APIKey())
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "APIキーを管理する権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "APIキーの作成に失敗しました")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "APIキーが正常に作成されました（キーは再表示できないため安全に保管してください）",
+		"data": response.CreatedAPIKeyResponse{
+			APIKeyResponse: response.ToAPIKeyResponse(*key),
+			Key:            rawKey,
+		},
+	})
+}
+
+// RevokeAPIKey revokes an API key
+// @Summary Revoke an API key
+// @Description Revoke an API key. It is rejected from the next request on.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} handler.APIResponse{data=response.APIKeyResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	key, err := h.apiKeyUsecase.RevokeAPIKey(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "このAPIキーを失効させる権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+			response.NotFoundError(c, "指定されたAPIキー")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidIDError(c, "id")
+			return
+		}
+		response.InternalServerError(c, "APIキーの失効に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "APIキーを失効させました",
+		"data":    response.ToAPIKeyResponse(*key),
+	})
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	authUsecase usecase.AuthUsecase
+}
+
+func NewAuthHandler(authUsecase usecase.AuthUsecase) *AuthHandler {
+	return &AuthHandler{
+		authUsecase: authUsecase,
+	}
+}
+
+// Login authenticates a user with email and password
+// @Summary Log in
+// @Description Log in with email and password. Returns a short-lived access token (JWT) and a refresh token.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param login body request.LoginRequest true "Login request"
+// @Success 200 {object} handler.APIResponse{data=response.AuthTokensResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewLoginRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	tokens, err := h.authUsecase.Login(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCredentials) {
+			response.UnauthorizedError(c, "メールアドレスまたはパスワードが正しくありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrLoginDisabled) {
+			response.InternalServerError(c, "ログインは設定されていません")
+			return
+		}
+		response.InternalServerError(c, "ログインに失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ログインしました",
+		"data":    response.ToAuthTokensResponse(*tokens),
+	})
+}
+
+// Refresh rotates a refresh token and issues a new access token
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and refresh token. The used refresh token is invalidated; reusing it revokes every token issued from the same login.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body request.RefreshTokenRequest true "Refresh token request"
+// @Success 200 {object} handler.APIResponse{data=response.AuthTokensResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewRefreshTokenRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	tokens, err := h.authUsecase.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidRefreshToken) {
+			response.UnauthorizedError(c, "リフレッシュトークンが無効です。再度ログインしてください")
+			return
+		}
+		if errors.Is(err, usecase.ErrLoginDisabled) {
+			response.InternalServerError(c, "ログインは設定されていません")
+			return
+		}
+		response.InternalServerError(c, "トークンの更新に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "トークンを更新しました",
+		"data":    response.ToAuthTokensResponse(*tokens),
+	})
+}
+
+// Logout revokes a refresh token
+// @Summary Log out
+// @Description Revoke the refresh token and every token issued from the same login. Already issued access tokens stay valid until they expire.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param logout body request.RefreshTokenRequest true "Logout request"
+// @Success 200 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewRefreshTokenRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	if err := h.authUsecase.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		response.InternalServerError(c, "ログアウトに失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ログアウトしました",
+	})
+}
+
+// RequestPasswordReset sends a password reset email
+// @Summary Request a password reset
+// @Description Email a single-use password reset link. Responds the same way whether or not the email address is registered.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param passwordReset body request.PasswordResetRequest true "Password reset request"
+// @Success 202 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/auth/password-reset [post]
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewPasswordResetRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	if err := h.authUsecase.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "パスワード再設定の受付に失敗しました")
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "登録されているメールアドレスの場合、パスワード再設定のメールを送信しました",
+	})
+}
+
+// ConfirmPasswordReset sets a new password with a reset token
+// @Summary Reset the password
+// @Description Set a new password with the token from the password reset email. The token can be used once, and every refresh token of the user is revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param passwordReset body request.ConfirmPasswordResetRequest true "Confirm password reset request"
+// @Success 200 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/auth/password-reset/confirm [post]
+func (h *AuthHandler) ConfirmPasswordReset(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewConfirmPasswordResetRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	if err := h.authUsecase.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		if errors.Is(err, usecase.ErrInvalidResetToken) {
+			response.InvalidRequestError(c, "パスワード再設定のリンクが無効または期限切れです")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "パスワードの再設定に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "パスワードを再設定しました",
+	})
+}
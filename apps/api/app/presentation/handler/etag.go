@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// todoETag はTodoのバージョンから強いETagを作る
+func todoETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseIfMatch は If-Match ヘッダーから更新・削除の前提となるバージョンを取り出す
+// ヘッダーが無い場合と * の場合は nil（条件なし）を返す
+// 弱いETag（W/）は If-Match では一致しないため、複数のETagと同様に受け付けない
+func parseIfMatch(c *gin.Context) (*int, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return nil, errInvalidIfMatch
+	}
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil {
+		return nil, errInvalidIfMatch
+	}
+	return &version, nil
+}
+
+// notModified は If-None-Match のいずれかのETagが etag と一致するかを返す（GET のため弱い比較で判定する）
+func notModified(c *gin.Context, etag string) bool {
+	value := c.GetHeader("If-None-Match")
+	if value == "" {
+		return false
+	}
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
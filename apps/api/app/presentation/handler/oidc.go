@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OIDCHandler struct {
+	oidcUsecase usecase.OIDCUsecase
+}
+
+func NewOIDCHandler(oidcUsecase usecase.OIDCUsecase) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUsecase: oidcUsecase,
+	}
+}
+
+// Authorize starts an OpenID Connect login
+// @Summary Start OpenID Connect login
+// @Description Returns the identity provider's authorization URL (authorization code flow with PKCE). Redirect the browser to it; the provider redirects back to the dashboard with code and state.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} handler.APIResponse{data=response.OIDCAuthorizationResponse}
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Failure 502 {object} response.ErrorResponse
+// @Router /api/v1/auth/oidc/authorize [get]
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	authURL, err := h.oidcUsecase.StartLogin(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, usecase.ErrOIDCProviderUnavailable) {
+			response.ExternalAPIError(c, "認証プロバイダーに接続できませんでした")
+			return
+		}
+		response.InternalServerError(c, "ログインの開始に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "認証プロバイダーのURLを取得しました",
+		"data":    response.OIDCAuthorizationResponse{AuthorizationURL: authURL},
+	})
+}
+
+// Callback completes an OpenID Connect login
+// @Summary Complete OpenID Connect login
+// @Description Exchange the code and state from the identity provider's redirect for an access token and refresh token. The first login creates a new user.
+// @Description If a user with the same email address already exists, the account is linked only when that user has no password and a verified email address; otherwise the response is 409 and the user must sign in and link the account with POST /auth/oidc/link.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param callback body request.OIDCCallbackRequest true "OIDC callback request"
+// @Success 200 {object} handler.APIResponse{data=response.AuthTokensResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Failure 502 {object} response.ErrorResponse
+// @Router /api/v1/auth/oidc/callback [post]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewOIDCCallbackRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	tokens, err := h.oidcUsecase.CompleteLogin(c.Request.Context(), req.State, req.Code)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidOIDCState) {
+			response.InvalidRequestError(c, "ログインの有効期限が切れています。もう一度ログインしてください")
+			return
+		}
+		if errors.Is(err, usecase.ErrOIDCLoginFailed) {
+			response.UnauthorizedError(c, "認証プロバイダーでの認証に失敗しました")
+			return
+		}
+		if errors.Is(err, usecase.ErrOIDCEmailNotVerified) {
+			response.BusinessRuleError(c, "認証プロバイダーでメールアドレスが確認されていません")
+			return
+		}
+		if errors.Is(err, usecase.ErrOIDCLinkRequired) {
+			response.ConflictError(c, "このメールアドレスのユーザーが既に存在します。ログインしてからアカウントを連携してください")
+			return
+		}
+		if errors.Is(err, usecase.ErrOIDCIdentityAlreadyLinked) {
+			response.ConflictError(c, "この認証プロバイダーのアカウントは既に別のユーザーに連携されています")
+			return
+		}
+		if errors.Is(err, usecase.ErrOIDCProviderUnavailable) {
+			response.ExternalAPIError(c, "認証プロバイダーに接続できませんでした")
+			return
+		}
+		if errors.Is(err, usecase.ErrLoginDisabled) {
+			response.InternalServerError(c, "ログインは設定されていません")
+			return
+		}
+		response.InternalServerError(c, "ログインに失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ログインしました",
+		"data":    response.ToAuthTokensResponse(*tokens),
+	})
+}
+
+// Link starts linking an identity provider account to the current user
+// @Summary Link OpenID Connect account
+// @Description Returns the identity provider's authorization URL for linking its account to the signed-in user. Complete it with POST /auth/oidc/callback like a login; afterwards the account can be used to sign in as this user.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} handler.APIResponse{data=response.OIDCAuthorizationResponse}
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Failure 502 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/auth/oidc/link [post]
+func (h *OIDCHandler) Link(c *gin.Context) {
+	authURL, err := h.oidcUsecase.StartLink(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "アカウントの連携にはログインが必要です")
+			return
+		}
+		if errors.Is(err, usecase.ErrOIDCProviderUnavailable) {
+			response.ExternalAPIError(c, "認証プロバイダーに接続できませんでした")
+			return
+		}
+		response.InternalServerError(c, "アカウントの連携の開始に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "認証プロバイダーのURLを取得しました",
+		"data":    response.OIDCAuthorizationResponse{AuthorizationURL: authURL},
+	})
+}
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"api/app/models"
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	projectUsecase usecase.ProjectUsecase
+}
+
+func NewProjectHandler(projectUsecase usecase.ProjectUsecase) *ProjectHandler {
+	return &ProjectHandler{
+		projectUsecase: projectUsecase,
+	}
+}
+
+// GetProjects retrieves the projects of the authenticated user
+// @Summary Get projects
+// @Description Get the authenticated user's projects ordered by name, with the number of open and completed todos in each.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param include_archived query bool false "Include archived projects"
+// @Success 200 {object} handler.APIResponse{data=[]response.ProjectResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects [get]
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	req, err := request.NewListProjectsRequest(c)
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+
+	projects, err := h.projectUsecase.GetProjects(c.Request.Context(), req.Query())
+	if err != nil {
+		response.InternalServerError(c, "プロジェクト一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "プロジェクト一覧を正常に取得しました",
+		"data":    response.ToProjectResponses(projects),
+	})
+}
+
+// GetProject retrieves a single project by ID
+// @Summary Get a project by ID
+// @Description Get a single project by its ID, with the number of open and completed todos
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} handler.APIResponse{data=response.ProjectResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id} [get]
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	project, err := h.projectUsecase.GetProjectByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "プロジェクトの取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "プロジェクトを正常に取得しました",
+		"data":    response.ToProjectResponse(*project),
+	})
+}
+
+// CreateProject creates a new project
+// @Summary Create a new project
+// @Description Create a new project owned by the authenticated user
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param project body request.CreateProjectRequest true "Create project request"
+// @Success 201 {object} handler.APIResponse{data=response.ProjectResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects [post]
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewCreateProjectRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	// model変換してusecaseに渡す
+	projectModel, err := req.Project()
+	if err != nil {
+		response.InternalServerError(c, "処理中にエラーが発生しました")
+		return
+	}
+	project, err := h.projectUsecase.CreateProject(c.Request.Context(), projectModel)
+	if err != nil {
+		h.handleError(c, err, "プロジェクトの作成に失敗しました")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "プロジェクトが正常に作成されました",
+		"data":    response.ToProjectResponse(*project),
+	})
+}
+
+// UpdateProject updates an existing project
+// @Summary Update a project
+// @Description Rename, recolor, archive or unarchive a project. Only the given fields are changed.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param project body request.UpdateProjectRequest true "Update project request"
+// @Success 200 {object} handler.APIResponse{data=response.ProjectResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id} [put]
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewUpdateProjectRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	project, err := h.projectUsecase.UpdateProject(c.Request.Context(), id, req.ProjectUpdate())
+	if err != nil {
+		h.handleError(c, err, "プロジェクトの更新に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "プロジェクトが正常に更新されました",
+		"data":    response.ToProjectResponse(*project),
+	})
+}
+
+// DeleteProject deletes a project
+// @Summary Delete a project
+// @Description Delete a project. With mode=inbox (default) its todos are moved to the inbox; with mode=cascade they are moved to the trash together with their subtasks.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param mode query string false "What to do with the project's todos" Enums(inbox, cascade) default(inbox)
+// @Success 200 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id} [delete]
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	req, validationDetails, err := request.NewDeleteProjectRequest(c)
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	if err := h.projectUsecase.DeleteProject(c.Request.Context(), id, req.Mode); err != nil {
+		h.handleError(c, err, "プロジェクトの削除に失敗しました")
+		return
+	}
+
+	message := "プロジェクトを削除し、Todoをインボックスに移動しました"
+	if req.Mode == models.ProjectDeleteCascade {
+		message = "プロジェクトを削除し、Todoをゴミ箱に移動しました"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+	})
+}
+
+// handleError はプロジェクトのusecaseのエラーをレスポンスに変換する
+func (h *ProjectHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usecase.ErrForbidden):
+		response.ForbiddenError(c, "このプロジェクトへのアクセス権限がありません")
+	case errors.Is(err, usecase.ErrProjectNotFound):
+		response.NotFoundError(c, "指定されたプロジェクト")
+	case errors.Is(err, usecase.ErrInvalidInput):
+		response.InvalidRequestError(c, "入力データが無効です")
+	default:
+		response.InternalServerError(c, fallback)
+	}
+}
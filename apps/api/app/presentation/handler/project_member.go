@@ -0,0 +1,386 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectMemberHandler struct {
+	projectMemberUsecase usecase.ProjectMemberUsecase
+}
+
+func NewProjectMemberHandler(projectMemberUsecase usecase.ProjectMemberUsecase) *ProjectMemberHandler {
+	return &ProjectMemberHandler{
+		projectMemberUsecase: projectMemberUsecase,
+	}
+}
+
+// GetMembers retrieves the members of a project
+// @Summary Get project members
+// @Description Get the members of a project with their roles. Any member can see the list.
+// @Tags project-members
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} handler.APIResponse{data=[]response.ProjectMemberResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id}/members [get]
+func (h *ProjectMemberHandler) GetMembers(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	members, err := h.projectMemberUsecase.GetMembers(c.Request.Context(), projectID)
+	if err != nil {
+		h.handleError(c, err, "メンバー一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "メンバー一覧を正常に取得しました",
+		"data":    response.ToProjectMemberResponses(members),
+	})
+}
+
+// UpdateMember changes the role of a project member
+// @Summary Change a member's role
+// @Description Change the role of a project member. Only owners can change roles, and the last owner cannot be demoted.
+// @Tags project-members
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param user_id path int true "User ID"
+// @Param member body request.UpdateProjectMemberRequest true "Update member request"
+// @Success 200 {object} handler.APIResponse{data=response.ProjectMemberResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id}/members/{user_id} [put]
+func (h *ProjectMemberHandler) UpdateMember(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		response.InvalidIDError(c, "user_id")
+		return
+	}
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewUpdateProjectMemberRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+
+	member, err := h.projectMemberUsecase.UpdateMemberRole(c.Request.Context(), projectID, userID, req.Role)
+	if err != nil {
+		h.handleError(c, err, "メンバーのロールの変更に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "メンバーのロールが正常に変更されました",
+		"data":    response.ToProjectMemberResponse(*member),
+	})
+}
+
+// RemoveMember removes a member from a project
+// @Summary Remove a project member
+// @Description Remove a member from a project. Owners can remove any member; other members can only remove themselves (leave). The last owner cannot be removed.
+// @Tags project-members
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id}/members/{user_id} [delete]
+func (h *ProjectMemberHandler) RemoveMember(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		response.InvalidIDError(c, "user_id")
+		return
+	}
+
+	if err := h.projectMemberUsecase.RemoveMember(c.Request.Context(), projectID, userID); err != nil {
+		h.handleError(c, err, "メンバーの削除に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "メンバーが正常に削除されました",
+	})
+}
+
+// GetProjectInvitations retrieves the pending invitations of a project
+// @Summary Get project invitations
+// @Description Get the pending invitations of a project, newest first. Only owners can see them.
+// @Tags project-members
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} handler.APIResponse{data=[]response.ProjectInvitationResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id}/invitations [get]
+func (h *ProjectMemberHandler) GetProjectInvitations(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	invitations, err := h.projectMemberUsecase.GetProjectInvitations(c.Request.Context(), projectID)
+	if err != nil {
+		h.handleError(c, err, "招待一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "招待一覧を正常に取得しました",
+		"data":    response.ToProjectInvitationResponses(invitations),
+	})
+}
+
+// CreateProjectInvitation invites a user to a project
+// @Summary Invite a user to a project
+// @Description Invite a registered user to a project by email. The invited user is notified and becomes a member with the given role when they accept.
+// @Tags project-members
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param invitation body request.CreateProjectInvitationRequest true "Create invitation request"
+// @Success 201 {object} handler.APIResponse{data=response.ProjectInvitationResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id}/invitations [post]
+func (h *ProjectMemberHandler) CreateProjectInvitation(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewCreateProjectInvitationRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+
+	invitation, err := h.projectMemberUsecase.InviteMember(c.Request.Context(), projectID, req.Email, req.Role)
+	if err != nil {
+		h.handleError(c, err, "招待の作成に失敗しました")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "ユーザーを招待しました",
+		"data":    response.ToProjectInvitationResponse(*invitation),
+	})
+}
+
+// RevokeProjectInvitation revokes a pending invitation
+// @Summary Revoke a project invitation
+// @Description Revoke a pending invitation so that it can no longer be accepted. Only owners can revoke invitations.
+// @Tags project-members
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param invitation_id path int true "Invitation ID"
+// @Success 200 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id}/invitations/{invitation_id} [delete]
+func (h *ProjectMemberHandler) RevokeProjectInvitation(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	invitationID, err := strconv.Atoi(c.Param("invitation_id"))
+	if err != nil {
+		response.InvalidIDError(c, "invitation_id")
+		return
+	}
+
+	if err := h.projectMemberUsecase.RevokeInvitation(c.Request.Context(), projectID, invitationID); err != nil {
+		h.handleError(c, err, "招待の取り消しに失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "招待を取り消しました",
+	})
+}
+
+// GetMyInvitations retrieves the pending invitations of the authenticated user
+// @Summary Get my invitations
+// @Description Get the pending project invitations the authenticated user has received, newest first.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Success 200 {object} handler.APIResponse{data=[]response.ProjectInvitationResponse}
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/invitations [get]
+func (h *ProjectMemberHandler) GetMyInvitations(c *gin.Context) {
+	invitations, err := h.projectMemberUsecase.GetMyInvitations(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err, "招待一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "招待一覧を正常に取得しました",
+		"data":    response.ToProjectInvitationResponses(invitations),
+	})
+}
+
+// AcceptInvitation accepts a project invitation
+// @Summary Accept an invitation
+// @Description Accept a pending invitation and join the project with the invited role. The inviter is notified.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param id path int true "Invitation ID"
+// @Success 200 {object} handler.APIResponse{data=response.ProjectInvitationResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/invitations/{id}/accept [post]
+func (h *ProjectMemberHandler) AcceptInvitation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	invitation, err := h.projectMemberUsecase.AcceptInvitation(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "招待の承諾に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "招待を承諾しました",
+		"data":    response.ToProjectInvitationResponse(*invitation),
+	})
+}
+
+// DeclineInvitation declines a project invitation
+// @Summary Decline an invitation
+// @Description Decline a pending invitation. The inviter is notified.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param id path int true "Invitation ID"
+// @Success 200 {object} handler.APIResponse{data=response.ProjectInvitationResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/invitations/{id}/decline [post]
+func (h *ProjectMemberHandler) DeclineInvitation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	invitation, err := h.projectMemberUsecase.DeclineInvitation(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "招待の辞退に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "招待を辞退しました",
+		"data":    response.ToProjectInvitationResponse(*invitation),
+	})
+}
+
+// handleError はメンバー・招待のusecaseのエラーをレスポンスに変換する
+func (h *ProjectMemberHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usecase.ErrForbidden):
+		response.ForbiddenError(c, "この操作を行う権限がありません")
+	case errors.Is(err, usecase.ErrProjectNotFound):
+		response.NotFoundError(c, "指定されたプロジェクト")
+	case errors.Is(err, usecase.ErrNotProjectMember):
+		response.NotFoundError(c, "指定されたメンバー")
+	case errors.Is(err, usecase.ErrUserNotFound):
+		response.NotFoundError(c, "指定されたメールアドレスのユーザー")
+	case errors.Is(err, usecase.ErrInvitationNotFound):
+		response.NotFoundError(c, "指定された招待")
+	case errors.Is(err, usecase.ErrAlreadyProjectMember):
+		response.AlreadyExistsError(c, "指定されたユーザーのメンバーシップ")
+	case errors.Is(err, usecase.ErrInvitationAlreadyExists):
+		response.AlreadyExistsError(c, "指定されたユーザーへの招待")
+	case errors.Is(err, usecase.ErrLastProjectOwner):
+		response.BusinessRuleError(c, "プロジェクトには少なくとも1人のオーナーが必要です")
+	case errors.Is(err, usecase.ErrNotWorkspaceMember):
+		response.BusinessRuleError(c, "ワークスペースのメンバーのみ招待できます")
+	case errors.Is(err, usecase.ErrInvalidInput):
+		response.InvalidRequestError(c, "入力データが無効です")
+	default:
+		response.InternalServerError(c, fallback)
+	}
+}
@@ -13,6 +13,14 @@ type APIResponse struct {
 	Data    any    `json:"data,omitempty"`
 }
 
+// PaginatedAPIResponse はカーソルページネーション付き一覧のレスポンス
+type PaginatedAPIResponse struct {
+	Message    string  `json:"message"`
+	Data       any     `json:"data"`
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
+}
+
 func NewSimpleHandler() *SimpleHandler {
 	return &SimpleHandler{}
 }
@@ -25,30 +33,3 @@ func (h *SimpleHandler) Hello(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, response)
 }
-
-func (h *SimpleHandler) GetUsers(c *gin.Context) {
-	users := []gin.H{
-		{"id": 1, "name": "John Doe", "email": "john@example.com"},
-		{"id": 2, "name": "Jane Smith", "email": "jane@example.com"},
-	}
-	response := APIResponse{
-		Message: "Users retrieved successfully",
-		Data:    users,
-	}
-	c.JSON(http.StatusOK, response)
-}
-
-func (h *SimpleHandler) CreateUser(c *gin.Context) {
-	var newUser gin.H
-	if err := c.ShouldBindJSON(&newUser); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	newUser["id"] = 3
-	response := APIResponse{
-		Message: "User created successfully",
-		Data:    newUser,
-	}
-	c.JSON(http.StatusCreated, response)
-}
\ No newline at end of file
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagUsecase usecase.TagUsecase
+}
+
+func NewTagHandler(tagUsecase usecase.TagUsecase) *TagHandler {
+	return &TagHandler{
+		tagUsecase: tagUsecase,
+	}
+}
+
+// GetTags retrieves all tags
+// @Summary Get tags
+// @Description Get all tags ordered by name
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Success 200 {object} handler.APIResponse{data=[]response.TagResponse}
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/tags [get]
+func (h *TagHandler) GetTags(c *gin.Context) {
+	tags, err := h.tagUsecase.GetAllTags(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, "タグ一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "タグ一覧を正常に取得しました",
+		"data":    response.ToTagResponses(tags),
+	})
+}
+
+// GetTag retrieves a single tag by ID
+// @Summary Get a tag by ID
+// @Description Get a single tag by its ID
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} handler.APIResponse{data=response.TagResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/tags/{id} [get]
+func (h *TagHandler) GetTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	tag, err := h.tagUsecase.GetTagByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTagNotFound) {
+			response.NotFoundError(c, "指定されたタグ")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidIDError(c, "id")
+			return
+		}
+		response.InternalServerError(c, "タグの取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "タグを正常に取得しました",
+		"data":    response.ToTagResponse(*tag),
+	})
+}
+
+// CreateTag creates a new tag
+// @Summary Create a new tag
+// @Description Create a new tag. Tag names are unique.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body request.CreateTagRequest true "Create tag request"
+// @Success 201 {object} handler.APIResponse{data=response.TagResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewCreateTagRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	// model変換してusecaseに渡す
+	tagModel, err := req.Tag()
+	if err != nil {
+		response.InternalServerError(c, "処理中にエラーが発生しました")
+		return
+	}
+	tag, err := h.tagUsecase.CreateTag(c.Request.Context(), tagModel)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTagAlreadyExists) {
+			response.AlreadyExistsError(c, "同じ名前のタグ")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "タグの作成に失敗しました")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "タグが正常に作成されました",
+		"data":    response.ToTagResponse(*tag),
+	})
+}
+
+// UpdateTag renames an existing tag
+// @Summary Update a tag
+// @Description Rename an existing tag. Todos keep the tag under its new name.
+// @Description Tags are shared in the workspace, so a tag on todos the current user cannot access cannot be renamed (403).
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param tag body request.UpdateTagRequest true "Update tag request"
+// @Success 200 {object} handler.APIResponse{data=response.TagResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/tags/{id} [put]
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewUpdateTagRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	// model変換してusecaseに渡す
+	tagModel, err := req.Tag()
+	if err != nil {
+		response.InternalServerError(c, "処理中にエラーが発生しました")
+		return
+	}
+	tag, err := h.tagUsecase.UpdateTag(c.Request.Context(), id, tagModel)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTagNotFound) {
+			response.NotFoundError(c, "指定されたタグ")
+			return
+		}
+		if errors.Is(err, usecase.ErrTagAlreadyExists) {
+			response.AlreadyExistsError(c, "同じ名前のタグ")
+			return
+		}
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "他のユーザーのTodoに付与されているタグは変更できません")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "タグの更新に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "タグが正常に更新されました",
+		"data":    response.ToTagResponse(*tag),
+	})
+}
+
+// DeleteTag deletes a tag
+// @Summary Delete a tag
+// @Description Delete a tag by its ID. The tag is removed from all todos.
+// @Description A tag on todos the current user cannot access cannot be deleted (403).
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	err = h.tagUsecase.DeleteTag(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTagNotFound) {
+			response.NotFoundError(c, "指定されたタグ")
+			return
+		}
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "他のユーザーのTodoに付与されているタグは削除できません")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidIDError(c, "id")
+			return
+		}
+		response.InternalServerError(c, "タグの削除に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "タグを正常に削除しました",
+	})
+}
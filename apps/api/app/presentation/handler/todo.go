@@ -21,42 +21,182 @@ func NewTodoHandler(todoUsecase usecase.TodoUsecase) *TodoHandler {
 	}
 }
 
-// GetTodos retrieves all todos
-// @Summary Get all todos
-// @Description Get a list of all todos
+// GetTodos retrieves todos with filtering, sorting and cursor pagination
+// @Summary Get todos
+// @Description Get a page of todos (newest first by default). Pass next_cursor as cursor with the same sort to fetch the next page.
+// @Description With Accept: text/csv or application/x-ndjson, all matching todos are streamed in that format instead (limit, cursor and tree are ignored).
 // @Tags todos
 // @Accept json
-// @Produce json
-// @Success 200 {object} handler.APIResponse{data=[]response.TodoResponse}
+// @Produce json,text/csv,application/x-ndjson
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor"
+// @Param completed query bool false "Filter by completion status"
+// @Param priority query []string false "Filter by priority (repeatable or comma separated)" collectionFormat(multi) Enums(low, medium, high)
+// @Param created_after query string false "Created at or after (RFC3339)"
+// @Param created_before query string false "Created before (RFC3339)"
+// @Param sort query string false "Comma separated sort keys (created_at, updated_at, priority, title). Prefix with - for descending" default(-created_at)
+// @Param tag query []string false "Filter by tag name (repeatable, todos must have all given tags)" collectionFormat(multi)
+// @Param tree query bool false "Paginate root todos only and nest their descendants in children"
+// @Param bom query bool false "Prefix CSV output with a UTF-8 BOM (for Excel)"
+// @Success 200 {object} handler.PaginatedAPIResponse{data=[]response.TodoResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/todos [get]
 func (h *TodoHandler) GetTodos(c *gin.Context) {
-	todos, err := h.todoUsecase.GetAllTodos()
+	h.listTodos(c, nil)
+}
+
+// GetProjectTodos retrieves the todos in a project
+// @Summary Get todos in a project
+// @Description Get a page of the todos in a project. Supports the same filters, sorting, pagination and CSV / NDJSON export as GET /api/v1/todos.
+// @Tags projects
+// @Accept json
+// @Produce json,text/csv,application/x-ndjson
+// @Param id path int true "Project ID"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor"
+// @Param completed query bool false "Filter by completion status"
+// @Param priority query []string false "Filter by priority (repeatable or comma separated)" collectionFormat(multi) Enums(low, medium, high)
+// @Param created_after query string false "Created at or after (RFC3339)"
+// @Param created_before query string false "Created before (RFC3339)"
+// @Param sort query string false "Comma separated sort keys (created_at, updated_at, priority, title). Prefix with - for descending" default(-created_at)
+// @Param tag query []string false "Filter by tag name (repeatable, todos must have all given tags)" collectionFormat(multi)
+// @Param tree query bool false "Paginate root todos only and nest their descendants in children"
+// @Param bom query bool false "Prefix CSV output with a UTF-8 BOM (for Excel)"
+// @Success 200 {object} handler.PaginatedAPIResponse{data=[]response.TodoResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/projects/{id}/todos [get]
+func (h *TodoHandler) GetProjectTodos(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	h.listTodos(c, &id)
+}
+
+// listTodos はTodo一覧を返す（projectID を指定した場合はそのプロジェクトのTodoのみ）
+func (h *TodoHandler) listTodos(c *gin.Context, projectID *int) {
+	req, validationDetails, err := request.NewListTodosRequest(c)
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	query, err := req.Query()
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+	query.ProjectID = projectID
+
+	// Accept で CSV・NDJSON が指定された場合は条件に一致するTodoを全件ストリーミングする
+	if format := c.NegotiateFormat(gin.MIMEJSON, response.MIMECSV, response.MIMENDJSON); format != gin.MIMEJSON && format != "" {
+		h.exportTodos(c, query, format, req.BOM)
+		return
+	}
+
+	page, err := h.todoUsecase.GetAllTodos(c.Request.Context(), query)
+	if err != nil {
+		handleListTodosError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Todo一覧を正常に取得しました",
+		"data":        response.ToTodoResponses(page.Todos),
+		"next_cursor": response.ToNextCursor(page),
+		"has_more":    page.HasMore,
+	})
+}
+
+// handleListTodosError はTodo一覧・エクスポートのエラーをレスポンスに変換する
+func handleListTodosError(c *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrForbidden) {
+		response.ForbiddenError(c, "このプロジェクトへのアクセス権限がありません")
+		return
+	}
+	if errors.Is(err, usecase.ErrProjectNotFound) {
+		response.NotFoundError(c, "指定されたプロジェクト")
+		return
+	}
+	if errors.Is(err, usecase.ErrInvalidInput) {
+		response.InvalidRequestError(c, "検索条件が無効です")
+		return
+	}
+	response.InternalServerError(c, "TODO一覧の取得に失敗しました")
+}
+
+// SearchTodos searches todos by keyword
+// @Summary Search todos
+// @Description Full-text search over todo titles and descriptions (Japanese supported). Results are ordered by relevance and include highlighted snippets.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param q query string true "Search keyword"
+// @Param limit query int false "Max results (1-100, default 20)"
+// @Success 200 {object} handler.APIResponse{data=[]response.TodoSearchResultResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/search [get]
+func (h *TodoHandler) SearchTodos(c *gin.Context) {
+	req, validationDetails, err := request.NewSearchTodosRequest(c)
 	if err != nil {
-		response.InternalServerError(c, "TODO一覧の取得に失敗しました")
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
 		return
 	}
-	todoResponses := make([]response.TodoResponse, len(todos))
-	for i, todo := range todos {
-		todoResponses[i] = response.ToTodoResponse(todo)
+
+	results, err := h.todoUsecase.SearchTodos(c.Request.Context(), req.Query())
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "検索条件が無効です")
+			return
+		}
+		response.InternalServerError(c, "Todoの検索に失敗しました")
+		return
 	}
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Todo一覧を正常に取得しました",
-		"data":    todoResponses,
+		"message": "Todoを正常に検索しました",
+		"data":    response.ToTodoSearchResultResponses(results),
 	})
 }
 
 // GetTodo retrieves a single todo by ID
 // @Summary Get a todo by ID
-// @Description Get a single todo by its ID
+// @Description Get a single todo by its ID. The response carries the todo's version as an `ETag`; send it back as `If-None-Match` to get `304 Not Modified` when unchanged, or as `If-Match` on PUT/PATCH/DELETE.
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Param id path int true "Todo ID"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} handler.APIResponse{data=response.TodoResponse}
+// @Header 200 {string} ETag "Version of the todo"
+// @Success 304 "Not modified"
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/todos/{id} [get]
 func (h *TodoHandler) GetTodo(c *gin.Context) {
 	req, err := request.NewGetByIDRequest(c)
@@ -67,8 +207,12 @@ func (h *TodoHandler) GetTodo(c *gin.Context) {
 		return
 	}
 
-	todo, err := h.todoUsecase.GetTodoByID(req.ID)
+	todo, err := h.todoUsecase.GetTodoByID(c.Request.Context(), req.ID)
 	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "このTodoへのアクセス権限がありません")
+			return
+		}
 		if errors.Is(err, usecase.ErrTodoNotFound) {
 			response.NotFoundError(c, "指定されたTodo")
 			return
@@ -81,6 +225,13 @@ func (h *TodoHandler) GetTodo(c *gin.Context) {
 		return
 	}
 
+	etag := todoETag(todo.Version)
+	c.Header("ETag", etag)
+	if notModified(c, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	todoResponse := response.ToTodoResponse(*todo)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Todoを正常に取得しました",
@@ -88,16 +239,208 @@ func (h *TodoHandler) GetTodo(c *gin.Context) {
 	})
 }
 
+// GetTodoChildren retrieves the child todos of a todo
+// @Summary Get child todos
+// @Description Get the direct children of a todo. With tree=true, all descendants are nested in children.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param id path int true "Parent todo ID"
+// @Param tag query []string false "Filter by tag name (repeatable, todos must have all given tags)" collectionFormat(multi)
+// @Param tree query bool false "Nest all descendants in children"
+// @Success 200 {object} handler.APIResponse{data=[]response.TodoResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/{id}/children [get]
+func (h *TodoHandler) GetTodoChildren(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	req, err := request.NewListChildTodosRequest(c)
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+
+	children, err := h.todoUsecase.GetChildTodos(c.Request.Context(), id, req.Tree)
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "このTodoへのアクセス権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrTodoNotFound) {
+			response.NotFoundError(c, "指定されたTodo")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidIDError(c, "id")
+			return
+		}
+		response.InternalServerError(c, "子Todoの取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "子Todoを正常に取得しました",
+		"data":    response.ToTodoResponses(children),
+	})
+}
+
+// GetTodoHistory retrieves the change history of a todo
+// @Summary Get todo history
+// @Description Get the change history (create, update, completion toggle, delete, restore) of a todo, newest first. Each event has the actor and a field-level before/after diff. Available for todos in the trash too.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor"
+// @Success 200 {object} handler.PaginatedAPIResponse{data=[]response.TodoEventResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/{id}/history [get]
+func (h *TodoHandler) GetTodoHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	req, validationDetails, err := request.NewListTodoHistoryRequest(c)
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	query, err := req.Query()
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+
+	page, err := h.todoUsecase.GetTodoHistory(c.Request.Context(), id, query)
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "このTodoへのアクセス権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrTodoNotFound) {
+			response.NotFoundError(c, "指定されたTodo")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "変更履歴の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "変更履歴を正常に取得しました",
+		"data":        response.ToTodoEventResponses(page.Events),
+		"next_cursor": response.ToTodoEventNextCursor(page),
+		"has_more":    page.HasMore,
+	})
+}
+
+// GetTodoOccurrences previews upcoming occurrences of a recurring todo
+// @Summary Preview occurrences
+// @Description Preview the upcoming due dates of a recurring todo. Weekdays and times are calculated in `timezone` (defaults to the todo's time zone) and returned with its offset.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param limit query int false "Number of occurrences (1-100, default 10)"
+// @Param timezone query string false "IANA time zone name (e.g. Asia/Tokyo)"
+// @Success 200 {object} handler.APIResponse{data=[]response.OccurrenceResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/{id}/occurrences [get]
+func (h *TodoHandler) GetTodoOccurrences(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	req, validationDetails, err := request.NewListOccurrencesRequest(c)
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+
+	occurrences, err := h.todoUsecase.PreviewOccurrences(c.Request.Context(), id, req.Query())
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "このTodoへのアクセス権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrTodoNotFound) {
+			response.NotFoundError(c, "指定されたTodo")
+			return
+		}
+		if errors.Is(err, usecase.ErrTodoNotRecurring) {
+			response.BusinessRuleError(c, "指定されたTodoには繰り返し設定がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidRecurrence) {
+			response.InvalidRequestError(c, "タイムゾーンが無効です")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "繰り返し予定の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "繰り返し予定を正常に取得しました",
+		"data":    response.ToOccurrenceResponses(occurrences),
+	})
+}
+
 // CreateTodo creates a new todo
 // @Summary Create a new todo
-// @Description Create a new todo item
+// @Description Create a new todo item. A recurring todo is created with `recurrence_rule` (RRULE) and `timezone`, and needs `due_at` as the first occurrence.
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Param todo body request.CreateTodoRequest true "Create todo request"
+// @Param Idempotency-Key header string false "Unique key for safe retries; a retry with the same key replays the first response"
 // @Success 201 {object} handler.APIResponse{data=response.TodoResponse}
+// @Header 201 {string} Idempotent-Replayed "true when the response was replayed for a retried Idempotency-Key"
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/todos [post]
 func (h *TodoHandler) CreateTodo(c *gin.Context) {
 	// バリデーション付きリクエスト作成
@@ -117,8 +460,32 @@ func (h *TodoHandler) CreateTodo(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "処理中にエラーが発生しました"})
 		return
 	}
-	todo, err := h.todoUsecase.CreateTodo(todoModel)
+	todo, err := h.todoUsecase.CreateTodo(c.Request.Context(), todoModel)
 	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "親Todoまたはプロジェクトへのアクセス権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrParentTodoNotFound) {
+			response.NotFoundError(c, "親Todo")
+			return
+		}
+		if errors.Is(err, usecase.ErrProjectNotFound) {
+			response.NotFoundError(c, "指定されたプロジェクト")
+			return
+		}
+		if errors.Is(err, usecase.ErrProjectArchived) {
+			response.BusinessRuleError(c, "アーカイブされたプロジェクトにはTodoを追加できません")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidRecurrence) {
+			response.InvalidRequestError(c, "繰り返し設定またはタイムゾーンが無効です")
+			return
+		}
+		if errors.Is(err, usecase.ErrRecurrenceRequiresDueAt) {
+			response.BusinessRuleError(c, "繰り返し設定には期限を指定してください")
+			return
+		}
 		if errors.Is(err, usecase.ErrInvalidInput) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "入力データが無効です"})
 			return
@@ -135,16 +502,23 @@ func (h *TodoHandler) CreateTodo(c *gin.Context) {
 
 // UpdateTodo updates an existing todo
 // @Summary Update a todo
-// @Description Update an existing todo item
+// @Description Update an existing todo item. Completing a recurring todo creates its next occurrence (returned as `next_occurrence_id`). An empty `recurrence_rule` stops the recurrence.
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Param id path int true "Todo ID"
 // @Param todo body request.UpdateTodoRequest true "Update todo request"
+// @Param If-Match header string false "ETag from GET /api/v1/todos/{id}; the request fails with 412 if the todo has changed since"
 // @Success 200 {object} handler.APIResponse{data=response.TodoResponse}
+// @Header 200 {string} ETag "Version of the updated todo"
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/todos/{id} [put]
 func (h *TodoHandler) UpdateTodo(c *gin.Context) {
 	idStr := c.Param("id")
@@ -153,6 +527,11 @@ func (h *TodoHandler) UpdateTodo(c *gin.Context) {
 		response.InvalidIDError(c, "id")
 		return
 	}
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		response.InvalidRequestError(c, "If-Match には取得したTodoのETagを1つ指定してください")
+		return
+	}
 	// バリデーション付きリクエスト作成
 	req, validationDetails, err := request.NewUpdateTodoRequest(c)
 	if err != nil {
@@ -170,19 +549,80 @@ func (h *TodoHandler) UpdateTodo(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "処理中にエラーが発生しました"})
 		return
 	}
-	todo, err := h.todoUsecase.UpdateTodo(id, todoModel)
+	todo, err := h.todoUsecase.UpdateTodo(c.Request.Context(), id, todoModel, usecase.UpdateTodoOptions{
+		CascadeCompletion: req.CascadeCompletion,
+		ExpectedVersion:   expectedVersion,
+	})
 	if err != nil {
-		if errors.Is(err, usecase.ErrTodoNotFound) {
-			response.NotFoundError(c, "指定されたTodo")
-			return
-		}
-		if errors.Is(err, usecase.ErrInvalidInput) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "入力データが無効です"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Todoの更新に失敗しました"})
+		handleUpdateTodoError(c, err)
+		return
+	}
+	c.Header("ETag", todoETag(todo.Version))
+	todoResponse := response.ToTodoResponse(*todo)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Todoが正常に更新されました",
+		"data":    todoResponse,
+	})
+}
+
+// PatchTodo partially updates a todo with JSON Merge Patch semantics
+// @Summary Patch a todo
+// @Description Partially update a todo following RFC 7396 (JSON Merge Patch): omitted fields are left unchanged and `null` clears a field (`description`, `due_at`, `remind_at`, `parent_id`, `project_id`, `tags`, `recurrence_rule`, `timezone`). `title`, `priority` and `completed` cannot be null (422).
+// @Tags todos
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param todo body request.PatchTodoRequest true "Merge patch"
+// @Param If-Match header string false "ETag from GET /api/v1/todos/{id}; the request fails with 412 if the todo has changed since"
+// @Success 200 {object} handler.APIResponse{data=response.TodoResponse}
+// @Header 200 {string} ETag "Version of the updated todo"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/{id} [patch]
+func (h *TodoHandler) PatchTodo(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		response.InvalidRequestError(c, "If-Match には取得したTodoのETagを1つ指定してください")
+		return
+	}
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewPatchTodoRequest(c)
+	if errors.Is(err, request.ErrNullNotAllowed) {
+		response.UnprocessableValidationError(c, toResponseDetails(validationDetails))
+		return
+	}
+	if err != nil {
+		response.InvalidJSONError(c)
 		return
 	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	todo, err := h.todoUsecase.PatchTodo(c.Request.Context(), id, req.Patch(), usecase.UpdateTodoOptions{
+		CascadeCompletion: req.CascadeCompletion,
+		ExpectedVersion:   expectedVersion,
+	})
+	if err != nil {
+		handleUpdateTodoError(c, err)
+		return
+	}
+	c.Header("ETag", todoETag(todo.Version))
 	todoResponse := response.ToTodoResponse(*todo)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Todoが正常に更新されました",
@@ -190,17 +630,72 @@ func (h *TodoHandler) UpdateTodo(c *gin.Context) {
 	})
 }
 
-// DeleteTodo deletes a todo
+// handleUpdateTodoError は UpdateTodo・PatchTodo のエラーをレスポンスに変換する
+func handleUpdateTodoError(c *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrTodoVersionMismatch) {
+		response.PreconditionFailedError(c, "Todoは他のリクエストによって更新されています。最新の内容を取得してからやり直してください")
+		return
+	}
+	if errors.Is(err, usecase.ErrForbidden) {
+		response.ForbiddenError(c, "このTodoへのアクセス権限がありません")
+		return
+	}
+	if errors.Is(err, usecase.ErrTodoNotFound) {
+		response.NotFoundError(c, "指定されたTodo")
+		return
+	}
+	if errors.Is(err, usecase.ErrParentTodoNotFound) {
+		response.NotFoundError(c, "親Todo")
+		return
+	}
+	if errors.Is(err, usecase.ErrProjectNotFound) {
+		response.NotFoundError(c, "指定されたプロジェクト")
+		return
+	}
+	if errors.Is(err, usecase.ErrProjectArchived) {
+		response.BusinessRuleError(c, "アーカイブされたプロジェクトにはTodoを追加できません")
+		return
+	}
+	if errors.Is(err, usecase.ErrTodoHierarchyCycle) {
+		response.BusinessRuleError(c, "自分自身または子孫のTodoを親に指定することはできません")
+		return
+	}
+	if errors.Is(err, usecase.ErrOpenChildTodos) {
+		response.BusinessRuleError(c, "未完了の子Todoがあるため完了にできません（cascade_completion を指定すると子Todoもまとめて完了にします）")
+		return
+	}
+	if errors.Is(err, usecase.ErrInvalidRecurrence) {
+		response.InvalidRequestError(c, "繰り返し設定またはタイムゾーンが無効です")
+		return
+	}
+	if errors.Is(err, usecase.ErrRecurrenceRequiresDueAt) {
+		response.BusinessRuleError(c, "繰り返し設定には期限を指定してください")
+		return
+	}
+	if errors.Is(err, usecase.ErrInvalidInput) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力データが無効です"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Todoの更新に失敗しました"})
+}
+
+// DeleteTodo moves a todo to the trash
 // @Summary Delete a todo
-// @Description Delete a todo item by its ID
+// @Description Move a todo and its subtasks to the trash. Use POST /api/v1/todos/{id}/restore to undo.
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Param id path int true "Todo ID"
+// @Param If-Match header string false "ETag from GET /api/v1/todos/{id}; the request fails with 412 if the todo has changed since"
 // @Success 200 {object} handler.APIResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/todos/{id} [delete]
 func (h *TodoHandler) DeleteTodo(c *gin.Context) {
 	idStr := c.Param("id")
@@ -209,8 +704,23 @@ func (h *TodoHandler) DeleteTodo(c *gin.Context) {
 		response.InvalidIDError(c, "id")
 		return
 	}
-	err = h.todoUsecase.DeleteTodo(id)
+	expectedVersion, err := parseIfMatch(c)
 	if err != nil {
+		response.InvalidRequestError(c, "If-Match には取得したTodoのETagを1つ指定してください")
+		return
+	}
+	err = h.todoUsecase.DeleteTodo(c.Request.Context(), id, usecase.DeleteTodoOptions{
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrTodoVersionMismatch) {
+			response.PreconditionFailedError(c, "Todoは他のリクエストによって更新されています。最新の内容を取得してからやり直してください")
+			return
+		}
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "このTodoへのアクセス権限がありません")
+			return
+		}
 		if errors.Is(err, usecase.ErrTodoNotFound) {
 			response.NotFoundError(c, "指定されたTodo")
 			return
@@ -223,6 +733,6 @@ func (h *TodoHandler) DeleteTodo(c *gin.Context) {
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Todoを正常に削除しました",
+		"message": "Todoをゴミ箱に移動しました",
 	})
 }
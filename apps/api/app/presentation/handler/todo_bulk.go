@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BulkTodos applies multiple todo operations in a single transaction
+// @Summary Bulk todo operations
+// @Description Apply create, update, complete and delete operations (up to 100) in a single transaction. In `atomic` mode nothing is committed if any operation fails; in `best_effort` mode only the failed operations are rolled back. Each result has the HTTP status and error the single-item API would have returned.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param request body request.BulkTodosRequest true "Bulk operations request"
+// @Success 200 {object} handler.APIResponse{data=response.BulkTodoResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/bulk [post]
+func (h *TodoHandler) BulkTodos(c *gin.Context) {
+	req, validationDetails, err := request.NewBulkTodosRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+
+	mode := usecase.BulkMode(req.Mode)
+	results := make([]response.BulkTodoItemResponse, len(req.Operations))
+
+	// 内容に不備がある操作はusecaseに渡さず、その場で失敗として扱う
+	var operations []usecase.BulkTodoOperation
+	var indexes []int
+	for i, opReq := range req.Operations {
+		results[i] = response.BulkTodoItemResponse{Index: i, Op: opReq.Op}
+
+		todo, details := opReq.Operation()
+		if details != nil {
+			results[i].Status = http.StatusBadRequest
+			results[i].Error = &response.UnifiedErrorResponse{
+				Message:   "入力内容に不備があります",
+				ErrorCode: response.ErrorCodeValidation,
+				Details:   toResponseDetails(details),
+			}
+			continue
+		}
+		operations = append(operations, usecase.BulkTodoOperation{
+			Type:    usecase.BulkOperationType(opReq.Op),
+			ID:      opReq.ID,
+			Todo:    todo,
+			Options: usecase.UpdateTodoOptions{CascadeCompletion: opReq.CascadeCompletion},
+		})
+		indexes = append(indexes, i)
+	}
+
+	committed := false
+	switch {
+	case len(operations) == 0:
+		// 全ての操作に不備がある場合は何も実行しない
+	case mode == usecase.BulkModeAtomic && len(operations) < len(req.Operations):
+		for _, i := range indexes {
+			results[i].Status, results[i].Error = bulkTodoErrorResponse(usecase.ErrBulkRolledBack)
+		}
+	default:
+		result, err := h.todoUsecase.BulkTodos(c.Request.Context(), mode, operations)
+		if err != nil {
+			if errors.Is(err, usecase.ErrInvalidInput) {
+				response.InvalidRequestError(c, "入力データが無効です")
+				return
+			}
+			response.InternalServerError(c, "一括操作に失敗しました")
+			return
+		}
+		committed = result.Committed
+		for j, item := range result.Items {
+			i := indexes[j]
+			if item.Err != nil {
+				results[i].Status, results[i].Error = bulkTodoErrorResponse(item.Err)
+				continue
+			}
+			results[i].Status = http.StatusOK
+			if item.Operation.Type == usecase.BulkOperationCreate {
+				results[i].Status = http.StatusCreated
+			}
+			if item.Todo != nil {
+				todoResponse := response.ToTodoResponse(*item.Todo)
+				results[i].Data = &todoResponse
+			}
+		}
+	}
+
+	data := response.BulkTodoResponse{Mode: req.Mode, Committed: committed, Results: results}
+	for _, r := range results {
+		switch {
+		case r.Error == nil:
+			data.Succeeded++
+		case r.Status == http.StatusFailedDependency:
+			data.RolledBack++
+		default:
+			data.Failed++
+		}
+	}
+
+	message := "一括操作を正常に実行しました"
+	if !committed {
+		message = "失敗した操作があるため、全ての変更を取り消しました"
+	} else if data.Failed > 0 {
+		message = "一部の操作が失敗しました（成功した操作は反映されています）"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    data,
+	})
+}
+
+// bulkTodoErrorResponse は一括操作の各操作のエラーを、個別のAPIと同じHTTPステータスとエラーコードに変換する
+func bulkTodoErrorResponse(err error) (int, *response.UnifiedErrorResponse) {
+	status, code, message := http.StatusInternalServerError, response.ErrorCodeInternalServer, "操作に失敗しました"
+	switch {
+	case errors.Is(err, usecase.ErrBulkRolledBack):
+		status, code, message = http.StatusFailedDependency, response.ErrorCodeBusinessRule, "他の操作が失敗したため、この操作は取り消されました"
+	case errors.Is(err, usecase.ErrForbidden):
+		status, code, message = http.StatusForbidden, response.ErrorCodeForbidden, "このTodoへのアクセス権限がありません"
+	case errors.Is(err, usecase.ErrTodoNotFound):
+		status, code, message = http.StatusNotFound, response.ErrorCodeNotFound, "指定されたTodoが見つかりません"
+	case errors.Is(err, usecase.ErrParentTodoNotFound):
+		status, code, message = http.StatusNotFound, response.ErrorCodeNotFound, "親Todoが見つかりません"
+	case errors.Is(err, usecase.ErrProjectNotFound):
+		status, code, message = http.StatusNotFound, response.ErrorCodeNotFound, "指定されたプロジェクトが見つかりません"
+	case errors.Is(err, usecase.ErrProjectArchived):
+		status, code, message = http.StatusUnprocessableEntity, response.ErrorCodeBusinessRule, "アーカイブされたプロジェクトにはTodoを追加できません"
+	case errors.Is(err, usecase.ErrTodoHierarchyCycle):
+		status, code, message = http.StatusUnprocessableEntity, response.ErrorCodeBusinessRule, "自分自身または子孫のTodoを親に指定することはできません"
+	case errors.Is(err, usecase.ErrOpenChildTodos):
+		status, code, message = http.StatusUnprocessableEntity, response.ErrorCodeBusinessRule, "未完了の子Todoがあるため完了にできません（cascade_completion を指定すると子Todoもまとめて完了にします）"
+	case errors.Is(err, usecase.ErrRecurrenceRequiresDueAt):
+		status, code, message = http.StatusUnprocessableEntity, response.ErrorCodeBusinessRule, "繰り返し設定には期限を指定してください"
+	case errors.Is(err, usecase.ErrInvalidRecurrence):
+		status, code, message = http.StatusBadRequest, response.ErrorCodeInvalidRequest, "繰り返し設定またはタイムゾーンが無効です"
+	case errors.Is(err, usecase.ErrInvalidInput):
+		status, code, message = http.StatusBadRequest, response.ErrorCodeInvalidRequest, "入力データが無効です"
+	}
+	return status, &response.UnifiedErrorResponse{
+		Message:   message,
+		ErrorCode: code,
+		Details:   []response.ValidationErrorDetail{},
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"api/app/models"
+	"api/app/presentation/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// todoExportFlushInterval はエクスポート中にクライアントへ送信する間隔（件数）
+const todoExportFlushInterval = 100
+
+// todoExportWriteTimeout はエクスポート中、次の送信までにクライアントが受信を進めるのを待つ時間
+// 受信の止まったクライアントに書き込みがブロックされ続け、エクスポートのトランザクションを開いたままにしないようにする
+const todoExportWriteTimeout = 30 * time.Second
+
+// exportTodos は一覧と同じ条件のTodoを全件、format（CSV・NDJSON）でストリーミングする
+// ステータスとヘッダーは最初のTodoを書き出す時点で確定させるため、検索条件・権限のエラーは通常のJSONで返す
+func (h *TodoHandler) exportTodos(c *gin.Context, query models.TodoListQuery, format string, bom bool) {
+	var writer response.TodoExportWriter
+	rc := http.NewResponseController(c.Writer)
+	extendDeadline := func() {
+		// テスト用の ResponseRecorder などデッドラインに対応しない Writer では何もしない
+		_ = rc.SetWriteDeadline(time.Now().Add(todoExportWriteTimeout))
+	}
+	start := func() error {
+		if writer != nil {
+			return nil
+		}
+		extendDeadline()
+		c.Header("Cache-Control", "no-store")
+		c.Header("X-Content-Type-Options", "nosniff")
+		var err error
+		switch format {
+		case response.MIMECSV:
+			c.Header("Content-Type", response.MIMECSV+"; charset=utf-8")
+			c.Header("Content-Disposition", `attachment; filename="todos.csv"`)
+			c.Status(http.StatusOK)
+			writer, err = response.NewTodoCSVWriter(c.Writer, bom)
+		default:
+			c.Header("Content-Type", response.MIMENDJSON+"; charset=utf-8")
+			c.Header("Content-Disposition", `attachment; filename="todos.ndjson"`)
+			c.Status(http.StatusOK)
+			writer = response.NewTodoNDJSONWriter(c.Writer)
+		}
+		return err
+	}
+
+	count := 0
+	err := h.todoUsecase.ExportTodos(c.Request.Context(), query, func(todo models.Todo) error {
+		if err := start(); err != nil {
+			return err
+		}
+		if err := writer.Write(todo); err != nil {
+			return err
+		}
+		count++
+		if count%todoExportFlushInterval == 0 {
+			extendDeadline()
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		// 該当するTodoが無い場合も見出し行だけのCSV（空のNDJSON）を返す
+		if err = start(); err == nil {
+			err = writer.Flush()
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	if writer == nil {
+		handleListTodosError(c, err)
+		return
+	}
+	// 書き出し開始後はステータスを変えられないため、接続を切って途中までの出力であることをクライアントに伝える
+	_ = c.Error(err)
+	abortStream(c)
+}
+
+// abortStream はストリーミング中のレスポンスを正常終了させずに接続を閉じる
+// HTTP/1.1 では終端のチャンクが送られないため、クライアントは出力が途中で切れたことを検知できる
+func abortStream(c *gin.Context) {
+	c.Abort()
+	conn, _, err := c.Writer.Hijack()
+	if err != nil {
+		return
+	}
+	_ = conn.Close()
+}
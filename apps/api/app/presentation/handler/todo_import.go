@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"api/app/models"
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoImportHandler struct {
+	todoUsecase       usecase.TodoUsecase
+	todoImportUsecase usecase.TodoImportUsecase
+}
+
+func NewTodoImportHandler(todoUsecase usecase.TodoUsecase, todoImportUsecase usecase.TodoImportUsecase) *TodoImportHandler {
+	return &TodoImportHandler{
+		todoUsecase:       todoUsecase,
+		todoImportUsecase: todoImportUsecase,
+	}
+}
+
+// ImportTodos imports todos from an uploaded CSV or JSON file
+// @Summary Import todos
+// @Description Import todos from a CSV (with a header row using the same columns as the CSV export) or JSON (an array or NDJSON of create todo requests) file.
+// @Description Every row is validated like POST /todos, and nothing is imported if any row is invalid. With dry_run=true the file is only validated and the per-row errors are returned.
+// @Description Files with more than 500 todos are imported by a background job: the response is 202 with the job, whose status can be polled at the Location header.
+// @Tags todos
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or JSON file (up to 10MB and 10000 todos)"
+// @Param dry_run query bool false "Only validate the file"
+// @Success 200 {object} handler.APIResponse{data=response.TodoImportResultResponse} "Dry run result, or the errors of a file that was not imported"
+// @Success 201 {object} handler.APIResponse{data=response.TodoImportResultResponse}
+// @Success 202 {object} handler.APIResponse{data=response.TodoImportJobResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/import [post]
+func (h *TodoImportHandler) ImportTodos(c *gin.Context) {
+	req, validationDetails, err := request.NewImportTodosRequest(c)
+	if err != nil {
+		response.InvalidRequestError(c, "リクエストの形式が正しくありません")
+		return
+	}
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+
+	rows, rowErrors, err := req.Rows()
+	if err != nil {
+		if errors.Is(err, request.ErrTooManyImportRows) {
+			HandleValidationError(c, []request.ValidationErrorDetail{{
+				Field:   "File",
+				Message: fmt.Sprintf("一度にインポートできるTodoは%d件までです", models.MaxTodoImportRows),
+			}})
+			return
+		}
+		response.InternalServerError(c, "ファイルの読み込みに失敗しました")
+		return
+	}
+
+	result := &models.TodoImportResult{TotalRows: len(rows) + countImportLines(rowErrors), Errors: rowErrors}
+	switch {
+	case len(rows) == 0:
+		// 全ての行に不備がある場合は何も実行しない
+	case len(rowErrors) > 0 && !req.DryRun:
+		// ファイルの内容に不備がある場合は、親Todo・プロジェクトなどの確認も行わずに全て登録しない
+	case len(rows) > usecase.MaxSyncTodoImportRows && !req.DryRun:
+		job, err := h.todoImportUsecase.StartImport(c.Request.Context(), rows)
+		if err != nil {
+			handleImportTodosError(c, err)
+			return
+		}
+		c.Header("Location", fmt.Sprintf("/api/v1/todos/import/jobs/%d", job.ID))
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "インポートを開始しました",
+			"data":    response.ToTodoImportJobResponse(job),
+		})
+		return
+	default:
+		imported, err := h.todoUsecase.ImportTodos(c.Request.Context(), rows, usecase.TodoImportOptions{DryRun: req.DryRun})
+		if err != nil {
+			handleImportTodosError(c, err)
+			return
+		}
+		result.ImportedRows = imported.ImportedRows
+		result.Errors = append(result.Errors, imported.Errors...)
+	}
+
+	// ファイルの形式の不備と親Todo・プロジェクトなどの不備を行の順に並べる
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Line < result.Errors[j].Line
+	})
+	data := response.TodoImportResultResponse{
+		DryRun:       req.DryRun,
+		TotalRows:    result.TotalRows,
+		ImportedRows: result.ImportedRows,
+		Errors:       response.ToTodoImportErrorResponses(result.Errors),
+	}
+
+	switch {
+	case req.DryRun:
+		message := "ファイルに不備はありません"
+		if len(data.Errors) > 0 {
+			message = "ファイルに不備のある行があります"
+		}
+		c.JSON(http.StatusOK, gin.H{"message": message, "data": data})
+	case len(data.Errors) > 0:
+		c.JSON(http.StatusOK, gin.H{"message": "不備のある行があるため、インポートしませんでした", "data": data})
+	default:
+		c.JSON(http.StatusCreated, gin.H{"message": "Todoをインポートしました", "data": data})
+	}
+}
+
+// GetImportJob retrieves the status of a todo import job
+// @Summary Get todo import job
+// @Description Get the status and progress of a background todo import started by the current user
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param id path int true "Import job ID"
+// @Success 200 {object} handler.APIResponse{data=response.TodoImportJobResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/import/jobs/{id} [get]
+func (h *TodoImportHandler) GetImportJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "ID")
+		return
+	}
+
+	job, err := h.todoImportUsecase.GetJob(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			response.InvalidIDError(c, "ID")
+		case errors.Is(err, usecase.ErrTodoImportJobNotFound):
+			response.NotFoundError(c, "指定されたインポート")
+		default:
+			response.InternalServerError(c, "インポートの状況の取得に失敗しました")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "インポートの状況を正常に取得しました",
+		"data":    response.ToTodoImportJobResponse(job),
+	})
+}
+
+func handleImportTodosError(c *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrInvalidInput) {
+		response.InvalidRequestError(c, "入力データが無効です")
+		return
+	}
+	response.InternalServerError(c, "Todoのインポートに失敗しました")
+}
+
+// countImportLines はエラーのある行の数を返す（1行に複数のエラーがある場合も1行と数える）
+func countImportLines(rowErrors []models.TodoImportRowError) int {
+	lines := make(map[int]struct{}, len(rowErrors))
+	for _, e := range rowErrors {
+		lines[e.Line] = struct{}{}
+	}
+	return len(lines)
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	trashUsecase usecase.TrashUsecase
+}
+
+func NewTrashHandler(trashUsecase usecase.TrashUsecase) *TrashHandler {
+	return &TrashHandler{
+		trashUsecase: trashUsecase,
+	}
+}
+
+// GetTrash retrieves deleted todos
+// @Summary Get trash
+// @Description Get a page of deleted todos (most recently deleted first). Subtasks deleted together with their parent are not listed separately.
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor"
+// @Success 200 {object} handler.PaginatedAPIResponse{data=[]response.TrashedTodoResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/trash [get]
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	req, validationDetails, err := request.NewListTrashRequest(c)
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	query, err := req.Query()
+	if err != nil {
+		response.InvalidRequestError(c, "クエリパラメータの形式が正しくありません")
+		return
+	}
+
+	page, err := h.trashUsecase.GetTrash(c.Request.Context(), query)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "検索条件が無効です")
+			return
+		}
+		response.InternalServerError(c, "ゴミ箱の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "ゴミ箱を正常に取得しました",
+		"data":        response.ToTrashedTodoResponses(page.Todos),
+		"next_cursor": response.ToTrashNextCursor(page),
+		"has_more":    page.HasMore,
+	})
+}
+
+// RestoreTodo restores a deleted todo
+// @Summary Restore a todo
+// @Description Restore a todo from the trash together with the subtasks deleted with it
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Success 200 {object} handler.APIResponse{data=response.TodoResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/todos/{id}/restore [post]
+func (h *TrashHandler) RestoreTodo(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	todo, err := h.trashUsecase.RestoreTodo(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "このTodoへのアクセス権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrTodoNotFound) {
+			response.NotFoundError(c, "ゴミ箱内の指定されたTodo")
+			return
+		}
+		if errors.Is(err, usecase.ErrParentTodoInTrash) {
+			response.BusinessRuleError(c, "親Todoがゴミ箱にあるため元に戻せません。先に親Todoを元に戻してください")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidIDError(c, "id")
+			return
+		}
+		response.InternalServerError(c, "Todoの復元に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Todoを正常に元に戻しました",
+		"data":    response.ToTodoResponse(*todo),
+	})
+}
+
+// PurgeTodo permanently deletes a todo in the trash
+// @Summary Purge a todo
+// @Description Permanently delete a todo in the trash together with its subtasks. This cannot be undone.
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Success 200 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/trash/{id} [delete]
+func (h *TrashHandler) PurgeTodo(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	err = h.trashUsecase.PurgeTodo(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "このTodoへのアクセス権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrTodoNotFound) {
+			response.NotFoundError(c, "ゴミ箱内の指定されたTodo")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidIDError(c, "id")
+			return
+		}
+		response.InternalServerError(c, "Todoの完全削除に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Todoを完全に削除しました",
+	})
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	userUsecase usecase.UserUsecase
+}
+
+func NewUserHandler(userUsecase usecase.UserUsecase) *UserHandler {
+	return &UserHandler{
+		userUsecase: userUsecase,
+	}
+}
+
+// GetUsers retrieves all users
+// @Summary Get users
+// @Description Get all users ordered by ID
+// @Tags users
+// @Accept json
+// @Produce json
+// @Success 200 {object} handler.APIResponse{data=[]response.UserResponse}
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/users [get]
+func (h *UserHandler) GetUsers(c *gin.Context) {
+	users, err := h.userUsecase.GetAllUsers(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, "ユーザー一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ユーザー一覧を正常に取得しました",
+		"data":    response.ToUserResponses(users),
+	})
+}
+
+// GetUser retrieves a single user by ID
+// @Summary Get a user by ID
+// @Description Get a single user by its ID
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} handler.APIResponse{data=response.UserResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/users/{id} [get]
+func (h *UserHandler) GetUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	user, err := h.userUsecase.GetUserByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			response.NotFoundError(c, "指定されたユーザー")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidIDError(c, "id")
+			return
+		}
+		response.InternalServerError(c, "ユーザーの取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ユーザーを正常に取得しました",
+		"data":    response.ToUserResponse(*user),
+	})
+}
+
+// CreateUser creates a new user
+// @Summary Create a new user
+// @Description Create a new user. Email addresses are unique (case-insensitive).
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body request.CreateUserRequest true "Create user request"
+// @Success 201 {object} handler.APIResponse{data=response.UserResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/users [post]
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewCreateUserRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	// model変換してusecaseに渡す
+	userModel, err := req.User()
+	if err != nil {
+		response.InternalServerError(c, "処理中にエラーが発生しました")
+		return
+	}
+	user, err := h.userUsecase.CreateUser(c.Request.Context(), userModel)
+	if err != nil {
+		if errors.Is(err, usecase.ErrEmailAlreadyTaken) {
+			response.AlreadyExistsError(c, "同じメールアドレスのユーザー")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "ユーザーの作成に失敗しました")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "ユーザーが正常に作成されました",
+		"data":    response.ToUserResponse(*user),
+	})
+}
+
+// UpdateUser updates an existing user
+// @Summary Update a user
+// @Description Update the name and/or email of an existing user. Omitted fields are left unchanged.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param user body request.UpdateUserRequest true "Update user request"
+// @Success 200 {object} handler.APIResponse{data=response.UserResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/users/{id} [put]
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewUpdateUserRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+	// model変換してusecaseに渡す
+	userModel, err := req.User()
+	if err != nil {
+		response.InternalServerError(c, "処理中にエラーが発生しました")
+		return
+	}
+	user, err := h.userUsecase.UpdateUser(c.Request.Context(), id, userModel)
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "他のユーザーを変更する権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			response.NotFoundError(c, "指定されたユーザー")
+			return
+		}
+		if errors.Is(err, usecase.ErrEmailAlreadyTaken) {
+			response.AlreadyExistsError(c, "同じメールアドレスのユーザー")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidRequestError(c, "入力データが無効です")
+			return
+		}
+		response.InternalServerError(c, "ユーザーの更新に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ユーザーが正常に更新されました",
+		"data":    response.ToUserResponse(*user),
+	})
+}
+
+// DeleteUser deletes a user
+// @Summary Delete a user
+// @Description Delete a user by its ID
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} handler.APIResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/users/{id} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	err = h.userUsecase.DeleteUser(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			response.ForbiddenError(c, "他のユーザーを変更する権限がありません")
+			return
+		}
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			response.NotFoundError(c, "指定されたユーザー")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			response.InvalidIDError(c, "id")
+			return
+		}
+		response.InternalServerError(c, "ユーザーの削除に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ユーザーを正常に削除しました",
+	})
+}
@@ -9,6 +9,11 @@ import (
 
 // HandleValidationError はバリデーションエラーをレスポンスに変換する共通関数
 func HandleValidationError(c *gin.Context, validationDetails []request.ValidationErrorDetail) {
+	response.ValidationError(c, toResponseDetails(validationDetails))
+}
+
+// toResponseDetails はリクエストのバリデーションエラー詳細をレスポンス用に変換する
+func toResponseDetails(validationDetails []request.ValidationErrorDetail) []response.ValidationErrorDetail {
 	var responseDetails []response.ValidationErrorDetail
 	for _, d := range validationDetails {
 		responseDetails = append(responseDetails, response.ValidationErrorDetail{
@@ -16,5 +21,5 @@ func HandleValidationError(c *gin.Context, validationDetails []request.Validatio
 			Message: d.Message,
 		})
 	}
-	response.ValidationError(c, responseDetails)
+	return responseDetails
 }
\ No newline at end of file
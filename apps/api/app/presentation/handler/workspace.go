@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"api/app/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceHandler struct {
+	workspaceUsecase usecase.WorkspaceUsecase
+}
+
+func NewWorkspaceHandler(workspaceUsecase usecase.WorkspaceUsecase) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceUsecase: workspaceUsecase,
+	}
+}
+
+// GetWorkspaces retrieves the workspaces of the authenticated user
+// @Summary Get workspaces
+// @Description Get the workspaces the authenticated user belongs to, with the user's role in each. Send the ID in the X-Workspace-ID header to work with the todos, projects and tags of a workspace.
+// @Tags workspaces
+// @Produce json
+// @Success 200 {object} handler.APIResponse{data=[]response.WorkspaceResponse}
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/workspaces [get]
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	workspaces, err := h.workspaceUsecase.GetWorkspaces(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err, "ワークスペース一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ワークスペース一覧を正常に取得しました",
+		"data":    response.ToWorkspaceResponses(workspaces),
+	})
+}
+
+// CreateWorkspace creates a new workspace
+// @Summary Create a new workspace
+// @Description Create a new workspace. The authenticated user becomes its owner.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param workspace body request.CreateWorkspaceRequest true "Create workspace request"
+// @Success 201 {object} handler.APIResponse{data=response.WorkspaceResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/workspaces [post]
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewCreateWorkspaceRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+
+	workspace, err := h.workspaceUsecase.CreateWorkspace(c.Request.Context(), req.Workspace())
+	if err != nil {
+		h.handleError(c, err, "ワークスペースの作成に失敗しました")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "ワークスペースを作成しました",
+		"data":    response.ToWorkspaceResponse(*workspace),
+	})
+}
+
+// GetWorkspaceMembers retrieves the members of a workspace
+// @Summary Get workspace members
+// @Description Get the members of a workspace the authenticated user belongs to
+// @Tags workspaces
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} handler.APIResponse{data=[]response.WorkspaceMemberResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/workspaces/{id}/members [get]
+func (h *WorkspaceHandler) GetWorkspaceMembers(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+
+	members, err := h.workspaceUsecase.GetMembers(c.Request.Context(), workspaceID)
+	if err != nil {
+		h.handleError(c, err, "メンバー一覧の取得に失敗しました")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "メンバー一覧を正常に取得しました",
+		"data":    response.ToWorkspaceMemberResponses(members),
+	})
+}
+
+// AddWorkspaceMember adds a user to a workspace
+// @Summary Add a workspace member
+// @Description Add a registered user to a workspace by email. Only owners can add members.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param member body request.AddWorkspaceMemberRequest true "Add member request"
+// @Success 201 {object} handler.APIResponse{data=response.WorkspaceMemberResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/workspaces/{id}/members [post]
+func (h *WorkspaceHandler) AddWorkspaceMember(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.InvalidIDError(c, "id")
+		return
+	}
+	// バリデーション付きリクエスト作成
+	req, validationDetails, err := request.NewAddWorkspaceMemberRequest(c)
+	if err != nil {
+		response.InvalidJSONError(c)
+		return
+	}
+	// バリデーションエラーがある場合
+	if validationDetails != nil {
+		HandleValidationError(c, validationDetails)
+		return
+	}
+
+	member, err := h.workspaceUsecase.AddMember(c.Request.Context(), workspaceID, req.Email, req.Role)
+	if err != nil {
+		h.handleError(c, err, "メンバーの追加に失敗しました")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "メンバーを追加しました",
+		"data":    response.ToWorkspaceMemberResponse(*member),
+	})
+}
+
+// handleError はワークスペースのusecaseのエラーをレスポンスに変換する
+func (h *WorkspaceHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usecase.ErrForbidden):
+		response.ForbiddenError(c, "この操作を行う権限がありません")
+	case errors.Is(err, usecase.ErrWorkspaceNotFound):
+		response.NotFoundError(c, "指定されたワークスペース")
+	case errors.Is(err, usecase.ErrUserNotFound):
+		response.NotFoundError(c, "指定されたメールアドレスのユーザー")
+	case errors.Is(err, usecase.ErrAlreadyWorkspaceMember):
+		response.AlreadyExistsError(c, "指定されたユーザーのメンバーシップ")
+	case errors.Is(err, usecase.ErrInvalidInput):
+		response.InvalidRequestError(c, "入力データが無効です")
+	default:
+		response.InternalServerError(c, fallback)
+	}
+}
@@ -0,0 +1,80 @@
+package request
+
+import (
+	"strings"
+	"time"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100" ja:"名前" example:"CI bot"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=todos:read todos:write" ja:"スコープ" example:"todos:read,todos:write"`
+	// 有効期限（省略時は90日後、最長1年後まで）
+	ExpiresAt *time.Time `json:"expires_at" ja:"有効期限"`
+}
+
+func (r *CreateAPIKeyRequest) Validate() ValidationErrors {
+	var errors ValidationErrors
+
+	if err := validate.Struct(r); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			fieldName := getJapaneseFieldName("", err.Field())
+
+			switch {
+			case err.Field() == "Name":
+				fieldName = "名前"
+			case strings.HasPrefix(err.Field(), "Scopes"):
+				// Scopes[0] など要素ごとのエラーも含む
+				fieldName = "スコープ"
+			}
+
+			errors = append(errors, translateValidationError(err, fieldName))
+		}
+	}
+
+	if r.ExpiresAt != nil {
+		now := time.Now()
+		if !r.ExpiresAt.After(now) {
+			errors = append(errors, ValidationError{Field: "ExpiresAt", Message: "有効期限は現在より後の日時を指定してください"})
+		} else if r.ExpiresAt.After(now.Add(models.MaxAPIKeyTTL)) {
+			errors = append(errors, ValidationError{Field: "ExpiresAt", Message: "有効期限は1年以内で指定してください"})
+		}
+	}
+
+	return errors
+}
+
+func (r *CreateAPIKeyRequest) APIKey() *models.APIKey {
+	key := &models.APIKey{
+		Name:   r.Name,
+		Scopes: r.Scopes,
+	}
+	// 省略時は usecase で既定の有効期限を設定する
+	if r.ExpiresAt != nil {
+		key.ExpiresAt = *r.ExpiresAt
+	}
+	return key
+}
+
+func (r *CreateAPIKeyRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewCreateAPIKeyRequest(c *gin.Context) (*CreateAPIKeyRequest, []ValidationErrorDetail, error) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
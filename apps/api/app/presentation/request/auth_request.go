@@ -0,0 +1,180 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255" ja:"メールアドレス" example:"alice@example.com"`
+	Password string `json:"password" validate:"required,max=128" ja:"パスワード"`
+}
+
+// RefreshTokenRequest はトークンの更新とログアウトのリクエスト
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required" ja:"リフレッシュトークン"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email,max=255" ja:"メールアドレス" example:"alice@example.com"`
+}
+
+type ConfirmPasswordResetRequest struct {
+	// メールで送られたリンクの token パラメータ
+	Token    string `json:"token" validate:"required" ja:"トークン"`
+	Password string `json:"password" validate:"required,min=8,max=128" ja:"パスワード"`
+}
+
+// OIDCCallbackRequest は IdP からダッシュボードにリダイレクトされた際に受け取った code と state
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required,max=2048" ja:"認可コード"`
+	State string `json:"state" validate:"required,max=255" ja:"state"`
+}
+
+func (r *LoginRequest) Validate() ValidationErrors {
+	return validateAuth(r)
+}
+
+func (r *RefreshTokenRequest) Validate() ValidationErrors {
+	return validateAuth(r)
+}
+
+func (r *PasswordResetRequest) Validate() ValidationErrors {
+	return validateAuth(r)
+}
+
+func (r *ConfirmPasswordResetRequest) Validate() ValidationErrors {
+	return validateAuth(r)
+}
+
+func (r *OIDCCallbackRequest) Validate() ValidationErrors {
+	return validateAuth(r)
+}
+
+func validateAuth(r interface{}) ValidationErrors {
+	err := validate.Struct(r)
+	if err == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, err := range err.(validator.ValidationErrors) {
+		fieldName := getJapaneseFieldName("", err.Field())
+
+		switch err.Field() {
+		case "Email":
+			fieldName = "メールアドレス"
+		case "Password":
+			fieldName = "パスワード"
+		case "RefreshToken":
+			fieldName = "リフレッシュトークン"
+		case "Token":
+			fieldName = "トークン"
+		case "Code":
+			fieldName = "認可コード"
+		case "State":
+			fieldName = "state"
+		}
+
+		errors = append(errors, translateValidationError(err, fieldName))
+	}
+	return errors
+}
+
+func (r *LoginRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *RefreshTokenRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *PasswordResetRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *ConfirmPasswordResetRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *OIDCCallbackRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewLoginRequest(c *gin.Context) (*LoginRequest, []ValidationErrorDetail, error) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Email = strings.TrimSpace(req.Email)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewRefreshTokenRequest(c *gin.Context) (*RefreshTokenRequest, []ValidationErrorDetail, error) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.RefreshToken = strings.TrimSpace(req.RefreshToken)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewPasswordResetRequest(c *gin.Context) (*PasswordResetRequest, []ValidationErrorDetail, error) {
+	var req PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Email = strings.TrimSpace(req.Email)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewConfirmPasswordResetRequest(c *gin.Context) (*ConfirmPasswordResetRequest, []ValidationErrorDetail, error) {
+	var req ConfirmPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Token = strings.TrimSpace(req.Token)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewOIDCCallbackRequest(c *gin.Context) (*OIDCCallbackRequest, []ValidationErrorDetail, error) {
+	var req OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Code = strings.TrimSpace(req.Code)
+	req.State = strings.TrimSpace(req.State)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
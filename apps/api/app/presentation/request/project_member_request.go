@@ -0,0 +1,87 @@
+package request
+
+import (
+	"strings"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type UpdateProjectMemberRequest struct {
+	Role models.ProjectRole `json:"role" validate:"required,oneof=owner editor viewer" ja:"ロール" example:"editor"`
+}
+
+type CreateProjectInvitationRequest struct {
+	// 招待するユーザーのメールアドレス（登録済みのユーザーのみ招待できる）
+	Email string             `json:"email" validate:"required,email,max=255" ja:"メールアドレス" example:"bob@example.com"`
+	Role  models.ProjectRole `json:"role" validate:"required,oneof=owner editor viewer" ja:"ロール" example:"editor"`
+}
+
+func (r *UpdateProjectMemberRequest) Validate() ValidationErrors {
+	return validateProjectMember(r)
+}
+
+func (r *CreateProjectInvitationRequest) Validate() ValidationErrors {
+	return validateProjectMember(r)
+}
+
+func validateProjectMember(r interface{}) ValidationErrors {
+	err := validate.Struct(r)
+	if err == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, err := range err.(validator.ValidationErrors) {
+		fieldName := getJapaneseFieldName("", err.Field())
+
+		switch err.Field() {
+		case "Email":
+			fieldName = "メールアドレス"
+		case "Role":
+			fieldName = "ロール"
+		}
+
+		errors = append(errors, translateValidationError(err, fieldName))
+	}
+	return errors
+}
+
+func (r *UpdateProjectMemberRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *CreateProjectInvitationRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewUpdateProjectMemberRequest(c *gin.Context) (*UpdateProjectMemberRequest, []ValidationErrorDetail, error) {
+	var req UpdateProjectMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewCreateProjectInvitationRequest(c *gin.Context) (*CreateProjectInvitationRequest, []ValidationErrorDetail, error) {
+	var req CreateProjectInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Email = strings.TrimSpace(req.Email)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
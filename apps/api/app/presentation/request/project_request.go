@@ -0,0 +1,166 @@
+package request
+
+import (
+	"strings"
+	"time"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateProjectRequest struct {
+	Name string `json:"name" validate:"required,max=100" ja:"プロジェクト名"`
+	// #rrggbb 形式（省略時は #808080）
+	Color string `json:"color" validate:"omitempty,len=7,hexcolor" ja:"色" example:"#4a90d9"`
+}
+
+type UpdateProjectRequest struct {
+	Name     *string `json:"name" validate:"omitempty,min=1,max=100" ja:"プロジェクト名"`
+	Color    *string `json:"color" validate:"omitempty,len=7,hexcolor" ja:"色" example:"#4a90d9"`
+	Archived *bool   `json:"archived" ja:"アーカイブ"`
+}
+
+type ListProjectsRequest struct {
+	// アーカイブ済みのプロジェクトも含める
+	IncludeArchived bool `form:"include_archived" ja:"アーカイブ済みを含める"`
+}
+
+type DeleteProjectRequest struct {
+	// プロジェクトのTodoの扱い（inbox: インボックスに移動、cascade: ゴミ箱に移動）
+	Mode models.ProjectDeleteMode `form:"mode" validate:"omitempty,oneof=inbox cascade" ja:"削除方法"`
+}
+
+func (r *CreateProjectRequest) Validate() ValidationErrors {
+	return validateProject(r)
+}
+
+func (r *UpdateProjectRequest) Validate() ValidationErrors {
+	return validateProject(r)
+}
+
+func (r *DeleteProjectRequest) Validate() ValidationErrors {
+	return validateProject(r)
+}
+
+func validateProject(r interface{}) ValidationErrors {
+	err := validate.Struct(r)
+	if err == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, err := range err.(validator.ValidationErrors) {
+		fieldName := getJapaneseFieldName("", err.Field())
+
+		switch err.Field() {
+		case "Name":
+			fieldName = "プロジェクト名"
+		case "Color":
+			fieldName = "色"
+		case "Mode":
+			fieldName = "削除方法"
+		}
+
+		errors = append(errors, translateValidationError(err, fieldName))
+	}
+	return errors
+}
+
+func (r *CreateProjectRequest) Project() (*models.Project, error) {
+	now := time.Now()
+
+	return &models.Project{
+		Name:      r.Name,
+		Color:     r.Color,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (r *UpdateProjectRequest) ProjectUpdate() models.ProjectUpdate {
+	return models.ProjectUpdate{
+		Name:     r.Name,
+		Color:    r.Color,
+		Archived: r.Archived,
+	}
+}
+
+func (r *ListProjectsRequest) Query() models.ProjectListQuery {
+	return models.ProjectListQuery{
+		IncludeArchived: r.IncludeArchived,
+	}
+}
+
+func (r *CreateProjectRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *UpdateProjectRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *DeleteProjectRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewCreateProjectRequest(c *gin.Context) (*CreateProjectRequest, []ValidationErrorDetail, error) {
+	var req CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Color = strings.TrimSpace(req.Color)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewUpdateProjectRequest(c *gin.Context) (*UpdateProjectRequest, []ValidationErrorDetail, error) {
+	var req UpdateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		req.Name = &name
+	}
+	if req.Color != nil {
+		color := strings.TrimSpace(*req.Color)
+		req.Color = &color
+	}
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewListProjectsRequest(c *gin.Context) (*ListProjectsRequest, error) {
+	var req ListProjectsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func NewDeleteProjectRequest(c *gin.Context) (*DeleteProjectRequest, []ValidationErrorDetail, error) {
+	var req DeleteProjectRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
@@ -0,0 +1,102 @@
+package request
+
+import (
+	"strings"
+	"time"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,max=50" ja:"タグ名"`
+}
+
+type UpdateTagRequest struct {
+	Name string `json:"name" validate:"required,max=50" ja:"タグ名"`
+}
+
+func (r *CreateTagRequest) Validate() ValidationErrors {
+	return validateTagName(r)
+}
+
+func (r *UpdateTagRequest) Validate() ValidationErrors {
+	return validateTagName(r)
+}
+
+func validateTagName(r interface{}) ValidationErrors {
+	err := validate.Struct(r)
+	if err == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, err := range err.(validator.ValidationErrors) {
+		fieldName := getJapaneseFieldName("", err.Field())
+
+		switch err.Field() {
+		case "Name":
+			fieldName = "タグ名"
+		}
+
+		errors = append(errors, translateValidationError(err, fieldName))
+	}
+	return errors
+}
+
+func (r *CreateTagRequest) Tag() (*models.Tag, error) {
+	now := time.Now()
+
+	return &models.Tag{
+		Name:      r.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (r *UpdateTagRequest) Tag() (*models.Tag, error) {
+	return &models.Tag{
+		Name:      r.Name,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (r *CreateTagRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *UpdateTagRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewCreateTagRequest(c *gin.Context) (*CreateTagRequest, []ValidationErrorDetail, error) {
+	var req CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewUpdateTagRequest(c *gin.Context) (*UpdateTagRequest, []ValidationErrorDetail, error) {
+	var req UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
@@ -0,0 +1,156 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type BulkTodosRequest struct {
+	// atomic: 1件でも失敗した場合は全て取り消す / best_effort: 成功した操作のみ確定する
+	Mode       string                     `json:"mode" validate:"required,oneof=atomic best_effort" ja:"モード" enums:"atomic,best_effort"`
+	Operations []BulkTodoOperationRequest `json:"operations" validate:"required,min=1,max=100" ja:"操作"`
+}
+
+type BulkTodoOperationRequest struct {
+	Op string `json:"op" validate:"required,oneof=create update complete delete" ja:"操作の種類" enums:"create,update,complete,delete"`
+	// 作成以外の操作対象のTodoのID
+	ID int `json:"id" validate:"omitempty,min=1" ja:"TodoのID"`
+	// create は CreateTodoRequest、update は UpdateTodoRequest と同じ形式
+	Todo json.RawMessage `json:"todo" swaggertype:"object"`
+	// 完了にする場合、未完了の子孫Todoもまとめて完了にする
+	CascadeCompletion bool `json:"cascade_completion" ja:"子Todoもまとめて完了"`
+}
+
+// Validate はリクエスト全体の形式のみを検証する（各操作の内容は Operation() で操作ごとに検証する）
+func (r *BulkTodosRequest) Validate() ValidationErrors {
+	err := validate.Struct(r)
+	if err == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, err := range err.(validator.ValidationErrors) {
+		fieldName := getJapaneseFieldName("", err.Field())
+
+		switch err.Field() {
+		case "Mode":
+			fieldName = "モード"
+		case "Operations":
+			fieldName = "操作"
+		}
+
+		errors = append(errors, translateValidationError(err, fieldName))
+	}
+	return errors
+}
+
+func (r *BulkTodosRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *BulkTodoOperationRequest) Validate() ValidationErrors {
+	var errors ValidationErrors
+
+	if err := validate.Struct(r); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			fieldName := getJapaneseFieldName("", err.Field())
+
+			switch err.Field() {
+			case "Op":
+				fieldName = "操作の種類"
+			case "ID":
+				fieldName = "TodoのID"
+			}
+
+			errors = append(errors, translateValidationError(err, fieldName))
+		}
+	}
+
+	if r.Op != "create" && r.ID == 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ID",
+			Message: "TodoのIDは必須です",
+		})
+	}
+	if (r.Op == "create" || r.Op == "update") && isEmptyJSON(r.Todo) {
+		errors = append(errors, ValidationError{
+			Field:   "Todo",
+			Message: "Todoの内容は必須です",
+		})
+	}
+
+	return errors
+}
+
+func (r *BulkTodoOperationRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+// Operation は操作を検証し、作成・更新の場合は内容をモデルに変換する
+// 検証エラーがある場合はエラー詳細を返す
+func (r *BulkTodoOperationRequest) Operation() (*models.Todo, []ValidationErrorDetail) {
+	if details, isValid := r.ValidateAndExtractDetails(); !isValid {
+		return nil, details
+	}
+
+	switch r.Op {
+	case "create":
+		var req CreateTodoRequest
+		if err := decodeStrict(r.Todo, &req); err != nil {
+			return nil, invalidTodoJSONDetails()
+		}
+		if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+			return nil, details
+		}
+		todo, _ := req.Todo()
+		return todo, nil
+	case "update":
+		var req UpdateTodoRequest
+		if err := decodeStrict(r.Todo, &req); err != nil {
+			return nil, invalidTodoJSONDetails()
+		}
+		if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+			return nil, details
+		}
+		todo, _ := req.Todo()
+		return todo, nil
+	}
+	return nil, nil
+}
+
+func NewBulkTodosRequest(c *gin.Context) (*BulkTodosRequest, []ValidationErrorDetail, error) {
+	var req BulkTodosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func decodeStrict(data json.RawMessage, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+func isEmptyJSON(data json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+func invalidTodoJSONDetails() []ValidationErrorDetail {
+	return []ValidationErrorDetail{{
+		Field:   "Todo",
+		Message: "Todoの内容の形式が正しくありません",
+	}}
+}
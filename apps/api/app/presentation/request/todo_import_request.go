@@ -0,0 +1,439 @@
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxTodoImportFileSize はインポートするファイルの最大サイズ
+const MaxTodoImportFileSize = 10 << 20
+
+// ErrTooManyImportRows はファイルの行数が models.MaxTodoImportRows を超えている場合のエラー
+var ErrTooManyImportRows = errors.New("too many import rows")
+
+// インポートするファイルの形式
+const (
+	todoImportCSV  = "csv"
+	todoImportJSON = "json"
+)
+
+// utf8BOM は Excel で保存したCSVの先頭に付くBOM
+const utf8BOM = "\xEF\xBB\xBF"
+
+type ImportTodosRequest struct {
+	// CSV（見出し行の列名は JSON と同じ）または JSON（配列または1行1件の NDJSON）
+	File *multipart.FileHeader `ja:"ファイル"`
+	// 検証のみ行い、何も登録しない
+	DryRun bool `form:"dry_run" ja:"ドライラン"`
+}
+
+func (r *ImportTodosRequest) Validate() ValidationErrors {
+	var errors ValidationErrors
+	switch {
+	case r.File == nil:
+		errors = append(errors, ValidationError{Field: "File", Message: "ファイルは必須です"})
+	case r.File.Size > MaxTodoImportFileSize:
+		errors = append(errors, ValidationError{
+			Field:   "File",
+			Message: fmt.Sprintf("ファイルは%dMB以下にしてください", MaxTodoImportFileSize>>20),
+		})
+	case r.format() == "":
+		errors = append(errors, ValidationError{Field: "File", Message: "ファイルはCSVまたはJSONを指定してください"})
+	}
+	return errors
+}
+
+func (r *ImportTodosRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+// format はファイル名の拡張子（無い場合は Content-Type）からファイルの形式を判定する
+func (r *ImportTodosRequest) format() string {
+	switch strings.ToLower(filepath.Ext(r.File.Filename)) {
+	case ".csv":
+		return todoImportCSV
+	case ".json", ".ndjson", ".jsonl":
+		return todoImportJSON
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.File.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return todoImportCSV
+	case "application/json", "application/x-ndjson":
+		return todoImportJSON
+	}
+	return ""
+}
+
+// Rows はファイルを読み込み、各行を CreateTodoRequest と同じく検証する
+// 不備のある行は行番号とともに rowErrors に入れ、rows には含めない
+// ファイルの形式が壊れている場合はその行のエラーを入れて以降を読み込まない
+func (r *ImportTodosRequest) Rows() (rows []models.TodoImportRow, rowErrors []models.TodoImportRowError, err error) {
+	file, err := r.File.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxTodoImportFileSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	// Excel で保存したCSV・JSONの先頭のBOMを読み飛ばす
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+
+	var parsed []todoImportRecord
+	if r.format() == todoImportCSV {
+		parsed = parseTodoImportCSV(data)
+	} else {
+		parsed = parseTodoImportJSON(data)
+	}
+	if len(parsed) > models.MaxTodoImportRows {
+		return nil, nil, ErrTooManyImportRows
+	}
+
+	rowErrors = []models.TodoImportRowError{}
+	for _, record := range parsed {
+		if record.req != nil {
+			record.errors = append(record.errors, record.req.Validate()...)
+		}
+		if len(record.errors) > 0 {
+			for _, ve := range record.errors {
+				rowErrors = append(rowErrors, models.TodoImportRowError{Line: record.line, Field: ve.Field, Message: ve.Message})
+			}
+			continue
+		}
+		rows = append(rows, record.req.ImportRow(record.line))
+	}
+	if len(rows) == 0 && len(rowErrors) == 0 {
+		rowErrors = append(rowErrors, models.TodoImportRowError{Line: 1, Field: "File", Message: "ファイルにTodoがありません"})
+	}
+	return rows, rowErrors, nil
+}
+
+// ImportRow は検証済みのリクエストを、ファイルの line 行目のインポートする行に変換する
+func (r *CreateTodoRequest) ImportRow(line int) models.TodoImportRow {
+	row := models.TodoImportRow{
+		Line:        line,
+		Title:       r.Title,
+		Description: r.Description,
+		Priority:    r.Priority,
+		DueAt:       r.DueAt,
+		RemindAt:    r.RemindAt,
+		ParentID:    r.ParentID,
+		ProjectID:   r.ProjectID,
+		Tags:        r.Tags,
+	}
+	if r.RecurrenceRule != "" {
+		rule, timezone := r.RecurrenceRule, r.Timezone
+		row.RecurrenceRule = &rule
+		row.RecurrenceTimezone = &timezone
+	}
+	return row
+}
+
+// todoImportRecord はファイルの1行を読み込んだ結果（読み込めなかった場合は req が nil）
+type todoImportRecord struct {
+	line   int
+	req    *CreateTodoRequest
+	errors ValidationErrors
+}
+
+// parseTodoImportCSV は見出し行の列名（title, description, priority, due_at, remind_at, parent_id, project_id, tags, recurrence_rule, timezone）で各行を読み込む
+// エクスポートしたCSVをそのまま読み込めるよう、それ以外の列（id, completed など）は無視する
+func parseTodoImportCSV(data []byte) []todoImportRecord {
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return []todoImportRecord{csvParseErrorRecord(err)}
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return []todoImportRecord{{line: 1, errors: ValidationErrors{{Field: "File", Message: "見出し行に title 列がありません"}}}}
+	}
+
+	var records []todoImportRecord
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			// 列数の違いはその行のみのエラーとし、以降の行は読み込む
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				records = append(records, todoImportRecord{
+					line:   parseErr.StartLine,
+					errors: ValidationErrors{{Field: "File", Message: "列の数が見出し行と一致しません"}},
+				})
+				continue
+			}
+			return append(records, csvParseErrorRecord(err))
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := todoImportRecord{line: line, req: &CreateTodoRequest{}}
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(unescapeCSVFormula(values[i]))
+			}
+			return ""
+		}
+		record.req.Title = cell("title")
+		record.req.Description = cell("description")
+		record.req.Priority = models.TodoPriority(cell("priority"))
+		record.req.RecurrenceRule = cell("recurrence_rule")
+		record.req.Timezone = cell("timezone")
+		for _, name := range strings.Split(cell("tags"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				record.req.Tags = append(record.req.Tags, name)
+			}
+		}
+		record.req.DueAt = parseImportTime(cell("due_at"), "DueAt", "期限", &record.errors)
+		record.req.RemindAt = parseImportTime(cell("remind_at"), "RemindAt", "リマインド日時", &record.errors)
+		record.req.ParentID = parseImportInt(cell("parent_id"), "ParentID", "親TodoのID", &record.errors)
+		record.req.ProjectID = parseImportInt(cell("project_id"), "ProjectID", "プロジェクトID", &record.errors)
+		records = append(records, record)
+	}
+	return records
+}
+
+// unescapeCSVFormula はエクスポートで数式の対策として付けた先頭の ' を取り除く
+func unescapeCSVFormula(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func csvParseErrorRecord(err error) todoImportRecord {
+	record := todoImportRecord{line: 1, errors: ValidationErrors{{Field: "File", Message: "CSVの形式が正しくありません"}}}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		record.line = parseErr.Line
+	}
+	return record
+}
+
+func parseImportTime(value string, field string, fieldName string, errors *ValidationErrors) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		*errors = append(*errors, ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("%sはRFC3339形式（例: 2025-01-01T09:00:00+09:00）で指定してください", fieldName),
+		})
+		return nil
+	}
+	return &t
+}
+
+func parseImportInt(value string, field string, fieldName string, errors *ValidationErrors) *int {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		*errors = append(*errors, ValidationError{Field: field, Message: fmt.Sprintf("%sは整数で指定してください", fieldName)})
+		return nil
+	}
+	return &n
+}
+
+// todoImportJSONRow は JSON の1件（エクスポートした NDJSON をそのまま読み込めるよう、タグはオブジェクトの配列も受け付ける）
+type todoImportJSONRow struct {
+	CreateTodoRequest
+	Tags todoImportTags `json:"tags"`
+}
+
+type todoImportTags []string
+
+func (t *todoImportTags) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		*t = names
+		return nil
+	}
+	var tags []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return err
+	}
+	*t = make([]string, len(tags))
+	for i, tag := range tags {
+		(*t)[i] = tag.Name
+	}
+	return nil
+}
+
+// parseTodoImportJSON は CreateTodoRequest と同じ形式のオブジェクトの配列、または1行1件の NDJSON を読み込む
+func parseTodoImportJSON(data []byte) []todoImportRecord {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) == 0 {
+		return nil
+	}
+	if trimmed[0] != '[' {
+		return parseTodoImportNDJSON(data)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return []todoImportRecord{jsonErrorRecord(err, syntaxErrorLine(data, err, 1))}
+	}
+	var records []todoImportRecord
+	for decoder.More() {
+		line := lineAt(data, nextValueOffset(data, decoder.InputOffset()))
+		var row todoImportJSONRow
+		if err := decoder.Decode(&row); err != nil {
+			var syntaxErr *json.SyntaxError
+			// 構文エラー以降は読み込めないが、型の違いなどはその要素のみのエラーとして以降の要素を読み込む
+			if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return append(records, jsonErrorRecord(err, syntaxErrorLine(data, err, line)))
+			}
+			records = append(records, jsonErrorRecord(err, line))
+			continue
+		}
+		records = append(records, jsonRecord(row, line))
+	}
+	if _, err := decoder.Token(); err != nil {
+		records = append(records, jsonErrorRecord(err, syntaxErrorLine(data, err, lineAt(data, int64(len(data))))))
+	}
+	return records
+}
+
+func parseTodoImportNDJSON(data []byte) []todoImportRecord {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxTodoImportFileSize+1)
+
+	var records []todoImportRecord
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var row todoImportJSONRow
+		if err := json.Unmarshal(text, &row); err != nil {
+			records = append(records, jsonErrorRecord(err, line))
+			continue
+		}
+		records = append(records, jsonRecord(row, line))
+	}
+	return records
+}
+
+func jsonRecord(row todoImportJSONRow, line int) todoImportRecord {
+	req := row.CreateTodoRequest
+	req.Tags = row.Tags
+	return todoImportRecord{line: line, req: &req}
+}
+
+// todoImportJSONFields は JSON の項目名に対応するフィールド名と日本語名
+var todoImportJSONFields = map[string][2]string{
+	"title":           {"Title", "タイトル"},
+	"description":     {"Description", "説明"},
+	"priority":        {"Priority", "優先度"},
+	"due_at":          {"DueAt", "期限"},
+	"remind_at":       {"RemindAt", "リマインド日時"},
+	"parent_id":       {"ParentID", "親TodoのID"},
+	"project_id":      {"ProjectID", "プロジェクトID"},
+	"tags":            {"Tags", "タグ"},
+	"recurrence_rule": {"RecurrenceRule", "繰り返し設定"},
+	"timezone":        {"Timezone", "タイムゾーン"},
+}
+
+// jsonErrorRecord は JSON を読み込めなかった要素のエラー（型が違う場合は項目も返す）
+func jsonErrorRecord(err error, line int) todoImportRecord {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if field, ok := todoImportJSONFields[typeErr.Field]; ok {
+			return todoImportRecord{line: line, errors: ValidationErrors{{
+				Field:   field[0],
+				Message: fmt.Sprintf("%sの形式が正しくありません", field[1]),
+			}}}
+		}
+	}
+	return todoImportRecord{line: line, errors: ValidationErrors{{Field: "File", Message: "JSONの形式が正しくありません"}}}
+}
+
+// syntaxErrorLine は構文エラーの位置の行番号を返す（構文エラーでない場合は line）
+func syntaxErrorLine(data []byte, err error, line int) int {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return lineAt(data, syntaxErr.Offset)
+	}
+	return line
+}
+
+// nextValueOffset は配列の次の要素が始まる位置（offset 以降の空白とカンマを読み飛ばした位置）を返す
+func nextValueOffset(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// lineAt は data の offset バイト目の行番号（1始まり）を返す
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+func NewImportTodosRequest(c *gin.Context) (*ImportTodosRequest, []ValidationErrorDetail, error) {
+	var req ImportTodosRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, nil, err
+	}
+	// 上限を超えるファイルは全て読み込まずにエラーにする（multipart の区切りなどの分を見込む）
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxTodoImportFileSize+1<<20)
+	file, err := c.FormFile("file")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
+		var maxBytesErr *http.MaxBytesError
+		if !errors.As(err, &maxBytesErr) {
+			return nil, nil, err
+		}
+		return nil, []ValidationErrorDetail{{
+			Field:   "File",
+			Message: fmt.Sprintf("ファイルは%dMB以下にしてください", MaxTodoImportFileSize>>20),
+		}}, nil
+	}
+	req.File = file
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
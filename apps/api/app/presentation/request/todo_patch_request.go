@@ -0,0 +1,144 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// PatchTodoRequest は JSON Merge Patch（RFC 7396）によるTodoの部分更新
+// 省略した項目は変更せず、null を指定した項目は値を消す
+type PatchTodoRequest struct {
+	Title       models.Nullable[string]              `json:"title" swaggertype:"string" ja:"タイトル"`
+	Description models.Nullable[string]              `json:"description" swaggertype:"string" ja:"説明"` // null で説明を消す
+	Priority    models.Nullable[models.TodoPriority] `json:"priority" swaggertype:"string" enums:"low,medium,high" ja:"優先度"`
+	Completed   models.Nullable[bool]                `json:"completed" swaggertype:"boolean" ja:"完了状態"`
+	DueAt       models.Nullable[time.Time]           `json:"due_at" swaggertype:"string" format:"date-time" ja:"期限"`         // null で期限を消す
+	RemindAt    models.Nullable[time.Time]           `json:"remind_at" swaggertype:"string" format:"date-time" ja:"リマインド日時"` // null でリマインド日時を消す
+	ParentID    models.Nullable[int]                 `json:"parent_id" swaggertype:"integer" ja:"親TodoのID"`                  // null でルートTodoにする
+	ProjectID   models.Nullable[int]                 `json:"project_id" swaggertype:"integer" ja:"プロジェクトID"`                 // null でインボックスに移動
+	// 指定した場合はタグを置き換える（null・空配列で全て外す）
+	Tags models.Nullable[[]string] `json:"tags" swaggertype:"array,string" ja:"タグ"`
+	// 指定した場合は繰り返し設定を置き換える（null で繰り返しを解除）
+	RecurrenceRule models.Nullable[string] `json:"recurrence_rule" swaggertype:"string" ja:"繰り返し設定" example:"FREQ=MONTHLY;BYDAY=1MO"`
+	Timezone       models.Nullable[string] `json:"timezone" swaggertype:"string" ja:"タイムゾーン" example:"Asia/Tokyo"`
+	// 未完了の子孫Todoがある状態で完了にする場合、子孫もまとめて完了にする
+	CascadeCompletion bool `json:"cascade_completion" ja:"子Todoもまとめて完了"`
+}
+
+// ErrNullNotAllowed は null を指定できない項目に null が指定されたことを表す
+var ErrNullNotAllowed = errors.New("null is not allowed")
+
+func (r *PatchTodoRequest) Validate() ValidationErrors {
+	var validationErrors ValidationErrors
+	checks := []*ValidationError{
+		validatePatchField("Title", "タイトル", r.Title, false, "required,max=100"),
+		validatePatchField("Description", "説明", r.Description, true, "max=500"),
+		validatePatchField("Priority", "優先度", r.Priority, false, "oneof=low medium high"),
+		validatePatchField("Completed", "完了状態", r.Completed, false, ""),
+		validatePatchField("ParentID", "親TodoのID", r.ParentID, true, "min=1"),
+		validatePatchField("ProjectID", "プロジェクトID", r.ProjectID, true, "min=1"),
+		validatePatchField("Tags", "タグ", r.Tags, true, "max=20,dive,required,max=50"),
+		validatePatchField("RecurrenceRule", "繰り返し設定", r.RecurrenceRule, true, "max=255"),
+		validatePatchField("Timezone", "タイムゾーン", r.Timezone, true, "max=64"),
+		validateReminder(r.DueAt.Ptr(), r.RemindAt.Ptr()),
+	}
+	for _, ve := range checks {
+		if ve != nil {
+			validationErrors = append(validationErrors, *ve)
+		}
+	}
+
+	if r.RecurrenceRule.HasValue() || r.Timezone.HasValue() {
+		validationErrors = append(validationErrors, validateRecurrence(r.RecurrenceRule.Value, r.Timezone.Value)...)
+	}
+	return validationErrors
+}
+
+// nullViolations は null を指定できない項目（タイトル・優先度・完了状態）に null が指定されたものを返す
+func (r *PatchTodoRequest) nullViolations() []ValidationErrorDetail {
+	var details []ValidationErrorDetail
+	for _, ve := range []*ValidationError{
+		validatePatchField("Title", "タイトル", r.Title, false, ""),
+		validatePatchField("Priority", "優先度", r.Priority, false, ""),
+		validatePatchField("Completed", "完了状態", r.Completed, false, ""),
+	} {
+		if ve != nil {
+			details = append(details, ValidationErrorDetail{Field: ve.Field, Message: ve.Message})
+		}
+	}
+	return details
+}
+
+// validatePatchField は値が指定された項目を tag で検証する
+// nullable でない項目に null が指定された場合もエラーにする
+func validatePatchField[T any](field string, fieldName string, value models.Nullable[T], nullable bool, tag string) *ValidationError {
+	if value.IsNull() {
+		if nullable {
+			return nil
+		}
+		return &ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("%sにnullは指定できません", fieldName),
+		}
+	}
+	if !value.Set || tag == "" {
+		return nil
+	}
+
+	err := validate.Var(value.Value, tag)
+	if err == nil {
+		return nil
+	}
+	for _, err := range err.(validator.ValidationErrors) {
+		ve := translateValidationError(err, fieldName)
+		ve.Field = field
+		return &ve
+	}
+	return nil
+}
+
+func (r *PatchTodoRequest) Patch() models.TodoPatch {
+	return models.TodoPatch{
+		Title:              r.Title,
+		Description:        r.Description,
+		Priority:           r.Priority,
+		Completed:          r.Completed,
+		ParentID:           r.ParentID,
+		ProjectID:          r.ProjectID,
+		DueAt:              r.DueAt,
+		RemindAt:           r.RemindAt,
+		Tags:               r.Tags,
+		RecurrenceRule:     r.RecurrenceRule,
+		RecurrenceTimezone: r.Timezone,
+	}
+}
+
+func (r *PatchTodoRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewPatchTodoRequest(c *gin.Context) (*PatchTodoRequest, []ValidationErrorDetail, error) {
+	var req PatchTodoRequest
+	// Content-Type が application/merge-patch+json の場合も JSON として読み込む
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// null を指定できない項目への null は形式ではなく内容の誤りとして区別する
+	if details := req.nullViolations(); details != nil {
+		return nil, details, ErrNullNotAllowed
+	}
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
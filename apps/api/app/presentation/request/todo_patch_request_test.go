@@ -0,0 +1,126 @@
+package request_test
+
+import (
+	"api/app/models"
+	"api/app/presentation/handler"
+	"api/app/presentation/request"
+	"api/app/presentation/response"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodePatch は body を PATCH リクエストとして NewPatchTodoRequest で読み込む
+func decodePatch(t *testing.T, body string) (*request.PatchTodoRequest, []request.ValidationErrorDetail, error) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPatch, "/todos/1", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+	return request.NewPatchTodoRequest(c)
+}
+
+func TestNewPatchTodoRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Empty object leaves every field unset", func(t *testing.T) {
+		req, details, err := decodePatch(t, `{}`)
+		require.NoError(t, err)
+		require.Nil(t, details)
+
+		assert.False(t, req.Title.Set)
+		assert.False(t, req.Description.Set)
+		assert.False(t, req.DueAt.Set)
+		assert.False(t, req.Tags.Set)
+		assert.Equal(t, models.TodoPatch{}, req.Patch())
+	})
+
+	t.Run("null clears a nullable field", func(t *testing.T) {
+		req, details, err := decodePatch(t, `{"description":null}`)
+		require.NoError(t, err)
+		require.Nil(t, details)
+
+		assert.True(t, req.Description.Set)
+		assert.True(t, req.Description.Null)
+		assert.True(t, req.Description.IsNull())
+		assert.Nil(t, req.Description.Ptr())
+		// 説明は NULL を持てないため空文字で消す
+		require.NotNil(t, req.Description.OrZero())
+		assert.Equal(t, "", *req.Description.OrZero())
+		assert.False(t, req.Title.Set)
+	})
+
+	t.Run("Value sets a field", func(t *testing.T) {
+		req, details, err := decodePatch(t, `{"title":"買い物","due_at":null,"tags":[]}`)
+		require.NoError(t, err)
+		require.Nil(t, details)
+
+		assert.True(t, req.Title.HasValue())
+		assert.Equal(t, "買い物", req.Title.Value)
+		assert.True(t, req.DueAt.IsNull())
+		assert.True(t, req.Tags.HasValue())
+		assert.Empty(t, req.Tags.Value)
+		assert.False(t, req.Description.Set)
+	})
+
+	t.Run("null for a non-nullable field is rejected", func(t *testing.T) {
+		for _, tt := range []struct {
+			body  string
+			field string
+		}{
+			{body: `{"title":null}`, field: "Title"},
+			{body: `{"priority":null}`, field: "Priority"},
+			{body: `{"completed":null}`, field: "Completed"},
+		} {
+			req, details, err := decodePatch(t, tt.body)
+			assert.ErrorIs(t, err, request.ErrNullNotAllowed, tt.body)
+			assert.Nil(t, req, tt.body)
+			require.Len(t, details, 1, tt.body)
+			assert.Equal(t, tt.field, details[0].Field)
+			assert.Contains(t, details[0].Message, "nullは指定できません")
+		}
+	})
+
+	t.Run("Empty title is a validation error", func(t *testing.T) {
+		_, details, err := decodePatch(t, `{"title":""}`)
+		require.NoError(t, err)
+		require.Len(t, details, 1)
+		assert.Equal(t, "Title", details[0].Field)
+	})
+
+	t.Run("Type mismatch is a decode error", func(t *testing.T) {
+		_, _, err := decodePatch(t, `{"completed":"yes"}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("null for a non-nullable field is returned as 422", func(t *testing.T) {
+		// バリデーションで弾かれるリクエストは usecase を呼ばない
+		r := gin.New()
+		r.PATCH("/todos/:id", handler.NewTodoHandler(nil).PatchTodo)
+
+		send := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPatch, "/todos/1", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			return w
+		}
+
+		assert.Equal(t, http.StatusBadRequest, send(`{"title":""}`).Code)
+		assert.Equal(t, http.StatusBadRequest, send(`{"completed":"yes"}`).Code)
+
+		w := send(`{"title":null}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var body response.UnifiedErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, response.ErrorCodeValidation, body.ErrorCode)
+		require.Len(t, body.Details, 1)
+		assert.Equal(t, "Title", body.Details[0].Field)
+	})
+}
@@ -2,10 +2,12 @@ package request
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
 	"api/app/models"
+	"api/app/recurrence"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -15,6 +17,15 @@ type CreateTodoRequest struct {
 	Title       string `json:"title" validate:"required,max=100" ja:"タイトル"`
 	Description string `json:"description" validate:"max=500" ja:"説明"`
 	Priority    models.TodoPriority `json:"priority" validate:"omitempty,oneof=low medium high" ja:"優先度"`
+	DueAt       *time.Time `json:"due_at" ja:"期限"`
+	RemindAt    *time.Time `json:"remind_at" ja:"リマインド日時"`
+	ParentID    *int       `json:"parent_id" validate:"omitempty,min=1" ja:"親TodoのID"`
+	ProjectID   *int       `json:"project_id" validate:"omitempty,min=1" ja:"プロジェクトID"` // 省略時はインボックス（サブタスクは親と同じプロジェクト）
+	Tags        []string   `json:"tags" validate:"omitempty,max=20,dive,required,max=50" ja:"タグ"`
+	// 繰り返し設定（RFC 5545 の RRULE、期限を起点に繰り返す）
+	RecurrenceRule string `json:"recurrence_rule" validate:"max=255" ja:"繰り返し設定" example:"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"`
+	// 繰り返しの曜日・時刻を計算するタイムゾーン（IANA のタイムゾーン名、省略時は UTC）
+	Timezone string `json:"timezone" validate:"max=64" ja:"タイムゾーン" example:"Asia/Tokyo"`
 }
 
 type UpdateTodoRequest struct {
@@ -22,6 +33,47 @@ type UpdateTodoRequest struct {
 	Description string `json:"description" validate:"max=500" ja:"説明"`
 	Priority    models.TodoPriority `json:"priority" validate:"omitempty,oneof=low medium high" ja:"優先度"`
 	Completed   *bool  `json:"completed" ja:"完了状態"`
+	DueAt       *time.Time `json:"due_at" ja:"期限"`
+	RemindAt    *time.Time `json:"remind_at" ja:"リマインド日時"`
+	ParentID    *int       `json:"parent_id" validate:"omitempty,min=1" ja:"親TodoのID"`
+	ProjectID   *int       `json:"project_id" validate:"omitempty,min=0" ja:"プロジェクトID"` // 0 でインボックスに移動
+	// 指定した場合はタグを置き換える（空配列で全て外す）
+	Tags []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50" ja:"タグ"`
+	// 指定した場合は繰り返し設定を置き換える（空文字で繰り返しを解除）
+	RecurrenceRule *string `json:"recurrence_rule" validate:"omitempty,max=255" ja:"繰り返し設定" example:"FREQ=MONTHLY;BYDAY=1MO"`
+	Timezone       *string `json:"timezone" validate:"omitempty,max=64" ja:"タイムゾーン" example:"Asia/Tokyo"`
+	// 未完了の子孫Todoがある状態で完了にする場合、子孫もまとめて完了にする
+	CascadeCompletion bool `json:"cascade_completion" ja:"子Todoもまとめて完了"`
+}
+
+type ListTodosRequest struct {
+	Limit         int                   `form:"limit" validate:"omitempty,min=1,max=100" ja:"取得件数"`
+	Cursor        string                `form:"cursor" ja:"カーソル"`
+	Completed     *bool                 `form:"completed" ja:"完了状態"`
+	Priority      []models.TodoPriority `form:"priority" validate:"omitempty,dive,oneof=low medium high" ja:"優先度"`
+	CreatedAfter  *time.Time            `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00" ja:"作成日時（開始）"`
+	CreatedBefore *time.Time            `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00" ja:"作成日時（終了）"`
+	Sort          string                `form:"sort" ja:"並び順"`
+	Tag           []string              `form:"tag" validate:"omitempty,dive,required,max=50" ja:"タグ"`
+	// ルートTodoのみをページングし、子孫を children に入れ子で返す
+	Tree bool `form:"tree" ja:"ツリー表示"`
+	// CSV（Accept: text/csv）の先頭にBOMを付ける（Excel で開く場合に指定する）
+	BOM bool `form:"bom" ja:"BOM"`
+}
+
+type ListChildTodosRequest struct {
+	Tree bool `form:"tree" ja:"ツリー表示"`
+}
+
+type ListOccurrencesRequest struct {
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100" ja:"取得件数"`
+	// 曜日・時刻を計算するタイムゾーン（省略時はTodoに設定したタイムゾーン）
+	Timezone string `form:"timezone" validate:"max=64" ja:"タイムゾーン"`
+}
+
+type SearchTodosRequest struct {
+	Q     string `form:"q" validate:"required,max=100" ja:"検索キーワード"`
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=100" ja:"取得件数"`
 }
 
 type ValidationError struct {
@@ -54,10 +106,27 @@ func translateValidationError(err validator.FieldError, fieldName string) Valida
 			Message: fmt.Sprintf("%sは必須です", fieldName),
 		}
 	case "max":
+		if isNumberKind(err.Kind()) {
+			return ValidationError{
+				Field:   err.Field(),
+				Message: fmt.Sprintf("%sは%s以下で指定してください", fieldName, err.Param()),
+			}
+		}
 		return ValidationError{
 			Field:   err.Field(),
 			Message: fmt.Sprintf("%sは%s文字以内で入力してください", fieldName, err.Param()),
 		}
+	case "min":
+		if isNumberKind(err.Kind()) {
+			return ValidationError{
+				Field:   err.Field(),
+				Message: fmt.Sprintf("%sは%s以上で指定してください", fieldName, err.Param()),
+			}
+		}
+		return ValidationError{
+			Field:   err.Field(),
+			Message: fmt.Sprintf("%sは%s文字以上で入力してください", fieldName, err.Param()),
+		}
 	case "oneof":
 		return ValidationError{
 			Field:   err.Field(),
@@ -71,6 +140,17 @@ func translateValidationError(err validator.FieldError, fieldName string) Valida
 	}
 }
 
+// 数値型のフィールドかどうか（min/maxのメッセージ切り替え用）
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
 // フィールド名を日本語に変換
 func getJapaneseFieldName(structTag string, fieldName string) string {
 	// jaタグがあればそれを使用、なければフィールド名をそのまま
@@ -81,23 +161,45 @@ func getJapaneseFieldName(structTag string, fieldName string) string {
 }
 
 func (r *CreateTodoRequest) Validate() ValidationErrors {
+	var errors ValidationErrors
+	if ve := validateReminder(r.DueAt, r.RemindAt); ve != nil {
+		errors = append(errors, *ve)
+	}
+	errors = append(errors, validateRecurrence(r.RecurrenceRule, r.Timezone)...)
+	if r.RecurrenceRule != "" && r.DueAt == nil {
+		errors = append(errors, ValidationError{
+			Field:   "DueAt",
+			Message: "繰り返し設定には期限を指定してください",
+		})
+	}
+
 	err := validate.Struct(r)
 	if err == nil {
-		return nil
+		return errors
 	}
 
-	var errors ValidationErrors
 	for _, err := range err.(validator.ValidationErrors) {
 		// リフレクションでjaタグを取得
 		fieldName := getJapaneseFieldName("", err.Field()) // 簡略化版
 
-		switch err.Field() {
+		// tags[0] のようなスライス要素はフィールド名部分で判定する
+		switch strings.SplitN(err.Field(), "[", 2)[0] {
 		case "Title":
 			fieldName = "タイトル"
 		case "Description":
 			fieldName = "説明"
 		case "Priority":
 			fieldName = "優先度"
+		case "ParentID":
+			fieldName = "親TodoのID"
+		case "ProjectID":
+			fieldName = "プロジェクトID"
+		case "Tags":
+			fieldName = "タグ"
+		case "RecurrenceRule":
+			fieldName = "繰り返し設定"
+		case "Timezone":
+			fieldName = "タイムゾーン"
 		}
 
 		errors = append(errors, translateValidationError(err, fieldName))
@@ -106,16 +208,23 @@ func (r *CreateTodoRequest) Validate() ValidationErrors {
 }
 
 func (r *UpdateTodoRequest) Validate() ValidationErrors {
+	var errors ValidationErrors
+	if ve := validateReminder(r.DueAt, r.RemindAt); ve != nil {
+		errors = append(errors, *ve)
+	}
+	if r.RecurrenceRule != nil || r.Timezone != nil {
+		errors = append(errors, validateRecurrence(derefString(r.RecurrenceRule), derefString(r.Timezone))...)
+	}
+
 	err := validate.Struct(r)
 	if err == nil {
-		return nil
+		return errors
 	}
 
-	var errors ValidationErrors
 	for _, err := range err.(validator.ValidationErrors) {
 		fieldName := getJapaneseFieldName("", err.Field())
 
-		switch err.Field() {
+		switch strings.SplitN(err.Field(), "[", 2)[0] {
 		case "Title":
 			fieldName = "タイトル"
 		case "Description":
@@ -124,6 +233,164 @@ func (r *UpdateTodoRequest) Validate() ValidationErrors {
 			fieldName = "優先度"
 		case "Completed":
 			fieldName = "完了状態"
+		case "ParentID":
+			fieldName = "親TodoのID"
+		case "ProjectID":
+			fieldName = "プロジェクトID"
+		case "Tags":
+			fieldName = "タグ"
+		case "RecurrenceRule":
+			fieldName = "繰り返し設定"
+		case "Timezone":
+			fieldName = "タイムゾーン"
+		}
+
+		errors = append(errors, translateValidationError(err, fieldName))
+	}
+	return errors
+}
+
+// 繰り返し設定は対応している RRULE、タイムゾーンは IANA のタイムゾーン名でなければならない
+func validateRecurrence(rule string, timezone string) ValidationErrors {
+	var errors ValidationErrors
+	if rule != "" {
+		if _, err := recurrence.Parse(rule); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "RecurrenceRule",
+				Message: "繰り返し設定はRFC 5545のRRULE形式（例: FREQ=WEEKLY;BYDAY=MO,WE,FR）で指定してください",
+			})
+		}
+	}
+	if timezone != "" {
+		if _, err := recurrence.LoadLocation(timezone); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "Timezone",
+				Message: "タイムゾーンはIANAのタイムゾーン名（例: Asia/Tokyo）で指定してください",
+			})
+		}
+	}
+	return errors
+}
+
+func derefString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// リマインド日時は期限以前でなければならない
+func validateReminder(dueAt, remindAt *time.Time) *ValidationError {
+	if dueAt == nil || remindAt == nil || !remindAt.After(*dueAt) {
+		return nil
+	}
+	return &ValidationError{
+		Field:   "RemindAt",
+		Message: "リマインド日時は期限以前の日時を指定してください",
+	}
+}
+
+func (r *ListTodosRequest) Validate() ValidationErrors {
+	var errors ValidationErrors
+
+	if err := validate.Struct(r); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			fieldName := getJapaneseFieldName("", err.Field())
+
+			// priority[0] のようなスライス要素はフィールド名部分で判定する
+			switch strings.SplitN(err.Field(), "[", 2)[0] {
+			case "Limit":
+				fieldName = "取得件数"
+			case "Priority":
+				fieldName = "優先度"
+			case "Tag":
+				fieldName = "タグ"
+			}
+
+			errors = append(errors, translateValidationError(err, fieldName))
+		}
+	}
+
+	if r.CreatedAfter != nil && r.CreatedBefore != nil && !r.CreatedBefore.After(*r.CreatedAfter) {
+		errors = append(errors, ValidationError{
+			Field:   "CreatedBefore",
+			Message: "作成日時（終了）は作成日時（開始）より後の日時を指定してください",
+		})
+	}
+
+	sorts, err := models.ParseTodoSort(r.Sort)
+	if err != nil {
+		errors = append(errors, ValidationError{
+			Field:   "Sort",
+			Message: fmt.Sprintf("並び順は %s のいずれかをカンマ区切りで指定してください（降順は先頭に - を付与）", joinSortFields()),
+		})
+	}
+
+	if r.Cursor != "" {
+		cursor, cursorErr := models.DecodeTodoCursor(r.Cursor)
+		if cursorErr != nil {
+			errors = append(errors, ValidationError{
+				Field:   "Cursor",
+				Message: "カーソルの形式が正しくありません",
+			})
+		} else if err == nil && cursor.Sort != models.FormatTodoSort(models.TodoListQuery{Sort: sorts}.SortOrDefault()) {
+			errors = append(errors, ValidationError{
+				Field:   "Cursor",
+				Message: "カーソルが並び順と一致しません",
+			})
+		}
+	}
+
+	return errors
+}
+
+func joinSortFields() string {
+	fields := make([]string, len(models.TodoSortFields))
+	for i, f := range models.TodoSortFields {
+		fields[i] = string(f)
+	}
+	return strings.Join(fields, ", ")
+}
+
+func (r *ListOccurrencesRequest) Validate() ValidationErrors {
+	var errors ValidationErrors
+
+	if err := validate.Struct(r); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			fieldName := getJapaneseFieldName("", err.Field())
+
+			switch err.Field() {
+			case "Limit":
+				fieldName = "取得件数"
+			case "Timezone":
+				fieldName = "タイムゾーン"
+			}
+
+			errors = append(errors, translateValidationError(err, fieldName))
+		}
+	}
+
+	errors = append(errors, validateRecurrence("", r.Timezone)...)
+	return errors
+}
+
+func (r *SearchTodosRequest) Validate() ValidationErrors {
+	r.Q = strings.TrimSpace(r.Q)
+
+	err := validate.Struct(r)
+	if err == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, err := range err.(validator.ValidationErrors) {
+		fieldName := getJapaneseFieldName("", err.Field())
+
+		switch err.Field() {
+		case "Q":
+			fieldName = "検索キーワード"
+		case "Limit":
+			fieldName = "取得件数"
 		}
 
 		errors = append(errors, translateValidationError(err, fieldName))
@@ -141,14 +408,24 @@ func (r *CreateTodoRequest) Todo() (*models.Todo, error) {
 
 	now := time.Now()
 
-	return &models.Todo{
+	todo := &models.Todo{
 		Title:       r.Title,
 		Description: r.Description,
 		Priority:    priority,
 		Completed:   false,
+		DueAt:       r.DueAt,
+		RemindAt:    r.RemindAt,
+		ParentID:    r.ParentID,
+		ProjectID:   r.ProjectID,
+		Tags:        toTags(r.Tags),
 		CreatedAt:   now,
 		UpdatedAt:   now,
-	}, nil
+	}
+	if r.RecurrenceRule != "" {
+		todo.RecurrenceRule = &r.RecurrenceRule
+		todo.RecurrenceTimezone = &r.Timezone
+	}
+	return todo, nil
 }
 
 func (r *UpdateTodoRequest) Todo() (*models.Todo, error) {
@@ -158,6 +435,13 @@ func (r *UpdateTodoRequest) Todo() (*models.Todo, error) {
 		Title:       r.Title,
 		Description: r.Description,
 		Priority:    r.Priority,
+		DueAt:       r.DueAt,
+		RemindAt:    r.RemindAt,
+		ParentID:    r.ParentID,
+		ProjectID:   r.ProjectID,
+		Tags:        toTags(r.Tags),
+		RecurrenceRule:     r.RecurrenceRule,
+		RecurrenceTimezone: r.Timezone,
 		UpdatedAt:   now,
 	}
 
@@ -168,6 +452,71 @@ func (r *UpdateTodoRequest) Todo() (*models.Todo, error) {
 	return todo, nil
 }
 
+// toTags はタグ名の配列をモデルに変換する（nil の場合は nil のまま返し、未指定と区別する）
+func toTags(names []string) []models.Tag {
+	if names == nil {
+		return nil
+	}
+	tags := make([]models.Tag, len(names))
+	for i, name := range names {
+		tags[i] = models.Tag{Name: strings.TrimSpace(name)}
+	}
+	return tags
+}
+
+func (r *ListTodosRequest) Query() (models.TodoListQuery, error) {
+	sorts, err := models.ParseTodoSort(r.Sort)
+	if err != nil {
+		return models.TodoListQuery{}, err
+	}
+
+	query := models.TodoListQuery{
+		Limit:         r.Limit,
+		Completed:     r.Completed,
+		Priorities:    r.Priority,
+		CreatedAfter:  r.CreatedAfter,
+		CreatedBefore: r.CreatedBefore,
+		Sort:          sorts,
+		Tags:          r.Tag,
+		RootsOnly:     r.Tree,
+	}
+	if query.Limit == 0 {
+		query.Limit = models.DefaultTodoListLimit
+	}
+
+	if r.Cursor != "" {
+		cursor, err := models.DecodeTodoCursor(r.Cursor)
+		if err != nil {
+			return models.TodoListQuery{}, err
+		}
+		query.Cursor = cursor
+	}
+
+	return query, nil
+}
+
+func (r *SearchTodosRequest) Query() models.TodoSearchQuery {
+	query := models.TodoSearchQuery{
+		Q:     r.Q,
+		Limit: r.Limit,
+	}
+	if query.Limit == 0 {
+		query.Limit = models.DefaultTodoListLimit
+	}
+	return query
+}
+
+func (r *ListOccurrencesRequest) Query() models.OccurrenceQuery {
+	query := models.OccurrenceQuery{
+		Limit:    r.Limit,
+		Timezone: r.Timezone,
+	}
+	if query.Limit == 0 {
+		query.Limit = models.DefaultOccurrenceLimit
+	}
+	return query
+}
+
 // ValidationErrorDetailをレスポンス用に定義
 type ValidationErrorDetail struct {
 	Field   string `json:"field" required:"true"`
@@ -183,6 +532,18 @@ func (r *UpdateTodoRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail
 	return ValidateAndExtractDetails(r)
 }
 
+func (r *ListTodosRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *SearchTodosRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *ListOccurrencesRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
 // 参考実装スタイル: バリデーション付きリクエスト作成関数
 func NewCreateTodoRequest(c *gin.Context) (*CreateTodoRequest, []ValidationErrorDetail, error) {
 	var req CreateTodoRequest
@@ -211,6 +572,67 @@ func NewUpdateTodoRequest(c *gin.Context) (*UpdateTodoRequest, []ValidationError
 	return &req, nil, nil
 }
 
+func NewListTodosRequest(c *gin.Context) (*ListTodosRequest, []ValidationErrorDetail, error) {
+	var req ListTodosRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// priority=high,medium 形式も priority=high&priority=medium と同様に扱う
+	var priorities []models.TodoPriority
+	for _, p := range req.Priority {
+		for _, v := range strings.Split(string(p), ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				priorities = append(priorities, models.TodoPriority(v))
+			}
+		}
+	}
+	req.Priority = priorities
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewSearchTodosRequest(c *gin.Context) (*SearchTodosRequest, []ValidationErrorDetail, error) {
+	var req SearchTodosRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewListOccurrencesRequest(c *gin.Context) (*ListOccurrencesRequest, []ValidationErrorDetail, error) {
+	var req ListOccurrencesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewListChildTodosRequest(c *gin.Context) (*ListChildTodosRequest, error) {
+	var req ListChildTodosRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
 type GetByIDRequest struct {
 	ID int `param:"id" validate:"required"`
 }
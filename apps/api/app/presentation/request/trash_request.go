@@ -0,0 +1,78 @@
+package request
+
+import (
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ListTrashRequest struct {
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=100" ja:"取得件数"`
+	Cursor string `form:"cursor" ja:"カーソル"`
+}
+
+func (r *ListTrashRequest) Validate() ValidationErrors {
+	var errors ValidationErrors
+
+	if err := validate.Struct(r); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			fieldName := getJapaneseFieldName("", err.Field())
+
+			switch err.Field() {
+			case "Limit":
+				fieldName = "取得件数"
+			}
+
+			errors = append(errors, translateValidationError(err, fieldName))
+		}
+	}
+
+	if r.Cursor != "" {
+		if _, err := models.DecodeTrashCursor(r.Cursor); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "Cursor",
+				Message: "カーソルの形式が正しくありません",
+			})
+		}
+	}
+
+	return errors
+}
+
+func (r *ListTrashRequest) Query() (models.TrashListQuery, error) {
+	query := models.TrashListQuery{
+		Limit: r.Limit,
+	}
+	if query.Limit == 0 {
+		query.Limit = models.DefaultTodoListLimit
+	}
+
+	if r.Cursor != "" {
+		cursor, err := models.DecodeTrashCursor(r.Cursor)
+		if err != nil {
+			return models.TrashListQuery{}, err
+		}
+		query.Cursor = cursor
+	}
+
+	return query, nil
+}
+
+func (r *ListTrashRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewListTrashRequest(c *gin.Context) (*ListTrashRequest, []ValidationErrorDetail, error) {
+	var req ListTrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
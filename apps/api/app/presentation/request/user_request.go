@@ -0,0 +1,110 @@
+package request
+
+import (
+	"strings"
+	"time"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateUserRequest struct {
+	Name  string `json:"name" validate:"required,max=100" ja:"ユーザー名"`
+	Email string `json:"email" validate:"required,email,max=255" ja:"メールアドレス"`
+}
+
+type UpdateUserRequest struct {
+	Name  string `json:"name" validate:"omitempty,max=100" ja:"ユーザー名"`
+	Email string `json:"email" validate:"omitempty,email,max=255" ja:"メールアドレス"`
+}
+
+func (r *CreateUserRequest) Validate() ValidationErrors {
+	return validateUser(r)
+}
+
+func (r *UpdateUserRequest) Validate() ValidationErrors {
+	return validateUser(r)
+}
+
+func validateUser(r interface{}) ValidationErrors {
+	err := validate.Struct(r)
+	if err == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, err := range err.(validator.ValidationErrors) {
+		fieldName := getJapaneseFieldName("", err.Field())
+
+		switch err.Field() {
+		case "Name":
+			fieldName = "ユーザー名"
+		case "Email":
+			fieldName = "メールアドレス"
+		}
+
+		errors = append(errors, translateValidationError(err, fieldName))
+	}
+	return errors
+}
+
+func (r *CreateUserRequest) User() (*models.User, error) {
+	now := time.Now()
+
+	return &models.User{
+		Name:      r.Name,
+		Email:     r.Email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (r *UpdateUserRequest) User() (*models.User, error) {
+	return &models.User{
+		Name:      r.Name,
+		Email:     r.Email,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (r *CreateUserRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *UpdateUserRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewCreateUserRequest(c *gin.Context) (*CreateUserRequest, []ValidationErrorDetail, error) {
+	var req CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.TrimSpace(req.Email)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewUpdateUserRequest(c *gin.Context) (*UpdateUserRequest, []ValidationErrorDetail, error) {
+	var req UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.TrimSpace(req.Email)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
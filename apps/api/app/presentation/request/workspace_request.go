@@ -0,0 +1,96 @@
+package request
+
+import (
+	"strings"
+
+	"api/app/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" validate:"required,max=100" ja:"ワークスペース名" example:"開発チーム"`
+}
+
+type AddWorkspaceMemberRequest struct {
+	// 追加するユーザーのメールアドレス（登録済みのユーザーのみ追加できる）
+	Email string               `json:"email" validate:"required,email,max=255" ja:"メールアドレス" example:"bob@example.com"`
+	Role  models.WorkspaceRole `json:"role" validate:"required,oneof=owner member" ja:"ロール" example:"member"`
+}
+
+func (r *CreateWorkspaceRequest) Validate() ValidationErrors {
+	return validateWorkspace(r)
+}
+
+func (r *AddWorkspaceMemberRequest) Validate() ValidationErrors {
+	return validateWorkspace(r)
+}
+
+func validateWorkspace(r interface{}) ValidationErrors {
+	err := validate.Struct(r)
+	if err == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, err := range err.(validator.ValidationErrors) {
+		fieldName := getJapaneseFieldName("", err.Field())
+
+		switch err.Field() {
+		case "Name":
+			fieldName = "ワークスペース名"
+		case "Email":
+			fieldName = "メールアドレス"
+		case "Role":
+			fieldName = "ロール"
+		}
+
+		errors = append(errors, translateValidationError(err, fieldName))
+	}
+	return errors
+}
+
+func (r *CreateWorkspaceRequest) Workspace() *models.Workspace {
+	return &models.Workspace{
+		Name: r.Name,
+	}
+}
+
+func (r *CreateWorkspaceRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func (r *AddWorkspaceMemberRequest) ValidateAndExtractDetails() ([]ValidationErrorDetail, bool) {
+	return ValidateAndExtractDetails(r)
+}
+
+func NewCreateWorkspaceRequest(c *gin.Context) (*CreateWorkspaceRequest, []ValidationErrorDetail, error) {
+	var req CreateWorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
+
+func NewAddWorkspaceMemberRequest(c *gin.Context) (*AddWorkspaceMemberRequest, []ValidationErrorDetail, error) {
+	var req AddWorkspaceMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, nil, err
+	}
+	req.Email = strings.TrimSpace(req.Email)
+
+	// バリデーション実行
+	if details, isValid := req.ValidateAndExtractDetails(); !isValid {
+		return nil, details, nil
+	}
+
+	return &req, nil, nil
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+type APIKeyResponse struct {
+	ID   int    `json:"id" binding:"required"`
+	Name string `json:"name" binding:"required"`
+	// キーの先頭部分（キー本体は作成時のみ返す）
+	Prefix     string     `json:"prefix" binding:"required" example:"tdk_1a2b3c4d5e6f7a8b"`
+	Scopes     []string   `json:"scopes" binding:"required" example:"todos:read,todos:write"`
+	ExpiresAt  time.Time  `json:"expires_at" binding:"required"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" binding:"required"`
+}
+
+// CreatedAPIKeyResponse は作成したAPIキーとキー本体（再取得できないため作成時のみ返す）
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" binding:"required"`
+}
+
+// ToAPIKeyResponse converts models.APIKey to APIKeyResponse
+func ToAPIKeyResponse(key models.APIKey) APIKeyResponse {
+	scopes := []string(key.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// ToAPIKeyResponses converts []models.APIKey to []APIKeyResponse
+func ToAPIKeyResponses(keys []models.APIKey) []APIKeyResponse {
+	keyResponses := make([]APIKeyResponse, len(keys))
+	for i, key := range keys {
+		keyResponses[i] = ToAPIKeyResponse(key)
+	}
+	return keyResponses
+}
@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+// AuthTokensResponse はログインとトークンの更新で発行したトークン
+type AuthTokensResponse struct {
+	AccessToken string `json:"access_token" binding:"required"`
+	// Authorization ヘッダーで送る際のスキーム
+	TokenType            string    `json:"token_type" binding:"required" example:"Bearer"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at" binding:"required"`
+	// 使用すると新しいトークンに置き換わる（古いトークンは使えなくなる）
+	RefreshToken          string    `json:"refresh_token" binding:"required"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at" binding:"required"`
+}
+
+// ToAuthTokensResponse converts models.AuthTokens to AuthTokensResponse
+func ToAuthTokensResponse(tokens models.AuthTokens) AuthTokensResponse {
+	return AuthTokensResponse{
+		AccessToken:           tokens.AccessToken,
+		TokenType:             "Bearer",
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
+
+// OIDCAuthorizationResponse は OpenID Connect のログインを開始するためのリダイレクト先
+type OIDCAuthorizationResponse struct {
+	// ブラウザをこのURL（IdP の認可エンドポイント）にリダイレクトする
+	AuthorizationURL string `json:"authorization_url" binding:"required"`
+}
@@ -0,0 +1,25 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+// OccurrenceResponse は繰り返しTodoの1回分の期限（指定したタイムゾーンのオフセット付き）
+type OccurrenceResponse struct {
+	DueAt    time.Time  `json:"due_at" binding:"required" example:"2025-01-06T09:00:00+09:00"`
+	RemindAt *time.Time `json:"remind_at"`
+}
+
+// ToOccurrenceResponses converts []models.Occurrence to []OccurrenceResponse
+func ToOccurrenceResponses(occurrences []models.Occurrence) []OccurrenceResponse {
+	responses := make([]OccurrenceResponse, len(occurrences))
+	for i, occurrence := range occurrences {
+		responses[i] = OccurrenceResponse{
+			DueAt:    occurrence.DueAt,
+			RemindAt: occurrence.RemindAt,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,75 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+type ProjectMemberResponse struct {
+	ProjectID int                `json:"project_id" binding:"required"`
+	UserID    int                `json:"user_id" binding:"required"`
+	Name      string             `json:"name" binding:"required"`
+	Email     string             `json:"email" binding:"required"`
+	Role      models.ProjectRole `json:"role" binding:"required" example:"editor"`
+	CreatedAt time.Time          `json:"created_at" binding:"required"`
+	UpdatedAt time.Time          `json:"updated_at" binding:"required"`
+}
+
+type ProjectInvitationResponse struct {
+	ID          int                     `json:"id" binding:"required"`
+	ProjectID   int                     `json:"project_id" binding:"required"`
+	ProjectName string                  `json:"project_name" binding:"required"`
+	InviterID   *int                    `json:"inviter_id"`
+	InviteeID   int                     `json:"invitee_id" binding:"required"`
+	Role        models.ProjectRole      `json:"role" binding:"required" example:"editor"`
+	Status      models.InvitationStatus `json:"status" binding:"required" example:"pending"`
+	CreatedAt   time.Time               `json:"created_at" binding:"required"`
+	RespondedAt *time.Time              `json:"responded_at"`
+}
+
+// ToProjectMemberResponse converts models.ProjectMember to ProjectMemberResponse
+func ToProjectMemberResponse(member models.ProjectMember) ProjectMemberResponse {
+	return ProjectMemberResponse{
+		ProjectID: member.ProjectID,
+		UserID:    member.UserID,
+		Name:      member.UserName,
+		Email:     member.UserEmail,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+		UpdatedAt: member.UpdatedAt,
+	}
+}
+
+// ToProjectMemberResponses converts []models.ProjectMember to []ProjectMemberResponse
+func ToProjectMemberResponses(members []models.ProjectMember) []ProjectMemberResponse {
+	memberResponses := make([]ProjectMemberResponse, len(members))
+	for i, member := range members {
+		memberResponses[i] = ToProjectMemberResponse(member)
+	}
+	return memberResponses
+}
+
+// ToProjectInvitationResponse converts models.ProjectInvitation to ProjectInvitationResponse
+func ToProjectInvitationResponse(invitation models.ProjectInvitation) ProjectInvitationResponse {
+	return ProjectInvitationResponse{
+		ID:          invitation.ID,
+		ProjectID:   invitation.ProjectID,
+		ProjectName: invitation.ProjectName,
+		InviterID:   invitation.InviterID,
+		InviteeID:   invitation.InviteeID,
+		Role:        invitation.Role,
+		Status:      invitation.Status,
+		CreatedAt:   invitation.CreatedAt,
+		RespondedAt: invitation.RespondedAt,
+	}
+}
+
+// ToProjectInvitationResponses converts []models.ProjectInvitation to []ProjectInvitationResponse
+func ToProjectInvitationResponses(invitations []models.ProjectInvitation) []ProjectInvitationResponse {
+	invitationResponses := make([]ProjectInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		invitationResponses[i] = ToProjectInvitationResponse(invitation)
+	}
+	return invitationResponses
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+type ProjectResponse struct {
+	ID       int    `json:"id" binding:"required"`
+	OwnerID  int    `json:"owner_id" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Color    string `json:"color" binding:"required" example:"#4a90d9"`
+	Archived bool   `json:"archived"`
+	// 取得したユーザーのロール
+	Role models.ProjectRole `json:"role" example:"owner"`
+	// プロジェクト内の未完了・完了済みのTodo（サブタスクを含み、ゴミ箱内を除く）の件数
+	OpenTodoCount      int       `json:"open_todo_count"`
+	CompletedTodoCount int       `json:"completed_todo_count"`
+	CreatedAt          time.Time `json:"created_at" binding:"required"`
+	UpdatedAt          time.Time `json:"updated_at" binding:"required"`
+}
+
+// ToProjectResponse converts models.Project to ProjectResponse
+func ToProjectResponse(project models.Project) ProjectResponse {
+	return ProjectResponse{
+		ID:                 project.ID,
+		OwnerID:            project.OwnerID,
+		Name:               project.Name,
+		Color:              project.Color,
+		Archived:           project.Archived,
+		Role:               project.Role,
+		OpenTodoCount:      project.OpenTodoCount,
+		CompletedTodoCount: project.CompletedTodoCount,
+		CreatedAt:          project.CreatedAt,
+		UpdatedAt:          project.UpdatedAt,
+	}
+}
+
+// ToProjectResponses converts []models.Project to []ProjectResponse
+func ToProjectResponses(projects []models.Project) []ProjectResponse {
+	projectResponses := make([]ProjectResponse, len(projects))
+	for i, project := range projects {
+		projectResponses[i] = ToProjectResponse(project)
+	}
+	return projectResponses
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+type TagResponse struct {
+	ID        int       `json:"id" binding:"required"`
+	Name      string    `json:"name" binding:"required"`
+	CreatedAt time.Time `json:"created_at" binding:"required"`
+	UpdatedAt time.Time `json:"updated_at" binding:"required"`
+}
+
+// ToTagResponse converts models.Tag to TagResponse
+func ToTagResponse(tag models.Tag) TagResponse {
+	return TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}
+}
+
+// ToTagResponses converts []models.Tag to []TagResponse
+func ToTagResponses(tags []models.Tag) []TagResponse {
+	tagResponses := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		tagResponses[i] = ToTagResponse(tag)
+	}
+	return tagResponses
+}
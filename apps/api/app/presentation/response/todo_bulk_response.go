@@ -0,0 +1,25 @@
+package response
+
+// BulkTodoItemResponse は一括操作1件分の結果
+type BulkTodoItemResponse struct {
+	// リクエストの operations 内の位置（0始まり）
+	Index int    `json:"index" binding:"required"`
+	Op    string `json:"op" binding:"required" enums:"create,update,complete,delete"`
+	// 個別のAPIで実行した場合と同じHTTPステータス（取り消された操作は 424）
+	Status int `json:"status" binding:"required" example:"200"`
+	// 作成・更新・完了後のTodo（削除・失敗の場合は省略）
+	Data *TodoResponse `json:"data,omitempty"`
+	// 失敗・取り消しの理由（成功の場合は省略）
+	Error *UnifiedErrorResponse `json:"error,omitempty"`
+}
+
+type BulkTodoResponse struct {
+	Mode string `json:"mode" binding:"required" enums:"atomic,best_effort"`
+	// 変更が確定したかどうか（atomic で失敗した操作がある場合は false）
+	Committed bool `json:"committed"`
+	Succeeded int  `json:"succeeded"`
+	Failed    int  `json:"failed"`
+	// 他の操作が失敗したため取り消された（または実行されなかった）操作の件数
+	RolledBack int                    `json:"rolled_back"`
+	Results    []BulkTodoItemResponse `json:"results" binding:"required"`
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+// FieldChangeResponse は1項目の変更前後の値（未設定の場合は null）
+type FieldChangeResponse struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type TodoEventResponse struct {
+	ID      int                  `json:"id" binding:"required"`
+	TodoID  int                  `json:"todo_id" binding:"required"`
+	Type    models.TodoEventType `json:"type" binding:"required" enums:"created,updated,completed,reopened,deleted,restored"`
+	ActorID *int                 `json:"actor_id"`
+	// 項目名（title, completed など）ごとの変更前後の値
+	Changes   map[string]FieldChangeResponse `json:"changes" binding:"required"`
+	CreatedAt time.Time                      `json:"created_at" binding:"required"`
+}
+
+// ToTodoEventResponses converts []models.TodoEvent to []TodoEventResponse
+func ToTodoEventResponses(events []models.TodoEvent) []TodoEventResponse {
+	responses := make([]TodoEventResponse, len(events))
+	for i, event := range events {
+		changes := make(map[string]FieldChangeResponse, len(event.Changes))
+		for field, change := range event.Changes {
+			changes[field] = FieldChangeResponse{Before: change.Before, After: change.After}
+		}
+		responses[i] = TodoEventResponse{
+			ID:        event.ID,
+			TodoID:    event.TodoID,
+			Type:      event.Type,
+			ActorID:   event.ActorID,
+			Changes:   changes,
+			CreatedAt: event.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// ToTodoEventNextCursor converts the history page cursor to the opaque string returned to clients
+func ToTodoEventNextCursor(page *models.TodoEventPage) *string {
+	if page.NextCursor == nil {
+		return nil
+	}
+	cursor := page.NextCursor.Encode()
+	return &cursor
+}
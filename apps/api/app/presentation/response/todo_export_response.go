@@ -0,0 +1,131 @@
+package response
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/app/models"
+)
+
+const (
+	MIMECSV    = "text/csv"
+	MIMENDJSON = "application/x-ndjson"
+)
+
+// utf8BOM は Excel にCSVを UTF-8 として認識させるためのBOM
+const utf8BOM = "\xEF\xBB\xBF"
+
+// csvFormulaPrefixes は表計算ソフトが数式として解釈するセルの先頭の文字
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// TodoCSVHeader はTodoのCSVの列（タグはカンマ区切りで1列にまとめる）
+var TodoCSVHeader = []string{
+	"id", "title", "description", "completed", "priority", "due_at", "remind_at",
+	"parent_id", "project_id", "tags", "recurrence_rule", "timezone", "version", "created_at", "updated_at",
+}
+
+// TodoExportWriter はエクスポートするTodoを1件ずつ書き出す
+type TodoExportWriter interface {
+	Write(todo models.Todo) error
+	// Flush はバッファに残っている内容を書き出す
+	Flush() error
+}
+
+type todoCSVWriter struct {
+	w *csv.Writer
+}
+
+// NewTodoCSVWriter は見出し行を書き込んだCSVの TodoExportWriter を返す
+// bom が true の場合は先頭にBOMを付ける（Excel で開いたときに文字化けしないようにする）
+func NewTodoCSVWriter(w io.Writer, bom bool) (TodoExportWriter, error) {
+	if bom {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return nil, err
+		}
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(TodoCSVHeader); err != nil {
+		return nil, err
+	}
+	return &todoCSVWriter{w: cw}, nil
+}
+
+func (w *todoCSVWriter) Write(todo models.Todo) error {
+	tags := make([]string, len(todo.Tags))
+	for i, tag := range todo.Tags {
+		tags[i] = tag.Name
+	}
+	return w.w.Write([]string{
+		strconv.Itoa(todo.ID),
+		escapeCSVFormula(todo.Title),
+		escapeCSVFormula(todo.Description),
+		strconv.FormatBool(todo.Completed),
+		string(todo.Priority),
+		formatCSVTime(todo.DueAt),
+		formatCSVTime(todo.RemindAt),
+		formatCSVInt(todo.ParentID),
+		formatCSVInt(todo.ProjectID),
+		escapeCSVFormula(strings.Join(tags, ",")),
+		escapeCSVFormula(formatCSVString(todo.RecurrenceRule)),
+		escapeCSVFormula(formatCSVString(todo.RecurrenceTimezone)),
+		strconv.Itoa(todo.Version),
+		todo.CreatedAt.Format(time.RFC3339),
+		todo.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+func (w *todoCSVWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type todoNDJSONWriter struct {
+	enc *json.Encoder
+}
+
+// NewTodoNDJSONWriter は1行に1件の TodoResponse を書き出す TodoExportWriter を返す
+func NewTodoNDJSONWriter(w io.Writer) TodoExportWriter {
+	return &todoNDJSONWriter{enc: json.NewEncoder(w)}
+}
+
+func (w *todoNDJSONWriter) Write(todo models.Todo) error {
+	return w.enc.Encode(ToTodoResponse(todo))
+}
+
+func (w *todoNDJSONWriter) Flush() error {
+	return nil
+}
+
+// escapeCSVFormula は数式として解釈される文字で始まるセルの先頭に ' を付ける（CSVインジェクション対策）
+// インポートでは先頭の ' を取り除いて読み込む
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatCSVInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatCSVString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,113 @@
+package response_test
+
+import (
+	"api/app/models"
+	"api/app/presentation/response"
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoCSVWriter(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule := "FREQ=WEEKLY"
+	timezone := "Asia/Tokyo"
+	projectID := 3
+	todo := models.Todo{
+		ID:                 1,
+		Title:              `買い物, "牛乳"`,
+		Description:        "1行目\n2行目",
+		Priority:           models.PriorityHigh,
+		ProjectID:          &projectID,
+		Tags:               []models.Tag{{Name: "仕事"}, {Name: "急ぎ"}},
+		RecurrenceRule:     &rule,
+		RecurrenceTimezone: &timezone,
+		Version:            2,
+		CreatedAt:          createdAt,
+		UpdatedAt:          createdAt,
+	}
+
+	// write は todos を書き出したCSVを返す
+	write := func(t *testing.T, bom bool, todos ...models.Todo) string {
+		t.Helper()
+		var buf bytes.Buffer
+		w, err := response.NewTodoCSVWriter(&buf, bom)
+		require.NoError(t, err)
+		for _, todo := range todos {
+			require.NoError(t, w.Write(todo))
+		}
+		require.NoError(t, w.Flush())
+		return buf.String()
+	}
+	// read はCSVを読み込み、見出し行の列名をキーにした行を返す
+	read := func(t *testing.T, data string) []map[string]string {
+		t.Helper()
+		records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+		require.NoError(t, err)
+		require.NotEmpty(t, records)
+		assert.Equal(t, response.TodoCSVHeader, records[0])
+		rows := make([]map[string]string, 0, len(records)-1)
+		for _, record := range records[1:] {
+			row := map[string]string{}
+			for i, name := range records[0] {
+				row[name] = record[i]
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	}
+
+	t.Run("BOM is written only when requested", func(t *testing.T) {
+		withBOM := write(t, true)
+		assert.True(t, strings.HasPrefix(withBOM, "\xEF\xBB\xBFid,title,"))
+		assert.Empty(t, read(t, strings.TrimPrefix(withBOM, "\xEF\xBB\xBF")))
+
+		withoutBOM := write(t, false)
+		assert.True(t, strings.HasPrefix(withoutBOM, "id,title,"))
+	})
+
+	t.Run("Cells are quoted and escaped", func(t *testing.T) {
+		data := write(t, false, todo)
+		assert.Contains(t, data, `"買い物, ""牛乳"""`)
+
+		rows := read(t, data)
+		require.Len(t, rows, 1)
+		assert.Equal(t, map[string]string{
+			"id":              "1",
+			"title":           `買い物, "牛乳"`,
+			"description":     "1行目\n2行目",
+			"completed":       "false",
+			"priority":        "high",
+			"due_at":          "",
+			"remind_at":       "",
+			"parent_id":       "",
+			"project_id":      "3",
+			"tags":            "仕事,急ぎ",
+			"recurrence_rule": "FREQ=WEEKLY",
+			"timezone":        "Asia/Tokyo",
+			"version":         "2",
+			"created_at":      "2025-01-01T09:00:00Z",
+			"updated_at":      "2025-01-01T09:00:00Z",
+		}, rows[0])
+	})
+
+	t.Run("Cells that start a formula are prefixed", func(t *testing.T) {
+		for _, value := range []string{"=1+1", "+1", "-1", "@SUM(A1)", "\tcmd", "\rcmd"} {
+			formula := todo
+			formula.Title = value
+			formula.Description = value
+			formula.Tags = []models.Tag{{Name: value}}
+
+			rows := read(t, write(t, false, formula))
+			require.Len(t, rows, 1)
+			assert.Equal(t, "'"+value, rows[0]["title"])
+			assert.Equal(t, "'"+value, rows[0]["description"])
+			assert.Equal(t, "'"+value, rows[0]["tags"])
+		}
+	})
+}
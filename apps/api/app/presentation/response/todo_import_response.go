@@ -0,0 +1,58 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+// TodoImportErrorResponse はインポートするファイルの1行のエラー（1行に複数のエラーがある場合は項目ごとに返す）
+type TodoImportErrorResponse struct {
+	// ファイル内の行番号（1始まり、CSVの見出し行を含む）
+	Line int `json:"line" binding:"required" example:"3"`
+	ValidationErrorDetail
+}
+
+type TodoImportResultResponse struct {
+	DryRun       bool                      `json:"dry_run" binding:"required"`
+	TotalRows    int                       `json:"total_rows" binding:"required"`
+	ImportedRows int                       `json:"imported_rows" binding:"required"`
+	Errors       []TodoImportErrorResponse `json:"errors" binding:"required"`
+}
+
+type TodoImportJobResponse struct {
+	ID     int                        `json:"id" binding:"required"`
+	Status models.TodoImportJobStatus `json:"status" binding:"required" enums:"pending,running,succeeded,failed"`
+	// ファイルのTodoの件数と、そのうち登録済みの件数
+	TotalRows    int `json:"total_rows" binding:"required"`
+	ImportedRows int `json:"imported_rows" binding:"required"`
+	// 失敗した場合の原因（不備のある行がある場合は1行も登録しない）
+	Errors     []TodoImportErrorResponse `json:"errors" binding:"required"`
+	CreatedAt  time.Time                 `json:"created_at" binding:"required"`
+	StartedAt  *time.Time                `json:"started_at"`
+	FinishedAt *time.Time                `json:"finished_at"`
+}
+
+func ToTodoImportErrorResponses(errors []models.TodoImportRowError) []TodoImportErrorResponse {
+	responses := make([]TodoImportErrorResponse, len(errors))
+	for i, e := range errors {
+		responses[i] = TodoImportErrorResponse{
+			Line:                  e.Line,
+			ValidationErrorDetail: ValidationErrorDetail{Field: e.Field, Message: e.Message},
+		}
+	}
+	return responses
+}
+
+func ToTodoImportJobResponse(job *models.TodoImportJob) TodoImportJobResponse {
+	return TodoImportJobResponse{
+		ID:           job.ID,
+		Status:       job.Status,
+		TotalRows:    job.TotalRows,
+		ImportedRows: job.ImportedRows,
+		Errors:       ToTodoImportErrorResponses(job.Errors),
+		CreatedAt:    job.CreatedAt,
+		StartedAt:    job.StartedAt,
+		FinishedAt:   job.FinishedAt,
+	}
+}
@@ -12,8 +12,35 @@ type TodoResponse struct {
 	Description string    `json:"description"`
 	Completed   bool      `json:"completed"`
 	Priority    models.TodoPriority `json:"priority" binding:"required"`
+	DueAt       *time.Time `json:"due_at"`
+	RemindAt    *time.Time `json:"remind_at"`
+	ParentID    *int       `json:"parent_id"`
+	OwnerID     *int       `json:"owner_id"`
+	ProjectID   *int       `json:"project_id"` // null の場合はインボックス
+	Tags        []TagResponse `json:"tags" binding:"required"`
+	// 繰り返し設定（RRULE）と曜日・時刻を計算するタイムゾーン
+	RecurrenceRule     *string `json:"recurrence_rule" example:"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"`
+	RecurrenceTimezone *string `json:"timezone" example:"Asia/Tokyo"`
+	// 完了時に自動作成された次回のTodoのID
+	NextOccurrenceID *int      `json:"next_occurrence_id"`
+	// 更新のたびに増えるバージョン（GET /api/v1/todos/{id} の ETag と同じ値）
+	Version   int       `json:"version" binding:"required"`
 	CreatedAt   time.Time `json:"created_at" binding:"required"`
 	UpdatedAt   time.Time `json:"updated_at" binding:"required"`
+	// ツリー表示（tree=true）の場合のみ子Todoを入れ子で返す
+	Children []TodoResponse `json:"children,omitempty"`
+}
+
+// TodoHighlightResponse は検索語を <mark> で囲んだ強調表示（HTMLエスケープ済み）
+type TodoHighlightResponse struct {
+	Title       string `json:"title" binding:"required" example:"<mark>牛乳</mark>を買う"`
+	Description string `json:"description" binding:"required" example:"帰りに<mark>牛乳</mark>を2本買う"`
+}
+
+type TodoSearchResultResponse struct {
+	TodoResponse
+	Rank      float64               `json:"rank" binding:"required"`
+	Highlight TodoHighlightResponse `json:"highlight" binding:"required"`
 }
 
 type TodoListResponse struct {
@@ -22,15 +49,65 @@ type TodoListResponse struct {
 
 // ToTodoResponse converts models.Todo to TodoResponse
 func ToTodoResponse(todo models.Todo) TodoResponse {
+	var children []TodoResponse
+	if len(todo.Children) > 0 {
+		children = ToTodoResponses(todo.Children)
+	}
+
 	return TodoResponse{
 		ID:          todo.ID,
 		Title:       todo.Title,
 		Description: todo.Description,
 		Completed:   todo.Completed,
 		Priority:    todo.Priority,
+		DueAt:       todo.DueAt,
+		RemindAt:    todo.RemindAt,
+		ParentID:    todo.ParentID,
+		OwnerID:     todo.OwnerID,
+		ProjectID:   todo.ProjectID,
+		Tags:        ToTagResponses(todo.Tags),
+		RecurrenceRule:     todo.RecurrenceRule,
+		RecurrenceTimezone: todo.RecurrenceTimezone,
+		NextOccurrenceID:   todo.NextOccurrenceID,
+		Version:            todo.Version,
 		CreatedAt:   todo.CreatedAt,
 		UpdatedAt:   todo.UpdatedAt,
+		Children:    children,
+	}
+}
+
+// ToTodoResponses converts []models.Todo to []TodoResponse
+func ToTodoResponses(todos []models.Todo) []TodoResponse {
+	todoResponses := make([]TodoResponse, len(todos))
+	for i, todo := range todos {
+		todoResponses[i] = ToTodoResponse(todo)
+	}
+	return todoResponses
+}
+
+// ToNextCursor converts the page cursor to the opaque string returned to clients
+func ToNextCursor(page *models.TodoPage) *string {
+	if page.NextCursor == nil {
+		return nil
+	}
+	cursor := page.NextCursor.Encode()
+	return &cursor
+}
+
+// ToTodoSearchResultResponses converts []models.TodoSearchResult to []TodoSearchResultResponse
+func ToTodoSearchResultResponses(results []models.TodoSearchResult) []TodoSearchResultResponse {
+	responses := make([]TodoSearchResultResponse, len(results))
+	for i, result := range results {
+		responses[i] = TodoSearchResultResponse{
+			TodoResponse: ToTodoResponse(result.Todo),
+			Rank:         result.Rank,
+			Highlight: TodoHighlightResponse{
+				Title:       result.TitleHighlight,
+				Description: result.DescriptionSnippet,
+			},
+		}
 	}
+	return responses
 }
 
 // ToTodoListResponse converts []models.Todo to TodoListResponse
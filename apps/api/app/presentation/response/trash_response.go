@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+type TrashedTodoResponse struct {
+	TodoResponse
+	DeletedAt time.Time `json:"deleted_at" binding:"required"`
+}
+
+// ToTrashedTodoResponses converts trashed []models.Todo to []TrashedTodoResponse
+func ToTrashedTodoResponses(todos []models.Todo) []TrashedTodoResponse {
+	responses := make([]TrashedTodoResponse, len(todos))
+	for i, todo := range todos {
+		responses[i] = TrashedTodoResponse{
+			TodoResponse: ToTodoResponse(todo),
+		}
+		if todo.DeletedAt != nil {
+			responses[i].DeletedAt = *todo.DeletedAt
+		}
+	}
+	return responses
+}
+
+// ToTrashNextCursor converts the trash page cursor to the opaque string returned to clients
+func ToTrashNextCursor(page *models.TrashPage) *string {
+	if page.NextCursor == nil {
+		return nil
+	}
+	cursor := page.NextCursor.Encode()
+	return &cursor
+}
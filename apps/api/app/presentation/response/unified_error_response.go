@@ -20,13 +20,17 @@ const (
 	ErrorCodeInvalidJSON    = "INVALID_JSON"
 	ErrorCodeInvalidRequest = "INVALID_REQUEST"
 	ErrorCodeInvalidID      = "INVALID_ID"
+	ErrorCodeTooLarge       = "REQUEST_TOO_LARGE"
 
 	// Usecase層のエラー
-	ErrorCodeNotFound      = "NOT_FOUND"
-	ErrorCodeAlreadyExists = "ALREADY_EXISTS"
-	ErrorCodeUnauthorized  = "UNAUTHORIZED"
-	ErrorCodeForbidden     = "FORBIDDEN"
-	ErrorCodeBusinessRule  = "BUSINESS_RULE_VIOLATION"
+	ErrorCodeNotFound           = "NOT_FOUND"
+	ErrorCodeAlreadyExists      = "ALREADY_EXISTS"
+	ErrorCodeUnauthorized       = "UNAUTHORIZED"
+	ErrorCodeForbidden          = "FORBIDDEN"
+	ErrorCodeBusinessRule       = "BUSINESS_RULE_VIOLATION"
+	ErrorCodeRateLimited        = "RATE_LIMITED"
+	ErrorCodePreconditionFailed = "PRECONDITION_FAILED"
+	ErrorCodeConflict           = "CONFLICT"
 
 	// Infrastructure層のエラー
 	ErrorCodeDatabaseError  = "DATABASE_ERROR"
@@ -43,6 +47,15 @@ func ValidationError(c *gin.Context, details []ValidationErrorDetail) {
 	})
 }
 
+// Presentation層エラー（内容を処理できないバリデーションエラー）
+func UnprocessableValidationError(c *gin.Context, details []ValidationErrorDetail) {
+	c.JSON(http.StatusUnprocessableEntity, UnifiedErrorResponse{
+		Message:   "入力内容に不備があります",
+		ErrorCode: ErrorCodeValidation,
+		Details:   details,
+	})
+}
+
 // Presentation層エラー（JSONエラー）
 func InvalidJSONError(c *gin.Context) {
 	c.JSON(http.StatusBadRequest, UnifiedErrorResponse{
@@ -61,6 +74,24 @@ func InvalidIDError(c *gin.Context, fieldName string) {
 	})
 }
 
+// Presentation層エラー（クエリパラメータ等の形式エラー）
+func InvalidRequestError(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, UnifiedErrorResponse{
+		Message:   message,
+		ErrorCode: ErrorCodeInvalidRequest,
+		Details:   []ValidationErrorDetail{},
+	})
+}
+
+// Presentation層エラー（本文が上限を超える）
+func RequestTooLargeError(c *gin.Context, message string) {
+	c.JSON(http.StatusRequestEntityTooLarge, UnifiedErrorResponse{
+		Message:   message,
+		ErrorCode: ErrorCodeTooLarge,
+		Details:   []ValidationErrorDetail{},
+	})
+}
+
 // Usecase層エラー（リソースが見つからない）
 func NotFoundError(c *gin.Context, resource string) {
 	c.JSON(http.StatusNotFound, UnifiedErrorResponse{
@@ -105,6 +136,33 @@ func BusinessRuleError(c *gin.Context, message string) {
 	})
 }
 
+// Usecase層エラー（If-Match の条件を満たさない）
+func PreconditionFailedError(c *gin.Context, message string) {
+	c.JSON(http.StatusPreconditionFailed, UnifiedErrorResponse{
+		Message:   message,
+		ErrorCode: ErrorCodePreconditionFailed,
+		Details:   []ValidationErrorDetail{},
+	})
+}
+
+// Usecase層エラー（同じリソースへの処理と競合した）
+func ConflictError(c *gin.Context, message string) {
+	c.JSON(http.StatusConflict, UnifiedErrorResponse{
+		Message:   message,
+		ErrorCode: ErrorCodeConflict,
+		Details:   []ValidationErrorDetail{},
+	})
+}
+
+// Presentation層エラー（レート制限）
+func RateLimitedError(c *gin.Context, message string) {
+	c.JSON(http.StatusTooManyRequests, UnifiedErrorResponse{
+		Message:   message,
+		ErrorCode: ErrorCodeRateLimited,
+		Details:   []ValidationErrorDetail{},
+	})
+}
+
 // Infrastructure層エラー（データベースエラー）
 func DatabaseError(c *gin.Context) {
 	c.JSON(http.StatusInternalServerError, UnifiedErrorResponse{
@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+type UserResponse struct {
+	ID        int       `json:"id" binding:"required"`
+	Name      string    `json:"name" binding:"required"`
+	Email     string    `json:"email" binding:"required"`
+	CreatedAt time.Time `json:"created_at" binding:"required"`
+	UpdatedAt time.Time `json:"updated_at" binding:"required"`
+}
+
+// ToUserResponse converts models.User to UserResponse
+func ToUserResponse(user models.User) UserResponse {
+	return UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// ToUserResponses converts []models.User to []UserResponse
+func ToUserResponses(users []models.User) []UserResponse {
+	userResponses := make([]UserResponse, len(users))
+	for i, user := range users {
+		userResponses[i] = ToUserResponse(user)
+	}
+	return userResponses
+}
@@ -0,0 +1,66 @@
+package response
+
+import (
+	"time"
+
+	"api/app/models"
+)
+
+type WorkspaceResponse struct {
+	ID   int    `json:"id" binding:"required"`
+	Name string `json:"name" binding:"required"`
+	// 取得したユーザーのロール
+	Role      models.WorkspaceRole `json:"role" binding:"required" example:"owner"`
+	CreatedAt time.Time            `json:"created_at" binding:"required"`
+	UpdatedAt time.Time            `json:"updated_at" binding:"required"`
+}
+
+type WorkspaceMemberResponse struct {
+	WorkspaceID int                  `json:"workspace_id" binding:"required"`
+	UserID      int                  `json:"user_id" binding:"required"`
+	Name        string               `json:"name" binding:"required"`
+	Email       string               `json:"email" binding:"required"`
+	Role        models.WorkspaceRole `json:"role" binding:"required" example:"member"`
+	CreatedAt   time.Time            `json:"created_at" binding:"required"`
+}
+
+// ToWorkspaceResponse converts models.Workspace to WorkspaceResponse
+func ToWorkspaceResponse(workspace models.Workspace) WorkspaceResponse {
+	return WorkspaceResponse{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Role:      workspace.Role,
+		CreatedAt: workspace.CreatedAt,
+		UpdatedAt: workspace.UpdatedAt,
+	}
+}
+
+// ToWorkspaceResponses converts []models.Workspace to []WorkspaceResponse
+func ToWorkspaceResponses(workspaces []models.Workspace) []WorkspaceResponse {
+	workspaceResponses := make([]WorkspaceResponse, len(workspaces))
+	for i, workspace := range workspaces {
+		workspaceResponses[i] = ToWorkspaceResponse(workspace)
+	}
+	return workspaceResponses
+}
+
+// ToWorkspaceMemberResponse converts models.WorkspaceMember to WorkspaceMemberResponse
+func ToWorkspaceMemberResponse(member models.WorkspaceMember) WorkspaceMemberResponse {
+	return WorkspaceMemberResponse{
+		WorkspaceID: member.WorkspaceID,
+		UserID:      member.UserID,
+		Name:        member.UserName,
+		Email:       member.UserEmail,
+		Role:        member.Role,
+		CreatedAt:   member.CreatedAt,
+	}
+}
+
+// ToWorkspaceMemberResponses converts []models.WorkspaceMember to []WorkspaceMemberResponse
+func ToWorkspaceMemberResponses(members []models.WorkspaceMember) []WorkspaceMemberResponse {
+	memberResponses := make([]WorkspaceMemberResponse, len(members))
+	for i, member := range members {
+		memberResponses[i] = ToWorkspaceMemberResponse(member)
+	}
+	return memberResponses
+}
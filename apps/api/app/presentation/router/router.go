@@ -3,6 +3,7 @@ package router
 import (
 	"api/app/container"
 	"api/app/middleware"
+	"api/app/models"
 	"api/config"
 	"api/db"
 	"os"
@@ -12,8 +13,12 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRouter(cfg *config.Config) *gin.Engine {
+func SetupRouter(cfg *config.Config) (*gin.Engine, error) {
 	r := gin.Default()
+	// gin は既定で全てのプロキシを信頼し、クライアントが送った X-Forwarded-For をIPアドレスとして使うため、設定したプロキシのみ信頼する
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, err
+	}
 
 	// CORS設定
 	r.Use(middleware.CORS(cfg))
@@ -25,7 +30,11 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 	// InitializeHandlersでハンドラーを一括初期化
 	var handlers *container.Handlers
 	if db.DB != nil {
-		handlers = container.InitializeHandlers(db.DB, cfg)
+		var err error
+		handlers, err = container.InitializeHandlers(db.DB, cfg)
+		if err != nil {
+			return nil, err
+		}
 	}
 	// ヘルスチェックエンドポイント
 	if handlers != nil && handlers.Health != nil {
@@ -34,26 +43,187 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 	// API v1 グループ
 	v1 := r.Group("/api/v1")
 	{
+		// 認証が不要なエンドポイント（IPアドレスごとにレート制限する）
+		public := v1.Group("")
+		if handlers != nil && handlers.RateLimit != nil {
+			public.Use(handlers.RateLimit)
+		}
 		if handlers != nil && handlers.Simple != nil {
-			v1.GET("/hello", handlers.Simple.Hello)
-			v1.GET("/users", handlers.Simple.GetUsers)
-			v1.POST("/users", handlers.Simple.CreateUser)
+			public.GET("/hello", handlers.Simple.Hello)
+		}
+
+		// Auth endpoints（ログイン前に呼び出すため認証は不要）
+		if handlers != nil && handlers.Login != nil {
+			authRoutes := public.Group("/auth")
+			{
+				// パスワードの総当たりと再設定メールの大量送信を防ぐため、IPアドレスごと・メールアドレスごとにも制限する
+				withLoginRateLimit := func(h gin.HandlerFunc) []gin.HandlerFunc {
+					if handlers.LoginRateLimit == nil {
+						return []gin.HandlerFunc{h}
+					}
+					return []gin.HandlerFunc{handlers.LoginRateLimit, h}
+				}
+				authRoutes.POST("/login", withLoginRateLimit(handlers.Login.Login)...)
+				authRoutes.POST("/refresh", handlers.Login.Refresh)
+				authRoutes.POST("/logout", handlers.Login.Logout)
+				authRoutes.POST("/password-reset", withLoginRateLimit(handlers.Login.RequestPasswordReset)...)
+				authRoutes.POST("/password-reset/confirm", handlers.Login.ConfirmPasswordReset)
+			}
+		}
+		if handlers != nil && handlers.OIDC != nil {
+			oidcRoutes := public.Group("/auth/oidc")
+			{
+				oidcRoutes.GET("/authorize", handlers.OIDC.Authorize)
+				oidcRoutes.POST("/callback", handlers.OIDC.Callback)
+			}
+		}
+
+		// 認証が必要なエンドポイント
+		authorized := v1.Group("")
+		// 不正な認証情報でのリクエストも制限するため、認証の前にIPアドレスごとにレート制限する
+		if handlers != nil && handlers.IPRateLimit != nil {
+			authorized.Use(handlers.IPRateLimit)
+		}
+		if handlers != nil && handlers.Auth != nil {
+			authorized.Use(handlers.Auth)
+		}
+		// 認証済みのユーザー・APIキーごとにレート制限する
+		if handlers != nil && handlers.RateLimit != nil {
+			authorized.Use(handlers.RateLimit)
+		}
+		// APIキーで呼び出せるのはTodo関連のエンドポイントのみ（スコープで参照・更新を制限）
+		todoScopes := middleware.RequireScopes(models.ScopeTodosRead, models.ScopeTodosWrite)
+		// Todo・プロジェクト・タグは X-Workspace-ID ヘッダーのワークスペース内のみ操作できる
+		workspaceScoped := authorized.Group("")
+		if handlers != nil && handlers.WorkspaceScope != nil {
+			workspaceScoped.Use(handlers.WorkspaceScope)
+		}
+
+		// ログイン中のユーザーに IdP のアカウントを紐付ける
+		if handlers != nil && handlers.OIDC != nil {
+			authorized.POST("/auth/oidc/link", middleware.RequireUser(), handlers.OIDC.Link)
+		}
+
+		// User CRUD endpoints
+		if handlers != nil && handlers.User != nil {
+			users := authorized.Group("/users", middleware.RequireUser())
+			{
+				users.GET("", handlers.User.GetUsers)
+				users.GET("/:id", handlers.User.GetUser)
+				users.POST("", handlers.User.CreateUser)
+				users.PUT("/:id", handlers.User.UpdateUser)
+				users.DELETE("/:id", handlers.User.DeleteUser)
+			}
 		}
 
 		// Todo CRUD endpoints
 		if handlers != nil && handlers.Todo != nil {
-			todos := v1.Group("/todos")
+			todos := workspaceScoped.Group("/todos", todoScopes)
 			{
 				todos.GET("", handlers.Todo.GetTodos)
+				todos.GET("/search", handlers.Todo.SearchTodos)
 				todos.GET("/:id", handlers.Todo.GetTodo)
-				todos.POST("", handlers.Todo.CreateTodo)
+				todos.GET("/:id/children", handlers.Todo.GetTodoChildren)
+				todos.GET("/:id/history", handlers.Todo.GetTodoHistory)
+				todos.GET("/:id/occurrences", handlers.Todo.GetTodoOccurrences)
+				// Idempotency-Key 付きの作成は再送されても1回だけ処理する
+				// レスポンスをそのままDBに保存するため、APIキーなどの秘密情報を返すルートには適用しない
+				todoCreate := todos.Group("")
+				if handlers.Idempotency != nil {
+					todoCreate.Use(handlers.Idempotency)
+				}
+				todoCreate.POST("", handlers.Todo.CreateTodo)
+				todoCreate.POST("/bulk", handlers.Todo.BulkTodos)
+				if handlers.TodoImport != nil {
+					todos.POST("/import", handlers.TodoImport.ImportTodos)
+					todos.GET("/import/jobs/:id", handlers.TodoImport.GetImportJob)
+				}
 				todos.PUT("/:id", handlers.Todo.UpdateTodo)
+				todos.PATCH("/:id", handlers.Todo.PatchTodo)
 				todos.DELETE("/:id", handlers.Todo.DeleteTodo)
+				if handlers.Trash != nil {
+					todos.POST("/:id/restore", handlers.Trash.RestoreTodo)
+				}
+			}
+		}
+
+		// Trash endpoints
+		if handlers != nil && handlers.Trash != nil {
+			trash := workspaceScoped.Group("/trash", todoScopes)
+			{
+				trash.GET("", handlers.Trash.GetTrash)
+				trash.DELETE("/:id", handlers.Trash.PurgeTodo)
+			}
+		}
+
+		// Tag CRUD endpoints
+		if handlers != nil && handlers.Tag != nil {
+			tags := workspaceScoped.Group("/tags", todoScopes)
+			{
+				tags.GET("", handlers.Tag.GetTags)
+				tags.GET("/:id", handlers.Tag.GetTag)
+				tags.POST("", handlers.Tag.CreateTag)
+				tags.PUT("/:id", handlers.Tag.UpdateTag)
+				tags.DELETE("/:id", handlers.Tag.DeleteTag)
+			}
+		}
+
+		// Project CRUD endpoints
+		if handlers != nil && handlers.Project != nil {
+			projects := workspaceScoped.Group("/projects", todoScopes)
+			{
+				projects.GET("", handlers.Project.GetProjects)
+				projects.GET("/:id", handlers.Project.GetProject)
+				projects.POST("", handlers.Project.CreateProject)
+				projects.PUT("/:id", handlers.Project.UpdateProject)
+				projects.DELETE("/:id", handlers.Project.DeleteProject)
+				if handlers.Todo != nil {
+					projects.GET("/:id/todos", handlers.Todo.GetProjectTodos)
+				}
+				if handlers.ProjectMember != nil {
+					projects.GET("/:id/members", handlers.ProjectMember.GetMembers)
+					projects.PUT("/:id/members/:user_id", handlers.ProjectMember.UpdateMember)
+					projects.DELETE("/:id/members/:user_id", handlers.ProjectMember.RemoveMember)
+					projects.GET("/:id/invitations", handlers.ProjectMember.GetProjectInvitations)
+					projects.POST("/:id/invitations", handlers.ProjectMember.CreateProjectInvitation)
+					projects.DELETE("/:id/invitations/:invitation_id", handlers.ProjectMember.RevokeProjectInvitation)
+				}
+			}
+		}
+
+		// Invitation endpoints（招待を受けたユーザー本人が回答する）
+		if handlers != nil && handlers.ProjectMember != nil {
+			invitations := workspaceScoped.Group("/invitations", middleware.RequireUser())
+			{
+				invitations.GET("", handlers.ProjectMember.GetMyInvitations)
+				invitations.POST("/:id/accept", handlers.ProjectMember.AcceptInvitation)
+				invitations.POST("/:id/decline", handlers.ProjectMember.DeclineInvitation)
+			}
+		}
+
+		// Workspace endpoints
+		if handlers != nil && handlers.Workspace != nil {
+			workspaces := authorized.Group("/workspaces", middleware.RequireUser())
+			{
+				workspaces.GET("", handlers.Workspace.GetWorkspaces)
+				workspaces.POST("", handlers.Workspace.CreateWorkspace)
+				workspaces.GET("/:id/members", handlers.Workspace.GetWorkspaceMembers)
+				workspaces.POST("/:id/members", handlers.Workspace.AddWorkspaceMember)
+			}
+		}
+
+		// API key endpoints（APIキー自身では管理できない）
+		if handlers != nil && handlers.APIKey != nil {
+			apiKeys := authorized.Group("/api-keys", middleware.RequireUser())
+			{
+				apiKeys.GET("", handlers.APIKey.GetAPIKeys)
+				apiKeys.POST("", handlers.APIKey.CreateAPIKey)
+				apiKeys.DELETE("/:id", handlers.APIKey.RevokeAPIKey)
 			}
 		}
 	}
 
-	return r
+	return r, nil
 }
 
 func StartServer(r *gin.Engine) error {
@@ -0,0 +1,61 @@
+package router_test
+
+import (
+	"api/app/middleware"
+	"api/app/presentation/router"
+	"api/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupRouter_TrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// newRouter は SetupRouter のルーターに、IPアドレスごとに1回まで呼び出せるルートを追加する
+	newRouter := func(t *testing.T, trustedProxies []string) *gin.Engine {
+		r, err := router.SetupRouter(&config.Config{Environment: "test", TrustedProxies: trustedProxies})
+		require.NoError(t, err)
+		limit := config.RateLimit{Requests: 1, Period: time.Minute}
+		r.GET("/limited", middleware.IPRateLimit(middleware.NewMemoryRateLimitStore(), limit), func(c *gin.Context) {
+			c.String(http.StatusOK, c.ClientIP())
+		})
+		return r
+	}
+	send := func(r *gin.Engine, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("X-Forwarded-For is ignored by default", func(t *testing.T) {
+		r := newRouter(t, nil)
+
+		first := send(r, "192.0.2.1:12345", "198.51.100.1")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "192.0.2.1", first.Body.String())
+		// ヘッダーを変えても同じ接続元は同じバケットで数える
+		assert.Equal(t, http.StatusTooManyRequests, send(r, "192.0.2.1:12345", "198.51.100.2").Code)
+	})
+
+	t.Run("X-Forwarded-For from a trusted proxy is used", func(t *testing.T) {
+		r := newRouter(t, []string{"10.0.0.0/8"})
+
+		first := send(r, "10.0.0.1:12345", "198.51.100.1")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "198.51.100.1", first.Body.String())
+		assert.Equal(t, http.StatusOK, send(r, "10.0.0.1:12345", "198.51.100.2").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(r, "10.0.0.2:12345", "198.51.100.1").Code)
+
+		// 信頼していない接続元からのヘッダーは使わない
+		assert.Equal(t, "192.0.2.1", send(r, "192.0.2.1:12345", "198.51.100.3").Body.String())
+	})
+}
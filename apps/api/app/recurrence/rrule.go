@@ -0,0 +1,547 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// コンテナにタイムゾーンデータベースが無い環境でも LoadLocation できるよう埋め込む
+	_ "time/tzdata"
+)
+
+// ErrInvalidRule は RRULE の形式が正しくない、または未対応の指定を含む場合のエラー
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// ErrInvalidTimezone は IANA タイムゾーン名として解釈できない場合のエラー
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxSearchYears は条件に合う日が見つからない場合に探索を打ち切る年数（2月30日など）
+const maxSearchYears = 100
+
+// WeekdayNum は BYDAY の1要素
+// N が 0 以外の場合は月（年）の第N週の曜日を表し、負の場合は末尾から数える（-1FR は最終金曜日）
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// Rule は RFC 5545 の RRULE のうち、Todoの繰り返しに必要な部分を表す
+// 対応: FREQ(DAILY/WEEKLY/MONTHLY/YEARLY), INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH, BYSETPOS, WKST
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	WeekStart  time.Weekday
+
+	// until は UNTIL の値（floating の場合は繰り返しのタイムゾーンの日時として扱う）
+	until         *time.Time
+	untilFloating bool
+	untilRaw      string
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// LoadLocation はタイムゾーン名を解決する（空文字の場合は UTC）
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || strings.EqualFold(name, "Local") {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// Parse は "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR" のような RRULE を解釈する（先頭の "RRULE:" は省略可）
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.ToUpper(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate %s", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			err = rule.parseFreq(value)
+		case "INTERVAL":
+			rule.Interval, err = parseInt(value, 1, 1000)
+		case "COUNT":
+			rule.Count, err = parseInt(value, 1, 1000)
+		case "UNTIL":
+			err = rule.parseUntil(value)
+		case "BYDAY":
+			err = rule.parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(value, 1, 31, true)
+		case "BYMONTH":
+			var months []int
+			months, err = parseIntList(value, 1, 12, false)
+			for _, m := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(m))
+			}
+		case "BYSETPOS":
+			rule.BySetPos, err = parseIntList(value, 1, 366, true)
+		case "WKST":
+			day, ok := weekdayCodes[value]
+			if !ok {
+				err = fmt.Errorf("invalid WKST %q", value)
+			}
+			rule.WeekStart = day
+		default:
+			err = fmt.Errorf("unsupported part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+	return rule, nil
+}
+
+func (r *Rule) parseFreq(value string) error {
+	switch f := Frequency(value); f {
+	case Daily, Weekly, Monthly, Yearly:
+		r.Freq = f
+		return nil
+	}
+	return fmt.Errorf("unsupported FREQ %q", value)
+}
+
+func (r *Rule) parseUntil(value string) error {
+	r.untilRaw = value
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		// 日付のみの場合はその日の終わりまでを含める
+		if layout == "20060102" {
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		r.until = &t
+		r.untilFloating = !strings.HasSuffix(layout, "Z")
+		return nil
+	}
+	return fmt.Errorf("invalid UNTIL %q", value)
+}
+
+func (r *Rule) parseByDay(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return fmt.Errorf("invalid BYDAY %q", item)
+		}
+		day, ok := weekdayCodes[item[len(item)-2:]]
+		if !ok {
+			return fmt.Errorf("invalid BYDAY %q", item)
+		}
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			if n, err = strconv.Atoi(prefix); err != nil || n == 0 || n < -53 || n > 53 {
+				return fmt.Errorf("invalid BYDAY %q", item)
+			}
+		}
+		r.ByDay = append(r.ByDay, WeekdayNum{N: n, Weekday: day})
+	}
+	return nil
+}
+
+func (r *Rule) validate() error {
+	if r.Freq == "" {
+		return errors.New("FREQ is required")
+	}
+	if r.Count > 0 && r.until != nil {
+		return errors.New("COUNT and UNTIL cannot be used together")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	for _, wd := range r.ByDay {
+		if wd.N == 0 {
+			continue
+		}
+		// 第N週の指定は月単位・年単位の繰り返しでのみ有効
+		if r.Freq != Monthly && r.Freq != Yearly {
+			return errors.New("BYDAY with a position requires FREQ=MONTHLY or FREQ=YEARLY")
+		}
+		if r.Freq == Monthly && (wd.N > 5 || wd.N < -5) {
+			return errors.New("BYDAY position must be between -5 and 5 for FREQ=MONTHLY")
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return errors.New("BYSETPOS requires another BYxxx part")
+	}
+	return nil
+}
+
+// String は RRULE を正規化した文字列で返す（保存用）
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.untilRaw != "" {
+		parts = append(parts, "UNTIL="+r.untilRaw)
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			days[i] = weekdayNames[wd.Weekday]
+			if wd.N != 0 {
+				days[i] = strconv.Itoa(wd.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next は dtstart から始まる繰り返しのうち、after より後の最初の日時を返す
+// 繰り返しが終了している場合は false を返す
+func (r *Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	occurrences := r.Occurrences(dtstart, after, 1)
+	if len(occurrences) == 0 {
+		return time.Time{}, false
+	}
+	return occurrences[0], true
+}
+
+// Occurrences は dtstart から始まる繰り返しのうち、after より後の日時を最大 limit 件返す
+// 曜日・日付・時刻は dtstart のタイムゾーンで計算するため、夏時間の切り替えをまたいでも現地時刻が保たれる
+func (r *Rule) Occurrences(dtstart, after time.Time, limit int) []time.Time {
+	if limit <= 0 {
+		return nil
+	}
+
+	loc := dtstart.Location()
+	until := r.untilIn(loc)
+	lastYear := dtstart.Year() + maxSearchYears
+	if after.In(loc).Year() >= dtstart.Year() {
+		lastYear = after.In(loc).Year() + maxSearchYears
+	}
+
+	var result []time.Time
+	count := 0
+	for period := 0; ; period++ {
+		start, candidates := r.expand(dtstart, period)
+		if start.Year() > lastYear {
+			return result
+		}
+		for _, t := range candidates {
+			if t.Before(dtstart) {
+				continue
+			}
+			if until != nil && t.After(*until) {
+				return result
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return result
+			}
+			if t.After(after) {
+				result = append(result, t)
+				if len(result) >= limit {
+					return result
+				}
+			}
+		}
+	}
+}
+
+func (r *Rule) untilIn(loc *time.Location) *time.Time {
+	if r.until == nil {
+		return nil
+	}
+	if !r.untilFloating {
+		return r.until
+	}
+	u := r.until
+	t := time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc)
+	return &t
+}
+
+// expand は period 番目の期間（日・週・月・年）の開始日と、期間内で条件に合う日時を昇順で返す
+func (r *Rule) expand(dtstart time.Time, period int) (time.Time, []time.Time) {
+	loc := dtstart.Location()
+	year, month, day := dtstart.Date()
+	n := period * r.Interval
+
+	var start time.Time
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		start = time.Date(year, month, day+n, 0, 0, 0, 0, loc)
+		if r.matchesMonth(start.Month()) && r.matchesMonthDay(start) && r.matchesWeekday(start) {
+			days = []time.Time{start}
+		}
+	case Weekly:
+		offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		start = time.Date(year, month, day-offset+n*7, 0, 0, 0, 0, loc)
+		for i := 0; i < 7; i++ {
+			d := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, loc)
+			if len(r.ByDay) > 0 {
+				if !r.matchesWeekday(d) {
+					continue
+				}
+			} else if d.Weekday() != dtstart.Weekday() {
+				continue
+			}
+			if r.matchesMonth(d.Month()) {
+				days = append(days, d)
+			}
+		}
+	case Monthly:
+		start = time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, loc)
+		if r.matchesMonth(start.Month()) {
+			days = r.daysInMonth(start, day)
+		}
+	case Yearly:
+		start = time.Date(year+n, time.January, 1, 0, 0, 0, 0, loc)
+		days = r.daysInYear(start, month, day)
+	}
+
+	days = r.applySetPos(days)
+
+	hour, min, sec := dtstart.Clock()
+	occurrences := make([]time.Time, len(days))
+	for i, d := range days {
+		occurrences[i] = time.Date(d.Year(), d.Month(), d.Day(), hour, min, sec, 0, loc)
+	}
+	return start, occurrences
+}
+
+// daysInMonth は月内で BYMONTHDAY・BYDAY に合う日を返す（どちらも無い場合は dtstart と同じ日）
+func (r *Rule) daysInMonth(first time.Time, defaultDay int) []time.Time {
+	last := daysIn(first)
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		// 31日始まりの場合、31日が無い月はスキップする（RFC 5545 の定義どおり）
+		if defaultDay > last {
+			return nil
+		}
+		return []time.Time{first.AddDate(0, 0, defaultDay-1)}
+	}
+
+	var days []time.Time
+	for d := 1; d <= last; d++ {
+		date := first.AddDate(0, 0, d-1)
+		if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(date) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !matchesWeekdayNum(r.ByDay, date, d, last) {
+			continue
+		}
+		days = append(days, date)
+	}
+	return days
+}
+
+// daysInYear は年内で条件に合う日を返す
+// BYMONTH が無く BYDAY のみの場合は、第N週を年単位で数える
+func (r *Rule) daysInYear(first time.Time, defaultMonth time.Month, defaultDay int) []time.Time {
+	if len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) > 0 {
+		last := first.AddDate(1, 0, -1).YearDay()
+		var days []time.Time
+		for d := 1; d <= last; d++ {
+			date := first.AddDate(0, 0, d-1)
+			if matchesWeekdayNum(r.ByDay, date, d, last) {
+				days = append(days, date)
+			}
+		}
+		return days
+	}
+
+	months := r.ByMonth
+	if len(months) == 0 {
+		months = []time.Month{defaultMonth}
+	}
+	sorted := append([]time.Month(nil), months...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var days []time.Time
+	for _, m := range sorted {
+		days = append(days, r.daysInMonth(time.Date(first.Year(), m, 1, 0, 0, 0, 0, first.Location()), defaultDay)...)
+	}
+	return days
+}
+
+// applySetPos は BYSETPOS で期間内の n 番目（負の場合は末尾から）の日のみに絞り込む
+func (r *Rule) applySetPos(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 || len(days) == 0 {
+		return days
+	}
+	selected := map[int]bool{}
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			selected[i] = true
+		}
+	}
+	var result []time.Time
+	for i, d := range days {
+		if selected[i] {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func (r *Rule) matchesMonth(m time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, month := range r.ByMonth {
+		if month == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := daysIn(date)
+	for _, md := range r.ByMonthDay {
+		if md == date.Day() || (md < 0 && last+md+1 == date.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesWeekday(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekdayNum は期間（月または年）の index 日目が BYDAY のいずれかに合うかを判定する
+func matchesWeekdayNum(byDay []WeekdayNum, date time.Time, index, last int) bool {
+	for _, wd := range byDay {
+		if wd.Weekday != date.Weekday() {
+			continue
+		}
+		switch {
+		case wd.N == 0:
+			return true
+		case wd.N > 0 && (index-1)/7+1 == wd.N:
+			return true
+		case wd.N < 0 && (last-index)/7+1 == -wd.N:
+			return true
+		}
+	}
+	return false
+}
+
+// daysIn は date を含む月の日数を返す
+func daysIn(date time.Time) int {
+	return time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func parseInt(value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	return n, nil
+}
+
+// parseIntList はカンマ区切りの数値を解釈する（allowNegative の場合は -max から -min も許可する）
+func parseIntList(value string, min, max int, allowNegative bool) ([]int, error) {
+	var result []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimPrefix(item, "+"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", item)
+		}
+		abs := n
+		if allowNegative && n < 0 {
+			abs = -n
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("number out of range %q", item)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
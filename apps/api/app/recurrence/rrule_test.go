@@ -0,0 +1,189 @@
+package recurrence_test
+
+import (
+	"api/app/recurrence"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// format は日時を比較しやすい文字列（dtstart のタイムゾーンの現地時刻）にする
+func format(times []time.Time) []string {
+	formatted := make([]string, len(times))
+	for i, t := range times {
+		formatted[i] = t.Format("2006-01-02 15:04 MST")
+	}
+	return formatted
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "Normalized", rule: "rrule:freq=weekly;byday=MO,WE;interval=2", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{name: "Positioned weekdays", rule: "FREQ=MONTHLY;BYDAY=1MO,-1FR", want: "FREQ=MONTHLY;BYDAY=1MO,-1FR"},
+		{name: "UNTIL is kept as written", rule: "FREQ=DAILY;UNTIL=20250103T090000Z", want: "FREQ=DAILY;UNTIL=20250103T090000Z"},
+		{name: "Empty", rule: "", wantErr: true},
+		{name: "Missing FREQ", rule: "BYDAY=MO", wantErr: true},
+		{name: "Unsupported FREQ", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "Unsupported part", rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+		{name: "Duplicate part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{name: "COUNT with UNTIL", rule: "FREQ=DAILY;COUNT=3;UNTIL=20250103", wantErr: true},
+		{name: "Positioned weekday with FREQ=WEEKLY", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "BYMONTHDAY out of range", rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{name: "BYSETPOS alone", rule: "FREQ=MONTHLY;BYSETPOS=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := recurrence.Parse(tt.rule)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, recurrence.ErrInvalidRule)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestRule_Occurrences(t *testing.T) {
+	newYork, err := recurrence.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   int
+		want    []string
+	}{
+		{
+			name:    "First Monday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=1MO",
+			dtstart: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    []string{"2025-01-06 09:00 UTC", "2025-02-03 09:00 UTC", "2025-03-03 09:00 UTC"},
+		},
+		{
+			name:    "Last Friday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   4,
+			want:    []string{"2025-01-31 09:00 UTC", "2025-02-28 09:00 UTC", "2025-03-28 09:00 UTC", "2025-04-25 09:00 UTC"},
+		},
+		{
+			name:    "Last weekday of the month with BYSETPOS",
+			rule:    "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart: time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   5,
+			want:    []string{"2025-01-31 09:00 UTC", "2025-02-28 09:00 UTC", "2025-03-31 09:00 UTC", "2025-04-30 09:00 UTC", "2025-05-30 09:00 UTC"},
+		},
+		{
+			name:    "BYMONTHDAY=31 skips short months",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31",
+			dtstart: time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   5,
+			want:    []string{"2025-01-31 09:00 UTC", "2025-03-31 09:00 UTC", "2025-05-31 09:00 UTC", "2025-07-31 09:00 UTC", "2025-08-31 09:00 UTC"},
+		},
+		{
+			name:    "Monthly from the 31st without BYMONTHDAY skips short months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    []string{"2025-01-31 09:00 UTC", "2025-03-31 09:00 UTC", "2025-05-31 09:00 UTC"},
+		},
+		{
+			name:    "UNTIL is inclusive",
+			rule:    "FREQ=DAILY;UNTIL=20250103T090000Z",
+			dtstart: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   10,
+			want:    []string{"2025-01-01 09:00 UTC", "2025-01-02 09:00 UTC", "2025-01-03 09:00 UTC"},
+		},
+		{
+			name:    "Date-only UNTIL includes the whole day",
+			rule:    "FREQ=DAILY;UNTIL=20250102",
+			dtstart: time.Date(2025, 1, 1, 23, 0, 0, 0, time.UTC),
+			limit:   10,
+			want:    []string{"2025-01-01 23:00 UTC", "2025-01-02 23:00 UTC"},
+		},
+		{
+			name:    "COUNT limits the occurrences",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=3",
+			dtstart: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+			limit:   10,
+			want:    []string{"2025-01-06 09:00 UTC", "2025-01-10 09:00 UTC", "2025-01-13 09:00 UTC"},
+		},
+		{
+			// 3月9日に夏時間が始まっても現地時刻の9時を保つ
+			name:    "Local time is kept across the start of DST",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2025, 3, 8, 9, 0, 0, 0, newYork),
+			limit:   3,
+			want:    []string{"2025-03-08 09:00 EST", "2025-03-09 09:00 EDT", "2025-03-10 09:00 EDT"},
+		},
+		{
+			// 11月2日に夏時間が終わっても現地時刻の9時を保つ
+			name:    "Local time is kept across the end of DST",
+			rule:    "FREQ=WEEKLY",
+			dtstart: time.Date(2025, 10, 26, 9, 0, 0, 0, newYork),
+			limit:   2,
+			want:    []string{"2025-10-26 09:00 EDT", "2025-11-02 09:00 EST"},
+		},
+		{
+			// floating の UNTIL は繰り返しのタイムゾーンの現地時刻として扱う
+			name:    "Floating UNTIL in the rule's time zone",
+			rule:    "FREQ=DAILY;UNTIL=20250309T090000",
+			dtstart: time.Date(2025, 3, 8, 9, 0, 0, 0, newYork),
+			limit:   10,
+			want:    []string{"2025-03-08 09:00 EST", "2025-03-09 09:00 EDT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := recurrence.Parse(tt.rule)
+			require.NoError(t, err)
+
+			got := rule.Occurrences(tt.dtstart, tt.dtstart.Add(-time.Second), tt.limit)
+			assert.Equal(t, tt.want, format(got))
+		})
+	}
+
+	t.Run("DST shifts the UTC offset", func(t *testing.T) {
+		rule, err := recurrence.Parse("FREQ=DAILY")
+		require.NoError(t, err)
+		dtstart := time.Date(2025, 3, 8, 9, 0, 0, 0, newYork)
+
+		next, ok := rule.Next(dtstart, dtstart)
+		require.True(t, ok)
+		// 夏時間の開始日は23時間しかない
+		assert.Equal(t, 23*time.Hour, next.Sub(dtstart))
+		assert.Equal(t, 13, next.UTC().Hour())
+	})
+}
+
+func TestRule_Next(t *testing.T) {
+	rule, err := recurrence.Parse("FREQ=DAILY;COUNT=3")
+	require.NoError(t, err)
+	dtstart := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// COUNT は after より前の回も含めて dtstart から数える
+	next, ok := rule.Next(dtstart, dtstart.Add(24*time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC), next)
+
+	_, ok = rule.Next(dtstart, next)
+	assert.False(t, ok)
+
+	// 条件に合う日が無い場合は打ち切る
+	never, err := recurrence.Parse("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30")
+	require.NoError(t, err)
+	_, ok = never.Next(dtstart, dtstart)
+	assert.False(t, ok)
+}
@@ -0,0 +1,426 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+
+	time "time"
+)
+
+// MockAPIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+type MockAPIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepository_Expecter {
+	return &MockAPIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: key
+func (_m *MockAPIKeyRepository) Create(key *models.APIKey) (*models.APIKey, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.APIKey) (*models.APIKey, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(*models.APIKey) *models.APIKey); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.APIKey) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAPIKeyRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - key *models.APIKey
+func (_e *MockAPIKeyRepository_Expecter) Create(key interface{}) *MockAPIKeyRepository_Create_Call {
+	return &MockAPIKeyRepository_Create_Call{Call: _e.mock.On("Create", key)}
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) Run(run func(key *models.APIKey)) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.APIKey))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) Return(_a0 *models.APIKey, _a1 error) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) RunAndReturn(run func(*models.APIKey) (*models.APIKey, error)) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockAPIKeyRepository) GetByID(id int) (*models.APIKey, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*models.APIKey, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *models.APIKey); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockAPIKeyRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id int
+func (_e *MockAPIKeyRepository_Expecter) GetByID(id interface{}) *MockAPIKeyRepository_GetByID_Call {
+	return &MockAPIKeyRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockAPIKeyRepository_GetByID_Call) Run(run func(id int)) *MockAPIKeyRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_GetByID_Call) Return(_a0 *models.APIKey, _a1 error) *MockAPIKeyRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_GetByID_Call) RunAndReturn(run func(int) (*models.APIKey, error)) *MockAPIKeyRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByPrefix provides a mock function with given fields: prefix
+func (_m *MockAPIKeyRepository) GetByPrefix(prefix string) (*models.APIKey, error) {
+	ret := _m.Called(prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByPrefix")
+	}
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.APIKey, error)); ok {
+		return rf(prefix)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.APIKey); ok {
+		r0 = rf(prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_GetByPrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByPrefix'
+type MockAPIKeyRepository_GetByPrefix_Call struct {
+	*mock.Call
+}
+
+// GetByPrefix is a helper method to define mock.On call
+//   - prefix string
+func (_e *MockAPIKeyRepository_Expecter) GetByPrefix(prefix interface{}) *MockAPIKeyRepository_GetByPrefix_Call {
+	return &MockAPIKeyRepository_GetByPrefix_Call{Call: _e.mock.On("GetByPrefix", prefix)}
+}
+
+func (_c *MockAPIKeyRepository_GetByPrefix_Call) Run(run func(prefix string)) *MockAPIKeyRepository_GetByPrefix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_GetByPrefix_Call) Return(_a0 *models.APIKey, _a1 error) *MockAPIKeyRepository_GetByPrefix_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_GetByPrefix_Call) RunAndReturn(run func(string) (*models.APIKey, error)) *MockAPIKeyRepository_GetByPrefix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: userID
+func (_m *MockAPIKeyRepository) GetByUserID(userID int) ([]models.APIKey, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.APIKey, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.APIKey); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockAPIKeyRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - userID int
+func (_e *MockAPIKeyRepository_Expecter) GetByUserID(userID interface{}) *MockAPIKeyRepository_GetByUserID_Call {
+	return &MockAPIKeyRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", userID)}
+}
+
+func (_c *MockAPIKeyRepository_GetByUserID_Call) Run(run func(userID int)) *MockAPIKeyRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_GetByUserID_Call) Return(_a0 []models.APIKey, _a1 error) *MockAPIKeyRepository_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_GetByUserID_Call) RunAndReturn(run func(int) ([]models.APIKey, error)) *MockAPIKeyRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: id, now
+func (_m *MockAPIKeyRepository) Revoke(id int, now time.Time) (*models.APIKey, error) {
+	ret := _m.Called(id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) (*models.APIKey, error)); ok {
+		return rf(id, now)
+	}
+	if rf, ok := ret.Get(0).(func(int, time.Time) *models.APIKey); ok {
+		r0 = rf(id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, time.Time) error); ok {
+		r1 = rf(id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockAPIKeyRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - id int
+//   - now time.Time
+func (_e *MockAPIKeyRepository_Expecter) Revoke(id interface{}, now interface{}) *MockAPIKeyRepository_Revoke_Call {
+	return &MockAPIKeyRepository_Revoke_Call{Call: _e.mock.On("Revoke", id, now)}
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) Run(run func(id int, now time.Time)) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) Return(_a0 *models.APIKey, _a1 error) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) RunAndReturn(run func(int, time.Time) (*models.APIKey, error)) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchLastUsed provides a mock function with given fields: id, now
+func (_m *MockAPIKeyRepository) TouchLastUsed(id int, now time.Time) error {
+	ret := _m.Called(id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) error); ok {
+		r0 = rf(id, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyRepository_TouchLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchLastUsed'
+type MockAPIKeyRepository_TouchLastUsed_Call struct {
+	*mock.Call
+}
+
+// TouchLastUsed is a helper method to define mock.On call
+//   - id int
+//   - now time.Time
+func (_e *MockAPIKeyRepository_Expecter) TouchLastUsed(id interface{}, now interface{}) *MockAPIKeyRepository_TouchLastUsed_Call {
+	return &MockAPIKeyRepository_TouchLastUsed_Call{Call: _e.mock.On("TouchLastUsed", id, now)}
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) Run(run func(id int, now time.Time)) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) Return(_a0 error) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) RunAndReturn(run func(int, time.Time) error) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockAPIKeyRepository) WithTx(tx repository.DBTX) repository.APIKeyRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.APIKeyRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.APIKeyRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.APIKeyRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockAPIKeyRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockAPIKeyRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockAPIKeyRepository_Expecter) WithTx(tx interface{}) *MockAPIKeyRepository_WithTx_Call {
+	return &MockAPIKeyRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockAPIKeyRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockAPIKeyRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_WithTx_Call) Return(_a0 repository.APIKeyRepository) *MockAPIKeyRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.APIKeyRepository) *MockAPIKeyRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIKeyRepository creates a new instance of MockAPIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
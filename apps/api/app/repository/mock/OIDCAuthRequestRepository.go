@@ -0,0 +1,198 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockOIDCAuthRequestRepository is an autogenerated mock type for the OIDCAuthRequestRepository type
+type MockOIDCAuthRequestRepository struct {
+	mock.Mock
+}
+
+type MockOIDCAuthRequestRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOIDCAuthRequestRepository) EXPECT() *MockOIDCAuthRequestRepository_Expecter {
+	return &MockOIDCAuthRequestRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: stateHash
+func (_m *MockOIDCAuthRequestRepository) Consume(stateHash string) (*models.OIDCAuthRequest, error) {
+	ret := _m.Called(stateHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 *models.OIDCAuthRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.OIDCAuthRequest, error)); ok {
+		return rf(stateHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.OIDCAuthRequest); ok {
+		r0 = rf(stateHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OIDCAuthRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(stateHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOIDCAuthRequestRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type MockOIDCAuthRequestRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - stateHash string
+func (_e *MockOIDCAuthRequestRepository_Expecter) Consume(stateHash interface{}) *MockOIDCAuthRequestRepository_Consume_Call {
+	return &MockOIDCAuthRequestRepository_Consume_Call{Call: _e.mock.On("Consume", stateHash)}
+}
+
+func (_c *MockOIDCAuthRequestRepository_Consume_Call) Run(run func(stateHash string)) *MockOIDCAuthRequestRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockOIDCAuthRequestRepository_Consume_Call) Return(_a0 *models.OIDCAuthRequest, _a1 error) *MockOIDCAuthRequestRepository_Consume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOIDCAuthRequestRepository_Consume_Call) RunAndReturn(run func(string) (*models.OIDCAuthRequest, error)) *MockOIDCAuthRequestRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: request
+func (_m *MockOIDCAuthRequestRepository) Create(request *models.OIDCAuthRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.OIDCAuthRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOIDCAuthRequestRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockOIDCAuthRequestRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - request *models.OIDCAuthRequest
+func (_e *MockOIDCAuthRequestRepository_Expecter) Create(request interface{}) *MockOIDCAuthRequestRepository_Create_Call {
+	return &MockOIDCAuthRequestRepository_Create_Call{Call: _e.mock.On("Create", request)}
+}
+
+func (_c *MockOIDCAuthRequestRepository_Create_Call) Run(run func(request *models.OIDCAuthRequest)) *MockOIDCAuthRequestRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.OIDCAuthRequest))
+	})
+	return _c
+}
+
+func (_c *MockOIDCAuthRequestRepository_Create_Call) Return(_a0 error) *MockOIDCAuthRequestRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOIDCAuthRequestRepository_Create_Call) RunAndReturn(run func(*models.OIDCAuthRequest) error) *MockOIDCAuthRequestRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpired provides a mock function with given fields: before
+func (_m *MockOIDCAuthRequestRepository) DeleteExpired(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOIDCAuthRequestRepository_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type MockOIDCAuthRequestRepository_DeleteExpired_Call struct {
+	*mock.Call
+}
+
+// DeleteExpired is a helper method to define mock.On call
+//   - before time.Time
+func (_e *MockOIDCAuthRequestRepository_Expecter) DeleteExpired(before interface{}) *MockOIDCAuthRequestRepository_DeleteExpired_Call {
+	return &MockOIDCAuthRequestRepository_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", before)}
+}
+
+func (_c *MockOIDCAuthRequestRepository_DeleteExpired_Call) Run(run func(before time.Time)) *MockOIDCAuthRequestRepository_DeleteExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockOIDCAuthRequestRepository_DeleteExpired_Call) Return(_a0 int64, _a1 error) *MockOIDCAuthRequestRepository_DeleteExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOIDCAuthRequestRepository_DeleteExpired_Call) RunAndReturn(run func(time.Time) (int64, error)) *MockOIDCAuthRequestRepository_DeleteExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOIDCAuthRequestRepository creates a new instance of MockOIDCAuthRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOIDCAuthRequestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOIDCAuthRequestRepository {
+	mock := &MockOIDCAuthRequestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
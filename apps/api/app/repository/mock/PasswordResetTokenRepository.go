@@ -0,0 +1,251 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+
+	time "time"
+)
+
+// MockPasswordResetTokenRepository is an autogenerated mock type for the PasswordResetTokenRepository type
+type MockPasswordResetTokenRepository struct {
+	mock.Mock
+}
+
+type MockPasswordResetTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPasswordResetTokenRepository) EXPECT() *MockPasswordResetTokenRepository_Expecter {
+	return &MockPasswordResetTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: token
+func (_m *MockPasswordResetTokenRepository) Create(token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.PasswordResetToken) (*models.PasswordResetToken, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(*models.PasswordResetToken) *models.PasswordResetToken); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasswordResetToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.PasswordResetToken) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPasswordResetTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockPasswordResetTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - token *models.PasswordResetToken
+func (_e *MockPasswordResetTokenRepository_Expecter) Create(token interface{}) *MockPasswordResetTokenRepository_Create_Call {
+	return &MockPasswordResetTokenRepository_Create_Call{Call: _e.mock.On("Create", token)}
+}
+
+func (_c *MockPasswordResetTokenRepository_Create_Call) Run(run func(token *models.PasswordResetToken)) *MockPasswordResetTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.PasswordResetToken))
+	})
+	return _c
+}
+
+func (_c *MockPasswordResetTokenRepository_Create_Call) Return(_a0 *models.PasswordResetToken, _a1 error) *MockPasswordResetTokenRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPasswordResetTokenRepository_Create_Call) RunAndReturn(run func(*models.PasswordResetToken) (*models.PasswordResetToken, error)) *MockPasswordResetTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByHashForUpdate provides a mock function with given fields: tokenHash
+func (_m *MockPasswordResetTokenRepository) GetByHashForUpdate(tokenHash string) (*models.PasswordResetToken, error) {
+	ret := _m.Called(tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByHashForUpdate")
+	}
+
+	var r0 *models.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.PasswordResetToken, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.PasswordResetToken); ok {
+		r0 = rf(tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasswordResetToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPasswordResetTokenRepository_GetByHashForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByHashForUpdate'
+type MockPasswordResetTokenRepository_GetByHashForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByHashForUpdate is a helper method to define mock.On call
+//   - tokenHash string
+func (_e *MockPasswordResetTokenRepository_Expecter) GetByHashForUpdate(tokenHash interface{}) *MockPasswordResetTokenRepository_GetByHashForUpdate_Call {
+	return &MockPasswordResetTokenRepository_GetByHashForUpdate_Call{Call: _e.mock.On("GetByHashForUpdate", tokenHash)}
+}
+
+func (_c *MockPasswordResetTokenRepository_GetByHashForUpdate_Call) Run(run func(tokenHash string)) *MockPasswordResetTokenRepository_GetByHashForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordResetTokenRepository_GetByHashForUpdate_Call) Return(_a0 *models.PasswordResetToken, _a1 error) *MockPasswordResetTokenRepository_GetByHashForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPasswordResetTokenRepository_GetByHashForUpdate_Call) RunAndReturn(run func(string) (*models.PasswordResetToken, error)) *MockPasswordResetTokenRepository_GetByHashForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateByUserID provides a mock function with given fields: userID, now
+func (_m *MockPasswordResetTokenRepository) InvalidateByUserID(userID int, now time.Time) error {
+	ret := _m.Called(userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) error); ok {
+		r0 = rf(userID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPasswordResetTokenRepository_InvalidateByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByUserID'
+type MockPasswordResetTokenRepository_InvalidateByUserID_Call struct {
+	*mock.Call
+}
+
+// InvalidateByUserID is a helper method to define mock.On call
+//   - userID int
+//   - now time.Time
+func (_e *MockPasswordResetTokenRepository_Expecter) InvalidateByUserID(userID interface{}, now interface{}) *MockPasswordResetTokenRepository_InvalidateByUserID_Call {
+	return &MockPasswordResetTokenRepository_InvalidateByUserID_Call{Call: _e.mock.On("InvalidateByUserID", userID, now)}
+}
+
+func (_c *MockPasswordResetTokenRepository_InvalidateByUserID_Call) Run(run func(userID int, now time.Time)) *MockPasswordResetTokenRepository_InvalidateByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockPasswordResetTokenRepository_InvalidateByUserID_Call) Return(_a0 error) *MockPasswordResetTokenRepository_InvalidateByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordResetTokenRepository_InvalidateByUserID_Call) RunAndReturn(run func(int, time.Time) error) *MockPasswordResetTokenRepository_InvalidateByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockPasswordResetTokenRepository) WithTx(tx repository.DBTX) repository.PasswordResetTokenRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.PasswordResetTokenRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.PasswordResetTokenRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.PasswordResetTokenRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockPasswordResetTokenRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockPasswordResetTokenRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockPasswordResetTokenRepository_Expecter) WithTx(tx interface{}) *MockPasswordResetTokenRepository_WithTx_Call {
+	return &MockPasswordResetTokenRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockPasswordResetTokenRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockPasswordResetTokenRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockPasswordResetTokenRepository_WithTx_Call) Return(_a0 repository.PasswordResetTokenRepository) *MockPasswordResetTokenRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordResetTokenRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.PasswordResetTokenRepository) *MockPasswordResetTokenRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPasswordResetTokenRepository creates a new instance of MockPasswordResetTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPasswordResetTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPasswordResetTokenRepository {
+	mock := &MockPasswordResetTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,428 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+
+	time "time"
+)
+
+// MockProjectInvitationRepository is an autogenerated mock type for the ProjectInvitationRepository type
+type MockProjectInvitationRepository struct {
+	mock.Mock
+}
+
+type MockProjectInvitationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProjectInvitationRepository) EXPECT() *MockProjectInvitationRepository_Expecter {
+	return &MockProjectInvitationRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: invitation
+func (_m *MockProjectInvitationRepository) Create(invitation *models.ProjectInvitation) (*models.ProjectInvitation, error) {
+	ret := _m.Called(invitation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.ProjectInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.ProjectInvitation) (*models.ProjectInvitation, error)); ok {
+		return rf(invitation)
+	}
+	if rf, ok := ret.Get(0).(func(*models.ProjectInvitation) *models.ProjectInvitation); ok {
+		r0 = rf(invitation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProjectInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.ProjectInvitation) error); ok {
+		r1 = rf(invitation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectInvitationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockProjectInvitationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - invitation *models.ProjectInvitation
+func (_e *MockProjectInvitationRepository_Expecter) Create(invitation interface{}) *MockProjectInvitationRepository_Create_Call {
+	return &MockProjectInvitationRepository_Create_Call{Call: _e.mock.On("Create", invitation)}
+}
+
+func (_c *MockProjectInvitationRepository_Create_Call) Run(run func(invitation *models.ProjectInvitation)) *MockProjectInvitationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ProjectInvitation))
+	})
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_Create_Call) Return(_a0 *models.ProjectInvitation, _a1 error) *MockProjectInvitationRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_Create_Call) RunAndReturn(run func(*models.ProjectInvitation) (*models.ProjectInvitation, error)) *MockProjectInvitationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockProjectInvitationRepository) GetByID(id int) (*models.ProjectInvitation, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.ProjectInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*models.ProjectInvitation, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *models.ProjectInvitation); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProjectInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectInvitationRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockProjectInvitationRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id int
+func (_e *MockProjectInvitationRepository_Expecter) GetByID(id interface{}) *MockProjectInvitationRepository_GetByID_Call {
+	return &MockProjectInvitationRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockProjectInvitationRepository_GetByID_Call) Run(run func(id int)) *MockProjectInvitationRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_GetByID_Call) Return(_a0 *models.ProjectInvitation, _a1 error) *MockProjectInvitationRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_GetByID_Call) RunAndReturn(run func(int) (*models.ProjectInvitation, error)) *MockProjectInvitationRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingByInviteeID provides a mock function with given fields: inviteeID
+func (_m *MockProjectInvitationRepository) GetPendingByInviteeID(inviteeID int) ([]models.ProjectInvitation, error) {
+	ret := _m.Called(inviteeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingByInviteeID")
+	}
+
+	var r0 []models.ProjectInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.ProjectInvitation, error)); ok {
+		return rf(inviteeID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.ProjectInvitation); ok {
+		r0 = rf(inviteeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProjectInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(inviteeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectInvitationRepository_GetPendingByInviteeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingByInviteeID'
+type MockProjectInvitationRepository_GetPendingByInviteeID_Call struct {
+	*mock.Call
+}
+
+// GetPendingByInviteeID is a helper method to define mock.On call
+//   - inviteeID int
+func (_e *MockProjectInvitationRepository_Expecter) GetPendingByInviteeID(inviteeID interface{}) *MockProjectInvitationRepository_GetPendingByInviteeID_Call {
+	return &MockProjectInvitationRepository_GetPendingByInviteeID_Call{Call: _e.mock.On("GetPendingByInviteeID", inviteeID)}
+}
+
+func (_c *MockProjectInvitationRepository_GetPendingByInviteeID_Call) Run(run func(inviteeID int)) *MockProjectInvitationRepository_GetPendingByInviteeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_GetPendingByInviteeID_Call) Return(_a0 []models.ProjectInvitation, _a1 error) *MockProjectInvitationRepository_GetPendingByInviteeID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_GetPendingByInviteeID_Call) RunAndReturn(run func(int) ([]models.ProjectInvitation, error)) *MockProjectInvitationRepository_GetPendingByInviteeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingByProjectID provides a mock function with given fields: projectID
+func (_m *MockProjectInvitationRepository) GetPendingByProjectID(projectID int) ([]models.ProjectInvitation, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingByProjectID")
+	}
+
+	var r0 []models.ProjectInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.ProjectInvitation, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.ProjectInvitation); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProjectInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectInvitationRepository_GetPendingByProjectID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingByProjectID'
+type MockProjectInvitationRepository_GetPendingByProjectID_Call struct {
+	*mock.Call
+}
+
+// GetPendingByProjectID is a helper method to define mock.On call
+//   - projectID int
+func (_e *MockProjectInvitationRepository_Expecter) GetPendingByProjectID(projectID interface{}) *MockProjectInvitationRepository_GetPendingByProjectID_Call {
+	return &MockProjectInvitationRepository_GetPendingByProjectID_Call{Call: _e.mock.On("GetPendingByProjectID", projectID)}
+}
+
+func (_c *MockProjectInvitationRepository_GetPendingByProjectID_Call) Run(run func(projectID int)) *MockProjectInvitationRepository_GetPendingByProjectID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_GetPendingByProjectID_Call) Return(_a0 []models.ProjectInvitation, _a1 error) *MockProjectInvitationRepository_GetPendingByProjectID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_GetPendingByProjectID_Call) RunAndReturn(run func(int) ([]models.ProjectInvitation, error)) *MockProjectInvitationRepository_GetPendingByProjectID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Respond provides a mock function with given fields: id, status, now
+func (_m *MockProjectInvitationRepository) Respond(id int, status models.InvitationStatus, now time.Time) (*models.ProjectInvitation, error) {
+	ret := _m.Called(id, status, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Respond")
+	}
+
+	var r0 *models.ProjectInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, models.InvitationStatus, time.Time) (*models.ProjectInvitation, error)); ok {
+		return rf(id, status, now)
+	}
+	if rf, ok := ret.Get(0).(func(int, models.InvitationStatus, time.Time) *models.ProjectInvitation); ok {
+		r0 = rf(id, status, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProjectInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, models.InvitationStatus, time.Time) error); ok {
+		r1 = rf(id, status, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectInvitationRepository_Respond_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Respond'
+type MockProjectInvitationRepository_Respond_Call struct {
+	*mock.Call
+}
+
+// Respond is a helper method to define mock.On call
+//   - id int
+//   - status models.InvitationStatus
+//   - now time.Time
+func (_e *MockProjectInvitationRepository_Expecter) Respond(id interface{}, status interface{}, now interface{}) *MockProjectInvitationRepository_Respond_Call {
+	return &MockProjectInvitationRepository_Respond_Call{Call: _e.mock.On("Respond", id, status, now)}
+}
+
+func (_c *MockProjectInvitationRepository_Respond_Call) Run(run func(id int, status models.InvitationStatus, now time.Time)) *MockProjectInvitationRepository_Respond_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(models.InvitationStatus), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_Respond_Call) Return(_a0 *models.ProjectInvitation, _a1 error) *MockProjectInvitationRepository_Respond_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_Respond_Call) RunAndReturn(run func(int, models.InvitationStatus, time.Time) (*models.ProjectInvitation, error)) *MockProjectInvitationRepository_Respond_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockProjectInvitationRepository) WithTx(tx repository.DBTX) repository.ProjectInvitationRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.ProjectInvitationRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.ProjectInvitationRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.ProjectInvitationRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockProjectInvitationRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockProjectInvitationRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockProjectInvitationRepository_Expecter) WithTx(tx interface{}) *MockProjectInvitationRepository_WithTx_Call {
+	return &MockProjectInvitationRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockProjectInvitationRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockProjectInvitationRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_WithTx_Call) Return(_a0 repository.ProjectInvitationRepository) *MockProjectInvitationRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.ProjectInvitationRepository) *MockProjectInvitationRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithWorkspace provides a mock function with given fields: workspaceID
+func (_m *MockProjectInvitationRepository) WithWorkspace(workspaceID int) repository.ProjectInvitationRepository {
+	ret := _m.Called(workspaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithWorkspace")
+	}
+
+	var r0 repository.ProjectInvitationRepository
+	if rf, ok := ret.Get(0).(func(int) repository.ProjectInvitationRepository); ok {
+		r0 = rf(workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.ProjectInvitationRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockProjectInvitationRepository_WithWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithWorkspace'
+type MockProjectInvitationRepository_WithWorkspace_Call struct {
+	*mock.Call
+}
+
+// WithWorkspace is a helper method to define mock.On call
+//   - workspaceID int
+func (_e *MockProjectInvitationRepository_Expecter) WithWorkspace(workspaceID interface{}) *MockProjectInvitationRepository_WithWorkspace_Call {
+	return &MockProjectInvitationRepository_WithWorkspace_Call{Call: _e.mock.On("WithWorkspace", workspaceID)}
+}
+
+func (_c *MockProjectInvitationRepository_WithWorkspace_Call) Run(run func(workspaceID int)) *MockProjectInvitationRepository_WithWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_WithWorkspace_Call) Return(_a0 repository.ProjectInvitationRepository) *MockProjectInvitationRepository_WithWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectInvitationRepository_WithWorkspace_Call) RunAndReturn(run func(int) repository.ProjectInvitationRepository) *MockProjectInvitationRepository_WithWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProjectInvitationRepository creates a new instance of MockProjectInvitationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProjectInvitationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProjectInvitationRepository {
+	mock := &MockProjectInvitationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
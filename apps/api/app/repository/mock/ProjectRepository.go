@@ -0,0 +1,739 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+)
+
+// MockProjectRepository is an autogenerated mock type for the ProjectRepository type
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+type MockProjectRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProjectRepository) EXPECT() *MockProjectRepository_Expecter {
+	return &MockProjectRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function with given fields: projectID, userID, role
+func (_m *MockProjectRepository) AddMember(projectID int, userID int, role models.ProjectRole) error {
+	ret := _m.Called(projectID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int, models.ProjectRole) error); ok {
+		r0 = rf(projectID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectRepository_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type MockProjectRepository_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - projectID int
+//   - userID int
+//   - role models.ProjectRole
+func (_e *MockProjectRepository_Expecter) AddMember(projectID interface{}, userID interface{}, role interface{}) *MockProjectRepository_AddMember_Call {
+	return &MockProjectRepository_AddMember_Call{Call: _e.mock.On("AddMember", projectID, userID, role)}
+}
+
+func (_c *MockProjectRepository_AddMember_Call) Run(run func(projectID int, userID int, role models.ProjectRole)) *MockProjectRepository_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int), args[2].(models.ProjectRole))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_AddMember_Call) Return(_a0 error) *MockProjectRepository_AddMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectRepository_AddMember_Call) RunAndReturn(run func(int, int, models.ProjectRole) error) *MockProjectRepository_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOwnersForUpdate provides a mock function with given fields: projectID
+func (_m *MockProjectRepository) CountOwnersForUpdate(projectID int) (int, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOwnersForUpdate")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(projectID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectRepository_CountOwnersForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOwnersForUpdate'
+type MockProjectRepository_CountOwnersForUpdate_Call struct {
+	*mock.Call
+}
+
+// CountOwnersForUpdate is a helper method to define mock.On call
+//   - projectID int
+func (_e *MockProjectRepository_Expecter) CountOwnersForUpdate(projectID interface{}) *MockProjectRepository_CountOwnersForUpdate_Call {
+	return &MockProjectRepository_CountOwnersForUpdate_Call{Call: _e.mock.On("CountOwnersForUpdate", projectID)}
+}
+
+func (_c *MockProjectRepository_CountOwnersForUpdate_Call) Run(run func(projectID int)) *MockProjectRepository_CountOwnersForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_CountOwnersForUpdate_Call) Return(_a0 int, _a1 error) *MockProjectRepository_CountOwnersForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectRepository_CountOwnersForUpdate_Call) RunAndReturn(run func(int) (int, error)) *MockProjectRepository_CountOwnersForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: project
+func (_m *MockProjectRepository) Create(project *models.Project) (*models.Project, error) {
+	ret := _m.Called(project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.Project) (*models.Project, error)); ok {
+		return rf(project)
+	}
+	if rf, ok := ret.Get(0).(func(*models.Project) *models.Project); ok {
+		r0 = rf(project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.Project) error); ok {
+		r1 = rf(project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockProjectRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - project *models.Project
+func (_e *MockProjectRepository_Expecter) Create(project interface{}) *MockProjectRepository_Create_Call {
+	return &MockProjectRepository_Create_Call{Call: _e.mock.On("Create", project)}
+}
+
+func (_c *MockProjectRepository_Create_Call) Run(run func(project *models.Project)) *MockProjectRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Project))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_Create_Call) Return(_a0 *models.Project, _a1 error) *MockProjectRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectRepository_Create_Call) RunAndReturn(run func(*models.Project) (*models.Project, error)) *MockProjectRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockProjectRepository) Delete(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockProjectRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id int
+func (_e *MockProjectRepository_Expecter) Delete(id interface{}) *MockProjectRepository_Delete_Call {
+	return &MockProjectRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockProjectRepository_Delete_Call) Run(run func(id int)) *MockProjectRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_Delete_Call) Return(_a0 error) *MockProjectRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectRepository_Delete_Call) RunAndReturn(run func(int) error) *MockProjectRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: query
+func (_m *MockProjectRepository) GetAll(query models.ProjectListQuery) ([]models.Project, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.ProjectListQuery) ([]models.Project, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(models.ProjectListQuery) []models.Project); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.ProjectListQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockProjectRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - query models.ProjectListQuery
+func (_e *MockProjectRepository_Expecter) GetAll(query interface{}) *MockProjectRepository_GetAll_Call {
+	return &MockProjectRepository_GetAll_Call{Call: _e.mock.On("GetAll", query)}
+}
+
+func (_c *MockProjectRepository_GetAll_Call) Run(run func(query models.ProjectListQuery)) *MockProjectRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.ProjectListQuery))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_GetAll_Call) Return(_a0 []models.Project, _a1 error) *MockProjectRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectRepository_GetAll_Call) RunAndReturn(run func(models.ProjectListQuery) ([]models.Project, error)) *MockProjectRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockProjectRepository) GetByID(id int) (*models.Project, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*models.Project, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *models.Project); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockProjectRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id int
+func (_e *MockProjectRepository_Expecter) GetByID(id interface{}) *MockProjectRepository_GetByID_Call {
+	return &MockProjectRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockProjectRepository_GetByID_Call) Run(run func(id int)) *MockProjectRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_GetByID_Call) Return(_a0 *models.Project, _a1 error) *MockProjectRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectRepository_GetByID_Call) RunAndReturn(run func(int) (*models.Project, error)) *MockProjectRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMemberRole provides a mock function with given fields: projectID, userID
+func (_m *MockProjectRepository) GetMemberRole(projectID int, userID int) (models.ProjectRole, error) {
+	ret := _m.Called(projectID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMemberRole")
+	}
+
+	var r0 models.ProjectRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (models.ProjectRole, error)); ok {
+		return rf(projectID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) models.ProjectRole); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		r0 = ret.Get(0).(models.ProjectRole)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(projectID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectRepository_GetMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMemberRole'
+type MockProjectRepository_GetMemberRole_Call struct {
+	*mock.Call
+}
+
+// GetMemberRole is a helper method to define mock.On call
+//   - projectID int
+//   - userID int
+func (_e *MockProjectRepository_Expecter) GetMemberRole(projectID interface{}, userID interface{}) *MockProjectRepository_GetMemberRole_Call {
+	return &MockProjectRepository_GetMemberRole_Call{Call: _e.mock.On("GetMemberRole", projectID, userID)}
+}
+
+func (_c *MockProjectRepository_GetMemberRole_Call) Run(run func(projectID int, userID int)) *MockProjectRepository_GetMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_GetMemberRole_Call) Return(_a0 models.ProjectRole, _a1 error) *MockProjectRepository_GetMemberRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectRepository_GetMemberRole_Call) RunAndReturn(run func(int, int) (models.ProjectRole, error)) *MockProjectRepository_GetMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembers provides a mock function with given fields: projectID
+func (_m *MockProjectRepository) GetMembers(projectID int) ([]models.ProjectMember, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembers")
+	}
+
+	var r0 []models.ProjectMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.ProjectMember, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.ProjectMember); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProjectMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectRepository_GetMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembers'
+type MockProjectRepository_GetMembers_Call struct {
+	*mock.Call
+}
+
+// GetMembers is a helper method to define mock.On call
+//   - projectID int
+func (_e *MockProjectRepository_Expecter) GetMembers(projectID interface{}) *MockProjectRepository_GetMembers_Call {
+	return &MockProjectRepository_GetMembers_Call{Call: _e.mock.On("GetMembers", projectID)}
+}
+
+func (_c *MockProjectRepository_GetMembers_Call) Run(run func(projectID int)) *MockProjectRepository_GetMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_GetMembers_Call) Return(_a0 []models.ProjectMember, _a1 error) *MockProjectRepository_GetMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectRepository_GetMembers_Call) RunAndReturn(run func(int) ([]models.ProjectMember, error)) *MockProjectRepository_GetMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: projectID, userID
+func (_m *MockProjectRepository) RemoveMember(projectID int, userID int) error {
+	ret := _m.Called(projectID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectRepository_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type MockProjectRepository_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - projectID int
+//   - userID int
+func (_e *MockProjectRepository_Expecter) RemoveMember(projectID interface{}, userID interface{}) *MockProjectRepository_RemoveMember_Call {
+	return &MockProjectRepository_RemoveMember_Call{Call: _e.mock.On("RemoveMember", projectID, userID)}
+}
+
+func (_c *MockProjectRepository_RemoveMember_Call) Run(run func(projectID int, userID int)) *MockProjectRepository_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_RemoveMember_Call) Return(_a0 error) *MockProjectRepository_RemoveMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectRepository_RemoveMember_Call) RunAndReturn(run func(int, int) error) *MockProjectRepository_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: id, update
+func (_m *MockProjectRepository) Update(id int, update models.ProjectUpdate) (*models.Project, error) {
+	ret := _m.Called(id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, models.ProjectUpdate) (*models.Project, error)); ok {
+		return rf(id, update)
+	}
+	if rf, ok := ret.Get(0).(func(int, models.ProjectUpdate) *models.Project); ok {
+		r0 = rf(id, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, models.ProjectUpdate) error); ok {
+		r1 = rf(id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockProjectRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - id int
+//   - update models.ProjectUpdate
+func (_e *MockProjectRepository_Expecter) Update(id interface{}, update interface{}) *MockProjectRepository_Update_Call {
+	return &MockProjectRepository_Update_Call{Call: _e.mock.On("Update", id, update)}
+}
+
+func (_c *MockProjectRepository_Update_Call) Run(run func(id int, update models.ProjectUpdate)) *MockProjectRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(models.ProjectUpdate))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_Update_Call) Return(_a0 *models.Project, _a1 error) *MockProjectRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectRepository_Update_Call) RunAndReturn(run func(int, models.ProjectUpdate) (*models.Project, error)) *MockProjectRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMemberRole provides a mock function with given fields: projectID, userID, role
+func (_m *MockProjectRepository) UpdateMemberRole(projectID int, userID int, role models.ProjectRole) (*models.ProjectMember, error) {
+	ret := _m.Called(projectID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRole")
+	}
+
+	var r0 *models.ProjectMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, models.ProjectRole) (*models.ProjectMember, error)); ok {
+		return rf(projectID, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, models.ProjectRole) *models.ProjectMember); ok {
+		r0 = rf(projectID, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProjectMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, models.ProjectRole) error); ok {
+		r1 = rf(projectID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectRepository_UpdateMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMemberRole'
+type MockProjectRepository_UpdateMemberRole_Call struct {
+	*mock.Call
+}
+
+// UpdateMemberRole is a helper method to define mock.On call
+//   - projectID int
+//   - userID int
+//   - role models.ProjectRole
+func (_e *MockProjectRepository_Expecter) UpdateMemberRole(projectID interface{}, userID interface{}, role interface{}) *MockProjectRepository_UpdateMemberRole_Call {
+	return &MockProjectRepository_UpdateMemberRole_Call{Call: _e.mock.On("UpdateMemberRole", projectID, userID, role)}
+}
+
+func (_c *MockProjectRepository_UpdateMemberRole_Call) Run(run func(projectID int, userID int, role models.ProjectRole)) *MockProjectRepository_UpdateMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int), args[2].(models.ProjectRole))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_UpdateMemberRole_Call) Return(_a0 *models.ProjectMember, _a1 error) *MockProjectRepository_UpdateMemberRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectRepository_UpdateMemberRole_Call) RunAndReturn(run func(int, int, models.ProjectRole) (*models.ProjectMember, error)) *MockProjectRepository_UpdateMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockProjectRepository) WithTx(tx repository.DBTX) repository.ProjectRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.ProjectRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.ProjectRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.ProjectRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockProjectRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockProjectRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockProjectRepository_Expecter) WithTx(tx interface{}) *MockProjectRepository_WithTx_Call {
+	return &MockProjectRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockProjectRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockProjectRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_WithTx_Call) Return(_a0 repository.ProjectRepository) *MockProjectRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.ProjectRepository) *MockProjectRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithWorkspace provides a mock function with given fields: workspaceID
+func (_m *MockProjectRepository) WithWorkspace(workspaceID int) repository.ProjectRepository {
+	ret := _m.Called(workspaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithWorkspace")
+	}
+
+	var r0 repository.ProjectRepository
+	if rf, ok := ret.Get(0).(func(int) repository.ProjectRepository); ok {
+		r0 = rf(workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.ProjectRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockProjectRepository_WithWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithWorkspace'
+type MockProjectRepository_WithWorkspace_Call struct {
+	*mock.Call
+}
+
+// WithWorkspace is a helper method to define mock.On call
+//   - workspaceID int
+func (_e *MockProjectRepository_Expecter) WithWorkspace(workspaceID interface{}) *MockProjectRepository_WithWorkspace_Call {
+	return &MockProjectRepository_WithWorkspace_Call{Call: _e.mock.On("WithWorkspace", workspaceID)}
+}
+
+func (_c *MockProjectRepository_WithWorkspace_Call) Run(run func(workspaceID int)) *MockProjectRepository_WithWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockProjectRepository_WithWorkspace_Call) Return(_a0 repository.ProjectRepository) *MockProjectRepository_WithWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectRepository_WithWorkspace_Call) RunAndReturn(run func(int) repository.ProjectRepository) *MockProjectRepository_WithWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProjectRepository creates a new instance of MockProjectRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProjectRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProjectRepository {
+	mock := &MockProjectRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
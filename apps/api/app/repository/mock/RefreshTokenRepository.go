@@ -0,0 +1,345 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+
+	time "time"
+)
+
+// MockRefreshTokenRepository is an autogenerated mock type for the RefreshTokenRepository type
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+type MockRefreshTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRefreshTokenRepository) EXPECT() *MockRefreshTokenRepository_Expecter {
+	return &MockRefreshTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: token
+func (_m *MockRefreshTokenRepository) Create(token *models.RefreshToken) (*models.RefreshToken, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.RefreshToken) (*models.RefreshToken, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(*models.RefreshToken) *models.RefreshToken); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.RefreshToken) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRefreshTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRefreshTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - token *models.RefreshToken
+func (_e *MockRefreshTokenRepository_Expecter) Create(token interface{}) *MockRefreshTokenRepository_Create_Call {
+	return &MockRefreshTokenRepository_Create_Call{Call: _e.mock.On("Create", token)}
+}
+
+func (_c *MockRefreshTokenRepository_Create_Call) Run(run func(token *models.RefreshToken)) *MockRefreshTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.RefreshToken))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_Create_Call) Return(_a0 *models.RefreshToken, _a1 error) *MockRefreshTokenRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_Create_Call) RunAndReturn(run func(*models.RefreshToken) (*models.RefreshToken, error)) *MockRefreshTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByHashForUpdate provides a mock function with given fields: tokenHash
+func (_m *MockRefreshTokenRepository) GetByHashForUpdate(tokenHash string) (*models.RefreshToken, error) {
+	ret := _m.Called(tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByHashForUpdate")
+	}
+
+	var r0 *models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.RefreshToken, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.RefreshToken); ok {
+		r0 = rf(tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRefreshTokenRepository_GetByHashForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByHashForUpdate'
+type MockRefreshTokenRepository_GetByHashForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByHashForUpdate is a helper method to define mock.On call
+//   - tokenHash string
+func (_e *MockRefreshTokenRepository_Expecter) GetByHashForUpdate(tokenHash interface{}) *MockRefreshTokenRepository_GetByHashForUpdate_Call {
+	return &MockRefreshTokenRepository_GetByHashForUpdate_Call{Call: _e.mock.On("GetByHashForUpdate", tokenHash)}
+}
+
+func (_c *MockRefreshTokenRepository_GetByHashForUpdate_Call) Run(run func(tokenHash string)) *MockRefreshTokenRepository_GetByHashForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_GetByHashForUpdate_Call) Return(_a0 *models.RefreshToken, _a1 error) *MockRefreshTokenRepository_GetByHashForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_GetByHashForUpdate_Call) RunAndReturn(run func(string) (*models.RefreshToken, error)) *MockRefreshTokenRepository_GetByHashForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkUsed provides a mock function with given fields: id, now
+func (_m *MockRefreshTokenRepository) MarkUsed(id int, now time.Time) error {
+	ret := _m.Called(id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) error); ok {
+		r0 = rf(id, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRefreshTokenRepository_MarkUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkUsed'
+type MockRefreshTokenRepository_MarkUsed_Call struct {
+	*mock.Call
+}
+
+// MarkUsed is a helper method to define mock.On call
+//   - id int
+//   - now time.Time
+func (_e *MockRefreshTokenRepository_Expecter) MarkUsed(id interface{}, now interface{}) *MockRefreshTokenRepository_MarkUsed_Call {
+	return &MockRefreshTokenRepository_MarkUsed_Call{Call: _e.mock.On("MarkUsed", id, now)}
+}
+
+func (_c *MockRefreshTokenRepository_MarkUsed_Call) Run(run func(id int, now time.Time)) *MockRefreshTokenRepository_MarkUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_MarkUsed_Call) Return(_a0 error) *MockRefreshTokenRepository_MarkUsed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_MarkUsed_Call) RunAndReturn(run func(int, time.Time) error) *MockRefreshTokenRepository_MarkUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeByUserID provides a mock function with given fields: userID, now
+func (_m *MockRefreshTokenRepository) RevokeByUserID(userID int, now time.Time) error {
+	ret := _m.Called(userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) error); ok {
+		r0 = rf(userID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRefreshTokenRepository_RevokeByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeByUserID'
+type MockRefreshTokenRepository_RevokeByUserID_Call struct {
+	*mock.Call
+}
+
+// RevokeByUserID is a helper method to define mock.On call
+//   - userID int
+//   - now time.Time
+func (_e *MockRefreshTokenRepository_Expecter) RevokeByUserID(userID interface{}, now interface{}) *MockRefreshTokenRepository_RevokeByUserID_Call {
+	return &MockRefreshTokenRepository_RevokeByUserID_Call{Call: _e.mock.On("RevokeByUserID", userID, now)}
+}
+
+func (_c *MockRefreshTokenRepository_RevokeByUserID_Call) Run(run func(userID int, now time.Time)) *MockRefreshTokenRepository_RevokeByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_RevokeByUserID_Call) Return(_a0 error) *MockRefreshTokenRepository_RevokeByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_RevokeByUserID_Call) RunAndReturn(run func(int, time.Time) error) *MockRefreshTokenRepository_RevokeByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeFamily provides a mock function with given fields: familyID, now
+func (_m *MockRefreshTokenRepository) RevokeFamily(familyID string, now time.Time) error {
+	ret := _m.Called(familyID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(familyID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRefreshTokenRepository_RevokeFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeFamily'
+type MockRefreshTokenRepository_RevokeFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeFamily is a helper method to define mock.On call
+//   - familyID string
+//   - now time.Time
+func (_e *MockRefreshTokenRepository_Expecter) RevokeFamily(familyID interface{}, now interface{}) *MockRefreshTokenRepository_RevokeFamily_Call {
+	return &MockRefreshTokenRepository_RevokeFamily_Call{Call: _e.mock.On("RevokeFamily", familyID, now)}
+}
+
+func (_c *MockRefreshTokenRepository_RevokeFamily_Call) Run(run func(familyID string, now time.Time)) *MockRefreshTokenRepository_RevokeFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_RevokeFamily_Call) Return(_a0 error) *MockRefreshTokenRepository_RevokeFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_RevokeFamily_Call) RunAndReturn(run func(string, time.Time) error) *MockRefreshTokenRepository_RevokeFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockRefreshTokenRepository) WithTx(tx repository.DBTX) repository.RefreshTokenRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.RefreshTokenRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.RefreshTokenRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.RefreshTokenRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockRefreshTokenRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockRefreshTokenRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockRefreshTokenRepository_Expecter) WithTx(tx interface{}) *MockRefreshTokenRepository_WithTx_Call {
+	return &MockRefreshTokenRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockRefreshTokenRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockRefreshTokenRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_WithTx_Call) Return(_a0 repository.RefreshTokenRepository) *MockRefreshTokenRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.RefreshTokenRepository) *MockRefreshTokenRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRefreshTokenRepository creates a new instance of MockRefreshTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRefreshTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRefreshTokenRepository {
+	mock := &MockRefreshTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,632 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+)
+
+// MockTagRepository is an autogenerated mock type for the TagRepository type
+type MockTagRepository struct {
+	mock.Mock
+}
+
+type MockTagRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTagRepository) EXPECT() *MockTagRepository_Expecter {
+	return &MockTagRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: name
+func (_m *MockTagRepository) Create(name string) (*models.Tag, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Tag, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Tag); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTagRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTagRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - name string
+func (_e *MockTagRepository_Expecter) Create(name interface{}) *MockTagRepository_Create_Call {
+	return &MockTagRepository_Create_Call{Call: _e.mock.On("Create", name)}
+}
+
+func (_c *MockTagRepository_Create_Call) Run(run func(name string)) *MockTagRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_Create_Call) Return(_a0 *models.Tag, _a1 error) *MockTagRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTagRepository_Create_Call) RunAndReturn(run func(string) (*models.Tag, error)) *MockTagRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockTagRepository) Delete(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTagRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockTagRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id int
+func (_e *MockTagRepository_Expecter) Delete(id interface{}) *MockTagRepository_Delete_Call {
+	return &MockTagRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockTagRepository_Delete_Call) Run(run func(id int)) *MockTagRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_Delete_Call) Return(_a0 error) *MockTagRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTagRepository_Delete_Call) RunAndReturn(run func(int) error) *MockTagRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureByNames provides a mock function with given fields: names
+func (_m *MockTagRepository) EnsureByNames(names []string) ([]models.Tag, error) {
+	ret := _m.Called(names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureByNames")
+	}
+
+	var r0 []models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]models.Tag, error)); ok {
+		return rf(names)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []models.Tag); ok {
+		r0 = rf(names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTagRepository_EnsureByNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureByNames'
+type MockTagRepository_EnsureByNames_Call struct {
+	*mock.Call
+}
+
+// EnsureByNames is a helper method to define mock.On call
+//   - names []string
+func (_e *MockTagRepository_Expecter) EnsureByNames(names interface{}) *MockTagRepository_EnsureByNames_Call {
+	return &MockTagRepository_EnsureByNames_Call{Call: _e.mock.On("EnsureByNames", names)}
+}
+
+func (_c *MockTagRepository_EnsureByNames_Call) Run(run func(names []string)) *MockTagRepository_EnsureByNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_EnsureByNames_Call) Return(_a0 []models.Tag, _a1 error) *MockTagRepository_EnsureByNames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTagRepository_EnsureByNames_Call) RunAndReturn(run func([]string) ([]models.Tag, error)) *MockTagRepository_EnsureByNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with no fields
+func (_m *MockTagRepository) GetAll() ([]models.Tag, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Tag, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Tag); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTagRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockTagRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+func (_e *MockTagRepository_Expecter) GetAll() *MockTagRepository_GetAll_Call {
+	return &MockTagRepository_GetAll_Call{Call: _e.mock.On("GetAll")}
+}
+
+func (_c *MockTagRepository_GetAll_Call) Run(run func()) *MockTagRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_GetAll_Call) Return(_a0 []models.Tag, _a1 error) *MockTagRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTagRepository_GetAll_Call) RunAndReturn(run func() ([]models.Tag, error)) *MockTagRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockTagRepository) GetByID(id int) (*models.Tag, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*models.Tag, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *models.Tag); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTagRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockTagRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id int
+func (_e *MockTagRepository_Expecter) GetByID(id interface{}) *MockTagRepository_GetByID_Call {
+	return &MockTagRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockTagRepository_GetByID_Call) Run(run func(id int)) *MockTagRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_GetByID_Call) Return(_a0 *models.Tag, _a1 error) *MockTagRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTagRepository_GetByID_Call) RunAndReturn(run func(int) (*models.Tag, error)) *MockTagRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTodoIDs provides a mock function with given fields: todoIDs
+func (_m *MockTagRepository) GetByTodoIDs(todoIDs []int) (map[int][]models.Tag, error) {
+	ret := _m.Called(todoIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTodoIDs")
+	}
+
+	var r0 map[int][]models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) (map[int][]models.Tag, error)); ok {
+		return rf(todoIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]int) map[int][]models.Tag); ok {
+		r0 = rf(todoIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(todoIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTagRepository_GetByTodoIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTodoIDs'
+type MockTagRepository_GetByTodoIDs_Call struct {
+	*mock.Call
+}
+
+// GetByTodoIDs is a helper method to define mock.On call
+//   - todoIDs []int
+func (_e *MockTagRepository_Expecter) GetByTodoIDs(todoIDs interface{}) *MockTagRepository_GetByTodoIDs_Call {
+	return &MockTagRepository_GetByTodoIDs_Call{Call: _e.mock.On("GetByTodoIDs", todoIDs)}
+}
+
+func (_c *MockTagRepository_GetByTodoIDs_Call) Run(run func(todoIDs []int)) *MockTagRepository_GetByTodoIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]int))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_GetByTodoIDs_Call) Return(_a0 map[int][]models.Tag, _a1 error) *MockTagRepository_GetByTodoIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTagRepository_GetByTodoIDs_Call) RunAndReturn(run func([]int) (map[int][]models.Tag, error)) *MockTagRepository_GetByTodoIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsUsedByInaccessibleTodos provides a mock function with given fields: id, userID
+func (_m *MockTagRepository) IsUsedByInaccessibleTodos(id int, userID int) (bool, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsUsedByInaccessibleTodos")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (bool, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) bool); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTagRepository_IsUsedByInaccessibleTodos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsUsedByInaccessibleTodos'
+type MockTagRepository_IsUsedByInaccessibleTodos_Call struct {
+	*mock.Call
+}
+
+// IsUsedByInaccessibleTodos is a helper method to define mock.On call
+//   - id int
+//   - userID int
+func (_e *MockTagRepository_Expecter) IsUsedByInaccessibleTodos(id interface{}, userID interface{}) *MockTagRepository_IsUsedByInaccessibleTodos_Call {
+	return &MockTagRepository_IsUsedByInaccessibleTodos_Call{Call: _e.mock.On("IsUsedByInaccessibleTodos", id, userID)}
+}
+
+func (_c *MockTagRepository_IsUsedByInaccessibleTodos_Call) Run(run func(id int, userID int)) *MockTagRepository_IsUsedByInaccessibleTodos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_IsUsedByInaccessibleTodos_Call) Return(_a0 bool, _a1 error) *MockTagRepository_IsUsedByInaccessibleTodos_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTagRepository_IsUsedByInaccessibleTodos_Call) RunAndReturn(run func(int, int) (bool, error)) *MockTagRepository_IsUsedByInaccessibleTodos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTodoTags provides a mock function with given fields: todoID, tagIDs
+func (_m *MockTagRepository) SetTodoTags(todoID int, tagIDs []int) error {
+	ret := _m.Called(todoID, tagIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTodoTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []int) error); ok {
+		r0 = rf(todoID, tagIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTagRepository_SetTodoTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTodoTags'
+type MockTagRepository_SetTodoTags_Call struct {
+	*mock.Call
+}
+
+// SetTodoTags is a helper method to define mock.On call
+//   - todoID int
+//   - tagIDs []int
+func (_e *MockTagRepository_Expecter) SetTodoTags(todoID interface{}, tagIDs interface{}) *MockTagRepository_SetTodoTags_Call {
+	return &MockTagRepository_SetTodoTags_Call{Call: _e.mock.On("SetTodoTags", todoID, tagIDs)}
+}
+
+func (_c *MockTagRepository_SetTodoTags_Call) Run(run func(todoID int, tagIDs []int)) *MockTagRepository_SetTodoTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].([]int))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_SetTodoTags_Call) Return(_a0 error) *MockTagRepository_SetTodoTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTagRepository_SetTodoTags_Call) RunAndReturn(run func(int, []int) error) *MockTagRepository_SetTodoTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: id, name
+func (_m *MockTagRepository) Update(id int, name string) (*models.Tag, error) {
+	ret := _m.Called(id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (*models.Tag, error)); ok {
+		return rf(id, name)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) *models.Tag); ok {
+		r0 = rf(id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(id, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTagRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockTagRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - id int
+//   - name string
+func (_e *MockTagRepository_Expecter) Update(id interface{}, name interface{}) *MockTagRepository_Update_Call {
+	return &MockTagRepository_Update_Call{Call: _e.mock.On("Update", id, name)}
+}
+
+func (_c *MockTagRepository_Update_Call) Run(run func(id int, name string)) *MockTagRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_Update_Call) Return(_a0 *models.Tag, _a1 error) *MockTagRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTagRepository_Update_Call) RunAndReturn(run func(int, string) (*models.Tag, error)) *MockTagRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockTagRepository) WithTx(tx repository.DBTX) repository.TagRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.TagRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.TagRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.TagRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockTagRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockTagRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockTagRepository_Expecter) WithTx(tx interface{}) *MockTagRepository_WithTx_Call {
+	return &MockTagRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockTagRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockTagRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_WithTx_Call) Return(_a0 repository.TagRepository) *MockTagRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTagRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.TagRepository) *MockTagRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithWorkspace provides a mock function with given fields: workspaceID
+func (_m *MockTagRepository) WithWorkspace(workspaceID int) repository.TagRepository {
+	ret := _m.Called(workspaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithWorkspace")
+	}
+
+	var r0 repository.TagRepository
+	if rf, ok := ret.Get(0).(func(int) repository.TagRepository); ok {
+		r0 = rf(workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.TagRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockTagRepository_WithWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithWorkspace'
+type MockTagRepository_WithWorkspace_Call struct {
+	*mock.Call
+}
+
+// WithWorkspace is a helper method to define mock.On call
+//   - workspaceID int
+func (_e *MockTagRepository_Expecter) WithWorkspace(workspaceID interface{}) *MockTagRepository_WithWorkspace_Call {
+	return &MockTagRepository_WithWorkspace_Call{Call: _e.mock.On("WithWorkspace", workspaceID)}
+}
+
+func (_c *MockTagRepository_WithWorkspace_Call) Run(run func(workspaceID int)) *MockTagRepository_WithWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_WithWorkspace_Call) Return(_a0 repository.TagRepository) *MockTagRepository_WithWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTagRepository_WithWorkspace_Call) RunAndReturn(run func(int) repository.TagRepository) *MockTagRepository_WithWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTagRepository creates a new instance of MockTagRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTagRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTagRepository {
+	mock := &MockTagRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
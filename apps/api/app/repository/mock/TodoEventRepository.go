@@ -0,0 +1,191 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+)
+
+// MockTodoEventRepository is an autogenerated mock type for the TodoEventRepository type
+type MockTodoEventRepository struct {
+	mock.Mock
+}
+
+type MockTodoEventRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTodoEventRepository) EXPECT() *MockTodoEventRepository_Expecter {
+	return &MockTodoEventRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: events
+func (_m *MockTodoEventRepository) Create(events []models.TodoEvent) error {
+	ret := _m.Called(events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.TodoEvent) error); ok {
+		r0 = rf(events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTodoEventRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTodoEventRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - events []models.TodoEvent
+func (_e *MockTodoEventRepository_Expecter) Create(events interface{}) *MockTodoEventRepository_Create_Call {
+	return &MockTodoEventRepository_Create_Call{Call: _e.mock.On("Create", events)}
+}
+
+func (_c *MockTodoEventRepository_Create_Call) Run(run func(events []models.TodoEvent)) *MockTodoEventRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]models.TodoEvent))
+	})
+	return _c
+}
+
+func (_c *MockTodoEventRepository_Create_Call) Return(_a0 error) *MockTodoEventRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoEventRepository_Create_Call) RunAndReturn(run func([]models.TodoEvent) error) *MockTodoEventRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTodoID provides a mock function with given fields: todoID, query
+func (_m *MockTodoEventRepository) GetByTodoID(todoID int, query models.TodoEventQuery) (*models.TodoEventPage, error) {
+	ret := _m.Called(todoID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTodoID")
+	}
+
+	var r0 *models.TodoEventPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, models.TodoEventQuery) (*models.TodoEventPage, error)); ok {
+		return rf(todoID, query)
+	}
+	if rf, ok := ret.Get(0).(func(int, models.TodoEventQuery) *models.TodoEventPage); ok {
+		r0 = rf(todoID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TodoEventPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, models.TodoEventQuery) error); ok {
+		r1 = rf(todoID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoEventRepository_GetByTodoID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTodoID'
+type MockTodoEventRepository_GetByTodoID_Call struct {
+	*mock.Call
+}
+
+// GetByTodoID is a helper method to define mock.On call
+//   - todoID int
+//   - query models.TodoEventQuery
+func (_e *MockTodoEventRepository_Expecter) GetByTodoID(todoID interface{}, query interface{}) *MockTodoEventRepository_GetByTodoID_Call {
+	return &MockTodoEventRepository_GetByTodoID_Call{Call: _e.mock.On("GetByTodoID", todoID, query)}
+}
+
+func (_c *MockTodoEventRepository_GetByTodoID_Call) Run(run func(todoID int, query models.TodoEventQuery)) *MockTodoEventRepository_GetByTodoID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(models.TodoEventQuery))
+	})
+	return _c
+}
+
+func (_c *MockTodoEventRepository_GetByTodoID_Call) Return(_a0 *models.TodoEventPage, _a1 error) *MockTodoEventRepository_GetByTodoID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoEventRepository_GetByTodoID_Call) RunAndReturn(run func(int, models.TodoEventQuery) (*models.TodoEventPage, error)) *MockTodoEventRepository_GetByTodoID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockTodoEventRepository) WithTx(tx repository.DBTX) repository.TodoEventRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.TodoEventRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.TodoEventRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.TodoEventRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockTodoEventRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockTodoEventRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockTodoEventRepository_Expecter) WithTx(tx interface{}) *MockTodoEventRepository_WithTx_Call {
+	return &MockTodoEventRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockTodoEventRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockTodoEventRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockTodoEventRepository_WithTx_Call) Return(_a0 repository.TodoEventRepository) *MockTodoEventRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoEventRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.TodoEventRepository) *MockTodoEventRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTodoEventRepository creates a new instance of MockTodoEventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTodoEventRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTodoEventRepository {
+	mock := &MockTodoEventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,46 +4,1131 @@ package mock
 
 import (
 	models "api/app/models"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+
+	time "time"
 )
 
-// MockTodoRepository is an autogenerated mock type for the TodoRepository type
-type MockTodoRepository struct {
-	mock.Mock
+// MockTodoRepository is an autogenerated mock type for the TodoRepository type
+type MockTodoRepository struct {
+	mock.Mock
+}
+
+type MockTodoRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTodoRepository) EXPECT() *MockTodoRepository_Expecter {
+	return &MockTodoRepository_Expecter{mock: &_m.Mock}
+}
+
+// BumpVersionByTag provides a mock function with given fields: tagID
+func (_m *MockTodoRepository) BumpVersionByTag(tagID int) error {
+	ret := _m.Called(tagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BumpVersionByTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(tagID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTodoRepository_BumpVersionByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpVersionByTag'
+type MockTodoRepository_BumpVersionByTag_Call struct {
+	*mock.Call
+}
+
+// BumpVersionByTag is a helper method to define mock.On call
+//   - tagID int
+func (_e *MockTodoRepository_Expecter) BumpVersionByTag(tagID interface{}) *MockTodoRepository_BumpVersionByTag_Call {
+	return &MockTodoRepository_BumpVersionByTag_Call{Call: _e.mock.On("BumpVersionByTag", tagID)}
+}
+
+func (_c *MockTodoRepository_BumpVersionByTag_Call) Run(run func(tagID int)) *MockTodoRepository_BumpVersionByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_BumpVersionByTag_Call) Return(_a0 error) *MockTodoRepository_BumpVersionByTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_BumpVersionByTag_Call) RunAndReturn(run func(int) error) *MockTodoRepository_BumpVersionByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDueReminders provides a mock function with given fields: now, leadTime, limit
+func (_m *MockTodoRepository) ClaimDueReminders(now time.Time, leadTime time.Duration, limit int) ([]models.Todo, error) {
+	ret := _m.Called(now, leadTime, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueReminders")
+	}
+
+	var r0 []models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Duration, int) ([]models.Todo, error)); ok {
+		return rf(now, leadTime, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Duration, int) []models.Todo); ok {
+		r0 = rf(now, leadTime, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Duration, int) error); ok {
+		r1 = rf(now, leadTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_ClaimDueReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueReminders'
+type MockTodoRepository_ClaimDueReminders_Call struct {
+	*mock.Call
+}
+
+// ClaimDueReminders is a helper method to define mock.On call
+//   - now time.Time
+//   - leadTime time.Duration
+//   - limit int
+func (_e *MockTodoRepository_Expecter) ClaimDueReminders(now interface{}, leadTime interface{}, limit interface{}) *MockTodoRepository_ClaimDueReminders_Call {
+	return &MockTodoRepository_ClaimDueReminders_Call{Call: _e.mock.On("ClaimDueReminders", now, leadTime, limit)}
+}
+
+func (_c *MockTodoRepository_ClaimDueReminders_Call) Run(run func(now time.Time, leadTime time.Duration, limit int)) *MockTodoRepository_ClaimDueReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(time.Duration), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_ClaimDueReminders_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_ClaimDueReminders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_ClaimDueReminders_Call) RunAndReturn(run func(time.Time, time.Duration, int) ([]models.Todo, error)) *MockTodoRepository_ClaimDueReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteDescendants provides a mock function with given fields: id
+func (_m *MockTodoRepository) CompleteDescendants(id int) ([]int, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteDescendants")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]int, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) []int); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_CompleteDescendants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteDescendants'
+type MockTodoRepository_CompleteDescendants_Call struct {
+	*mock.Call
+}
+
+// CompleteDescendants is a helper method to define mock.On call
+//   - id int
+func (_e *MockTodoRepository_Expecter) CompleteDescendants(id interface{}) *MockTodoRepository_CompleteDescendants_Call {
+	return &MockTodoRepository_CompleteDescendants_Call{Call: _e.mock.On("CompleteDescendants", id)}
+}
+
+func (_c *MockTodoRepository_CompleteDescendants_Call) Run(run func(id int)) *MockTodoRepository_CompleteDescendants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_CompleteDescendants_Call) Return(_a0 []int, _a1 error) *MockTodoRepository_CompleteDescendants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_CompleteDescendants_Call) RunAndReturn(run func(int) ([]int, error)) *MockTodoRepository_CompleteDescendants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOpenDescendants provides a mock function with given fields: id
+func (_m *MockTodoRepository) CountOpenDescendants(id int) (int, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOpenDescendants")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_CountOpenDescendants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOpenDescendants'
+type MockTodoRepository_CountOpenDescendants_Call struct {
+	*mock.Call
+}
+
+// CountOpenDescendants is a helper method to define mock.On call
+//   - id int
+func (_e *MockTodoRepository_Expecter) CountOpenDescendants(id interface{}) *MockTodoRepository_CountOpenDescendants_Call {
+	return &MockTodoRepository_CountOpenDescendants_Call{Call: _e.mock.On("CountOpenDescendants", id)}
+}
+
+func (_c *MockTodoRepository_CountOpenDescendants_Call) Run(run func(id int)) *MockTodoRepository_CountOpenDescendants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_CountOpenDescendants_Call) Return(_a0 int, _a1 error) *MockTodoRepository_CountOpenDescendants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_CountOpenDescendants_Call) RunAndReturn(run func(int) (int, error)) *MockTodoRepository_CountOpenDescendants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: todo
+func (_m *MockTodoRepository) Create(todo *models.Todo) (*models.Todo, error) {
+	ret := _m.Called(todo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.Todo) (*models.Todo, error)); ok {
+		return rf(todo)
+	}
+	if rf, ok := ret.Get(0).(func(*models.Todo) *models.Todo); ok {
+		r0 = rf(todo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.Todo) error); ok {
+		r1 = rf(todo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTodoRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - todo *models.Todo
+func (_e *MockTodoRepository_Expecter) Create(todo interface{}) *MockTodoRepository_Create_Call {
+	return &MockTodoRepository_Create_Call{Call: _e.mock.On("Create", todo)}
+}
+
+func (_c *MockTodoRepository_Create_Call) Run(run func(todo *models.Todo)) *MockTodoRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Todo))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_Create_Call) Return(_a0 *models.Todo, _a1 error) *MockTodoRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_Create_Call) RunAndReturn(run func(*models.Todo) (*models.Todo, error)) *MockTodoRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function with given fields: todos
+func (_m *MockTodoRepository) CreateBatch(todos []*models.Todo) ([]models.Todo, error) {
+	ret := _m.Called(todos)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 []models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*models.Todo) ([]models.Todo, error)); ok {
+		return rf(todos)
+	}
+	if rf, ok := ret.Get(0).(func([]*models.Todo) []models.Todo); ok {
+		r0 = rf(todos)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*models.Todo) error); ok {
+		r1 = rf(todos)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockTodoRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - todos []*models.Todo
+func (_e *MockTodoRepository_Expecter) CreateBatch(todos interface{}) *MockTodoRepository_CreateBatch_Call {
+	return &MockTodoRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", todos)}
+}
+
+func (_c *MockTodoRepository_CreateBatch_Call) Run(run func(todos []*models.Todo)) *MockTodoRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]*models.Todo))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_CreateBatch_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_CreateBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_CreateBatch_Call) RunAndReturn(run func([]*models.Todo) ([]models.Todo, error)) *MockTodoRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockTodoRepository) Delete(id int) ([]models.Todo, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 []models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.Todo, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.Todo); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockTodoRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id int
+func (_e *MockTodoRepository_Expecter) Delete(id interface{}) *MockTodoRepository_Delete_Call {
+	return &MockTodoRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockTodoRepository_Delete_Call) Run(run func(id int)) *MockTodoRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_Delete_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_Delete_Call) RunAndReturn(run func(int) ([]models.Todo, error)) *MockTodoRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByProject provides a mock function with given fields: projectID
+func (_m *MockTodoRepository) DeleteByProject(projectID int) ([]models.Todo, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByProject")
+	}
+
+	var r0 []models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.Todo, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.Todo); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_DeleteByProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByProject'
+type MockTodoRepository_DeleteByProject_Call struct {
+	*mock.Call
+}
+
+// DeleteByProject is a helper method to define mock.On call
+//   - projectID int
+func (_e *MockTodoRepository_Expecter) DeleteByProject(projectID interface{}) *MockTodoRepository_DeleteByProject_Call {
+	return &MockTodoRepository_DeleteByProject_Call{Call: _e.mock.On("DeleteByProject", projectID)}
+}
+
+func (_c *MockTodoRepository_DeleteByProject_Call) Run(run func(projectID int)) *MockTodoRepository_DeleteByProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_DeleteByProject_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_DeleteByProject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_DeleteByProject_Call) RunAndReturn(run func(int) ([]models.Todo, error)) *MockTodoRepository_DeleteByProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function with given fields: ctx, query, batchSize, fn
+func (_m *MockTodoRepository) Export(ctx context.Context, query models.TodoListQuery, batchSize int, fn func(repository.DBTX, []models.Todo) error) error {
+	ret := _m.Called(ctx, query, batchSize, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.TodoListQuery, int, func(repository.DBTX, []models.Todo) error) error); ok {
+		r0 = rf(ctx, query, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTodoRepository_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockTodoRepository_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query models.TodoListQuery
+//   - batchSize int
+//   - fn func(repository.DBTX , []models.Todo) error
+func (_e *MockTodoRepository_Expecter) Export(ctx interface{}, query interface{}, batchSize interface{}, fn interface{}) *MockTodoRepository_Export_Call {
+	return &MockTodoRepository_Export_Call{Call: _e.mock.On("Export", ctx, query, batchSize, fn)}
+}
+
+func (_c *MockTodoRepository_Export_Call) Run(run func(ctx context.Context, query models.TodoListQuery, batchSize int, fn func(repository.DBTX, []models.Todo) error)) *MockTodoRepository_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.TodoListQuery), args[2].(int), args[3].(func(repository.DBTX, []models.Todo) error))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_Export_Call) Return(_a0 error) *MockTodoRepository_Export_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_Export_Call) RunAndReturn(run func(context.Context, models.TodoListQuery, int, func(repository.DBTX, []models.Todo) error) error) *MockTodoRepository_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: query
+func (_m *MockTodoRepository) GetAll(query models.TodoListQuery) (*models.TodoPage, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 *models.TodoPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.TodoListQuery) (*models.TodoPage, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(models.TodoListQuery) *models.TodoPage); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TodoPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.TodoListQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockTodoRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - query models.TodoListQuery
+func (_e *MockTodoRepository_Expecter) GetAll(query interface{}) *MockTodoRepository_GetAll_Call {
+	return &MockTodoRepository_GetAll_Call{Call: _e.mock.On("GetAll", query)}
+}
+
+func (_c *MockTodoRepository_GetAll_Call) Run(run func(query models.TodoListQuery)) *MockTodoRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.TodoListQuery))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetAll_Call) Return(_a0 *models.TodoPage, _a1 error) *MockTodoRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetAll_Call) RunAndReturn(run func(models.TodoListQuery) (*models.TodoPage, error)) *MockTodoRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockTodoRepository) GetByID(id int) (*models.Todo, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*models.Todo, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *models.Todo); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockTodoRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id int
+func (_e *MockTodoRepository_Expecter) GetByID(id interface{}) *MockTodoRepository_GetByID_Call {
+	return &MockTodoRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockTodoRepository_GetByID_Call) Run(run func(id int)) *MockTodoRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetByID_Call) Return(_a0 *models.Todo, _a1 error) *MockTodoRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetByID_Call) RunAndReturn(run func(int) (*models.Todo, error)) *MockTodoRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDForUpdate provides a mock function with given fields: id
+func (_m *MockTodoRepository) GetByIDForUpdate(id int) (*models.Todo, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 *models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*models.Todo, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *models.Todo); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDForUpdate'
+type MockTodoRepository_GetByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByIDForUpdate is a helper method to define mock.On call
+//   - id int
+func (_e *MockTodoRepository_Expecter) GetByIDForUpdate(id interface{}) *MockTodoRepository_GetByIDForUpdate_Call {
+	return &MockTodoRepository_GetByIDForUpdate_Call{Call: _e.mock.On("GetByIDForUpdate", id)}
+}
+
+func (_c *MockTodoRepository_GetByIDForUpdate_Call) Run(run func(id int)) *MockTodoRepository_GetByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetByIDForUpdate_Call) Return(_a0 *models.Todo, _a1 error) *MockTodoRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetByIDForUpdate_Call) RunAndReturn(run func(int) (*models.Todo, error)) *MockTodoRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChildren provides a mock function with given fields: parentID
+func (_m *MockTodoRepository) GetChildren(parentID int) ([]models.Todo, error) {
+	ret := _m.Called(parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChildren")
+	}
+
+	var r0 []models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.Todo, error)); ok {
+		return rf(parentID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.Todo); ok {
+		r0 = rf(parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChildren'
+type MockTodoRepository_GetChildren_Call struct {
+	*mock.Call
+}
+
+// GetChildren is a helper method to define mock.On call
+//   - parentID int
+func (_e *MockTodoRepository_Expecter) GetChildren(parentID interface{}) *MockTodoRepository_GetChildren_Call {
+	return &MockTodoRepository_GetChildren_Call{Call: _e.mock.On("GetChildren", parentID)}
+}
+
+func (_c *MockTodoRepository_GetChildren_Call) Run(run func(parentID int)) *MockTodoRepository_GetChildren_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetChildren_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_GetChildren_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetChildren_Call) RunAndReturn(run func(int) ([]models.Todo, error)) *MockTodoRepository_GetChildren_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedByID provides a mock function with given fields: id
+func (_m *MockTodoRepository) GetDeletedByID(id int) (*models.Todo, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedByID")
+	}
+
+	var r0 *models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*models.Todo, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *models.Todo); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetDeletedByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedByID'
+type MockTodoRepository_GetDeletedByID_Call struct {
+	*mock.Call
+}
+
+// GetDeletedByID is a helper method to define mock.On call
+//   - id int
+func (_e *MockTodoRepository_Expecter) GetDeletedByID(id interface{}) *MockTodoRepository_GetDeletedByID_Call {
+	return &MockTodoRepository_GetDeletedByID_Call{Call: _e.mock.On("GetDeletedByID", id)}
+}
+
+func (_c *MockTodoRepository_GetDeletedByID_Call) Run(run func(id int)) *MockTodoRepository_GetDeletedByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetDeletedByID_Call) Return(_a0 *models.Todo, _a1 error) *MockTodoRepository_GetDeletedByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetDeletedByID_Call) RunAndReturn(run func(int) (*models.Todo, error)) *MockTodoRepository_GetDeletedByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDescendants provides a mock function with given fields: rootIDs
+func (_m *MockTodoRepository) GetDescendants(rootIDs []int) ([]models.Todo, error) {
+	ret := _m.Called(rootIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDescendants")
+	}
+
+	var r0 []models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]models.Todo, error)); ok {
+		return rf(rootIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []models.Todo); ok {
+		r0 = rf(rootIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(rootIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetDescendants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDescendants'
+type MockTodoRepository_GetDescendants_Call struct {
+	*mock.Call
+}
+
+// GetDescendants is a helper method to define mock.On call
+//   - rootIDs []int
+func (_e *MockTodoRepository_Expecter) GetDescendants(rootIDs interface{}) *MockTodoRepository_GetDescendants_Call {
+	return &MockTodoRepository_GetDescendants_Call{Call: _e.mock.On("GetDescendants", rootIDs)}
+}
+
+func (_c *MockTodoRepository_GetDescendants_Call) Run(run func(rootIDs []int)) *MockTodoRepository_GetDescendants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetDescendants_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_GetDescendants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetDescendants_Call) RunAndReturn(run func([]int) ([]models.Todo, error)) *MockTodoRepository_GetDescendants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDescendantsWithDeleted provides a mock function with given fields: id
+func (_m *MockTodoRepository) GetDescendantsWithDeleted(id int) ([]models.Todo, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDescendantsWithDeleted")
+	}
+
+	var r0 []models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.Todo, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.Todo); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetDescendantsWithDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDescendantsWithDeleted'
+type MockTodoRepository_GetDescendantsWithDeleted_Call struct {
+	*mock.Call
+}
+
+// GetDescendantsWithDeleted is a helper method to define mock.On call
+//   - id int
+func (_e *MockTodoRepository_Expecter) GetDescendantsWithDeleted(id interface{}) *MockTodoRepository_GetDescendantsWithDeleted_Call {
+	return &MockTodoRepository_GetDescendantsWithDeleted_Call{Call: _e.mock.On("GetDescendantsWithDeleted", id)}
+}
+
+func (_c *MockTodoRepository_GetDescendantsWithDeleted_Call) Run(run func(id int)) *MockTodoRepository_GetDescendantsWithDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetDescendantsWithDeleted_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_GetDescendantsWithDeleted_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetDescendantsWithDeleted_Call) RunAndReturn(run func(int) ([]models.Todo, error)) *MockTodoRepository_GetDescendantsWithDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProjectDescendants provides a mock function with given fields: projectID
+func (_m *MockTodoRepository) GetProjectDescendants(projectID int) ([]models.Todo, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectDescendants")
+	}
+
+	var r0 []models.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.Todo, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.Todo); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetProjectDescendants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectDescendants'
+type MockTodoRepository_GetProjectDescendants_Call struct {
+	*mock.Call
+}
+
+// GetProjectDescendants is a helper method to define mock.On call
+//   - projectID int
+func (_e *MockTodoRepository_Expecter) GetProjectDescendants(projectID interface{}) *MockTodoRepository_GetProjectDescendants_Call {
+	return &MockTodoRepository_GetProjectDescendants_Call{Call: _e.mock.On("GetProjectDescendants", projectID)}
+}
+
+func (_c *MockTodoRepository_GetProjectDescendants_Call) Run(run func(projectID int)) *MockTodoRepository_GetProjectDescendants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetProjectDescendants_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_GetProjectDescendants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetProjectDescendants_Call) RunAndReturn(run func(int) ([]models.Todo, error)) *MockTodoRepository_GetProjectDescendants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrash provides a mock function with given fields: query
+func (_m *MockTodoRepository) GetTrash(query models.TrashListQuery) (*models.TrashPage, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 *models.TrashPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.TrashListQuery) (*models.TrashPage, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(models.TrashListQuery) *models.TrashPage); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TrashPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.TrashListQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_GetTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrash'
+type MockTodoRepository_GetTrash_Call struct {
+	*mock.Call
+}
+
+// GetTrash is a helper method to define mock.On call
+//   - query models.TrashListQuery
+func (_e *MockTodoRepository_Expecter) GetTrash(query interface{}) *MockTodoRepository_GetTrash_Call {
+	return &MockTodoRepository_GetTrash_Call{Call: _e.mock.On("GetTrash", query)}
 }
 
-type MockTodoRepository_Expecter struct {
-	mock *mock.Mock
+func (_c *MockTodoRepository_GetTrash_Call) Run(run func(query models.TrashListQuery)) *MockTodoRepository_GetTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.TrashListQuery))
+	})
+	return _c
 }
 
-func (_m *MockTodoRepository) EXPECT() *MockTodoRepository_Expecter {
-	return &MockTodoRepository_Expecter{mock: &_m.Mock}
+func (_c *MockTodoRepository_GetTrash_Call) Return(_a0 *models.TrashPage, _a1 error) *MockTodoRepository_GetTrash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetTrash_Call) RunAndReturn(run func(models.TrashListQuery) (*models.TrashPage, error)) *MockTodoRepository_GetTrash_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-// Create provides a mock function with given fields: title, description, priority
-func (_m *MockTodoRepository) Create(title string, description string, priority string) (*models.Todo, error) {
-	ret := _m.Called(title, description, priority)
+// IsDescendant provides a mock function with given fields: ancestorID, id
+func (_m *MockTodoRepository) IsDescendant(ancestorID int, id int) (bool, error) {
+	ret := _m.Called(ancestorID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for IsDescendant")
 	}
 
-	var r0 *models.Todo
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (bool, error)); ok {
+		return rf(ancestorID, id)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) bool); ok {
+		r0 = rf(ancestorID, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(ancestorID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_IsDescendant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsDescendant'
+type MockTodoRepository_IsDescendant_Call struct {
+	*mock.Call
+}
+
+// IsDescendant is a helper method to define mock.On call
+//   - ancestorID int
+//   - id int
+func (_e *MockTodoRepository_Expecter) IsDescendant(ancestorID interface{}, id interface{}) *MockTodoRepository_IsDescendant_Call {
+	return &MockTodoRepository_IsDescendant_Call{Call: _e.mock.On("IsDescendant", ancestorID, id)}
+}
+
+func (_c *MockTodoRepository_IsDescendant_Call) Run(run func(ancestorID int, id int)) *MockTodoRepository_IsDescendant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_IsDescendant_Call) Return(_a0 bool, _a1 error) *MockTodoRepository_IsDescendant_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_IsDescendant_Call) RunAndReturn(run func(int, int) (bool, error)) *MockTodoRepository_IsDescendant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveProjectTodosToInbox provides a mock function with given fields: projectID
+func (_m *MockTodoRepository) MoveProjectTodosToInbox(projectID int) ([]int, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveProjectTodosToInbox")
+	}
+
+	var r0 []int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (*models.Todo, error)); ok {
-		return rf(title, description, priority)
+	if rf, ok := ret.Get(0).(func(int) ([]int, error)); ok {
+		return rf(projectID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) *models.Todo); ok {
-		r0 = rf(title, description, priority)
+	if rf, ok := ret.Get(0).(func(int) []int); ok {
+		r0 = rf(projectID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Todo)
+			r0 = ret.Get(0).([]int)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(title, description, priority)
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(projectID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,42 +1136,40 @@ func (_m *MockTodoRepository) Create(title string, description string, priority
 	return r0, r1
 }
 
-// MockTodoRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockTodoRepository_Create_Call struct {
+// MockTodoRepository_MoveProjectTodosToInbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveProjectTodosToInbox'
+type MockTodoRepository_MoveProjectTodosToInbox_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
-//   - title string
-//   - description string
-//   - priority string
-func (_e *MockTodoRepository_Expecter) Create(title interface{}, description interface{}, priority interface{}) *MockTodoRepository_Create_Call {
-	return &MockTodoRepository_Create_Call{Call: _e.mock.On("Create", title, description, priority)}
+// MoveProjectTodosToInbox is a helper method to define mock.On call
+//   - projectID int
+func (_e *MockTodoRepository_Expecter) MoveProjectTodosToInbox(projectID interface{}) *MockTodoRepository_MoveProjectTodosToInbox_Call {
+	return &MockTodoRepository_MoveProjectTodosToInbox_Call{Call: _e.mock.On("MoveProjectTodosToInbox", projectID)}
 }
 
-func (_c *MockTodoRepository_Create_Call) Run(run func(title string, description string, priority string)) *MockTodoRepository_Create_Call {
+func (_c *MockTodoRepository_MoveProjectTodosToInbox_Call) Run(run func(projectID int)) *MockTodoRepository_MoveProjectTodosToInbox_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(int))
 	})
 	return _c
 }
 
-func (_c *MockTodoRepository_Create_Call) Return(_a0 *models.Todo, _a1 error) *MockTodoRepository_Create_Call {
+func (_c *MockTodoRepository_MoveProjectTodosToInbox_Call) Return(_a0 []int, _a1 error) *MockTodoRepository_MoveProjectTodosToInbox_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTodoRepository_Create_Call) RunAndReturn(run func(string, string, string) (*models.Todo, error)) *MockTodoRepository_Create_Call {
+func (_c *MockTodoRepository_MoveProjectTodosToInbox_Call) RunAndReturn(run func(int) ([]int, error)) *MockTodoRepository_MoveProjectTodosToInbox_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *MockTodoRepository) Delete(id int) error {
+// Purge provides a mock function with given fields: id
+func (_m *MockTodoRepository) Purge(id int) error {
 	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for Purge")
 	}
 
 	var r0 error
@@ -99,57 +1182,55 @@ func (_m *MockTodoRepository) Delete(id int) error {
 	return r0
 }
 
-// MockTodoRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockTodoRepository_Delete_Call struct {
+// MockTodoRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockTodoRepository_Purge_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// Purge is a helper method to define mock.On call
 //   - id int
-func (_e *MockTodoRepository_Expecter) Delete(id interface{}) *MockTodoRepository_Delete_Call {
-	return &MockTodoRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+func (_e *MockTodoRepository_Expecter) Purge(id interface{}) *MockTodoRepository_Purge_Call {
+	return &MockTodoRepository_Purge_Call{Call: _e.mock.On("Purge", id)}
 }
 
-func (_c *MockTodoRepository_Delete_Call) Run(run func(id int)) *MockTodoRepository_Delete_Call {
+func (_c *MockTodoRepository_Purge_Call) Run(run func(id int)) *MockTodoRepository_Purge_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(int))
 	})
 	return _c
 }
 
-func (_c *MockTodoRepository_Delete_Call) Return(_a0 error) *MockTodoRepository_Delete_Call {
+func (_c *MockTodoRepository_Purge_Call) Return(_a0 error) *MockTodoRepository_Purge_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockTodoRepository_Delete_Call) RunAndReturn(run func(int) error) *MockTodoRepository_Delete_Call {
+func (_c *MockTodoRepository_Purge_Call) RunAndReturn(run func(int) error) *MockTodoRepository_Purge_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAll provides a mock function with no fields
-func (_m *MockTodoRepository) GetAll() ([]models.Todo, error) {
-	ret := _m.Called()
+// PurgeDeletedBefore provides a mock function with given fields: cutoff
+func (_m *MockTodoRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	ret := _m.Called(cutoff)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for PurgeDeletedBefore")
 	}
 
-	var r0 []models.Todo
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]models.Todo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(cutoff)
 	}
-	if rf, ok := ret.Get(0).(func() []models.Todo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(cutoff)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Todo)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(cutoff)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -157,51 +1238,98 @@ func (_m *MockTodoRepository) GetAll() ([]models.Todo, error) {
 	return r0, r1
 }
 
-// MockTodoRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type MockTodoRepository_GetAll_Call struct {
+// MockTodoRepository_PurgeDeletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedBefore'
+type MockTodoRepository_PurgeDeletedBefore_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
-func (_e *MockTodoRepository_Expecter) GetAll() *MockTodoRepository_GetAll_Call {
-	return &MockTodoRepository_GetAll_Call{Call: _e.mock.On("GetAll")}
+// PurgeDeletedBefore is a helper method to define mock.On call
+//   - cutoff time.Time
+func (_e *MockTodoRepository_Expecter) PurgeDeletedBefore(cutoff interface{}) *MockTodoRepository_PurgeDeletedBefore_Call {
+	return &MockTodoRepository_PurgeDeletedBefore_Call{Call: _e.mock.On("PurgeDeletedBefore", cutoff)}
 }
 
-func (_c *MockTodoRepository_GetAll_Call) Run(run func()) *MockTodoRepository_GetAll_Call {
+func (_c *MockTodoRepository_PurgeDeletedBefore_Call) Run(run func(cutoff time.Time)) *MockTodoRepository_PurgeDeletedBefore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockTodoRepository_GetAll_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_GetAll_Call {
+func (_c *MockTodoRepository_PurgeDeletedBefore_Call) Return(_a0 int64, _a1 error) *MockTodoRepository_PurgeDeletedBefore_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTodoRepository_GetAll_Call) RunAndReturn(run func() ([]models.Todo, error)) *MockTodoRepository_GetAll_Call {
+func (_c *MockTodoRepository_PurgeDeletedBefore_Call) RunAndReturn(run func(time.Time) (int64, error)) *MockTodoRepository_PurgeDeletedBefore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function with given fields: id
-func (_m *MockTodoRepository) GetByID(id int) (*models.Todo, error) {
+// ReleaseReminder provides a mock function with given fields: id
+func (_m *MockTodoRepository) ReleaseReminder(id int) error {
 	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for ReleaseReminder")
 	}
 
-	var r0 *models.Todo
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTodoRepository_ReleaseReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseReminder'
+type MockTodoRepository_ReleaseReminder_Call struct {
+	*mock.Call
+}
+
+// ReleaseReminder is a helper method to define mock.On call
+//   - id int
+func (_e *MockTodoRepository_Expecter) ReleaseReminder(id interface{}) *MockTodoRepository_ReleaseReminder_Call {
+	return &MockTodoRepository_ReleaseReminder_Call{Call: _e.mock.On("ReleaseReminder", id)}
+}
+
+func (_c *MockTodoRepository_ReleaseReminder_Call) Run(run func(id int)) *MockTodoRepository_ReleaseReminder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_ReleaseReminder_Call) Return(_a0 error) *MockTodoRepository_ReleaseReminder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_ReleaseReminder_Call) RunAndReturn(run func(int) error) *MockTodoRepository_ReleaseReminder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: id
+func (_m *MockTodoRepository) Restore(id int) ([]models.Todo, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 []models.Todo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*models.Todo, error)); ok {
+	if rf, ok := ret.Get(0).(func(int) ([]models.Todo, error)); ok {
 		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(int) *models.Todo); ok {
+	if rf, ok := ret.Get(0).(func(int) []models.Todo); ok {
 		r0 = rf(id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Todo)
+			r0 = ret.Get(0).([]models.Todo)
 		}
 	}
 
@@ -214,37 +1342,142 @@ func (_m *MockTodoRepository) GetByID(id int) (*models.Todo, error) {
 	return r0, r1
 }
 
-// MockTodoRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockTodoRepository_GetByID_Call struct {
+// MockTodoRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockTodoRepository_Restore_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// Restore is a helper method to define mock.On call
 //   - id int
-func (_e *MockTodoRepository_Expecter) GetByID(id interface{}) *MockTodoRepository_GetByID_Call {
-	return &MockTodoRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+func (_e *MockTodoRepository_Expecter) Restore(id interface{}) *MockTodoRepository_Restore_Call {
+	return &MockTodoRepository_Restore_Call{Call: _e.mock.On("Restore", id)}
 }
 
-func (_c *MockTodoRepository_GetByID_Call) Run(run func(id int)) *MockTodoRepository_GetByID_Call {
+func (_c *MockTodoRepository_Restore_Call) Run(run func(id int)) *MockTodoRepository_Restore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(int))
 	})
 	return _c
 }
 
-func (_c *MockTodoRepository_GetByID_Call) Return(_a0 *models.Todo, _a1 error) *MockTodoRepository_GetByID_Call {
+func (_c *MockTodoRepository_Restore_Call) Return(_a0 []models.Todo, _a1 error) *MockTodoRepository_Restore_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTodoRepository_GetByID_Call) RunAndReturn(run func(int) (*models.Todo, error)) *MockTodoRepository_GetByID_Call {
+func (_c *MockTodoRepository_Restore_Call) RunAndReturn(run func(int) ([]models.Todo, error)) *MockTodoRepository_Restore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function with given fields: id, title, description, priority, completed
-func (_m *MockTodoRepository) Update(id int, title string, description string, priority string, completed *bool) (*models.Todo, error) {
-	ret := _m.Called(id, title, description, priority, completed)
+// Search provides a mock function with given fields: query
+func (_m *MockTodoRepository) Search(query models.TodoSearchQuery) ([]models.TodoSearchResult, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []models.TodoSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.TodoSearchQuery) ([]models.TodoSearchResult, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(models.TodoSearchQuery) []models.TodoSearchResult); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TodoSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.TodoSearchQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTodoRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockTodoRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - query models.TodoSearchQuery
+func (_e *MockTodoRepository_Expecter) Search(query interface{}) *MockTodoRepository_Search_Call {
+	return &MockTodoRepository_Search_Call{Call: _e.mock.On("Search", query)}
+}
+
+func (_c *MockTodoRepository_Search_Call) Run(run func(query models.TodoSearchQuery)) *MockTodoRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.TodoSearchQuery))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_Search_Call) Return(_a0 []models.TodoSearchResult, _a1 error) *MockTodoRepository_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_Search_Call) RunAndReturn(run func(models.TodoSearchQuery) ([]models.TodoSearchResult, error)) *MockTodoRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNextOccurrence provides a mock function with given fields: id, nextID
+func (_m *MockTodoRepository) SetNextOccurrence(id int, nextID int) error {
+	ret := _m.Called(id, nextID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNextOccurrence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(id, nextID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTodoRepository_SetNextOccurrence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNextOccurrence'
+type MockTodoRepository_SetNextOccurrence_Call struct {
+	*mock.Call
+}
+
+// SetNextOccurrence is a helper method to define mock.On call
+//   - id int
+//   - nextID int
+func (_e *MockTodoRepository_Expecter) SetNextOccurrence(id interface{}, nextID interface{}) *MockTodoRepository_SetNextOccurrence_Call {
+	return &MockTodoRepository_SetNextOccurrence_Call{Call: _e.mock.On("SetNextOccurrence", id, nextID)}
+}
+
+func (_c *MockTodoRepository_SetNextOccurrence_Call) Run(run func(id int, nextID int)) *MockTodoRepository_SetNextOccurrence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_SetNextOccurrence_Call) Return(_a0 error) *MockTodoRepository_SetNextOccurrence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_SetNextOccurrence_Call) RunAndReturn(run func(int, int) error) *MockTodoRepository_SetNextOccurrence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: id, update
+func (_m *MockTodoRepository) Update(id int, update models.TodoUpdate) (*models.Todo, error) {
+	ret := _m.Called(id, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -252,19 +1485,19 @@ func (_m *MockTodoRepository) Update(id int, title string, description string, p
 
 	var r0 *models.Todo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, string, string, *bool) (*models.Todo, error)); ok {
-		return rf(id, title, description, priority, completed)
+	if rf, ok := ret.Get(0).(func(int, models.TodoUpdate) (*models.Todo, error)); ok {
+		return rf(id, update)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, string, string, *bool) *models.Todo); ok {
-		r0 = rf(id, title, description, priority, completed)
+	if rf, ok := ret.Get(0).(func(int, models.TodoUpdate) *models.Todo); ok {
+		r0 = rf(id, update)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Todo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, string, string, *bool) error); ok {
-		r1 = rf(id, title, description, priority, completed)
+	if rf, ok := ret.Get(1).(func(int, models.TodoUpdate) error); ok {
+		r1 = rf(id, update)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -279,17 +1512,14 @@ type MockTodoRepository_Update_Call struct {
 
 // Update is a helper method to define mock.On call
 //   - id int
-//   - title string
-//   - description string
-//   - priority string
-//   - completed *bool
-func (_e *MockTodoRepository_Expecter) Update(id interface{}, title interface{}, description interface{}, priority interface{}, completed interface{}) *MockTodoRepository_Update_Call {
-	return &MockTodoRepository_Update_Call{Call: _e.mock.On("Update", id, title, description, priority, completed)}
+//   - update models.TodoUpdate
+func (_e *MockTodoRepository_Expecter) Update(id interface{}, update interface{}) *MockTodoRepository_Update_Call {
+	return &MockTodoRepository_Update_Call{Call: _e.mock.On("Update", id, update)}
 }
 
-func (_c *MockTodoRepository_Update_Call) Run(run func(id int, title string, description string, priority string, completed *bool)) *MockTodoRepository_Update_Call {
+func (_c *MockTodoRepository_Update_Call) Run(run func(id int, update models.TodoUpdate)) *MockTodoRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int), args[1].(string), args[2].(string), args[3].(string), args[4].(*bool))
+		run(args[0].(int), args[1].(models.TodoUpdate))
 	})
 	return _c
 }
@@ -299,7 +1529,103 @@ func (_c *MockTodoRepository_Update_Call) Return(_a0 *models.Todo, _a1 error) *M
 	return _c
 }
 
-func (_c *MockTodoRepository_Update_Call) RunAndReturn(run func(int, string, string, string, *bool) (*models.Todo, error)) *MockTodoRepository_Update_Call {
+func (_c *MockTodoRepository_Update_Call) RunAndReturn(run func(int, models.TodoUpdate) (*models.Todo, error)) *MockTodoRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockTodoRepository) WithTx(tx repository.DBTX) repository.TodoRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.TodoRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.TodoRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.TodoRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockTodoRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockTodoRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockTodoRepository_Expecter) WithTx(tx interface{}) *MockTodoRepository_WithTx_Call {
+	return &MockTodoRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockTodoRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockTodoRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_WithTx_Call) Return(_a0 repository.TodoRepository) *MockTodoRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.TodoRepository) *MockTodoRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithWorkspace provides a mock function with given fields: workspaceID
+func (_m *MockTodoRepository) WithWorkspace(workspaceID int) repository.TodoRepository {
+	ret := _m.Called(workspaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithWorkspace")
+	}
+
+	var r0 repository.TodoRepository
+	if rf, ok := ret.Get(0).(func(int) repository.TodoRepository); ok {
+		r0 = rf(workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.TodoRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockTodoRepository_WithWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithWorkspace'
+type MockTodoRepository_WithWorkspace_Call struct {
+	*mock.Call
+}
+
+// WithWorkspace is a helper method to define mock.On call
+//   - workspaceID int
+func (_e *MockTodoRepository_Expecter) WithWorkspace(workspaceID interface{}) *MockTodoRepository_WithWorkspace_Call {
+	return &MockTodoRepository_WithWorkspace_Call{Call: _e.mock.On("WithWorkspace", workspaceID)}
+}
+
+func (_c *MockTodoRepository_WithWorkspace_Call) Run(run func(workspaceID int)) *MockTodoRepository_WithWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_WithWorkspace_Call) Return(_a0 repository.TodoRepository) *MockTodoRepository_WithWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_WithWorkspace_Call) RunAndReturn(run func(int) repository.TodoRepository) *MockTodoRepository_WithWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,253 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+
+	time "time"
+)
+
+// MockUserIdentityRepository is an autogenerated mock type for the UserIdentityRepository type
+type MockUserIdentityRepository struct {
+	mock.Mock
+}
+
+type MockUserIdentityRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserIdentityRepository) EXPECT() *MockUserIdentityRepository_Expecter {
+	return &MockUserIdentityRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: identity
+func (_m *MockUserIdentityRepository) Create(identity *models.UserIdentity) (*models.UserIdentity, error) {
+	ret := _m.Called(identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.UserIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.UserIdentity) (*models.UserIdentity, error)); ok {
+		return rf(identity)
+	}
+	if rf, ok := ret.Get(0).(func(*models.UserIdentity) *models.UserIdentity); ok {
+		r0 = rf(identity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.UserIdentity) error); ok {
+		r1 = rf(identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserIdentityRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUserIdentityRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - identity *models.UserIdentity
+func (_e *MockUserIdentityRepository_Expecter) Create(identity interface{}) *MockUserIdentityRepository_Create_Call {
+	return &MockUserIdentityRepository_Create_Call{Call: _e.mock.On("Create", identity)}
+}
+
+func (_c *MockUserIdentityRepository_Create_Call) Run(run func(identity *models.UserIdentity)) *MockUserIdentityRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.UserIdentity))
+	})
+	return _c
+}
+
+func (_c *MockUserIdentityRepository_Create_Call) Return(_a0 *models.UserIdentity, _a1 error) *MockUserIdentityRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserIdentityRepository_Create_Call) RunAndReturn(run func(*models.UserIdentity) (*models.UserIdentity, error)) *MockUserIdentityRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIssuerAndSubject provides a mock function with given fields: issuer, subject
+func (_m *MockUserIdentityRepository) GetByIssuerAndSubject(issuer string, subject string) (*models.UserIdentity, error) {
+	ret := _m.Called(issuer, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIssuerAndSubject")
+	}
+
+	var r0 *models.UserIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*models.UserIdentity, error)); ok {
+		return rf(issuer, subject)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *models.UserIdentity); ok {
+		r0 = rf(issuer, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(issuer, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserIdentityRepository_GetByIssuerAndSubject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIssuerAndSubject'
+type MockUserIdentityRepository_GetByIssuerAndSubject_Call struct {
+	*mock.Call
+}
+
+// GetByIssuerAndSubject is a helper method to define mock.On call
+//   - issuer string
+//   - subject string
+func (_e *MockUserIdentityRepository_Expecter) GetByIssuerAndSubject(issuer interface{}, subject interface{}) *MockUserIdentityRepository_GetByIssuerAndSubject_Call {
+	return &MockUserIdentityRepository_GetByIssuerAndSubject_Call{Call: _e.mock.On("GetByIssuerAndSubject", issuer, subject)}
+}
+
+func (_c *MockUserIdentityRepository_GetByIssuerAndSubject_Call) Run(run func(issuer string, subject string)) *MockUserIdentityRepository_GetByIssuerAndSubject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserIdentityRepository_GetByIssuerAndSubject_Call) Return(_a0 *models.UserIdentity, _a1 error) *MockUserIdentityRepository_GetByIssuerAndSubject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserIdentityRepository_GetByIssuerAndSubject_Call) RunAndReturn(run func(string, string) (*models.UserIdentity, error)) *MockUserIdentityRepository_GetByIssuerAndSubject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchLastLogin provides a mock function with given fields: id, email, now
+func (_m *MockUserIdentityRepository) TouchLastLogin(id int, email *string, now time.Time) error {
+	ret := _m.Called(id, email, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchLastLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, *string, time.Time) error); ok {
+		r0 = rf(id, email, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserIdentityRepository_TouchLastLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchLastLogin'
+type MockUserIdentityRepository_TouchLastLogin_Call struct {
+	*mock.Call
+}
+
+// TouchLastLogin is a helper method to define mock.On call
+//   - id int
+//   - email *string
+//   - now time.Time
+func (_e *MockUserIdentityRepository_Expecter) TouchLastLogin(id interface{}, email interface{}, now interface{}) *MockUserIdentityRepository_TouchLastLogin_Call {
+	return &MockUserIdentityRepository_TouchLastLogin_Call{Call: _e.mock.On("TouchLastLogin", id, email, now)}
+}
+
+func (_c *MockUserIdentityRepository_TouchLastLogin_Call) Run(run func(id int, email *string, now time.Time)) *MockUserIdentityRepository_TouchLastLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(*string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserIdentityRepository_TouchLastLogin_Call) Return(_a0 error) *MockUserIdentityRepository_TouchLastLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserIdentityRepository_TouchLastLogin_Call) RunAndReturn(run func(int, *string, time.Time) error) *MockUserIdentityRepository_TouchLastLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockUserIdentityRepository) WithTx(tx repository.DBTX) repository.UserIdentityRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.UserIdentityRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.UserIdentityRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.UserIdentityRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockUserIdentityRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockUserIdentityRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockUserIdentityRepository_Expecter) WithTx(tx interface{}) *MockUserIdentityRepository_WithTx_Call {
+	return &MockUserIdentityRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockUserIdentityRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockUserIdentityRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockUserIdentityRepository_WithTx_Call) Return(_a0 repository.UserIdentityRepository) *MockUserIdentityRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserIdentityRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.UserIdentityRepository) *MockUserIdentityRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserIdentityRepository creates a new instance of MockUserIdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserIdentityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserIdentityRepository {
+	mock := &MockUserIdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
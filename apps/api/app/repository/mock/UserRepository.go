@@ -0,0 +1,574 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+
+	time "time"
+)
+
+// MockUserRepository is an autogenerated mock type for the UserRepository type
+type MockUserRepository struct {
+	mock.Mock
+}
+
+type MockUserRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
+	return &MockUserRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: user
+func (_m *MockUserRepository) Create(user *models.User) (*models.User, error) {
+	ret := _m.Called(user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.User) (*models.User, error)); ok {
+		return rf(user)
+	}
+	if rf, ok := ret.Get(0).(func(*models.User) *models.User); ok {
+		r0 = rf(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUserRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - user *models.User
+func (_e *MockUserRepository_Expecter) Create(user interface{}) *MockUserRepository_Create_Call {
+	return &MockUserRepository_Create_Call{Call: _e.mock.On("Create", user)}
+}
+
+func (_c *MockUserRepository_Create_Call) Run(run func(user *models.User)) *MockUserRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.User))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Create_Call) Return(_a0 *models.User, _a1 error) *MockUserRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_Create_Call) RunAndReturn(run func(*models.User) (*models.User, error)) *MockUserRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockUserRepository) Delete(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUserRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id int
+func (_e *MockUserRepository_Expecter) Delete(id interface{}) *MockUserRepository_Delete_Call {
+	return &MockUserRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockUserRepository_Delete_Call) Run(run func(id int)) *MockUserRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Delete_Call) Return(_a0 error) *MockUserRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepository_Delete_Call) RunAndReturn(run func(int) error) *MockUserRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with no fields
+func (_m *MockUserRepository) GetAll() ([]models.User, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.User, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.User); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockUserRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+func (_e *MockUserRepository_Expecter) GetAll() *MockUserRepository_GetAll_Call {
+	return &MockUserRepository_GetAll_Call{Call: _e.mock.On("GetAll")}
+}
+
+func (_c *MockUserRepository_GetAll_Call) Run(run func()) *MockUserRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetAll_Call) Return(_a0 []models.User, _a1 error) *MockUserRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_GetAll_Call) RunAndReturn(run func() ([]models.User, error)) *MockUserRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEmail provides a mock function with given fields: email
+func (_m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEmail")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.User, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
+type MockUserRepository_GetByEmail_Call struct {
+	*mock.Call
+}
+
+// GetByEmail is a helper method to define mock.On call
+//   - email string
+func (_e *MockUserRepository_Expecter) GetByEmail(email interface{}) *MockUserRepository_GetByEmail_Call {
+	return &MockUserRepository_GetByEmail_Call{Call: _e.mock.On("GetByEmail", email)}
+}
+
+func (_c *MockUserRepository_GetByEmail_Call) Run(run func(email string)) *MockUserRepository_GetByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetByEmail_Call) Return(_a0 *models.User, _a1 error) *MockUserRepository_GetByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_GetByEmail_Call) RunAndReturn(run func(string) (*models.User, error)) *MockUserRepository_GetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockUserRepository) GetByID(id int) (*models.User, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*models.User, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *models.User); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockUserRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id int
+func (_e *MockUserRepository_Expecter) GetByID(id interface{}) *MockUserRepository_GetByID_Call {
+	return &MockUserRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockUserRepository_GetByID_Call) Run(run func(id int)) *MockUserRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetByID_Call) Return(_a0 *models.User, _a1 error) *MockUserRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_GetByID_Call) RunAndReturn(run func(int) (*models.User, error)) *MockUserRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPasswordHash provides a mock function with given fields: id
+func (_m *MockUserRepository) GetPasswordHash(id int) (string, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordHash")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (string, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_GetPasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPasswordHash'
+type MockUserRepository_GetPasswordHash_Call struct {
+	*mock.Call
+}
+
+// GetPasswordHash is a helper method to define mock.On call
+//   - id int
+func (_e *MockUserRepository_Expecter) GetPasswordHash(id interface{}) *MockUserRepository_GetPasswordHash_Call {
+	return &MockUserRepository_GetPasswordHash_Call{Call: _e.mock.On("GetPasswordHash", id)}
+}
+
+func (_c *MockUserRepository_GetPasswordHash_Call) Run(run func(id int)) *MockUserRepository_GetPasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetPasswordHash_Call) Return(_a0 string, _a1 error) *MockUserRepository_GetPasswordHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_GetPasswordHash_Call) RunAndReturn(run func(int) (string, error)) *MockUserRepository_GetPasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkEmailVerified provides a mock function with given fields: id, now
+func (_m *MockUserRepository) MarkEmailVerified(id int, now time.Time) error {
+	ret := _m.Called(id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEmailVerified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) error); ok {
+		r0 = rf(id, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepository_MarkEmailVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEmailVerified'
+type MockUserRepository_MarkEmailVerified_Call struct {
+	*mock.Call
+}
+
+// MarkEmailVerified is a helper method to define mock.On call
+//   - id int
+//   - now time.Time
+func (_e *MockUserRepository_Expecter) MarkEmailVerified(id interface{}, now interface{}) *MockUserRepository_MarkEmailVerified_Call {
+	return &MockUserRepository_MarkEmailVerified_Call{Call: _e.mock.On("MarkEmailVerified", id, now)}
+}
+
+func (_c *MockUserRepository_MarkEmailVerified_Call) Run(run func(id int, now time.Time)) *MockUserRepository_MarkEmailVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_MarkEmailVerified_Call) Return(_a0 error) *MockUserRepository_MarkEmailVerified_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepository_MarkEmailVerified_Call) RunAndReturn(run func(int, time.Time) error) *MockUserRepository_MarkEmailVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPasswordHash provides a mock function with given fields: id, hash
+func (_m *MockUserRepository) SetPasswordHash(id int, hash string) error {
+	ret := _m.Called(id, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPasswordHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(id, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepository_SetPasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPasswordHash'
+type MockUserRepository_SetPasswordHash_Call struct {
+	*mock.Call
+}
+
+// SetPasswordHash is a helper method to define mock.On call
+//   - id int
+//   - hash string
+func (_e *MockUserRepository_Expecter) SetPasswordHash(id interface{}, hash interface{}) *MockUserRepository_SetPasswordHash_Call {
+	return &MockUserRepository_SetPasswordHash_Call{Call: _e.mock.On("SetPasswordHash", id, hash)}
+}
+
+func (_c *MockUserRepository_SetPasswordHash_Call) Run(run func(id int, hash string)) *MockUserRepository_SetPasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SetPasswordHash_Call) Return(_a0 error) *MockUserRepository_SetPasswordHash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepository_SetPasswordHash_Call) RunAndReturn(run func(int, string) error) *MockUserRepository_SetPasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: id, update
+func (_m *MockUserRepository) Update(id int, update models.UserUpdate) (*models.User, error) {
+	ret := _m.Called(id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, models.UserUpdate) (*models.User, error)); ok {
+		return rf(id, update)
+	}
+	if rf, ok := ret.Get(0).(func(int, models.UserUpdate) *models.User); ok {
+		r0 = rf(id, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, models.UserUpdate) error); ok {
+		r1 = rf(id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockUserRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - id int
+//   - update models.UserUpdate
+func (_e *MockUserRepository_Expecter) Update(id interface{}, update interface{}) *MockUserRepository_Update_Call {
+	return &MockUserRepository_Update_Call{Call: _e.mock.On("Update", id, update)}
+}
+
+func (_c *MockUserRepository_Update_Call) Run(run func(id int, update models.UserUpdate)) *MockUserRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(models.UserUpdate))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Update_Call) Return(_a0 *models.User, _a1 error) *MockUserRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_Update_Call) RunAndReturn(run func(int, models.UserUpdate) (*models.User, error)) *MockUserRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockUserRepository) WithTx(tx repository.DBTX) repository.UserRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.UserRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.UserRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.UserRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockUserRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockUserRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockUserRepository_Expecter) WithTx(tx interface{}) *MockUserRepository_WithTx_Call {
+	return &MockUserRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockUserRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockUserRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_WithTx_Call) Return(_a0 repository.UserRepository) *MockUserRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.UserRepository) *MockUserRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserRepository creates a new instance of MockUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserRepository {
+	mock := &MockUserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
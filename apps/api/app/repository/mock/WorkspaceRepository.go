@@ -0,0 +1,434 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock
+
+import (
+	models "api/app/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "api/repository"
+)
+
+// MockWorkspaceRepository is an autogenerated mock type for the WorkspaceRepository type
+type MockWorkspaceRepository struct {
+	mock.Mock
+}
+
+type MockWorkspaceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWorkspaceRepository) EXPECT() *MockWorkspaceRepository_Expecter {
+	return &MockWorkspaceRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function with given fields: workspaceID, userID, role
+func (_m *MockWorkspaceRepository) AddMember(workspaceID int, userID int, role models.WorkspaceRole) (*models.WorkspaceMember, error) {
+	ret := _m.Called(workspaceID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 *models.WorkspaceMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, models.WorkspaceRole) (*models.WorkspaceMember, error)); ok {
+		return rf(workspaceID, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, models.WorkspaceRole) *models.WorkspaceMember); ok {
+		r0 = rf(workspaceID, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WorkspaceMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, models.WorkspaceRole) error); ok {
+		r1 = rf(workspaceID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkspaceRepository_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type MockWorkspaceRepository_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - workspaceID int
+//   - userID int
+//   - role models.WorkspaceRole
+func (_e *MockWorkspaceRepository_Expecter) AddMember(workspaceID interface{}, userID interface{}, role interface{}) *MockWorkspaceRepository_AddMember_Call {
+	return &MockWorkspaceRepository_AddMember_Call{Call: _e.mock.On("AddMember", workspaceID, userID, role)}
+}
+
+func (_c *MockWorkspaceRepository_AddMember_Call) Run(run func(workspaceID int, userID int, role models.WorkspaceRole)) *MockWorkspaceRepository_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int), args[2].(models.WorkspaceRole))
+	})
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_AddMember_Call) Return(_a0 *models.WorkspaceMember, _a1 error) *MockWorkspaceRepository_AddMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_AddMember_Call) RunAndReturn(run func(int, int, models.WorkspaceRole) (*models.WorkspaceMember, error)) *MockWorkspaceRepository_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: workspace
+func (_m *MockWorkspaceRepository) Create(workspace *models.Workspace) (*models.Workspace, error) {
+	ret := _m.Called(workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.Workspace) (*models.Workspace, error)); ok {
+		return rf(workspace)
+	}
+	if rf, ok := ret.Get(0).(func(*models.Workspace) *models.Workspace); ok {
+		r0 = rf(workspace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.Workspace) error); ok {
+		r1 = rf(workspace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkspaceRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWorkspaceRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - workspace *models.Workspace
+func (_e *MockWorkspaceRepository_Expecter) Create(workspace interface{}) *MockWorkspaceRepository_Create_Call {
+	return &MockWorkspaceRepository_Create_Call{Call: _e.mock.On("Create", workspace)}
+}
+
+func (_c *MockWorkspaceRepository_Create_Call) Run(run func(workspace *models.Workspace)) *MockWorkspaceRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Workspace))
+	})
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_Create_Call) Return(_a0 *models.Workspace, _a1 error) *MockWorkspaceRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_Create_Call) RunAndReturn(run func(*models.Workspace) (*models.Workspace, error)) *MockWorkspaceRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllIDs provides a mock function with no fields
+func (_m *MockWorkspaceRepository) GetAllIDs() ([]int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllIDs")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkspaceRepository_GetAllIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllIDs'
+type MockWorkspaceRepository_GetAllIDs_Call struct {
+	*mock.Call
+}
+
+// GetAllIDs is a helper method to define mock.On call
+func (_e *MockWorkspaceRepository_Expecter) GetAllIDs() *MockWorkspaceRepository_GetAllIDs_Call {
+	return &MockWorkspaceRepository_GetAllIDs_Call{Call: _e.mock.On("GetAllIDs")}
+}
+
+func (_c *MockWorkspaceRepository_GetAllIDs_Call) Run(run func()) *MockWorkspaceRepository_GetAllIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_GetAllIDs_Call) Return(_a0 []int, _a1 error) *MockWorkspaceRepository_GetAllIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_GetAllIDs_Call) RunAndReturn(run func() ([]int, error)) *MockWorkspaceRepository_GetAllIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByMemberID provides a mock function with given fields: userID
+func (_m *MockWorkspaceRepository) GetByMemberID(userID int) ([]models.Workspace, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByMemberID")
+	}
+
+	var r0 []models.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.Workspace, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.Workspace); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkspaceRepository_GetByMemberID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByMemberID'
+type MockWorkspaceRepository_GetByMemberID_Call struct {
+	*mock.Call
+}
+
+// GetByMemberID is a helper method to define mock.On call
+//   - userID int
+func (_e *MockWorkspaceRepository_Expecter) GetByMemberID(userID interface{}) *MockWorkspaceRepository_GetByMemberID_Call {
+	return &MockWorkspaceRepository_GetByMemberID_Call{Call: _e.mock.On("GetByMemberID", userID)}
+}
+
+func (_c *MockWorkspaceRepository_GetByMemberID_Call) Run(run func(userID int)) *MockWorkspaceRepository_GetByMemberID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_GetByMemberID_Call) Return(_a0 []models.Workspace, _a1 error) *MockWorkspaceRepository_GetByMemberID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_GetByMemberID_Call) RunAndReturn(run func(int) ([]models.Workspace, error)) *MockWorkspaceRepository_GetByMemberID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMemberRole provides a mock function with given fields: workspaceID, userID
+func (_m *MockWorkspaceRepository) GetMemberRole(workspaceID int, userID int) (models.WorkspaceRole, error) {
+	ret := _m.Called(workspaceID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMemberRole")
+	}
+
+	var r0 models.WorkspaceRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (models.WorkspaceRole, error)); ok {
+		return rf(workspaceID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) models.WorkspaceRole); ok {
+		r0 = rf(workspaceID, userID)
+	} else {
+		r0 = ret.Get(0).(models.WorkspaceRole)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(workspaceID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkspaceRepository_GetMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMemberRole'
+type MockWorkspaceRepository_GetMemberRole_Call struct {
+	*mock.Call
+}
+
+// GetMemberRole is a helper method to define mock.On call
+//   - workspaceID int
+//   - userID int
+func (_e *MockWorkspaceRepository_Expecter) GetMemberRole(workspaceID interface{}, userID interface{}) *MockWorkspaceRepository_GetMemberRole_Call {
+	return &MockWorkspaceRepository_GetMemberRole_Call{Call: _e.mock.On("GetMemberRole", workspaceID, userID)}
+}
+
+func (_c *MockWorkspaceRepository_GetMemberRole_Call) Run(run func(workspaceID int, userID int)) *MockWorkspaceRepository_GetMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_GetMemberRole_Call) Return(_a0 models.WorkspaceRole, _a1 error) *MockWorkspaceRepository_GetMemberRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_GetMemberRole_Call) RunAndReturn(run func(int, int) (models.WorkspaceRole, error)) *MockWorkspaceRepository_GetMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembers provides a mock function with given fields: workspaceID
+func (_m *MockWorkspaceRepository) GetMembers(workspaceID int) ([]models.WorkspaceMember, error) {
+	ret := _m.Called(workspaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembers")
+	}
+
+	var r0 []models.WorkspaceMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.WorkspaceMember, error)); ok {
+		return rf(workspaceID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.WorkspaceMember); ok {
+		r0 = rf(workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WorkspaceMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(workspaceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkspaceRepository_GetMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembers'
+type MockWorkspaceRepository_GetMembers_Call struct {
+	*mock.Call
+}
+
+// GetMembers is a helper method to define mock.On call
+//   - workspaceID int
+func (_e *MockWorkspaceRepository_Expecter) GetMembers(workspaceID interface{}) *MockWorkspaceRepository_GetMembers_Call {
+	return &MockWorkspaceRepository_GetMembers_Call{Call: _e.mock.On("GetMembers", workspaceID)}
+}
+
+func (_c *MockWorkspaceRepository_GetMembers_Call) Run(run func(workspaceID int)) *MockWorkspaceRepository_GetMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_GetMembers_Call) Return(_a0 []models.WorkspaceMember, _a1 error) *MockWorkspaceRepository_GetMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_GetMembers_Call) RunAndReturn(run func(int) ([]models.WorkspaceMember, error)) *MockWorkspaceRepository_GetMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MockWorkspaceRepository) WithTx(tx repository.DBTX) repository.WorkspaceRepository {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repository.WorkspaceRepository
+	if rf, ok := ret.Get(0).(func(repository.DBTX) repository.WorkspaceRepository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.WorkspaceRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockWorkspaceRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockWorkspaceRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx repository.DBTX
+func (_e *MockWorkspaceRepository_Expecter) WithTx(tx interface{}) *MockWorkspaceRepository_WithTx_Call {
+	return &MockWorkspaceRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockWorkspaceRepository_WithTx_Call) Run(run func(tx repository.DBTX)) *MockWorkspaceRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.DBTX))
+	})
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_WithTx_Call) Return(_a0 repository.WorkspaceRepository) *MockWorkspaceRepository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWorkspaceRepository_WithTx_Call) RunAndReturn(run func(repository.DBTX) repository.WorkspaceRepository) *MockWorkspaceRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWorkspaceRepository creates a new instance of MockWorkspaceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWorkspaceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWorkspaceRepository {
+	mock := &MockWorkspaceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
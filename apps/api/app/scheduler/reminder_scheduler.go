@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"api/app/usecase"
+	"context"
+	"log"
+	"time"
+)
+
+// ReminderScheduler は一定間隔で期限が近いTodoのリマインドを送信するバックグラウンドジョブ
+type ReminderScheduler struct {
+	reminderUsecase usecase.ReminderUsecase
+	interval        time.Duration
+}
+
+func NewReminderScheduler(reminderUsecase usecase.ReminderUsecase, interval time.Duration) *ReminderScheduler {
+	return &ReminderScheduler{
+		reminderUsecase: reminderUsecase,
+		interval:        interval,
+	}
+}
+
+// Start は ctx がキャンセルされるまでリマインド送信を繰り返す（goroutineで呼び出す想定）
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	log.Printf("Reminder scheduler started (interval: %s)", s.interval)
+	for {
+		s.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Reminder scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReminderScheduler) run(ctx context.Context) {
+	sent, err := s.reminderUsecase.SendDueReminders(ctx, time.Now())
+	// 一部の失敗があっても送信できた件数は記録する
+	if err != nil {
+		log.Printf("Failed to send reminders: %v", err)
+	}
+	if sent > 0 {
+		log.Printf("Sent %d reminder(s)", sent)
+	}
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"api/app/usecase"
+	"context"
+	"log"
+	"time"
+)
+
+// TodoImportScheduler は一定間隔で待機中のTodoのインポートを実行するバックグラウンドジョブ
+type TodoImportScheduler struct {
+	todoImportUsecase usecase.TodoImportUsecase
+	interval          time.Duration
+}
+
+func NewTodoImportScheduler(todoImportUsecase usecase.TodoImportUsecase, interval time.Duration) *TodoImportScheduler {
+	return &TodoImportScheduler{
+		todoImportUsecase: todoImportUsecase,
+		interval:          interval,
+	}
+}
+
+// Start は ctx がキャンセルされるまでインポートの実行を繰り返す（goroutineで呼び出す想定）
+func (s *TodoImportScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	log.Printf("Todo import scheduler started (interval: %s)", s.interval)
+	for {
+		s.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Todo import scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *TodoImportScheduler) run(ctx context.Context) {
+	processed, err := s.todoImportUsecase.RunPendingJobs(ctx, time.Now())
+	if err != nil {
+		log.Printf("Failed to run todo import jobs: %v", err)
+		return
+	}
+	if processed > 0 {
+		log.Printf("Ran %d todo import job(s)", processed)
+	}
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"api/app/usecase"
+	"context"
+	"log"
+	"time"
+)
+
+// TrashPurgeScheduler は一定間隔で保存期間を過ぎたゴミ箱内のTodoを完全に削除するバックグラウンドジョブ
+type TrashPurgeScheduler struct {
+	trashUsecase usecase.TrashUsecase
+	interval     time.Duration
+}
+
+func NewTrashPurgeScheduler(trashUsecase usecase.TrashUsecase, interval time.Duration) *TrashPurgeScheduler {
+	return &TrashPurgeScheduler{
+		trashUsecase: trashUsecase,
+		interval:     interval,
+	}
+}
+
+// Start は ctx がキャンセルされるまでゴミ箱の自動削除を繰り返す（goroutineで呼び出す想定）
+func (s *TrashPurgeScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	log.Printf("Trash purge scheduler started (interval: %s)", s.interval)
+	for {
+		s.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Trash purge scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *TrashPurgeScheduler) run(ctx context.Context) {
+	purged, err := s.trashUsecase.PurgeExpired(ctx, time.Now())
+	if err != nil {
+		log.Printf("Failed to purge trash: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged %d todo(s) from trash", purged)
+	}
+}
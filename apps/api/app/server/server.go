@@ -1,14 +1,19 @@
 package server
 
 import (
+	"api/app/container"
 	"api/app/presentation/router"
 	"api/config"
 	"api/db"
+	"context"
 	"log"
 
 	"github.com/joho/godotenv"
 )
 
+// stopBackgroundJobs はバックグラウンドジョブを停止する（Shutdownで呼び出す）
+var stopBackgroundJobs context.CancelFunc = func() {}
+
 func Initialize() error {
 	// Load .env file for local development (ignore errors in production)
 	if err := godotenv.Load(); err != nil {
@@ -35,10 +40,32 @@ func Start() error {
 		return err
 	}
 
-	r := router.SetupRouter(cfg)
+	// バックグラウンドジョブを起動
+	if db.DB != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		stopBackgroundJobs = cancel
+		// リマインド送信
+		if cfg.ReminderEnabled {
+			go container.InitializeReminderScheduler(db.DB, cfg).Start(ctx)
+		}
+		// ゴミ箱の自動削除
+		if cfg.TrashPurgeEnabled {
+			go container.InitializeTrashPurgeScheduler(db.DB, cfg).Start(ctx)
+		}
+		// Todoのインポート
+		if cfg.TodoImportEnabled {
+			go container.InitializeTodoImportScheduler(db.DB, cfg).Start(ctx)
+		}
+	}
+
+	r, err := router.SetupRouter(cfg)
+	if err != nil {
+		return err
+	}
 	return router.StartServer(r)
 }
 
 func Shutdown() {
+	stopBackgroundJobs()
 	db.CloseDB()
 }
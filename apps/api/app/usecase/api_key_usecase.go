@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+)
+
+type APIKeyUsecase interface {
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	// CreateAPIKey はAPIキーを発行し、キー本体を返す（キー本体はこの時だけ取得できる）
+	CreateAPIKey(ctx context.Context, key *models.APIKey) (*models.APIKey, string, error)
+	// RevokeAPIKey はAPIキーを失効させる（次のリクエストから使えなくなる）
+	RevokeAPIKey(ctx context.Context, id int) (*models.APIKey, error)
+	// Authenticate はキー本体を検証し、有効なAPIキーを返す（最終利用日時を記録する）
+	Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewAPIKeyUsecase(apiKeyRepo repository.APIKeyRepository) APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+func (u *apiKeyUsecase) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+
+	keys, err := u.apiKeyRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return empty slice instead of nil for consistency
+	if keys == nil {
+		return []models.APIKey{}, nil
+	}
+	return keys, nil
+}
+
+func (u *apiKeyUsecase) CreateAPIKey(ctx context.Context, key *models.APIKey) (*models.APIKey, string, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, "", ErrForbidden
+	}
+
+	name := strings.TrimSpace(key.Name)
+	if name == "" || utf8.RuneCountInString(name) > models.MaxAPIKeyNameLength {
+		return nil, "", ErrInvalidInput
+	}
+	scopes, ok := normalizeScopes(key.Scopes)
+	if !ok {
+		return nil, "", ErrInvalidInput
+	}
+
+	now := time.Now()
+	expiresAt := key.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(models.DefaultAPIKeyTTL)
+	}
+	if !expiresAt.After(now) || expiresAt.After(now.Add(models.MaxAPIKeyTTL)) {
+		return nil, "", ErrInvalidInput
+	}
+
+	prefix, rawKey, err := generateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	created, err := u.apiKeyRepo.Create(&models.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashToken(rawKey),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return created, rawKey, nil
+}
+
+func (u *apiKeyUsecase) RevokeAPIKey(ctx context.Context, id int) (*models.APIKey, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	key, err := u.apiKeyRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+	if userID, ok := auth.UserIDFromContext(ctx); !ok || userID != key.UserID {
+		return nil, ErrForbidden
+	}
+
+	revoked, err := u.apiKeyRepo.Revoke(id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if revoked == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+	return revoked, nil
+}
+
+func (u *apiKeyUsecase) Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error) {
+	prefix, ok := apiKeyPrefixOf(rawKey)
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// 失効の確認をキャッシュしないため、失効は次のリクエストから反映される
+	key, err := u.apiKeyRepo.GetByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrInvalidAPIKey
+	}
+	if subtle.ConstantTimeCompare([]byte(hashToken(rawKey)), []byte(key.KeyHash)) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err := u.apiKeyRepo.TouchLastUsed(key.ID, now); err != nil {
+		return nil, err
+	}
+	key.LastUsedAt = &now
+	return key, nil
+}
+
+// normalizeScopes は重複を除いたスコープを返す（空または未知のスコープを含む場合は false）
+func normalizeScopes(scopes []string) ([]string, bool) {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, scope := range scopes {
+		switch models.APIKeyScope(scope) {
+		case models.ScopeTodosRead, models.ScopeTodosWrite:
+		default:
+			return nil, false
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, len(normalized) > 0
+}
+
+// generateAPIKey は "tdk_<識別子>_<シークレット>" 形式のAPIキーと、その先頭部分（tdk_<識別子>）を生成する
+func generateAPIKey() (prefix string, rawKey string, err error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	prefix = models.APIKeyPrefix + hex.EncodeToString(id)
+	return prefix, prefix + "_" + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// apiKeyPrefixOf はAPIキーから検索用の先頭部分を取り出す
+func apiKeyPrefixOf(rawKey string) (string, bool) {
+	if !strings.HasPrefix(rawKey, models.APIKeyPrefix) {
+		return "", false
+	}
+	prefix, secret, found := strings.Cut(strings.TrimPrefix(rawKey, models.APIKeyPrefix), "_")
+	if !found || prefix == "" || secret == "" {
+		return "", false
+	}
+	return models.APIKeyPrefix + prefix, true
+}
+
+// hashToken はAPIキーやリフレッシュトークンなど、サーバーが生成したトークンのハッシュを返す
+// トークンは十分なエントロピーを持つランダム値のため、パスワード用の低速なハッシュは使わない
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyUsecase(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo)
+
+	aliceID := createTestUser(t, db, "alice@example.com")
+	bobID := createTestUser(t, db, "bob@example.com")
+	alice := auth.WithUserID(context.Background(), aliceID)
+	bob := auth.WithUserID(context.Background(), bobID)
+
+	key, rawKey, err := apiKeyUsecase.CreateAPIKey(alice, &models.APIKey{
+		Name:   "  CI bot  ",
+		Scopes: []string{"todos:read", "todos:write", "todos:read"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CI bot", key.Name)
+	assert.Equal(t, []string{"todos:read", "todos:write"}, []string(key.Scopes))
+	assert.True(t, strings.HasPrefix(rawKey, key.Prefix+"_"), rawKey)
+	// キー本体は保存しない
+	assert.NotContains(t, key.KeyHash, rawKey)
+	assert.WithinDuration(t, time.Now().Add(models.DefaultAPIKeyTTL), key.ExpiresAt, time.Minute)
+
+	t.Run("Authenticate records the last used time", func(t *testing.T) {
+		authenticated, err := apiKeyUsecase.Authenticate(context.Background(), rawKey)
+		require.NoError(t, err)
+		assert.Equal(t, aliceID, authenticated.UserID)
+
+		keys, err := apiKeyUsecase.GetAPIKeys(alice)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.NotNil(t, keys[0].LastUsedAt)
+
+		keys, err = apiKeyUsecase.GetAPIKeys(bob)
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("Invalid keys are rejected", func(t *testing.T) {
+		for _, invalid := range []string{"", "not-a-key", key.Prefix + "_wrong-secret", "tdk_unknown_secret"} {
+			_, err := apiKeyUsecase.Authenticate(context.Background(), invalid)
+			assert.ErrorIs(t, err, usecase.ErrInvalidAPIKey, invalid)
+		}
+	})
+
+	t.Run("Expired keys are rejected", func(t *testing.T) {
+		expiredKey := "tdk_00000000000000ff_expired-secret"
+		sum := sha256.Sum256([]byte(expiredKey))
+		_, err := apiKeyRepo.Create(&models.APIKey{
+			UserID:    aliceID,
+			Name:      "expired",
+			Prefix:    "tdk_00000000000000ff",
+			KeyHash:   hex.EncodeToString(sum[:]),
+			Scopes:    []string{"todos:read"},
+			ExpiresAt: time.Now().Add(-time.Hour),
+		})
+		require.NoError(t, err)
+
+		_, err = apiKeyUsecase.Authenticate(context.Background(), expiredKey)
+		assert.ErrorIs(t, err, usecase.ErrInvalidAPIKey)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, _, err := apiKeyUsecase.CreateAPIKey(alice, &models.APIKey{Name: "bad scope", Scopes: []string{"admin"}})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+		_, _, err = apiKeyUsecase.CreateAPIKey(alice, &models.APIKey{Name: "no scope"})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+		_, _, err = apiKeyUsecase.CreateAPIKey(alice, &models.APIKey{Name: "past", Scopes: []string{"todos:read"}, ExpiresAt: time.Now().Add(-time.Minute)})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+		_, _, err = apiKeyUsecase.CreateAPIKey(context.Background(), &models.APIKey{Name: "anonymous", Scopes: []string{"todos:read"}})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+	})
+
+	t.Run("Revocation takes effect immediately", func(t *testing.T) {
+		_, err := apiKeyUsecase.RevokeAPIKey(bob, key.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+
+		revoked, err := apiKeyUsecase.RevokeAPIKey(alice, key.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, revoked.RevokedAt)
+
+		_, err = apiKeyUsecase.Authenticate(context.Background(), rawKey)
+		assert.ErrorIs(t, err, usecase.ErrInvalidAPIKey)
+
+		_, err = apiKeyUsecase.RevokeAPIKey(alice, 99999)
+		assert.ErrorIs(t, err, usecase.ErrAPIKeyNotFound)
+	})
+}
@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCredentials   = errors.New("invalid email or password")
+	ErrInvalidRefreshToken  = errors.New("invalid refresh token")
+	ErrInvalidResetToken    = errors.New("invalid password reset token")
+	ErrLoginDisabled        = errors.New("login is not configured")
+	ErrPasswordResetNotSent = errors.New("failed to send password reset email")
+)
+
+// AccessTokenIssuer はログインしたユーザーのアクセストークンを発行する（auth.JWTSigner）
+type AccessTokenIssuer interface {
+	IssueAccessToken(userID int, now time.Time) (string, time.Time, error)
+}
+
+// AuthSettings はトークンの有効期間とパスワードリセットのリンク先
+type AuthSettings struct {
+	RefreshTokenTTL  time.Duration
+	PasswordResetTTL time.Duration
+	// PasswordResetURL はメールに記載するパスワード再設定ページのURL（token パラメータを付けて送る）
+	PasswordResetURL string
+	// Dispatch はパスワード再設定のメールの送信をリクエストの処理から切り離して実行する（nil の場合は goroutine で実行する）
+	Dispatch func(task func())
+}
+
+type AuthUsecase interface {
+	// Login はメールアドレスとパスワードを検証し、アクセストークンとリフレッシュトークンを発行する
+	Login(ctx context.Context, email string, password string) (*models.AuthTokens, error)
+	// Refresh はリフレッシュトークンを新しいトークンに置き換え、アクセストークンを再発行する
+	// 使用済みのリフレッシュトークンが再利用された場合は漏洩とみなし、同じログインのトークンを全て失効させる
+	Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error)
+	// IssueTokens は外部の IdP などで認証済みのユーザーにトークンを発行する
+	IssueTokens(ctx context.Context, userID int) (*models.AuthTokens, error)
+	// Logout はリフレッシュトークンと同じログインで発行したトークンを全て失効させる（無効なトークンでもエラーにしない）
+	Logout(ctx context.Context, refreshToken string) error
+	// RequestPasswordReset はパスワードリセット用のトークンをメールで送る
+	// 登録の有無を応答時間やエラーから推測されないよう、メールアドレスの形式のみ検証し、
+	// ユーザーの検索・トークンの作成・メールの送信は Dispatch で実行する（失敗はログに記録する）
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword はトークンを検証してパスワードを変更し、全てのリフレッシュトークンを失効させる
+	ResetPassword(ctx context.Context, resetToken string, password string) error
+}
+
+type authUsecase struct {
+	userRepo           repository.UserRepository
+	refreshTokenRepo   repository.RefreshTokenRepository
+	resetTokenRepo     repository.PasswordResetTokenRepository
+	txManager          repository.TxManager
+	tokenIssuer        AccessTokenIssuer
+	notificationClient external.NotificationClient
+	settings           AuthSettings
+}
+
+// NewAuthUsecase は AuthUsecase を作成する（tokenIssuer が nil の場合はログインできない）
+func NewAuthUsecase(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	resetTokenRepo repository.PasswordResetTokenRepository,
+	txManager repository.TxManager,
+	tokenIssuer AccessTokenIssuer,
+	notificationClient external.NotificationClient,
+	settings AuthSettings,
+) AuthUsecase {
+	return &authUsecase{
+		userRepo:           userRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		resetTokenRepo:     resetTokenRepo,
+		txManager:          txManager,
+		tokenIssuer:        tokenIssuer,
+		notificationClient: notificationClient,
+		settings:           settings,
+	}
+}
+
+// dummyPasswordHash は存在しないユーザーのログインでも同じ時間をかけるために検証するハッシュ
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, err := auth.HashPassword("dummy-password")
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
+func (u *authUsecase) Login(ctx context.Context, email string, password string) (*models.AuthTokens, error) {
+	if u.tokenIssuer == nil {
+		return nil, ErrLoginDisabled
+	}
+	email, ok := normalizeEmail(email)
+	if !ok || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	user, err := u.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	var hash string
+	if user != nil {
+		if hash, err = u.userRepo.GetPasswordHash(user.ID); err != nil {
+			return nil, err
+		}
+	}
+	if hash == "" {
+		// ユーザーの有無やパスワード設定の有無を応答時間から推測されないようにする
+		_, _ = auth.VerifyPassword(password, dummyPasswordHash())
+		return nil, ErrInvalidCredentials
+	}
+
+	matched, err := auth.VerifyPassword(password, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		return nil, ErrInvalidCredentials
+	}
+
+	return u.IssueTokens(ctx, user.ID)
+}
+
+func (u *authUsecase) IssueTokens(ctx context.Context, userID int) (*models.AuthTokens, error) {
+	if u.tokenIssuer == nil {
+		return nil, ErrLoginDisabled
+	}
+
+	// ログインごとに新しい family のリフレッシュトークンを発行する
+	return u.issueTokens(u.refreshTokenRepo, userID, uuid.NewString(), time.Now())
+}
+
+func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	if u.tokenIssuer == nil {
+		return nil, ErrLoginDisabled
+	}
+	if refreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	var tokens *models.AuthTokens
+	reused := false
+	err := u.txManager.WithinTx(func(tx repository.DBTX) error {
+		refreshTokenRepo := u.refreshTokenRepo.WithTx(tx)
+		now := time.Now()
+
+		current, err := refreshTokenRepo.GetByHashForUpdate(hashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if current == nil || current.RevokedAt != nil || !now.Before(current.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+		if current.UsedAt != nil {
+			// 失効はコミットしたうえでエラーを返すため、ここではエラーにしない
+			reused = true
+			return refreshTokenRepo.RevokeFamily(current.FamilyID, now)
+		}
+
+		if err := refreshTokenRepo.MarkUsed(current.ID, now); err != nil {
+			return err
+		}
+		tokens, err = u.issueTokens(refreshTokenRepo, current.UserID, current.FamilyID, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, ErrInvalidRefreshToken
+	}
+	return tokens, nil
+}
+
+func (u *authUsecase) Logout(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+
+	return u.txManager.WithinTx(func(tx repository.DBTX) error {
+		refreshTokenRepo := u.refreshTokenRepo.WithTx(tx)
+
+		current, err := refreshTokenRepo.GetByHashForUpdate(hashToken(refreshToken))
+		if err != nil || current == nil {
+			return err
+		}
+		return refreshTokenRepo.RevokeFamily(current.FamilyID, time.Now())
+	})
+}
+
+func (u *authUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	email, ok := normalizeEmail(email)
+	if !ok {
+		return ErrInvalidInput
+	}
+
+	dispatch := u.settings.Dispatch
+	if dispatch == nil {
+		dispatch = func(task func()) { go task() }
+	}
+	// リクエストが終わってもキャンセルされないようにする
+	ctx = context.WithoutCancel(ctx)
+	dispatch(func() {
+		if err := u.sendPasswordReset(ctx, email); err != nil {
+			fmt.Printf("Failed to send password reset email: %v\n", err)
+		}
+	})
+	return nil
+}
+
+// sendPasswordReset は email のユーザーがいる場合にパスワードリセット用のトークンを作成してメールで送る
+func (u *authUsecase) sendPasswordReset(ctx context.Context, email string) error {
+	user, err := u.userRepo.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+
+	rawToken, err := generateToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expiresAt := now.Add(u.settings.PasswordResetTTL)
+
+	// 以前に送ったトークンは使えなくする（最新のメールのリンクのみ有効）
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		resetTokenRepo := u.resetTokenRepo.WithTx(tx)
+		if err := resetTokenRepo.InvalidateByUserID(user.ID, now); err != nil {
+			return err
+		}
+		_, err := resetTokenRepo.Create(&models.PasswordResetToken{
+			UserID:    user.ID,
+			TokenHash: hashToken(rawToken),
+			ExpiresAt: expiresAt,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = u.notificationClient.SendNotification(ctx, &external.NotificationRequest{
+		UserID: user.ID,
+		Title:  "パスワードの再設定",
+		Message: fmt.Sprintf("以下のリンクからパスワードを再設定してください（有効期限: %s）\n%s",
+			expiresAt.Format("2006-01-02 15:04 MST"), passwordResetLink(u.settings.PasswordResetURL, rawToken)),
+		Type: "email",
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPasswordResetNotSent, err)
+	}
+	return nil
+}
+
+func (u *authUsecase) ResetPassword(ctx context.Context, resetToken string, password string) error {
+	if resetToken == "" {
+		return ErrInvalidResetToken
+	}
+	if !isValidPassword(password) {
+		return ErrInvalidInput
+	}
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return u.txManager.WithinTx(func(tx repository.DBTX) error {
+		resetTokenRepo := u.resetTokenRepo.WithTx(tx)
+		now := time.Now()
+
+		token, err := resetTokenRepo.GetByHashForUpdate(hashToken(resetToken))
+		if err != nil {
+			return err
+		}
+		if token == nil || !token.IsActive(now) {
+			return ErrInvalidResetToken
+		}
+
+		// 使用したトークンを含め、未使用のトークンを全て使えなくする
+		if err := resetTokenRepo.InvalidateByUserID(token.UserID, now); err != nil {
+			return err
+		}
+		userRepo := u.userRepo.WithTx(tx)
+		if err := userRepo.SetPasswordHash(token.UserID, hash); err != nil {
+			if errors.Is(err, repository.ErrNoRows) {
+				return ErrInvalidResetToken
+			}
+			return err
+		}
+		// メールで送信したトークンを使えたので、メールアドレスの所有を確認できている
+		if err := userRepo.MarkEmailVerified(token.UserID, now); err != nil {
+			return err
+		}
+		// 漏洩したパスワードでログインされていた場合に備え、全ての端末をログアウトさせる
+		return u.refreshTokenRepo.WithTx(tx).RevokeByUserID(token.UserID, now)
+	})
+}
+
+// issueTokens はアクセストークンと、familyID のリフレッシュトークンを発行する
+func (u *authUsecase) issueTokens(refreshTokenRepo repository.RefreshTokenRepository, userID int, familyID string, now time.Time) (*models.AuthTokens, error) {
+	accessToken, accessExpiresAt, err := u.tokenIssuer.IssueAccessToken(userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	rawRefreshToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	created, err := refreshTokenRepo.Create(&models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(rawRefreshToken),
+		ExpiresAt: now.Add(u.settings.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          rawRefreshToken,
+		RefreshTokenExpiresAt: created.ExpiresAt,
+	}, nil
+}
+
+// generateToken はリフレッシュトークンやパスワードリセットに使うランダムなトークンを生成する
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// passwordResetLink はパスワード再設定ページのURLに token パラメータを付ける
+func passwordResetLink(baseURL string, token string) string {
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL + "?token=" + url.QueryEscape(token)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
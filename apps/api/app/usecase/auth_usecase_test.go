@@ -0,0 +1,195 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	extMock "api/app/external/mock"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	ctx := context.Background()
+
+	key, err := auth.NewHS256Key("test", []byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	signer, err := auth.NewJWTSigner(key, "todo-api", "", 15*time.Minute)
+	require.NoError(t, err)
+	verifier := auth.NewJWTVerifier([]auth.Key{key}, auth.WithIssuer("todo-api"))
+
+	// パスワードリセットのメールを記録する
+	var emails []*external.NotificationRequest
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, req *external.NotificationRequest) {
+			emails = append(emails, req)
+		}).
+		Return(&external.NotificationResponse{Status: "sent"}, nil).
+		Maybe()
+
+	userRepo := repository.NewUserRepository(db)
+	txManager := repository.NewTxManager(db)
+	authUsecase := usecase.NewAuthUsecase(userRepo, repository.NewRefreshTokenRepository(db), repository.NewPasswordResetTokenRepository(db), txManager, signer, mockNotificationClient, usecase.AuthSettings{
+		RefreshTokenTTL:  time.Hour,
+		PasswordResetTTL: 30 * time.Minute,
+		PasswordResetURL: "http://localhost:5173/reset-password",
+		// メールの送信を待ってから確認する
+		Dispatch: func(task func()) { task() },
+	})
+
+	// パスワードは本人がパスワードリセットで設定する（テストではハッシュを直接保存する）
+	aliceID := createTestUser(t, db, "alice@example.com")
+	hash, err := auth.HashPassword("correct horse")
+	require.NoError(t, err)
+	require.NoError(t, userRepo.SetPasswordHash(aliceID, hash))
+	bobID := createTestUser(t, db, "bob@example.com")
+
+	// resetTokenOf はメールのリンクからパスワードリセットのトークンを取り出す
+	resetTokenOf := func(t *testing.T, req *external.NotificationRequest) string {
+		t.Helper()
+		link := req.Message[strings.Index(req.Message, "http"):]
+		parsed, err := url.Parse(strings.TrimSpace(link))
+		require.NoError(t, err)
+		return parsed.Query().Get("token")
+	}
+
+	t.Run("Login issues an access token for the user", func(t *testing.T) {
+		tokens, err := authUsecase.Login(ctx, " Alice@Example.com ", "correct horse")
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokens.RefreshToken)
+
+		claims, err := verifier.Verify(tokens.AccessToken)
+		require.NoError(t, err)
+		userID, err := claims.UserID()
+		require.NoError(t, err)
+		assert.Equal(t, aliceID, userID)
+
+		_, err = authUsecase.Login(ctx, "alice@example.com", "wrong password")
+		assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		_, err = authUsecase.Login(ctx, "nobody@example.com", "correct horse")
+		assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		// パスワード未設定のユーザーはログインできない
+		_, err = authUsecase.Login(ctx, "bob@example.com", "correct horse")
+		assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+	})
+
+	t.Run("Refresh tokens rotate and reuse revokes the family", func(t *testing.T) {
+		first, err := authUsecase.Login(ctx, "alice@example.com", "correct horse")
+		require.NoError(t, err)
+
+		second, err := authUsecase.Refresh(ctx, first.RefreshToken)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+		// 使用済みのトークンの再利用で、ローテーション後のトークンも失効する
+		_, err = authUsecase.Refresh(ctx, first.RefreshToken)
+		assert.ErrorIs(t, err, usecase.ErrInvalidRefreshToken)
+		_, err = authUsecase.Refresh(ctx, second.RefreshToken)
+		assert.ErrorIs(t, err, usecase.ErrInvalidRefreshToken)
+
+		_, err = authUsecase.Refresh(ctx, "unknown")
+		assert.ErrorIs(t, err, usecase.ErrInvalidRefreshToken)
+	})
+
+	t.Run("Logout revokes only that login", func(t *testing.T) {
+		laptop, err := authUsecase.Login(ctx, "alice@example.com", "correct horse")
+		require.NoError(t, err)
+		phone, err := authUsecase.Login(ctx, "alice@example.com", "correct horse")
+		require.NoError(t, err)
+
+		require.NoError(t, authUsecase.Logout(ctx, laptop.RefreshToken))
+		require.NoError(t, authUsecase.Logout(ctx, laptop.RefreshToken))
+		_, err = authUsecase.Refresh(ctx, laptop.RefreshToken)
+		assert.ErrorIs(t, err, usecase.ErrInvalidRefreshToken)
+
+		_, err = authUsecase.Refresh(ctx, phone.RefreshToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("Password reset emails a single-use token", func(t *testing.T) {
+		session, err := authUsecase.Login(ctx, "alice@example.com", "correct horse")
+		require.NoError(t, err)
+
+		emails = nil
+		require.NoError(t, authUsecase.RequestPasswordReset(ctx, "alice@example.com"))
+		require.NoError(t, authUsecase.RequestPasswordReset(ctx, "nobody@example.com"))
+		require.Len(t, emails, 1)
+		assert.Equal(t, "email", emails[0].Type)
+		assert.Equal(t, aliceID, emails[0].UserID)
+		token := resetTokenOf(t, emails[0])
+		require.NotEmpty(t, token)
+
+		assert.ErrorIs(t, authUsecase.ResetPassword(ctx, token, "short"), usecase.ErrInvalidInput)
+		require.NoError(t, authUsecase.ResetPassword(ctx, token, "battery staple"))
+		assert.ErrorIs(t, authUsecase.ResetPassword(ctx, token, "another password"), usecase.ErrInvalidResetToken)
+		// メールのリンクからパスワードを再設定できたので、メールアドレスは確認済みになる
+		alice, err := userRepo.GetByID(aliceID)
+		require.NoError(t, err)
+		assert.NotNil(t, alice.EmailVerifiedAt)
+
+		_, err = authUsecase.Login(ctx, "alice@example.com", "correct horse")
+		assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		_, err = authUsecase.Login(ctx, "alice@example.com", "battery staple")
+		require.NoError(t, err)
+
+		// パスワードの変更前に発行したリフレッシュトークンは使えない
+		_, err = authUsecase.Refresh(ctx, session.RefreshToken)
+		assert.ErrorIs(t, err, usecase.ErrInvalidRefreshToken)
+	})
+
+	t.Run("Only the latest reset email is valid", func(t *testing.T) {
+		emails = nil
+		require.NoError(t, authUsecase.RequestPasswordReset(ctx, "bob@example.com"))
+		require.NoError(t, authUsecase.RequestPasswordReset(ctx, "bob@example.com"))
+		require.Len(t, emails, 2)
+		assert.Equal(t, bobID, emails[1].UserID)
+
+		assert.ErrorIs(t, authUsecase.ResetPassword(ctx, resetTokenOf(t, emails[0]), "bob password"), usecase.ErrInvalidResetToken)
+		require.NoError(t, authUsecase.ResetPassword(ctx, resetTokenOf(t, emails[1]), "bob password"))
+		_, err := authUsecase.Login(ctx, "bob@example.com", "bob password")
+		require.NoError(t, err)
+	})
+}
+
+func TestAuthUsecase_PasswordResetEmailFailure(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	ctx := context.Background()
+
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		Return(nil, errors.New("smtp unavailable")).
+		Once()
+
+	userRepo := repository.NewUserRepository(db)
+	authUsecase := usecase.NewAuthUsecase(userRepo, repository.NewRefreshTokenRepository(db), repository.NewPasswordResetTokenRepository(db), repository.NewTxManager(db), nil, mockNotificationClient, usecase.AuthSettings{
+		RefreshTokenTTL:  time.Hour,
+		PasswordResetTTL: 30 * time.Minute,
+		PasswordResetURL: "http://localhost:5173/reset-password",
+		Dispatch:         func(task func()) { task() },
+	})
+	createTestUser(t, db, "alice@example.com")
+
+	// 送信に失敗しても、登録の有無を推測されないようにエラーは返さない
+	require.NoError(t, authUsecase.RequestPasswordReset(ctx, "alice@example.com"))
+	assert.ErrorIs(t, authUsecase.RequestPasswordReset(ctx, "not an email"), usecase.ErrInvalidInput)
+
+	// 署名鍵が設定されていない場合はログインできない
+	_, err := authUsecase.Login(ctx, "alice@example.com", "password")
+	assert.ErrorIs(t, err, usecase.ErrLoginDisabled)
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+// ErrWorkspaceRequired はワークスペースを指定せずにTodo・プロジェクト・タグを操作しようとした場合のエラー
+var ErrWorkspaceRequired = repository.ErrWorkspaceRequired
+
+// workspaceScope は操作対象のワークスペース（middleware.Workspace で解決したワークスペース）のIDを返す
+// 指定されていない場合は ErrWorkspaceRequired を返す（全てのワークスペースを対象にはしない）
+func workspaceScope(ctx context.Context) (int, error) {
+	workspaceID, ok := auth.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return 0, ErrWorkspaceRequired
+	}
+	return workspaceID, nil
+}
+
+// userScope は一覧・検索を絞り込むユーザー（認証済みユーザー）のIDを返す
+// 未認証（バックグラウンドジョブなど）の場合は nil を返し、絞り込まない
+func userScope(ctx context.Context) *int {
+	return actorID(ctx)
+}
+
+// authorizeTodo は認証済みユーザーが todo に required 以上のロールでアクセスできることを検証する
+// プロジェクトのTodoはメンバーのロールで判定し、インボックスのTodoは所有者のみアクセスできる
+// 所有者のいないインボックスのTodoは認証済みユーザーからはアクセスできない
+func authorizeTodo(ctx context.Context, projectRepo repository.ProjectRepository, todo *models.Todo, required models.ProjectRole) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if todo.ProjectID != nil {
+		_, err := requireProjectRole(ctx, projectRepo, *todo.ProjectID, required)
+		return err
+	}
+	if todo.OwnerID == nil || *todo.OwnerID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// authorizeTodos は認証済みユーザーが todos の全てに required 以上のロールでアクセスできることを検証する
+// 子孫Todoは親と別のプロジェクトに属することがあるため、子孫をまとめて変更する前に子孫ごとに検証する
+func authorizeTodos(ctx context.Context, projectRepo repository.ProjectRepository, todos []models.Todo, required models.ProjectRole) error {
+	checked := make(map[int]bool)
+	for i := range todos {
+		todo := &todos[i]
+		// 同じプロジェクトのロールは1回だけ確認する
+		if todo.ProjectID != nil {
+			if checked[*todo.ProjectID] {
+				continue
+			}
+			checked[*todo.ProjectID] = true
+		}
+		if err := authorizeTodo(ctx, projectRepo, todo, required); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireProjectRole は認証済みユーザーがプロジェクトのメンバーで、required 以上のロールを持つことを検証し、そのロールを返す
+// 未認証の場合は空のロールを返し、検証しない
+func requireProjectRole(ctx context.Context, projectRepo repository.ProjectRepository, projectID int, required models.ProjectRole) (models.ProjectRole, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	role, err := projectRepo.GetMemberRole(projectID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !role.Includes(required) {
+		return "", ErrForbidden
+	}
+	return role, nil
+}
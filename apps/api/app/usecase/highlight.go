@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightOpenTag  = "<mark>"
+	highlightCloseTag = "</mark>"
+
+	// 説明の抜粋の長さ（文字数）と、最初の一致箇所より前に含める文字数
+	snippetLength = 100
+	snippetLead   = 30
+)
+
+// searchTerms は検索語を英数字・かな漢字などの連続（空白や記号で区切られた単位）に分割する
+func searchTerms(q string) [][]rune {
+	var terms [][]rune
+	for _, field := range strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		terms = append(terms, toLowerRunes([]rune(field)))
+	}
+	return terms
+}
+
+func toLowerRunes(runes []rune) []rune {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	return lower
+}
+
+// matchRanges は text 中で terms に一致する位置に印を付ける
+// DB側はbigram単位で照合しているため、語全体が見つからない場合は2文字ずつの一致で代用する
+func matchRanges(text []rune, terms [][]rune) []bool {
+	lower := toLowerRunes(text)
+	marked := make([]bool, len(text))
+
+	markAll := func(term []rune) bool {
+		found := false
+		for i := 0; i+len(term) <= len(lower); i++ {
+			if string(lower[i:i+len(term)]) == string(term) {
+				for j := i; j < i+len(term); j++ {
+					marked[j] = true
+				}
+				found = true
+			}
+		}
+		return found
+	}
+
+	for _, term := range terms {
+		if len(term) == 0 || markAll(term) || len(term) <= 2 {
+			continue
+		}
+		for i := 0; i+2 <= len(term); i++ {
+			markAll(term[i : i+2])
+		}
+	}
+
+	return marked
+}
+
+// renderHighlight は印の付いた範囲を <mark> で囲み、それ以外をHTMLエスケープして連結する
+func renderHighlight(text []rune, marked []bool) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(text[i:j]))
+		if marked[i] {
+			b.WriteString(highlightOpenTag + segment + highlightCloseTag)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// highlight は text 全体の一致箇所を強調表示する
+func highlight(text string, terms [][]rune) string {
+	runes := []rune(text)
+	return renderHighlight(runes, matchRanges(runes, terms))
+}
+
+// snippet は最初の一致箇所を含む snippetLength 文字程度の抜粋を強調表示付きで返す
+func snippet(text string, terms [][]rune) string {
+	runes := []rune(text)
+	marked := matchRanges(runes, terms)
+
+	start := 0
+	for i, m := range marked {
+		if m {
+			start = i - snippetLead
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	result := renderHighlight(runes[start:end], marked[start:end])
+	if start > 0 {
+		result = "…" + result
+	}
+	if end < len(runes) {
+		result += "…"
+	}
+	return result
+}
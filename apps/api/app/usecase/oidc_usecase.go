@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidOIDCState        = errors.New("invalid oidc state")
+	ErrOIDCLoginFailed         = errors.New("oidc login failed")
+	ErrOIDCEmailNotVerified    = errors.New("oidc email is not verified")
+	ErrOIDCProviderUnavailable = errors.New("oidc provider unavailable")
+	// ErrOIDCLinkRequired は IdP のメールアドレスのユーザーが既に存在し、自動では紐付けられない場合のエラー
+	ErrOIDCLinkRequired = errors.New("oidc account link required")
+	// ErrOIDCIdentityAlreadyLinked は IdP のアカウントが既に別のユーザーに紐付いている場合のエラー
+	ErrOIDCIdentityAlreadyLinked = errors.New("oidc identity already linked")
+)
+
+// IdentityProvider は OpenID Connect の IdP（auth.OIDCProvider）
+type IdentityProvider interface {
+	Issuer() string
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier string) (string, error)
+	VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*auth.IDTokenClaims, error)
+}
+
+type OIDCUsecase interface {
+	// StartLogin は認可リクエストの state / nonce / code_verifier を保存し、IdP の認可エンドポイントの URL を返す
+	StartLogin(ctx context.Context) (string, error)
+	// CompleteLogin は IdP から戻った state と認可コードを検証し、ユーザーにトークンを発行する
+	// 初めてログインした IdP のアカウントは新しいユーザーを作成する
+	// メールアドレスが一致するユーザーが既に存在する場合は、パスワードが未設定でメールアドレスを確認済みのユーザーにのみ紐付ける
+	// StartLink で開始した場合は、開始したユーザーに IdP のアカウントを紐付ける
+	CompleteLogin(ctx context.Context, state string, code string) (*models.AuthTokens, error)
+	// StartLink はログイン中のユーザーに IdP のアカウントを紐付ける認可リクエストを開始し、認可エンドポイントの URL を返す
+	StartLink(ctx context.Context) (string, error)
+}
+
+type oidcUsecase struct {
+	userRepo        repository.UserRepository
+	identityRepo    repository.UserIdentityRepository
+	authRequestRepo repository.OIDCAuthRequestRepository
+	txManager       repository.TxManager
+	provider        IdentityProvider
+	authUsecase     AuthUsecase
+}
+
+func NewOIDCUsecase(
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	authRequestRepo repository.OIDCAuthRequestRepository,
+	txManager repository.TxManager,
+	provider IdentityProvider,
+	authUsecase AuthUsecase,
+) OIDCUsecase {
+	return &oidcUsecase{
+		userRepo:        userRepo,
+		identityRepo:    identityRepo,
+		authRequestRepo: authRequestRepo,
+		txManager:       txManager,
+		provider:        provider,
+		authUsecase:     authUsecase,
+	}
+}
+
+func (u *oidcUsecase) StartLogin(ctx context.Context) (string, error) {
+	return u.startAuthRequest(ctx, nil)
+}
+
+func (u *oidcUsecase) StartLink(ctx context.Context) (string, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return "", ErrForbidden
+	}
+	return u.startAuthRequest(ctx, &userID)
+}
+
+// startAuthRequest は認可リクエストを保存し、認可エンドポイントの URL を返す（linkUserID はアカウントを紐付けるユーザー）
+func (u *oidcUsecase) startAuthRequest(ctx context.Context, linkUserID *int) (string, error) {
+	state, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+	codeVerifier, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	// 完了しなかった認可リクエストを掃除する（失敗してもログインは続ける）
+	if _, err := u.authRequestRepo.DeleteExpired(now); err != nil {
+		fmt.Printf("Failed to delete expired oidc auth requests: %v\n", err)
+	}
+	err = u.authRequestRepo.Create(&models.OIDCAuthRequest{
+		StateHash:    hashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(models.OIDCLoginTTL),
+		LinkUserID:   linkUserID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := u.provider.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrOIDCProviderUnavailable, err)
+	}
+	return authURL, nil
+}
+
+func (u *oidcUsecase) CompleteLogin(ctx context.Context, state string, code string) (*models.AuthTokens, error) {
+	if state == "" || code == "" {
+		return nil, ErrInvalidOIDCState
+	}
+
+	request, err := u.authRequestRepo.Consume(hashToken(state))
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !time.Now().Before(request.ExpiresAt) {
+		return nil, ErrInvalidOIDCState
+	}
+
+	rawIDToken, err := u.provider.Exchange(ctx, code, request.CodeVerifier)
+	if err != nil {
+		if errors.Is(err, auth.ErrOIDCDiscovery) {
+			return nil, fmt.Errorf("%w: %v", ErrOIDCProviderUnavailable, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginFailed, err)
+	}
+	claims, err := u.provider.VerifyIDToken(ctx, rawIDToken, request.Nonce)
+	if err != nil {
+		if errors.Is(err, auth.ErrOIDCDiscovery) {
+			return nil, fmt.Errorf("%w: %v", ErrOIDCProviderUnavailable, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginFailed, err)
+	}
+
+	var userID int
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		var err error
+		if request.LinkUserID != nil {
+			userID, err = u.linkUser(u.identityRepo.WithTx(tx), *request.LinkUserID, claims)
+			return err
+		}
+		userID, err = u.provisionUser(u.userRepo.WithTx(tx), u.identityRepo.WithTx(tx), claims)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.authUsecase.IssueTokens(ctx, userID)
+}
+
+// provisionUser は IdP のアカウントに紐付くユーザーのIDを返す（初回のログインではユーザーを紐付け・作成する）
+func (u *oidcUsecase) provisionUser(userRepo repository.UserRepository, identityRepo repository.UserIdentityRepository, claims *auth.IDTokenClaims) (int, error) {
+	now := time.Now()
+	var verifiedEmail *string
+	if email, ok := normalizeEmail(claims.Email); ok && bool(claims.EmailVerified) {
+		verifiedEmail = &email
+	}
+
+	identity, err := identityRepo.GetByIssuerAndSubject(u.provider.Issuer(), claims.Subject)
+	if err != nil {
+		return 0, err
+	}
+	if identity != nil {
+		if err := identityRepo.TouchLastLogin(identity.ID, verifiedEmail, now); err != nil {
+			return 0, err
+		}
+		return identity.UserID, nil
+	}
+
+	// 確認されていないメールアドレスで既存のユーザーに紐付けると、アカウントを乗っ取られるおそれがある
+	if verifiedEmail == nil {
+		return 0, ErrOIDCEmailNotVerified
+	}
+
+	user, err := userRepo.GetByEmail(*verifiedEmail)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		user, err = userRepo.Create(&models.User{Name: oidcUserName(claims, *verifiedEmail), Email: *verifiedEmail, EmailVerifiedAt: &now})
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		// 他人が作成したユーザーや、パスワードを知っている人がいるユーザーに紐付けると、
+		// 作成した人がパスワードでログインしてアカウントを乗っ取れるため、ログイン済みのユーザー本人に紐付けてもらう
+		hash, err := userRepo.GetPasswordHash(user.ID)
+		if err != nil {
+			return 0, err
+		}
+		if hash != "" || user.EmailVerifiedAt == nil {
+			return 0, ErrOIDCLinkRequired
+		}
+	}
+
+	_, err = identityRepo.Create(&models.UserIdentity{
+		UserID:      user.ID,
+		Issuer:      u.provider.Issuer(),
+		Subject:     claims.Subject,
+		Email:       verifiedEmail,
+		LastLoginAt: &now,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// linkUser は IdP のアカウントを userID のユーザーに紐付ける（紐付け済みの場合は何もしない）
+func (u *oidcUsecase) linkUser(identityRepo repository.UserIdentityRepository, userID int, claims *auth.IDTokenClaims) (int, error) {
+	now := time.Now()
+	var email *string
+	if normalized, ok := normalizeEmail(claims.Email); ok && bool(claims.EmailVerified) {
+		email = &normalized
+	}
+
+	identity, err := identityRepo.GetByIssuerAndSubject(u.provider.Issuer(), claims.Subject)
+	if err != nil {
+		return 0, err
+	}
+	if identity != nil {
+		if identity.UserID != userID {
+			return 0, ErrOIDCIdentityAlreadyLinked
+		}
+		return userID, identityRepo.TouchLastLogin(identity.ID, email, now)
+	}
+
+	_, err = identityRepo.Create(&models.UserIdentity{
+		UserID:      userID,
+		Issuer:      u.provider.Issuer(),
+		Subject:     claims.Subject,
+		Email:       email,
+		LastLoginAt: &now,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateIdentity) {
+			return 0, ErrOIDCIdentityAlreadyLinked
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+// oidcUserName は作成するユーザーの名前を返す（name クレームがない場合はメールアドレスの @ より前を使う）
+func oidcUserName(claims *auth.IDTokenClaims, email string) string {
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	return truncateRunes(name, models.MaxUserNameLength)
+}
+
+// truncateRunes は s を先頭から n 文字までに切り詰める
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
@@ -0,0 +1,184 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	extMock "api/app/external/mock"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCUsecase(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	ctx := context.Background()
+
+	// IdP はプロセス内で起動し、外部のネットワークには接続しない
+	idp := test.NewFakeOIDCProvider("todo-api", "client-secret")
+	defer idp.Close()
+	provider := auth.NewOIDCProvider(auth.OIDCConfig{
+		Issuer:       idp.Issuer(),
+		ClientID:     "todo-api",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost:5173/auth/callback",
+	})
+
+	key, err := auth.NewHS256Key("test", []byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	signer, err := auth.NewJWTSigner(key, "", "", 15*time.Minute)
+	require.NoError(t, err)
+	verifier := auth.NewJWTVerifier([]auth.Key{key})
+
+	userRepo := repository.NewUserRepository(db)
+	txManager := repository.NewTxManager(db)
+	authUsecase := usecase.NewAuthUsecase(userRepo, repository.NewRefreshTokenRepository(db), repository.NewPasswordResetTokenRepository(db), txManager, signer, extMock.NewMockNotificationClient(t), usecase.AuthSettings{
+		RefreshTokenTTL:  time.Hour,
+		PasswordResetTTL: 30 * time.Minute,
+	})
+	oidcUsecase := usecase.NewOIDCUsecase(userRepo, repository.NewUserIdentityRepository(db), repository.NewOIDCAuthRequestRepository(db), txManager, provider, authUsecase)
+
+	// login は認可リクエストを開始し、IdP で user がログインしてコールバックするまでを行う
+	login := func(t *testing.T, user test.FakeOIDCUser) (*models.AuthTokens, error) {
+		t.Helper()
+		authURL, err := oidcUsecase.StartLogin(ctx)
+		require.NoError(t, err)
+		state, code, err := idp.Authorize(authURL, user)
+		require.NoError(t, err)
+		return oidcUsecase.CompleteLogin(ctx, state, code)
+	}
+	// link はログイン中の userID のユーザーとして IdP のアカウントの紐付けを開始し、コールバックするまでを行う
+	link := func(t *testing.T, userID int, user test.FakeOIDCUser) (*models.AuthTokens, error) {
+		t.Helper()
+		authURL, err := oidcUsecase.StartLink(auth.WithUserID(ctx, userID))
+		require.NoError(t, err)
+		state, code, err := idp.Authorize(authURL, user)
+		require.NoError(t, err)
+		return oidcUsecase.CompleteLogin(ctx, state, code)
+	}
+	// userIDOf はアクセストークンのユーザーIDを返す
+	userIDOf := func(t *testing.T, tokens *models.AuthTokens) int {
+		t.Helper()
+		claims, err := verifier.Verify(tokens.AccessToken)
+		require.NoError(t, err)
+		userID, err := claims.UserID()
+		require.NoError(t, err)
+		return userID
+	}
+
+	t.Run("First login provisions a user", func(t *testing.T) {
+		alice := test.FakeOIDCUser{Subject: "alice-sub", Email: "Alice@Example.com", EmailVerified: true, Name: "Alice"}
+		tokens, err := login(t, alice)
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokens.RefreshToken)
+
+		user, err := userRepo.GetByID(userIDOf(t, tokens))
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, "Alice", user.Name)
+		assert.Equal(t, "alice@example.com", user.Email)
+
+		// 2回目以降は sub で同じユーザーを識別する（IdP 側でメールアドレスを変更しても同じユーザー）
+		alice.Email = "alice@new.example.com"
+		again, err := login(t, alice)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, userIDOf(t, again))
+	})
+
+	t.Run("Verified email links an existing passwordless user with a verified email", func(t *testing.T) {
+		verifiedAt := time.Now()
+		bob, err := userRepo.Create(&models.User{Name: "Bob", Email: "bob@example.com", EmailVerifiedAt: &verifiedAt})
+		require.NoError(t, err)
+
+		tokens, err := login(t, test.FakeOIDCUser{Subject: "bob-sub", Email: "bob@example.com", EmailVerified: true})
+		require.NoError(t, err)
+		assert.Equal(t, bob.ID, userIDOf(t, tokens))
+	})
+
+	t.Run("Existing user with a password is not linked automatically", func(t *testing.T) {
+		// 攻撃者が先に被害者のメールアドレスでユーザーを作成し、パスワードを設定していた場合
+		victimID := createTestUser(t, db, "victim@example.com")
+		hash, err := auth.HashPassword("attacker password")
+		require.NoError(t, err)
+		require.NoError(t, userRepo.SetPasswordHash(victimID, hash))
+		victim := test.FakeOIDCUser{Subject: "victim-sub", Email: "victim@example.com", EmailVerified: true}
+
+		_, err = login(t, victim)
+		assert.ErrorIs(t, err, usecase.ErrOIDCLinkRequired)
+
+		// ログイン済みの本人が紐付けた後は IdP でログインできる
+		tokens, err := link(t, victimID, victim)
+		require.NoError(t, err)
+		assert.Equal(t, victimID, userIDOf(t, tokens))
+		again, err := login(t, victim)
+		require.NoError(t, err)
+		assert.Equal(t, victimID, userIDOf(t, again))
+	})
+
+	t.Run("Existing user with an unverified email is not linked automatically", func(t *testing.T) {
+		createTestUser(t, db, "erin@example.com")
+
+		_, err := login(t, test.FakeOIDCUser{Subject: "erin-sub", Email: "erin@example.com", EmailVerified: true})
+		assert.ErrorIs(t, err, usecase.ErrOIDCLinkRequired)
+	})
+
+	t.Run("Changing the email resets verification and blocks automatic linking", func(t *testing.T) {
+		// IdP でログインしたパスワードのないユーザーが、未登録の被害者のメールアドレスに変更した場合
+		attacker := test.FakeOIDCUser{Subject: "mallory-sub", Email: "mallory@example.com", EmailVerified: true}
+		tokens, err := login(t, attacker)
+		require.NoError(t, err)
+		attackerID := userIDOf(t, tokens)
+
+		userUsecase := usecase.NewUserUsecase(userRepo)
+		updated, err := userUsecase.UpdateUser(auth.WithUserID(ctx, attackerID), attackerID, &models.User{Email: "frank@example.com"})
+		require.NoError(t, err)
+		assert.Nil(t, updated.EmailVerifiedAt)
+
+		// 被害者の初回のログインは攻撃者のユーザーに紐付かない
+		_, err = login(t, test.FakeOIDCUser{Subject: "frank-sub", Email: "frank@example.com", EmailVerified: true})
+		assert.ErrorIs(t, err, usecase.ErrOIDCLinkRequired)
+
+		// 同じメールアドレスへの変更（名前のみの変更を含む）では確認済みの日時は残る
+		verifiedAt := time.Now()
+		grace, err := userRepo.Create(&models.User{Name: "Grace", Email: "grace@example.com", EmailVerifiedAt: &verifiedAt})
+		require.NoError(t, err)
+		updated, err = userUsecase.UpdateUser(auth.WithUserID(ctx, grace.ID), grace.ID, &models.User{Name: "Grace H", Email: "Grace@Example.com"})
+		require.NoError(t, err)
+		assert.NotNil(t, updated.EmailVerifiedAt)
+	})
+
+	t.Run("Account linked to another user cannot be linked", func(t *testing.T) {
+		frankID := createTestUser(t, db, "frank@example.com")
+
+		_, err := link(t, frankID, test.FakeOIDCUser{Subject: "alice-sub", Email: "alice@new.example.com", EmailVerified: true})
+		assert.ErrorIs(t, err, usecase.ErrOIDCIdentityAlreadyLinked)
+	})
+
+	t.Run("Unverified email is not provisioned", func(t *testing.T) {
+		createTestUser(t, db, "carol@example.com")
+
+		_, err := login(t, test.FakeOIDCUser{Subject: "mallory-sub", Email: "carol@example.com", EmailVerified: false})
+		assert.ErrorIs(t, err, usecase.ErrOIDCEmailNotVerified)
+	})
+
+	t.Run("State and code are single use", func(t *testing.T) {
+		authURL, err := oidcUsecase.StartLogin(ctx)
+		require.NoError(t, err)
+		state, code, err := idp.Authorize(authURL, test.FakeOIDCUser{Subject: "dave-sub", Email: "dave@example.com", EmailVerified: true})
+		require.NoError(t, err)
+
+		_, err = oidcUsecase.CompleteLogin(ctx, "unknown-state", code)
+		assert.ErrorIs(t, err, usecase.ErrInvalidOIDCState)
+		_, err = oidcUsecase.CompleteLogin(ctx, state, "unknown-code")
+		assert.ErrorIs(t, err, usecase.ErrOIDCLoginFailed)
+		// 失敗した state も再利用できない
+		_, err = oidcUsecase.CompleteLogin(ctx, state, code)
+		assert.ErrorIs(t, err, usecase.ErrInvalidOIDCState)
+	})
+}
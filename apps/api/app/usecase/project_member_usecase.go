@@ -0,0 +1,442 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrNotProjectMember = errors.New("user is not a project member")
+	// ErrNotWorkspaceMember はプロジェクトのワークスペースに所属していないユーザーを招待した場合のエラー
+	ErrNotWorkspaceMember      = errors.New("user is not a workspace member")
+	ErrAlreadyProjectMember    = errors.New("user is already a project member")
+	ErrInvitationNotFound      = errors.New("invitation not found")
+	ErrInvitationAlreadyExists = errors.New("invitation already exists")
+	// ErrLastProjectOwner はプロジェクトからオーナーがいなくなる変更の場合のエラー
+	ErrLastProjectOwner = errors.New("project must have at least one owner")
+)
+
+type ProjectMemberUsecase interface {
+	GetMembers(ctx context.Context, projectID int) ([]models.ProjectMember, error)
+	UpdateMemberRole(ctx context.Context, projectID int, userID int, role models.ProjectRole) (*models.ProjectMember, error)
+	// RemoveMember はメンバーを削除する（オーナー以外のメンバーは自分自身のみ削除＝退出できる）
+	RemoveMember(ctx context.Context, projectID int, userID int) error
+
+	// InviteMember は email のユーザーを role のメンバーとしてプロジェクトに招待する
+	InviteMember(ctx context.Context, projectID int, email string, role models.ProjectRole) (*models.ProjectInvitation, error)
+	GetProjectInvitations(ctx context.Context, projectID int) ([]models.ProjectInvitation, error)
+	RevokeInvitation(ctx context.Context, projectID int, invitationID int) error
+	// GetMyInvitations は認証済みユーザーが受け取った未回答の招待を返す
+	GetMyInvitations(ctx context.Context) ([]models.ProjectInvitation, error)
+	AcceptInvitation(ctx context.Context, invitationID int) (*models.ProjectInvitation, error)
+	DeclineInvitation(ctx context.Context, invitationID int) (*models.ProjectInvitation, error)
+}
+
+type projectMemberUsecase struct {
+	projectRepo        repository.ProjectRepository
+	invitationRepo     repository.ProjectInvitationRepository
+	userRepo           repository.UserRepository
+	workspaceRepo      repository.WorkspaceRepository
+	txManager          repository.TxManager
+	notificationClient external.NotificationClient
+	// 操作対象のワークスペース（inWorkspace で設定する）
+	workspaceID int
+}
+
+func NewProjectMemberUsecase(projectRepo repository.ProjectRepository, invitationRepo repository.ProjectInvitationRepository, userRepo repository.UserRepository, workspaceRepo repository.WorkspaceRepository, txManager repository.TxManager, notificationClient external.NotificationClient) ProjectMemberUsecase {
+	return &projectMemberUsecase{
+		projectRepo:        projectRepo,
+		invitationRepo:     invitationRepo,
+		userRepo:           userRepo,
+		workspaceRepo:      workspaceRepo,
+		txManager:          txManager,
+		notificationClient: notificationClient,
+	}
+}
+
+// inWorkspace は ctx のワークスペースのプロジェクトと招待のみを扱う projectMemberUsecase を返す
+func (u *projectMemberUsecase) inWorkspace(ctx context.Context) (*projectMemberUsecase, error) {
+	workspaceID, err := workspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &projectMemberUsecase{
+		projectRepo:        u.projectRepo.WithWorkspace(workspaceID),
+		invitationRepo:     u.invitationRepo.WithWorkspace(workspaceID),
+		userRepo:           u.userRepo,
+		workspaceRepo:      u.workspaceRepo,
+		txManager:          u.txManager,
+		notificationClient: u.notificationClient,
+		workspaceID:        workspaceID,
+	}, nil
+}
+
+func (u *projectMemberUsecase) GetMembers(ctx context.Context, projectID int) ([]models.ProjectMember, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := findProject(ctx, u.projectRepo, projectID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	members, err := u.projectRepo.GetMembers(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return empty slice instead of nil for consistency
+	if members == nil {
+		return []models.ProjectMember{}, nil
+	}
+	return members, nil
+}
+
+func (u *projectMemberUsecase) UpdateMemberRole(ctx context.Context, projectID int, userID int, role models.ProjectRole) (*models.ProjectMember, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if userID <= 0 || !role.IsValid() {
+		return nil, ErrInvalidInput
+	}
+	project, err := findProject(ctx, u.projectRepo, projectID, models.ProjectRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *models.ProjectMember
+	var previous models.ProjectRole
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		projectRepo := u.projectRepo.WithTx(tx)
+
+		var err error
+		if previous, err = projectRepo.GetMemberRole(projectID, userID); err != nil {
+			return err
+		}
+		if previous == "" {
+			return ErrNotProjectMember
+		}
+		if previous == models.ProjectRoleOwner && role != models.ProjectRoleOwner {
+			if err := ensureAnotherOwner(projectRepo, projectID); err != nil {
+				return err
+			}
+		}
+
+		if updated, err = projectRepo.UpdateMemberRole(projectID, userID, role); err != nil {
+			return err
+		}
+		if updated == nil {
+			return ErrNotProjectMember
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != role {
+		u.notify(ctx, userID, "プロジェクトのロールが変更されました",
+			fmt.Sprintf("「%s」でのロールが %s から %s に変更されました", project.Name, previous, role))
+	}
+	return updated, nil
+}
+
+func (u *projectMemberUsecase) RemoveMember(ctx context.Context, projectID int, userID int) error {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return err
+	}
+	if userID <= 0 {
+		return ErrInvalidInput
+	}
+
+	// 自分自身の退出はロールに関係なく行える
+	required := models.ProjectRoleOwner
+	if actor, ok := auth.UserIDFromContext(ctx); ok && actor == userID {
+		required = models.ProjectRoleViewer
+	}
+	project, err := findProject(ctx, u.projectRepo, projectID, required)
+	if err != nil {
+		return err
+	}
+
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		projectRepo := u.projectRepo.WithTx(tx)
+
+		role, err := projectRepo.GetMemberRole(projectID, userID)
+		if err != nil {
+			return err
+		}
+		if role == "" {
+			return ErrNotProjectMember
+		}
+		if role == models.ProjectRoleOwner {
+			if err := ensureAnotherOwner(projectRepo, projectID); err != nil {
+				return err
+			}
+		}
+
+		if err := projectRepo.RemoveMember(projectID, userID); err != nil {
+			if errors.Is(err, repository.ErrNoRows) {
+				return ErrNotProjectMember
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	u.notify(ctx, userID, "プロジェクトから削除されました",
+		fmt.Sprintf("「%s」のメンバーから削除されました", project.Name))
+	return nil
+}
+
+func (u *projectMemberUsecase) InviteMember(ctx context.Context, projectID int, email string, role models.ProjectRole) (*models.ProjectInvitation, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	email, ok := normalizeEmail(email)
+	if !ok || !role.IsValid() {
+		return nil, ErrInvalidInput
+	}
+	project, err := findProject(ctx, u.projectRepo, projectID, models.ProjectRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	invitee, err := u.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if invitee == nil {
+		return nil, ErrUserNotFound
+	}
+	// ワークスペースのメンバーのみ招待できる
+	workspaceRole, err := u.workspaceRepo.GetMemberRole(u.workspaceID, invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if workspaceRole == "" {
+		return nil, ErrNotWorkspaceMember
+	}
+
+	current, err := u.projectRepo.GetMemberRole(projectID, invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current != "" {
+		return nil, ErrAlreadyProjectMember
+	}
+
+	invitation, err := u.invitationRepo.Create(&models.ProjectInvitation{
+		ProjectID: projectID,
+		InviterID: actorID(ctx),
+		InviteeID: invitee.ID,
+		Role:      role,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateInvitation) {
+			return nil, ErrInvitationAlreadyExists
+		}
+		return nil, err
+	}
+
+	u.notify(ctx, invitee.ID, "プロジェクトに招待されました",
+		fmt.Sprintf("「%s」に %s として招待されました", project.Name, role))
+	return invitation, nil
+}
+
+func (u *projectMemberUsecase) GetProjectInvitations(ctx context.Context, projectID int) ([]models.ProjectInvitation, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := findProject(ctx, u.projectRepo, projectID, models.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	invitations, err := u.invitationRepo.GetPendingByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return invitationsOrEmpty(invitations), nil
+}
+
+func (u *projectMemberUsecase) RevokeInvitation(ctx context.Context, projectID int, invitationID int) error {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return err
+	}
+	if invitationID <= 0 {
+		return ErrInvalidInput
+	}
+	if _, err := findProject(ctx, u.projectRepo, projectID, models.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	invitation, err := u.invitationRepo.GetByID(invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation == nil || invitation.ProjectID != projectID {
+		return ErrInvitationNotFound
+	}
+
+	revoked, err := u.invitationRepo.Respond(invitationID, models.InvitationRevoked, time.Now())
+	if err != nil {
+		return err
+	}
+	if revoked == nil {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (u *projectMemberUsecase) GetMyInvitations(ctx context.Context) ([]models.ProjectInvitation, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+
+	invitations, err := u.invitationRepo.GetPendingByInviteeID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return invitationsOrEmpty(invitations), nil
+}
+
+func (u *projectMemberUsecase) AcceptInvitation(ctx context.Context, invitationID int) (*models.ProjectInvitation, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invitation, err := u.getOwnInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var accepted *models.ProjectInvitation
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		var err error
+		accepted, err = u.invitationRepo.WithTx(tx).Respond(invitation.ID, models.InvitationAccepted, time.Now())
+		if err != nil {
+			return err
+		}
+		// 他のリクエストで回答済みの場合
+		if accepted == nil {
+			return ErrInvitationNotFound
+		}
+		return u.projectRepo.WithTx(tx).AddMember(accepted.ProjectID, accepted.InviteeID, accepted.Role)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if accepted.InviterID != nil {
+		u.notify(ctx, *accepted.InviterID, "プロジェクトへの招待が承諾されました",
+			fmt.Sprintf("「%s」への招待が承諾されました", accepted.ProjectName))
+	}
+	return accepted, nil
+}
+
+func (u *projectMemberUsecase) DeclineInvitation(ctx context.Context, invitationID int) (*models.ProjectInvitation, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invitation, err := u.getOwnInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	declined, err := u.invitationRepo.Respond(invitation.ID, models.InvitationDeclined, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if declined == nil {
+		return nil, ErrInvitationNotFound
+	}
+
+	if declined.InviterID != nil {
+		u.notify(ctx, *declined.InviterID, "プロジェクトへの招待が辞退されました",
+			fmt.Sprintf("「%s」への招待が辞退されました", declined.ProjectName))
+	}
+	return declined, nil
+}
+
+// getOwnInvitation は認証済みユーザーが受け取った未回答の招待を返す
+// 他のユーザーの招待は存在を明かさないため ErrInvitationNotFound を返す
+func (u *projectMemberUsecase) getOwnInvitation(ctx context.Context, invitationID int) (*models.ProjectInvitation, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	if invitationID <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	invitation, err := u.invitationRepo.GetByID(invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil || invitation.InviteeID != userID || invitation.Status != models.InvitationPending {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+// notify はメンバーシップの変更を影響を受けるユーザーにプッシュ通知する
+// 自分自身の操作は通知しない
+func (u *projectMemberUsecase) notify(ctx context.Context, userID int, title string, message string) {
+	if actor, ok := auth.UserIDFromContext(ctx); ok && actor == userID {
+		return
+	}
+
+	notificationReq := &external.NotificationRequest{
+		UserID:  userID,
+		Title:   title,
+		Message: message,
+		Type:    "push",
+	}
+
+	// 通知送信（エラーが発生してもメンバーシップの変更は成功として扱う）
+	if _, err := u.notificationClient.SendNotification(ctx, notificationReq); err != nil {
+		// ログに記録するだけで、エラーは返さない
+		fmt.Printf("Failed to send notification: %v\n", err)
+	}
+}
+
+// ensureAnotherOwner はオーナーが1人しかいない場合に ErrLastProjectOwner を返す
+// 同時にオーナーを外す操作でオーナーがいなくならないよう、オーナーの行をロックして数える
+func ensureAnotherOwner(projectRepo repository.ProjectRepository, projectID int) error {
+	owners, err := projectRepo.CountOwnersForUpdate(projectID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastProjectOwner
+	}
+	return nil
+}
+
+// Return empty slice instead of nil for consistency
+func invitationsOrEmpty(invitations []models.ProjectInvitation) []models.ProjectInvitation {
+	if invitations == nil {
+		return []models.ProjectInvitation{}
+	}
+	return invitations
+}
@@ -0,0 +1,245 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	extMock "api/app/external/mock"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectMemberUsecase(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	todoRepo := repository.NewTodoRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	eventRepo := repository.NewTodoEventRepository(db)
+	txManager := repository.NewTxManager(db)
+
+	// 通知先のユーザーIDを記録する
+	var notified []int
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, req *external.NotificationRequest) {
+			assert.Equal(t, "push", req.Type)
+			notified = append(notified, req.UserID)
+		}).
+		Return(&external.NotificationResponse{Status: "sent"}, nil).
+		Maybe()
+
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, tagRepo, projectRepo, eventRepo, txManager, mockNotificationClient)
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, todoRepo, eventRepo, txManager)
+	trashUsecase := usecase.NewTrashUsecase(todoRepo, tagRepo, projectRepo, eventRepo, repository.NewWorkspaceRepository(db), txManager, 30*24*time.Hour)
+	memberUsecase := usecase.NewProjectMemberUsecase(projectRepo, repository.NewProjectInvitationRepository(db), repository.NewUserRepository(db), repository.NewWorkspaceRepository(db), txManager, mockNotificationClient)
+
+	aliceID := createTestUser(t, db, "alice@example.com")
+	bobID := createTestUser(t, db, "bob@example.com")
+	carolID := createTestUser(t, db, "carol@example.com")
+	workspace := auth.WithWorkspaceID(context.Background(), createTestWorkspace(t, db, "test", aliceID, bobID, carolID))
+	alice := auth.WithUserID(workspace, aliceID)
+	bob := auth.WithUserID(workspace, bobID)
+	carol := auth.WithUserID(workspace, carolID)
+
+	project, err := projectUsecase.CreateProject(alice, &models.Project{Name: "チーム"})
+	require.NoError(t, err)
+	assert.Equal(t, models.ProjectRoleOwner, project.Role)
+	todo, err := todoUsecase.CreateTodo(alice, &models.Todo{Title: "共有タスク", ProjectID: &project.ID})
+	require.NoError(t, err)
+
+	// invite は alice が user を role で招待し、user が承諾する
+	invite := func(t *testing.T, user context.Context, email string, role models.ProjectRole) {
+		invitation, err := memberUsecase.InviteMember(alice, project.ID, email, role)
+		require.NoError(t, err)
+		_, err = memberUsecase.AcceptInvitation(user, invitation.ID)
+		require.NoError(t, err)
+	}
+
+	t.Run("Invitations notify the invitee and the inviter", func(t *testing.T) {
+		notified = nil
+		invitation, err := memberUsecase.InviteMember(alice, project.ID, " Bob@Example.com ", models.ProjectRoleViewer)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvitationPending, invitation.Status)
+		assert.Equal(t, "チーム", invitation.ProjectName)
+
+		_, err = memberUsecase.InviteMember(alice, project.ID, "bob@example.com", models.ProjectRoleEditor)
+		assert.ErrorIs(t, err, usecase.ErrInvitationAlreadyExists)
+		_, err = memberUsecase.InviteMember(alice, project.ID, "nobody@example.com", models.ProjectRoleEditor)
+		assert.ErrorIs(t, err, usecase.ErrUserNotFound)
+
+		// 招待されたユーザー以外は承諾できない
+		_, err = memberUsecase.AcceptInvitation(carol, invitation.ID)
+		assert.ErrorIs(t, err, usecase.ErrInvitationNotFound)
+
+		received, err := memberUsecase.GetMyInvitations(bob)
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+
+		accepted, err := memberUsecase.AcceptInvitation(bob, invitation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvitationAccepted, accepted.Status)
+		_, err = memberUsecase.AcceptInvitation(bob, invitation.ID)
+		assert.ErrorIs(t, err, usecase.ErrInvitationNotFound)
+		_, err = memberUsecase.InviteMember(alice, project.ID, "bob@example.com", models.ProjectRoleEditor)
+		assert.ErrorIs(t, err, usecase.ErrAlreadyProjectMember)
+
+		assert.Equal(t, []int{bobID, aliceID}, notified)
+	})
+
+	t.Run("Viewers can read but not write", func(t *testing.T) {
+		saved, err := todoUsecase.GetTodoByID(bob, todo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, todo.ID, saved.ID)
+
+		page, err := todoUsecase.GetAllTodos(bob, models.TodoListQuery{})
+		require.NoError(t, err)
+		require.Len(t, page.Todos, 1)
+
+		projects, err := projectUsecase.GetProjects(bob, models.ProjectListQuery{})
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		assert.Equal(t, models.ProjectRoleViewer, projects[0].Role)
+
+		_, err = todoUsecase.UpdateTodo(bob, todo.ID, &models.Todo{Title: "変更"}, usecase.UpdateTodoOptions{})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = todoUsecase.CreateTodo(bob, &models.Todo{Title: "追加", ProjectID: &project.ID})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, todoUsecase.DeleteTodo(bob, todo.ID, usecase.DeleteTodoOptions{}), usecase.ErrForbidden)
+		_, err = memberUsecase.GetProjectInvitations(bob, project.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+
+		// メンバー以外は閲覧もできない
+		_, err = todoUsecase.GetTodoByID(carol, todo.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = memberUsecase.GetMembers(carol, project.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+	})
+
+	t.Run("Editors can write todos but not manage the project", func(t *testing.T) {
+		notified = nil
+		member, err := memberUsecase.UpdateMemberRole(alice, project.ID, bobID, models.ProjectRoleEditor)
+		require.NoError(t, err)
+		assert.Equal(t, models.ProjectRoleEditor, member.Role)
+		assert.Equal(t, "bob@example.com", member.UserEmail)
+		assert.Equal(t, []int{bobID}, notified)
+
+		updated, err := todoUsecase.UpdateTodo(bob, todo.ID, &models.Todo{Title: "編集者が変更"}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "編集者が変更", updated.Title)
+		_, err = todoUsecase.CreateTodo(bob, &models.Todo{Title: "編集者が追加", ProjectID: &project.ID})
+		require.NoError(t, err)
+
+		name := "乗っ取り"
+		_, err = projectUsecase.UpdateProject(bob, project.ID, models.ProjectUpdate{Name: &name})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = memberUsecase.InviteMember(bob, project.ID, "carol@example.com", models.ProjectRoleOwner)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, memberUsecase.RemoveMember(bob, project.ID, aliceID), usecase.ErrForbidden)
+	})
+
+	t.Run("Subtasks in another project need a role in that project", func(t *testing.T) {
+		// alice のみがメンバーのプロジェクトにあるサブタスクを、bob が編集者のプロジェクトのTodoの下に作成する
+		private, err := projectUsecase.CreateProject(alice, &models.Project{Name: "非公開"})
+		require.NoError(t, err)
+		parent, err := todoUsecase.CreateTodo(alice, &models.Todo{Title: "親", ProjectID: &project.ID})
+		require.NoError(t, err)
+		child, err := todoUsecase.CreateTodo(alice, &models.Todo{Title: "別プロジェクトの子", ParentID: &parent.ID, ProjectID: &private.ID})
+		require.NoError(t, err)
+
+		// 親のプロジェクトの編集者でも、子孫をまとめて変更できない
+		assert.ErrorIs(t, todoUsecase.DeleteTodo(bob, parent.ID, usecase.DeleteTodoOptions{}), usecase.ErrForbidden)
+		_, err = todoUsecase.PatchTodo(bob, parent.ID, models.TodoPatch{Completed: models.NewNullable(true)}, usecase.UpdateTodoOptions{CascadeCompletion: true})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		saved, err := todoUsecase.GetTodoByID(alice, child.ID)
+		require.NoError(t, err)
+		assert.False(t, saved.Completed)
+
+		// ゴミ箱に移動した後も、子孫を含めて戻す・完全に削除することはできない
+		require.NoError(t, todoUsecase.DeleteTodo(alice, parent.ID, usecase.DeleteTodoOptions{}))
+		_, err = trashUsecase.RestoreTodo(bob, parent.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, trashUsecase.PurgeTodo(bob, parent.ID), usecase.ErrForbidden)
+		_, err = trashUsecase.RestoreTodo(alice, parent.ID)
+		require.NoError(t, err)
+
+		// 自分が所有するプロジェクトでも、他のプロジェクトにあるサブタスクごと削除することはできない
+		owned, err := projectUsecase.CreateProject(bob, &models.Project{Name: "bob のプロジェクト"})
+		require.NoError(t, err)
+		invitation, err := memberUsecase.InviteMember(bob, owned.ID, "alice@example.com", models.ProjectRoleEditor)
+		require.NoError(t, err)
+		_, err = memberUsecase.AcceptInvitation(alice, invitation.ID)
+		require.NoError(t, err)
+		ownedParent, err := todoUsecase.CreateTodo(bob, &models.Todo{Title: "bob の親", ProjectID: &owned.ID})
+		require.NoError(t, err)
+		_, err = todoUsecase.CreateTodo(alice, &models.Todo{Title: "alice の子", ParentID: &ownedParent.ID, ProjectID: &private.ID})
+		require.NoError(t, err)
+		assert.ErrorIs(t, projectUsecase.DeleteProject(bob, owned.ID, models.ProjectDeleteCascade), usecase.ErrForbidden)
+
+		// 全ての子孫のプロジェクトのロールがあれば削除できる
+		require.NoError(t, todoUsecase.DeleteTodo(alice, parent.ID, usecase.DeleteTodoOptions{}))
+		require.NoError(t, trashUsecase.PurgeTodo(alice, parent.ID))
+		_, err = todoUsecase.GetTodoByID(alice, child.ID)
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+	})
+
+	t.Run("The last owner cannot be demoted or removed", func(t *testing.T) {
+		_, err := memberUsecase.UpdateMemberRole(alice, project.ID, aliceID, models.ProjectRoleEditor)
+		assert.ErrorIs(t, err, usecase.ErrLastProjectOwner)
+		assert.ErrorIs(t, memberUsecase.RemoveMember(alice, project.ID, aliceID), usecase.ErrLastProjectOwner)
+		_, err = memberUsecase.UpdateMemberRole(alice, project.ID, carolID, models.ProjectRoleEditor)
+		assert.ErrorIs(t, err, usecase.ErrNotProjectMember)
+		_, err = memberUsecase.UpdateMemberRole(alice, project.ID, bobID, "admin")
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+	})
+
+	t.Run("Removed members lose access and are notified", func(t *testing.T) {
+		invite(t, carol, "carol@example.com", models.ProjectRoleEditor)
+
+		notified = nil
+		require.NoError(t, memberUsecase.RemoveMember(alice, project.ID, carolID))
+		assert.Equal(t, []int{carolID}, notified)
+		_, err := todoUsecase.GetTodoByID(carol, todo.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+
+		// 自分自身の退出は通知しない
+		invite(t, carol, "carol@example.com", models.ProjectRoleViewer)
+		notified = nil
+		require.NoError(t, memberUsecase.RemoveMember(carol, project.ID, carolID))
+		assert.Empty(t, notified)
+
+		members, err := memberUsecase.GetMembers(alice, project.ID)
+		require.NoError(t, err)
+		assert.Len(t, members, 2)
+	})
+
+	t.Run("Revoked and declined invitations cannot be accepted", func(t *testing.T) {
+		invitation, err := memberUsecase.InviteMember(alice, project.ID, "carol@example.com", models.ProjectRoleViewer)
+		require.NoError(t, err)
+		require.NoError(t, memberUsecase.RevokeInvitation(alice, project.ID, invitation.ID))
+		_, err = memberUsecase.AcceptInvitation(carol, invitation.ID)
+		assert.ErrorIs(t, err, usecase.ErrInvitationNotFound)
+
+		invitation, err = memberUsecase.InviteMember(alice, project.ID, "carol@example.com", models.ProjectRoleViewer)
+		require.NoError(t, err)
+		declined, err := memberUsecase.DeclineInvitation(carol, invitation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvitationDeclined, declined.Status)
+
+		pending, err := memberUsecase.GetProjectInvitations(alice, project.ID)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+		_, err = todoUsecase.GetTodoByID(carol, todo.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+	})
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrProjectArchived = errors.New("project is archived")
+)
+
+// projectColorPattern はプロジェクトの色（#rrggbb 形式）
+var projectColorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+type ProjectUsecase interface {
+	GetProjects(ctx context.Context, query models.ProjectListQuery) ([]models.Project, error)
+	GetProjectByID(ctx context.Context, id int) (*models.Project, error)
+	CreateProject(ctx context.Context, project *models.Project) (*models.Project, error)
+	UpdateProject(ctx context.Context, id int, update models.ProjectUpdate) (*models.Project, error)
+	// DeleteProject はプロジェクトを削除し、Todoを mode に応じてインボックスまたはゴミ箱に移動する
+	DeleteProject(ctx context.Context, id int, mode models.ProjectDeleteMode) error
+}
+
+type projectUsecase struct {
+	projectRepo repository.ProjectRepository
+	todoRepo    repository.TodoRepository
+	eventRepo   repository.TodoEventRepository
+	txManager   repository.TxManager
+}
+
+func NewProjectUsecase(projectRepo repository.ProjectRepository, todoRepo repository.TodoRepository, eventRepo repository.TodoEventRepository, txManager repository.TxManager) ProjectUsecase {
+	return &projectUsecase{
+		projectRepo: projectRepo,
+		todoRepo:    todoRepo,
+		eventRepo:   eventRepo,
+		txManager:   txManager,
+	}
+}
+
+// inWorkspace は ctx のワークスペースのプロジェクトのみを扱う projectUsecase を返す
+func (u *projectUsecase) inWorkspace(ctx context.Context) (*projectUsecase, error) {
+	workspaceID, err := workspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &projectUsecase{
+		projectRepo: u.projectRepo.WithWorkspace(workspaceID),
+		todoRepo:    u.todoRepo.WithWorkspace(workspaceID),
+		eventRepo:   u.eventRepo,
+		txManager:   u.txManager,
+	}, nil
+}
+
+func (u *projectUsecase) GetProjects(ctx context.Context, query models.ProjectListQuery) ([]models.Project, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query.MemberID = userScope(ctx)
+
+	projects, err := u.projectRepo.GetAll(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return empty slice instead of nil for consistency
+	if projects == nil {
+		return []models.Project{}, nil
+	}
+	return projects, nil
+}
+
+func (u *projectUsecase) GetProjectByID(ctx context.Context, id int) (*models.Project, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findProject(ctx, u.projectRepo, id, models.ProjectRoleViewer)
+}
+
+func (u *projectUsecase) CreateProject(ctx context.Context, project *models.Project) (*models.Project, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// プロジェクトには必ず所有者が必要
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+
+	name, ok := normalizeProjectName(project.Name)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+	color := models.DefaultProjectColor
+	if project.Color != "" {
+		if color, ok = normalizeProjectColor(project.Color); !ok {
+			return nil, ErrInvalidInput
+		}
+	}
+
+	// 作成したユーザーをオーナーとしてメンバーに追加する
+	var created *models.Project
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		projectRepo := u.projectRepo.WithTx(tx)
+
+		var err error
+		created, err = projectRepo.Create(&models.Project{
+			OwnerID: userID,
+			Name:    name,
+			Color:   color,
+		})
+		if err != nil {
+			return err
+		}
+		return projectRepo.AddMember(created.ID, userID, models.ProjectRoleOwner)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created.Role = models.ProjectRoleOwner
+	return created, nil
+}
+
+func (u *projectUsecase) UpdateProject(ctx context.Context, id int, update models.ProjectUpdate) (*models.Project, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	project, err := findProject(ctx, u.projectRepo, id, models.ProjectRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		name, ok := normalizeProjectName(*update.Name)
+		if !ok {
+			return nil, ErrInvalidInput
+		}
+		update.Name = &name
+	}
+	if update.Color != nil {
+		color, ok := normalizeProjectColor(*update.Color)
+		if !ok {
+			return nil, ErrInvalidInput
+		}
+		update.Color = &color
+	}
+
+	updated, err := u.projectRepo.Update(id, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	updated.Role = project.Role
+	return updated, nil
+}
+
+func (u *projectUsecase) DeleteProject(ctx context.Context, id int, mode models.ProjectDeleteMode) error {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return err
+	}
+	if mode == "" {
+		mode = models.ProjectDeleteMoveToInbox
+	}
+	if mode != models.ProjectDeleteMoveToInbox && mode != models.ProjectDeleteCascade {
+		return ErrInvalidInput
+	}
+
+	if _, err := findProject(ctx, u.projectRepo, id, models.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	return u.txManager.WithinTx(func(tx repository.DBTX) error {
+		todoRepo := u.todoRepo.WithTx(tx)
+
+		// 移動したTodoにも履歴を残す
+		var events []models.TodoEvent
+		switch mode {
+		case models.ProjectDeleteCascade:
+			// 別のプロジェクト・インボックスにあるサブタスクも一緒にゴミ箱に移動するため、それぞれのロールを確認する
+			descendants, err := todoRepo.GetProjectDescendants(id)
+			if err != nil {
+				return err
+			}
+			if err := authorizeTodos(ctx, u.projectRepo, descendants, models.ProjectRoleEditor); err != nil {
+				return err
+			}
+			deleted, err := todoRepo.DeleteByProject(id)
+			if err != nil {
+				return err
+			}
+			for _, todo := range deleted {
+				events = append(events, newTodoEvent(ctx, todo.ID, models.TodoEventDeleted, models.TodoChanges{
+					"deleted_at": {Before: nil, After: timeOrNil(todo.DeletedAt)},
+				}))
+			}
+		case models.ProjectDeleteMoveToInbox:
+			movedIDs, err := todoRepo.MoveProjectTodosToInbox(id)
+			if err != nil {
+				return err
+			}
+			for _, todoID := range movedIDs {
+				events = append(events, newTodoEvent(ctx, todoID, models.TodoEventUpdated, models.TodoChanges{
+					"project_id": {Before: id, After: nil},
+				}))
+			}
+		}
+
+		if err := u.projectRepo.WithTx(tx).Delete(id); err != nil {
+			if errors.Is(err, repository.ErrNoRows) {
+				return ErrProjectNotFound
+			}
+			return err
+		}
+		return u.eventRepo.WithTx(tx).Create(events)
+	})
+}
+
+// findProject は required 以上のロールでアクセスできるプロジェクトを、ユーザーのロールとともに返す
+func findProject(ctx context.Context, projectRepo repository.ProjectRepository, id int, required models.ProjectRole) (*models.Project, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	project, err := projectRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if project.Role, err = requireProjectRole(ctx, projectRepo, id, required); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// normalizeProjectName は前後の空白を除いたプロジェクト名を返す（空または長すぎる場合は false）
+func normalizeProjectName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > models.MaxProjectNameLength {
+		return "", false
+	}
+	return name, true
+}
+
+// normalizeProjectColor は色を小文字の #rrggbb 形式に正規化する（形式が正しくない場合は false）
+func normalizeProjectColor(color string) (string, bool) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	return color, projectColorPattern.MatchString(color)
+}
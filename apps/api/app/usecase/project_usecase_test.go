@@ -0,0 +1,169 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	extMock "api/app/external/mock"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectUsecase(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	todoRepo := repository.NewTodoRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	eventRepo := repository.NewTodoEventRepository(db)
+	txManager := repository.NewTxManager(db)
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		Return(&external.NotificationResponse{Status: "sent"}, nil).
+		Maybe()
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, tagRepo, projectRepo, eventRepo, txManager, mockNotificationClient)
+	trashUsecase := usecase.NewTrashUsecase(todoRepo, tagRepo, projectRepo, eventRepo, repository.NewWorkspaceRepository(db), txManager, 30*24*time.Hour)
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, todoRepo, eventRepo, txManager)
+
+	aliceID := createTestUser(t, db, "alice@example.com")
+	bobID := createTestUser(t, db, "bob@example.com")
+	workspace := auth.WithWorkspaceID(context.Background(), createTestWorkspace(t, db, "test", aliceID, bobID))
+	alice := auth.WithUserID(workspace, aliceID)
+	bob := auth.WithUserID(workspace, bobID)
+
+	project, err := projectUsecase.CreateProject(alice, &models.Project{Name: " 仕事 ", Color: "#4A90D9"})
+	require.NoError(t, err)
+	assert.Equal(t, "仕事", project.Name)
+	assert.Equal(t, "#4a90d9", project.Color)
+	assert.Equal(t, aliceID, project.OwnerID)
+	assert.False(t, project.Archived)
+
+	t.Run("Todos are grouped into projects with counts", func(t *testing.T) {
+		parent, err := todoUsecase.CreateTodo(alice, &models.Todo{Title: "資料作成", ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.NotNil(t, parent.ProjectID)
+		assert.Equal(t, project.ID, *parent.ProjectID)
+
+		// プロジェクト未指定のサブタスクは親のプロジェクトに入る
+		child, err := todoUsecase.CreateTodo(alice, &models.Todo{Title: "グラフ作成", ParentID: &parent.ID})
+		require.NoError(t, err)
+		require.NotNil(t, child.ProjectID)
+		assert.Equal(t, project.ID, *child.ProjectID)
+		_, err = todoUsecase.UpdateTodo(alice, child.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+
+		_, err = todoUsecase.CreateTodo(alice, &models.Todo{Title: "インボックス"})
+		require.NoError(t, err)
+
+		saved, err := projectUsecase.GetProjectByID(alice, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, saved.OpenTodoCount)
+		assert.Equal(t, 1, saved.CompletedTodoCount)
+
+		page, err := todoUsecase.GetAllTodos(alice, models.TodoListQuery{ProjectID: &project.ID})
+		require.NoError(t, err)
+		assert.Len(t, page.Todos, 2)
+	})
+
+	t.Run("Other users cannot access the project", func(t *testing.T) {
+		_, err := projectUsecase.GetProjectByID(bob, project.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = todoUsecase.GetAllTodos(bob, models.TodoListQuery{ProjectID: &project.ID})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = todoUsecase.CreateTodo(bob, &models.Todo{Title: "侵入", ProjectID: &project.ID})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, projectUsecase.DeleteProject(bob, project.ID, models.ProjectDeleteCascade), usecase.ErrForbidden)
+
+		projects, err := projectUsecase.GetProjects(bob, models.ProjectListQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+	})
+
+	t.Run("Archived projects are hidden and reject new todos", func(t *testing.T) {
+		archived := true
+		updated, err := projectUsecase.UpdateProject(alice, project.ID, models.ProjectUpdate{Archived: &archived})
+		require.NoError(t, err)
+		assert.True(t, updated.Archived)
+		assert.Equal(t, 1, updated.OpenTodoCount)
+
+		projects, err := projectUsecase.GetProjects(alice, models.ProjectListQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+		projects, err = projectUsecase.GetProjects(alice, models.ProjectListQuery{IncludeArchived: true})
+		require.NoError(t, err)
+		assert.Len(t, projects, 1)
+
+		_, err = todoUsecase.CreateTodo(alice, &models.Todo{Title: "追加", ProjectID: &project.ID})
+		assert.ErrorIs(t, err, usecase.ErrProjectArchived)
+
+		archived = false
+		_, err = projectUsecase.UpdateProject(alice, project.ID, models.ProjectUpdate{Archived: &archived})
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := projectUsecase.CreateProject(alice, &models.Project{Name: "  "})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+		_, err = projectUsecase.CreateProject(alice, &models.Project{Name: "色", Color: "red"})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+		_, err = projectUsecase.CreateProject(workspace, &models.Project{Name: "所有者なし"})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, projectUsecase.DeleteProject(alice, project.ID, "archive"), usecase.ErrInvalidInput)
+
+		missing := 99999
+		_, err = todoUsecase.CreateTodo(alice, &models.Todo{Title: "存在しない", ProjectID: &missing})
+		assert.ErrorIs(t, err, usecase.ErrProjectNotFound)
+	})
+
+	t.Run("Deleting moves todos to the inbox", func(t *testing.T) {
+		inboxProject, err := projectUsecase.CreateProject(alice, &models.Project{Name: "一時"})
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultProjectColor, inboxProject.Color)
+		todo, err := todoUsecase.CreateTodo(alice, &models.Todo{Title: "残すTodo", ProjectID: &inboxProject.ID})
+		require.NoError(t, err)
+
+		require.NoError(t, projectUsecase.DeleteProject(alice, inboxProject.ID, models.ProjectDeleteMoveToInbox))
+
+		saved, err := todoUsecase.GetTodoByID(alice, todo.ID)
+		require.NoError(t, err)
+		assert.Nil(t, saved.ProjectID)
+		_, err = projectUsecase.GetProjectByID(alice, inboxProject.ID)
+		assert.ErrorIs(t, err, usecase.ErrProjectNotFound)
+
+		history, err := todoUsecase.GetTodoHistory(alice, todo.ID, models.TodoEventQuery{})
+		require.NoError(t, err)
+		require.NotEmpty(t, history.Events)
+		assert.Contains(t, history.Events[0].Changes, "project_id")
+	})
+
+	t.Run("Deleting with cascade moves todos to the trash", func(t *testing.T) {
+		page, err := todoUsecase.GetAllTodos(alice, models.TodoListQuery{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, page.Todos, 2)
+
+		require.NoError(t, projectUsecase.DeleteProject(alice, project.ID, models.ProjectDeleteCascade))
+
+		for _, todo := range page.Todos {
+			_, err := todoUsecase.GetTodoByID(alice, todo.ID)
+			assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+		}
+
+		// ゴミ箱から戻したTodoはインボックスに入る
+		trash, err := trashUsecase.GetTrash(alice, models.TrashListQuery{})
+		require.NoError(t, err)
+		require.Len(t, trash.Todos, 1)
+		restored, err := trashUsecase.RestoreTodo(alice, trash.Todos[0].ID)
+		require.NoError(t, err)
+		assert.Nil(t, restored.ProjectID)
+	})
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"api/app/external"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// reminderBatchSize は1回の実行で送信するリマインドの最大件数
+const reminderBatchSize = 100
+
+// 通知メッセージの日時は日本時間で表示する
+var jst = time.FixedZone("JST", 9*60*60)
+
+type ReminderUsecase interface {
+	// SendDueReminders はリマインド時刻を過ぎたTodoの通知を送信し、送信した件数を返す
+	// 一部のワークスペース・Todoの処理に失敗しても残りは処理し、失敗をまとめて返す
+	SendDueReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type reminderUsecase struct {
+	todoRepo           repository.TodoRepository
+	workspaceRepo      repository.WorkspaceRepository
+	notificationClient external.NotificationClient
+	leadTime           time.Duration
+}
+
+// NewReminderUsecase は remind_at 未設定のTodoを due_at の leadTime 前にリマインドする ReminderUsecase を作成する
+func NewReminderUsecase(todoRepo repository.TodoRepository, workspaceRepo repository.WorkspaceRepository, notificationClient external.NotificationClient, leadTime time.Duration) ReminderUsecase {
+	return &reminderUsecase{
+		todoRepo:           todoRepo,
+		workspaceRepo:      workspaceRepo,
+		notificationClient: notificationClient,
+		leadTime:           leadTime,
+	}
+}
+
+// SendDueReminders は全てのワークスペースを順に処理する（リクエストのワークスペースには依存しない）
+func (u *reminderUsecase) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	workspaceIDs, err := u.workspaceRepo.GetAllIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, workspaceID := range workspaceIDs {
+		count, err := u.sendDueReminders(ctx, u.todoRepo.WithWorkspace(workspaceID), now)
+		sent += count
+		if err != nil {
+			errs = append(errs, fmt.Errorf("workspace %d: %w", workspaceID, err))
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (u *reminderUsecase) sendDueReminders(ctx context.Context, todoRepo repository.TodoRepository, now time.Time) (int, error) {
+	// 送信前に reminded_at を記録済みのTodoのみ返るため、再起動しても二重送信しない
+	// 残りは次回の実行で処理する（上限はワークスペースごと）
+	todos, err := todoRepo.ClaimDueReminders(now, u.leadTime, reminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, todo := range todos {
+		// 所有者のいるTodoのみ取得されるが、念のため確認する
+		if todo.OwnerID == nil {
+			continue
+		}
+		_, notifErr := u.notificationClient.SendNotification(ctx, newReminderNotification(todo))
+		if notifErr != nil {
+			fmt.Printf("Failed to send reminder for todo %d: %v\n", todo.ID, notifErr)
+			// 次回の実行で再送する
+			if err := todoRepo.ReleaseReminder(todo.ID); err != nil {
+				// 戻せなかったリマインドは再送されないが、残りのTodoの送信は続ける
+				fmt.Printf("Failed to release reminder for todo %d: %v\n", todo.ID, err)
+				errs = append(errs, fmt.Errorf("release reminder for todo %d: %w", todo.ID, err))
+			}
+			continue
+		}
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func newReminderNotification(todo models.Todo) *external.NotificationRequest {
+	message := fmt.Sprintf("「%s」のリマインダーです", todo.Title)
+	if todo.DueAt != nil {
+		message = fmt.Sprintf("「%s」の期限は %s です", todo.Title, todo.DueAt.In(jst).Format("2006/01/02 15:04"))
+	}
+
+	return &external.NotificationRequest{
+		UserID:  *todo.OwnerID,
+		Title:   "Todoの期限が近づいています",
+		Message: message,
+		Type:    "push",
+	}
+}
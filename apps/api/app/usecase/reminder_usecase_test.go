@@ -0,0 +1,169 @@
+package usecase_test
+
+import (
+	"api/app/external"
+	extMock "api/app/external/mock"
+	"api/app/models"
+	repoMock "api/app/repository/mock"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderUsecase_SendDueReminders(t *testing.T) {
+	// 実DBセットアップ
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	todoRepo := repository.NewTodoRepository(db)
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	reminderUsecase := usecase.NewReminderUsecase(todoRepo, repository.NewWorkspaceRepository(db), mockNotificationClient, time.Hour)
+	// リマインドは全てのワークスペースを対象に送信する（テストデータはワークスペースのRepositoryで作成する）
+	workspaceTodoRepo := todoRepo.WithWorkspace(createTestWorkspace(t, db, "test"))
+	ownerID := createTestUser(t, db, "owner@example.com")
+
+	now := time.Now()
+	remindPast := now.Add(-time.Minute)
+	dueSoon := now.Add(30 * time.Minute)
+	dueLater := now.Add(3 * time.Hour)
+
+	// テストデータ（通知を伴わないようRepositoryで直接作成）
+	for _, todo := range []*models.Todo{
+		{Title: "リマインド対象", RemindAt: &remindPast, DueAt: &dueLater},
+		{Title: "期限間近", DueAt: &dueSoon},
+		{Title: "期限まで余裕あり", DueAt: &dueLater},
+		{Title: "期限なし"},
+	} {
+		todo.OwnerID = &ownerID
+		_, err := workspaceTodoRepo.Create(todo)
+		require.NoError(t, err)
+	}
+	// 所有者のいないTodoは通知先がないため対象外
+	_, err := workspaceTodoRepo.Create(&models.Todo{Title: "所有者なし", RemindAt: &remindPast})
+	require.NoError(t, err)
+	completedTodo, err := workspaceTodoRepo.Create(&models.Todo{Title: "完了済み", RemindAt: &remindPast, OwnerID: &ownerID})
+	require.NoError(t, err)
+	completed := true
+	_, err = workspaceTodoRepo.Update(completedTodo.ID, models.TodoUpdate{Completed: &completed})
+	require.NoError(t, err)
+
+	var notifiedMessages []string
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.AnythingOfType("*external.NotificationRequest")).
+		Run(func(_ context.Context, req *external.NotificationRequest) {
+			assert.Equal(t, ownerID, req.UserID)
+			notifiedMessages = append(notifiedMessages, req.Message)
+		}).
+		Return(&external.NotificationResponse{Status: "sent"}, nil).
+		Times(2)
+
+	// 期限が近いTodoのみ通知される
+	sent, err := reminderUsecase.SendDueReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	require.Len(t, notifiedMessages, 2)
+	sort.Strings(notifiedMessages)
+	assert.True(t, strings.HasPrefix(notifiedMessages[0], "「リマインド対象」の期限は "), notifiedMessages[0])
+	assert.True(t, strings.HasPrefix(notifiedMessages[1], "「期限間近」の期限は "), notifiedMessages[1])
+
+	// 再実行（再起動後を想定）しても二重送信されない
+	sent, err = reminderUsecase.SendDueReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+}
+
+func TestReminderUsecase_SendDueReminders_RetryAfterFailure(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	todoRepo := repository.NewTodoRepository(db)
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	reminderUsecase := usecase.NewReminderUsecase(todoRepo, repository.NewWorkspaceRepository(db), mockNotificationClient, time.Hour)
+	// リマインドは全てのワークスペースを対象に送信する（テストデータはワークスペースのRepositoryで作成する）
+	workspaceTodoRepo := todoRepo.WithWorkspace(createTestWorkspace(t, db, "test"))
+
+	ownerID := createTestUser(t, db, "owner@example.com")
+
+	now := time.Now()
+	remindPast := now.Add(-time.Minute)
+	todo, err := workspaceTodoRepo.Create(&models.Todo{Title: "通知失敗", RemindAt: &remindPast, OwnerID: &ownerID})
+	require.NoError(t, err)
+
+	// 1回目は外部APIが失敗
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		Return(nil, assert.AnError).
+		Once()
+
+	sent, err := reminderUsecase.SendDueReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// 失敗したリマインドは未送信に戻っている
+	saved, err := workspaceTodoRepo.GetByID(todo.ID)
+	require.NoError(t, err)
+	assert.Nil(t, saved.RemindedAt)
+
+	// 2回目で送信される
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		Return(&external.NotificationResponse{Status: "sent"}, nil).
+		Once()
+
+	sent, err = reminderUsecase.SendDueReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestReminderUsecase_SendDueReminders_ContinuesAfterReleaseFailure(t *testing.T) {
+	mockTodoRepo := repoMock.NewMockTodoRepository(t)
+	mockWorkspaceRepo := repoMock.NewMockWorkspaceRepository(t)
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	reminderUsecase := usecase.NewReminderUsecase(mockTodoRepo, mockWorkspaceRepo, mockNotificationClient, time.Hour)
+
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	ownerID := 1
+	firstWorkspace := repoMock.NewMockTodoRepository(t)
+	secondWorkspace := repoMock.NewMockTodoRepository(t)
+	mockWorkspaceRepo.EXPECT().GetAllIDs().Return([]int{1, 2}, nil)
+	mockTodoRepo.EXPECT().WithWorkspace(1).Return(firstWorkspace)
+	mockTodoRepo.EXPECT().WithWorkspace(2).Return(secondWorkspace)
+
+	// 1つ目のワークスペースは通知に失敗したTodoを未送信に戻せない
+	firstWorkspace.EXPECT().ClaimDueReminders(now, time.Hour, mock.Anything).Return([]models.Todo{
+		{ID: 10, Title: "通知失敗", OwnerID: &ownerID},
+		{ID: 11, Title: "同じバッチの残り", OwnerID: &ownerID},
+	}, nil)
+	firstWorkspace.EXPECT().ReleaseReminder(10).Return(assert.AnError)
+	secondWorkspace.EXPECT().ClaimDueReminders(now, time.Hour, mock.Anything).Return([]models.Todo{
+		{ID: 20, Title: "別のワークスペース", OwnerID: &ownerID},
+	}, nil)
+
+	var notified []string
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, req *external.NotificationRequest) (*external.NotificationResponse, error) {
+			notified = append(notified, req.Message)
+			if strings.Contains(req.Message, "通知失敗") {
+				return nil, assert.AnError
+			}
+			return &external.NotificationResponse{Status: "sent"}, nil
+		}).
+		Times(3)
+
+	// 戻せなかったTodoのエラーを返しつつ、同じバッチの残りと他のワークスペースは送信する
+	sent, err := reminderUsecase.SendDueReminders(context.Background(), now)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "todo 10")
+	assert.Equal(t, 2, sent)
+	assert.Len(t, notified, 3)
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagAlreadyExists = errors.New("tag already exists")
+)
+
+type TagUsecase interface {
+	GetAllTags(ctx context.Context) ([]models.Tag, error)
+	GetTagByID(ctx context.Context, id int) (*models.Tag, error)
+	CreateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+	UpdateTag(ctx context.Context, id int, tag *models.Tag) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id int) error
+}
+
+type tagUsecase struct {
+	tagRepo   repository.TagRepository
+	todoRepo  repository.TodoRepository
+	txManager repository.TxManager
+}
+
+func NewTagUsecase(tagRepo repository.TagRepository, todoRepo repository.TodoRepository, txManager repository.TxManager) TagUsecase {
+	return &tagUsecase{
+		tagRepo:   tagRepo,
+		todoRepo:  todoRepo,
+		txManager: txManager,
+	}
+}
+
+// inWorkspace は ctx のワークスペースのタグのみを扱う tagUsecase を返す
+func (u *tagUsecase) inWorkspace(ctx context.Context) (*tagUsecase, error) {
+	workspaceID, err := workspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tagUsecase{
+		tagRepo:   u.tagRepo.WithWorkspace(workspaceID),
+		todoRepo:  u.todoRepo.WithWorkspace(workspaceID),
+		txManager: u.txManager,
+	}, nil
+}
+
+func (u *tagUsecase) GetAllTags(ctx context.Context) ([]models.Tag, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := u.tagRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return empty slice instead of nil for consistency
+	if tags == nil {
+		return []models.Tag{}, nil
+	}
+	return tags, nil
+}
+
+func (u *tagUsecase) GetTagByID(ctx context.Context, id int) (*models.Tag, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	tag, err := u.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+
+	return tag, nil
+}
+
+func (u *tagUsecase) CreateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := normalizeTagName(tag.Name)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+
+	created, err := u.tagRepo.Create(name)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateTag) {
+			return nil, ErrTagAlreadyExists
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (u *tagUsecase) UpdateTag(ctx context.Context, id int, tag *models.Tag) (*models.Tag, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	name, ok := normalizeTagName(tag.Name)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+
+	// タグが付与されたTodoの ETag も同じトランザクションで変更する
+	var updated *models.Tag
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		if err := u.authorizeTagChange(ctx, tx, id); err != nil {
+			return err
+		}
+		var err error
+		updated, err = u.tagRepo.WithTx(tx).Update(id, name)
+		if err != nil {
+			if errors.Is(err, repository.ErrDuplicateTag) {
+				return ErrTagAlreadyExists
+			}
+			return err
+		}
+		if updated == nil {
+			return ErrTagNotFound
+		}
+		return u.todoRepo.WithTx(tx).BumpVersionByTag(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (u *tagUsecase) DeleteTag(ctx context.Context, id int) error {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return err
+	}
+	if id <= 0 {
+		return ErrInvalidInput
+	}
+
+	// Todoとの関連は削除で消えるため、先にタグが付与されたTodoの ETag を変更する
+	return u.txManager.WithinTx(func(tx repository.DBTX) error {
+		if err := u.authorizeTagChange(ctx, tx, id); err != nil {
+			return err
+		}
+		if err := u.todoRepo.WithTx(tx).BumpVersionByTag(id); err != nil {
+			return err
+		}
+		if err := u.tagRepo.WithTx(tx).Delete(id); err != nil {
+			if errors.Is(err, repository.ErrNoRows) {
+				return ErrTagNotFound
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// authorizeTagChange はタグの名前の変更・削除が他のユーザーのTodoに影響しないことを検証する
+// タグはワークスペースで共有するため、アクセスできないTodoに付与されているタグは変更できない（ErrForbidden）
+func (u *tagUsecase) authorizeTagChange(ctx context.Context, tx repository.DBTX, id int) error {
+	userID := userScope(ctx)
+	if userID == nil {
+		return nil
+	}
+	used, err := u.tagRepo.WithTx(tx).IsUsedByInaccessibleTodos(id, *userID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// normalizeTagName は前後の空白を除いたタグ名を返す（空または長すぎる場合は false）
+func normalizeTagName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > models.MaxTagNameLength {
+		return "", false
+	}
+	return name, true
+}
+
+// normalizeTagNames はタグ名を正規化し、重複を除いて指定順に返す
+func normalizeTagNames(names []string) ([]string, bool) {
+	normalized := make([]string, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		name, ok := normalizeTagName(name)
+		if !ok {
+			return nil, false
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized, true
+}
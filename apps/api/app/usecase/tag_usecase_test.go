@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagUsecase_CRUD(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	ctx := auth.WithWorkspaceID(context.Background(), createTestWorkspace(t, db, "test"))
+
+	tagUsecase := usecase.NewTagUsecase(repository.NewTagRepository(db), repository.NewTodoRepository(db), repository.NewTxManager(db))
+
+	created, err := tagUsecase.CreateTag(ctx, &models.Tag{Name: "  仕事  "})
+	require.NoError(t, err)
+	assert.Equal(t, "仕事", created.Name)
+
+	_, err = tagUsecase.CreateTag(ctx, &models.Tag{Name: "買い物"})
+	require.NoError(t, err)
+
+	tags, err := tagUsecase.GetAllTags(ctx)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+
+	updated, err := tagUsecase.UpdateTag(ctx, created.ID, &models.Tag{Name: "work"})
+	require.NoError(t, err)
+	assert.Equal(t, "work", updated.Name)
+
+	_, err = tagUsecase.UpdateTag(ctx, created.ID, &models.Tag{Name: "買い物"})
+	assert.ErrorIs(t, err, usecase.ErrTagAlreadyExists)
+
+	_, err = tagUsecase.CreateTag(ctx, &models.Tag{Name: "work"})
+	assert.ErrorIs(t, err, usecase.ErrTagAlreadyExists)
+
+	_, err = tagUsecase.CreateTag(ctx, &models.Tag{Name: " "})
+	assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+
+	require.NoError(t, tagUsecase.DeleteTag(ctx, created.ID))
+	_, err = tagUsecase.GetTagByID(ctx, created.ID)
+	assert.ErrorIs(t, err, usecase.ErrTagNotFound)
+	assert.ErrorIs(t, tagUsecase.DeleteTag(ctx, created.ID), usecase.ErrTagNotFound)
+}
+
+func TestTagUsecase_ChangesTodoVersion(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	ownerID := createTestUser(t, db, "owner@example.com")
+	workspaceID := createTestWorkspace(t, db, "test", ownerID)
+	ctx := auth.WithWorkspaceID(auth.WithUserID(context.Background(), ownerID), workspaceID)
+
+	tagRepo := repository.NewTagRepository(db)
+	todoRepo := repository.NewTodoRepository(db)
+	tagUsecase := usecase.NewTagUsecase(tagRepo, todoRepo, repository.NewTxManager(db))
+
+	// タグを付与したTodoと付与していないTodo
+	tagged, err := todoRepo.WithWorkspace(workspaceID).Create(&models.Todo{Title: "タグあり", OwnerID: &ownerID})
+	require.NoError(t, err)
+	untagged, err := todoRepo.WithWorkspace(workspaceID).Create(&models.Todo{Title: "タグなし", OwnerID: &ownerID})
+	require.NoError(t, err)
+	tags, err := tagRepo.WithWorkspace(workspaceID).EnsureByNames([]string{"仕事"})
+	require.NoError(t, err)
+	require.NoError(t, tagRepo.WithWorkspace(workspaceID).SetTodoTags(tagged.ID, []int{tags[0].ID}))
+
+	// version は ETag に使われるため、タグの変更でTodoの表現が変わった場合は上がる
+	version := func(t *testing.T, id int) int {
+		t.Helper()
+		todo, err := todoRepo.WithWorkspace(workspaceID).GetByID(id)
+		require.NoError(t, err)
+		require.NotNil(t, todo)
+		return todo.Version
+	}
+
+	_, err = tagUsecase.UpdateTag(ctx, tags[0].ID, &models.Tag{Name: "work"})
+	require.NoError(t, err)
+	assert.Equal(t, tagged.Version+1, version(t, tagged.ID))
+	assert.Equal(t, untagged.Version, version(t, untagged.ID))
+
+	require.NoError(t, tagUsecase.DeleteTag(ctx, tags[0].ID))
+	assert.Equal(t, tagged.Version+2, version(t, tagged.ID))
+	assert.Equal(t, untagged.Version, version(t, untagged.ID))
+}
+
+func TestTagUsecase_TagsOnInaccessibleTodos(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	aliceID := createTestUser(t, db, "alice@example.com")
+	bobID := createTestUser(t, db, "bob@example.com")
+	workspaceID := createTestWorkspace(t, db, "test", aliceID, bobID)
+	workspaceCtx := auth.WithWorkspaceID(context.Background(), workspaceID)
+	alice := auth.WithUserID(workspaceCtx, aliceID)
+	bob := auth.WithUserID(workspaceCtx, bobID)
+
+	tagRepo := repository.NewTagRepository(db)
+	todoRepo := repository.NewTodoRepository(db)
+	tagUsecase := usecase.NewTagUsecase(tagRepo, todoRepo, repository.NewTxManager(db))
+
+	// bob のインボックスのTodo（alice はアクセスできない）にだけ付与されているタグ
+	bobsTodo, err := todoRepo.WithWorkspace(workspaceID).Create(&models.Todo{Title: "bob のTodo", OwnerID: &bobID})
+	require.NoError(t, err)
+	tags, err := tagRepo.WithWorkspace(workspaceID).EnsureByNames([]string{"bob"})
+	require.NoError(t, err)
+	require.NoError(t, tagRepo.WithWorkspace(workspaceID).SetTodoTags(bobsTodo.ID, []int{tags[0].ID}))
+	unused, err := tagUsecase.CreateTag(alice, &models.Tag{Name: "未使用"})
+	require.NoError(t, err)
+
+	t.Run("Other users cannot rename or delete the tag", func(t *testing.T) {
+		_, err := tagUsecase.UpdateTag(alice, tags[0].ID, &models.Tag{Name: "alice"})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, tagUsecase.DeleteTag(alice, tags[0].ID), usecase.ErrForbidden)
+
+		tag, err := tagUsecase.GetTagByID(alice, tags[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "bob", tag.Name)
+	})
+
+	t.Run("Owner of the todos can rename the tag", func(t *testing.T) {
+		updated, err := tagUsecase.UpdateTag(bob, tags[0].ID, &models.Tag{Name: "bob2"})
+		require.NoError(t, err)
+		assert.Equal(t, "bob2", updated.Name)
+	})
+
+	t.Run("Unused tags can be changed by any member", func(t *testing.T) {
+		_, err := tagUsecase.UpdateTag(bob, unused.ID, &models.Tag{Name: "共有"})
+		require.NoError(t, err)
+		require.NoError(t, tagUsecase.DeleteTag(bob, unused.ID))
+	})
+}
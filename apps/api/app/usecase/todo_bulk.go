@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+)
+
+// ErrBulkRolledBack は一括操作（atomic）で他の操作が失敗したため、この操作が取り消されたことを表す
+var ErrBulkRolledBack = errors.New("bulk operation rolled back")
+
+// BulkMode は一括操作で一部の操作が失敗した場合の扱い
+type BulkMode string
+
+const (
+	// BulkModeAtomic は1件でも失敗した場合に全ての操作を取り消す
+	BulkModeAtomic BulkMode = "atomic"
+	// BulkModeBestEffort は失敗した操作のみを取り消し、成功した操作は確定する
+	BulkModeBestEffort BulkMode = "best_effort"
+)
+
+type BulkOperationType string
+
+const (
+	BulkOperationCreate   BulkOperationType = "create"
+	BulkOperationUpdate   BulkOperationType = "update"
+	BulkOperationComplete BulkOperationType = "complete"
+	BulkOperationDelete   BulkOperationType = "delete"
+)
+
+// MaxBulkOperations は1回の一括操作で指定できる最大件数
+const MaxBulkOperations = 100
+
+// BulkTodoOperation は一括操作の1件分
+type BulkTodoOperation struct {
+	Type BulkOperationType
+	// 作成以外の操作対象のTodoのID
+	ID int
+	// 作成・更新の内容
+	Todo *models.Todo
+	// 更新・完了時の振る舞い
+	Options UpdateTodoOptions
+}
+
+// BulkTodoItemResult は一括操作1件分の結果（Err が nil の場合は成功）
+type BulkTodoItemResult struct {
+	Operation BulkTodoOperation
+	// 作成・更新・完了後のTodo（削除の場合は nil）
+	Todo *models.Todo
+	Err  error
+}
+
+type BulkTodoResult struct {
+	// Committed は変更が確定したかどうか（atomic で失敗した場合は false）
+	Committed bool
+	// Items は指定された順の各操作の結果
+	Items []BulkTodoItemResult
+}
+
+// errBulkAborted は atomic モードでトランザクションをロールバックさせるための内部エラー
+var errBulkAborted = errors.New("bulk operation aborted")
+
+func (u *todoUsecase) BulkTodos(ctx context.Context, mode BulkMode, operations []BulkTodoOperation) (*BulkTodoResult, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if mode != BulkModeAtomic && mode != BulkModeBestEffort {
+		return nil, ErrInvalidInput
+	}
+	if len(operations) == 0 || len(operations) > MaxBulkOperations {
+		return nil, ErrInvalidInput
+	}
+
+	items := make([]BulkTodoItemResult, len(operations))
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		scoped := u.withTx(tx)
+
+		failed := false
+		for i, op := range operations {
+			items[i] = BulkTodoItemResult{Operation: op}
+			// 失敗した操作の途中までの変更はセーブポイントまで戻し、後続の操作を続けられるようにする
+			err := scoped.txManager.WithinTx(func(repository.DBTX) error {
+				var err error
+				items[i].Todo, err = scoped.applyBulkOperation(ctx, op)
+				return err
+			})
+			if err != nil {
+				items[i].Todo, items[i].Err = nil, err
+				failed = true
+			}
+		}
+
+		if failed && mode == BulkModeAtomic {
+			return errBulkAborted
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkAborted) {
+		return nil, err
+	}
+
+	if errors.Is(err, errBulkAborted) {
+		for i := range items {
+			if items[i].Err == nil {
+				items[i].Todo, items[i].Err = nil, ErrBulkRolledBack
+			}
+		}
+		return &BulkTodoResult{Committed: false, Items: items}, nil
+	}
+
+	// 通知は変更の確定後に送信する
+	for _, item := range items {
+		if item.Err == nil && item.Operation.Type == BulkOperationCreate {
+			u.notifyCreated(ctx, item.Todo)
+		}
+	}
+	return &BulkTodoResult{Committed: true, Items: items}, nil
+}
+
+func (u *todoUsecase) applyBulkOperation(ctx context.Context, op BulkTodoOperation) (*models.Todo, error) {
+	switch op.Type {
+	case BulkOperationCreate:
+		if op.Todo == nil {
+			return nil, ErrInvalidInput
+		}
+		return u.createTodo(ctx, op.Todo)
+	case BulkOperationUpdate:
+		if op.Todo == nil {
+			return nil, ErrInvalidInput
+		}
+		return u.UpdateTodo(ctx, op.ID, op.Todo, op.Options)
+	case BulkOperationComplete:
+		return u.UpdateTodo(ctx, op.ID, &models.Todo{Completed: true}, op.Options)
+	case BulkOperationDelete:
+		return nil, u.DeleteTodo(ctx, op.ID, DeleteTodoOptions{})
+	}
+	return nil, ErrInvalidInput
+}
+
+// withTx は tx 内でクエリを実行する todoUsecase を返す
+// Usecase内のトランザクションはセーブポイントとして tx に入れ子になる
+func (u *todoUsecase) withTx(tx repository.DBTX) *todoUsecase {
+	return &todoUsecase{
+		todoRepo:           u.todoRepo.WithTx(tx),
+		tagRepo:            u.tagRepo.WithTx(tx),
+		projectRepo:        u.projectRepo.WithTx(tx),
+		eventRepo:          u.eventRepo.WithTx(tx),
+		txManager:          repository.NewSavepointTxManager(tx),
+		notificationClient: u.notificationClient,
+	}
+}
@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"api/app/models"
+	"api/app/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoUsecase_BulkTodos(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	first, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "first"})
+	require.NoError(t, err)
+	second, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "second"})
+	require.NoError(t, err)
+
+	t.Run("Complete all selected in one call", func(t *testing.T) {
+		result, err := todoUsecase.BulkTodos(ctx, usecase.BulkModeAtomic, []usecase.BulkTodoOperation{
+			{Type: usecase.BulkOperationComplete, ID: first.ID},
+			{Type: usecase.BulkOperationComplete, ID: second.ID},
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Committed)
+		require.Len(t, result.Items, 2)
+		for _, item := range result.Items {
+			require.NoError(t, item.Err)
+			assert.True(t, item.Todo.Completed)
+		}
+	})
+
+	t.Run("Atomic mode rolls back everything on failure", func(t *testing.T) {
+		result, err := todoUsecase.BulkTodos(ctx, usecase.BulkModeAtomic, []usecase.BulkTodoOperation{
+			{Type: usecase.BulkOperationCreate, Todo: &models.Todo{Title: "rolled back"}},
+			{Type: usecase.BulkOperationUpdate, ID: first.ID, Todo: &models.Todo{Title: "renamed"}},
+			{Type: usecase.BulkOperationDelete, ID: 99999},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Committed)
+		assert.ErrorIs(t, result.Items[0].Err, usecase.ErrBulkRolledBack)
+		assert.ErrorIs(t, result.Items[1].Err, usecase.ErrBulkRolledBack)
+		assert.ErrorIs(t, result.Items[2].Err, usecase.ErrTodoNotFound)
+
+		got, err := todoUsecase.GetTodoByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "first", got.Title)
+
+		results, err := todoUsecase.SearchTodos(ctx, models.TodoSearchQuery{Q: "rolled", Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Best-effort mode keeps successful operations", func(t *testing.T) {
+		result, err := todoUsecase.BulkTodos(ctx, usecase.BulkModeBestEffort, []usecase.BulkTodoOperation{
+			{Type: usecase.BulkOperationCreate, Todo: &models.Todo{Title: "kept"}},
+			{Type: usecase.BulkOperationUpdate, ID: 99999, Todo: &models.Todo{Title: "missing"}},
+			{Type: usecase.BulkOperationDelete, ID: second.ID},
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Committed)
+		require.NoError(t, result.Items[0].Err)
+		assert.ErrorIs(t, result.Items[1].Err, usecase.ErrTodoNotFound)
+		require.NoError(t, result.Items[2].Err)
+
+		_, err = todoUsecase.GetTodoByID(ctx, result.Items[0].Todo.ID)
+		require.NoError(t, err)
+		_, err = todoUsecase.GetTodoByID(ctx, second.ID)
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+	})
+
+	t.Run("Invalid mode", func(t *testing.T) {
+		_, err := todoUsecase.BulkTodos(ctx, usecase.BulkMode("partial"), []usecase.BulkTodoOperation{
+			{Type: usecase.BulkOperationDelete, ID: first.ID},
+		})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"context"
+	"time"
+)
+
+// actorID は ctx の認証済みユーザーIDを返す（バックグラウンドジョブなど未認証の場合は nil）
+func actorID(ctx context.Context) *int {
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		return &userID
+	}
+	return nil
+}
+
+func newTodoEvent(ctx context.Context, todoID int, eventType models.TodoEventType, changes models.TodoChanges) models.TodoEvent {
+	return models.TodoEvent{
+		TodoID:  todoID,
+		Type:    eventType,
+		ActorID: actorID(ctx),
+		Changes: changes,
+	}
+}
+
+// diffTodo は変更前後のTodoを比較し、変更された項目の前後の値を返す
+func diffTodo(before, after *models.Todo) models.TodoChanges {
+	changes := models.TodoChanges{}
+	add := func(field string, b, a interface{}) {
+		if b != a {
+			changes[field] = models.FieldChange{Before: b, After: a}
+		}
+	}
+
+	add("title", before.Title, after.Title)
+	add("description", before.Description, after.Description)
+	add("completed", before.Completed, after.Completed)
+	add("priority", string(before.Priority), string(after.Priority))
+	add("parent_id", intOrNil(before.ParentID), intOrNil(after.ParentID))
+	add("project_id", intOrNil(before.ProjectID), intOrNil(after.ProjectID))
+	add("due_at", timeOrNil(before.DueAt), timeOrNil(after.DueAt))
+	add("remind_at", timeOrNil(before.RemindAt), timeOrNil(after.RemindAt))
+	add("recurrence_rule", stringOrNil(before.RecurrenceRule), stringOrNil(after.RecurrenceRule))
+	add("recurrence_timezone", stringOrNil(before.RecurrenceTimezone), stringOrNil(after.RecurrenceTimezone))
+
+	beforeTags, afterTags := tagNamesOf(before.Tags), tagNamesOf(after.Tags)
+	if !equalStrings(beforeTags, afterTags) {
+		changes["tags"] = models.FieldChange{Before: beforeTags, After: afterTags}
+	}
+
+	return changes
+}
+
+// createdChanges は作成時の履歴として、初期値が設定された項目を返す（変更前は全て null）
+func createdChanges(todo *models.Todo) models.TodoChanges {
+	changes := diffTodo(&models.Todo{}, todo)
+	for field, change := range changes {
+		changes[field] = models.FieldChange{Before: nil, After: change.After}
+	}
+	return changes
+}
+
+// updateEventType は変更内容から履歴の種類を判定する（完了状態の切り替えを区別する）
+func updateEventType(changes models.TodoChanges) models.TodoEventType {
+	if change, ok := changes["completed"]; ok {
+		if change.After == true {
+			return models.TodoEventCompleted
+		}
+		return models.TodoEventReopened
+	}
+	return models.TodoEventUpdated
+}
+
+func intOrNil(v *int) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func stringOrNil(v *string) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// timeOrNil は比較・保存用に日時を RFC3339 形式の文字列に変換する
+func timeOrNil(v *time.Time) interface{} {
+	if v == nil {
+		return nil
+	}
+	return v.UTC().Format(time.RFC3339Nano)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
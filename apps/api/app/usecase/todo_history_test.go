@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoUsecase_GetTodoHistory(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	userID := createTestUser(t, db, "history@example.com")
+	workspace := auth.WithWorkspaceID(context.Background(), createTestWorkspace(t, db, "test", userID))
+	ctx := auth.WithUserID(workspace, userID)
+
+	todoRepo := repository.NewTodoRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	eventRepo := repository.NewTodoEventRepository(db)
+	txManager := repository.NewTxManager(db)
+	notificationClient := external.NewHTTPNotificationClient("http://localhost:9999", "test-key")
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, tagRepo, projectRepo, eventRepo, txManager, notificationClient)
+	trashUsecase := usecase.NewTrashUsecase(todoRepo, tagRepo, projectRepo, eventRepo, repository.NewWorkspaceRepository(db), txManager, 30*24*time.Hour)
+
+	todo, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "before", Priority: models.PriorityLow})
+	require.NoError(t, err)
+	_, err = todoUsecase.UpdateTodo(ctx, todo.ID, &models.Todo{Title: "after"}, usecase.UpdateTodoOptions{})
+	require.NoError(t, err)
+	_, err = todoUsecase.UpdateTodo(ctx, todo.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+	require.NoError(t, err)
+	// 値が変わらない更新は履歴に残らない
+	_, err = todoUsecase.UpdateTodo(ctx, todo.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+	require.NoError(t, err)
+	require.NoError(t, todoUsecase.DeleteTodo(ctx, todo.ID, usecase.DeleteTodoOptions{}))
+	_, err = trashUsecase.RestoreTodo(ctx, todo.ID)
+	require.NoError(t, err)
+
+	t.Run("Events are returned newest first with field-level diffs", func(t *testing.T) {
+		page, err := todoUsecase.GetTodoHistory(ctx, todo.ID, models.TodoEventQuery{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Events, 5)
+		assert.False(t, page.HasMore)
+
+		types := make([]models.TodoEventType, len(page.Events))
+		for i, event := range page.Events {
+			types[i] = event.Type
+			require.NotNil(t, event.ActorID)
+			assert.Equal(t, userID, *event.ActorID)
+		}
+		assert.Equal(t, []models.TodoEventType{
+			models.TodoEventRestored,
+			models.TodoEventDeleted,
+			models.TodoEventCompleted,
+			models.TodoEventUpdated,
+			models.TodoEventCreated,
+		}, types)
+
+		updated := page.Events[3]
+		assert.Equal(t, models.FieldChange{Before: "before", After: "after"}, updated.Changes["title"])
+		assert.NotContains(t, updated.Changes, "priority")
+
+		created := page.Events[4]
+		assert.Equal(t, models.FieldChange{Before: nil, After: "before"}, created.Changes["title"])
+	})
+
+	t.Run("Paginate with cursor", func(t *testing.T) {
+		first, err := todoUsecase.GetTodoHistory(ctx, todo.ID, models.TodoEventQuery{Limit: 3})
+		require.NoError(t, err)
+		require.Len(t, first.Events, 3)
+		assert.True(t, first.HasMore)
+		require.NotNil(t, first.NextCursor)
+
+		second, err := todoUsecase.GetTodoHistory(ctx, todo.ID, models.TodoEventQuery{Limit: 3, Cursor: first.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, second.Events, 2)
+		assert.False(t, second.HasMore)
+		assert.Equal(t, models.TodoEventCreated, second.Events[1].Type)
+	})
+
+	t.Run("Events without an authenticated user have no actor", func(t *testing.T) {
+		other, err := todoUsecase.CreateTodo(workspace, &models.Todo{Title: "anonymous"})
+		require.NoError(t, err)
+
+		page, err := todoUsecase.GetTodoHistory(workspace, other.ID, models.TodoEventQuery{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Events, 1)
+		assert.Nil(t, page.Events[0].ActorID)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := todoUsecase.GetTodoHistory(ctx, 99999, models.TodoEventQuery{Limit: 10})
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+	})
+}
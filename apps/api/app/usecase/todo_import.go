@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// todoImportBatchSize はインポートで1回のクエリ・トランザクションで登録する行数
+const todoImportBatchSize = 100
+
+// MaxSyncTodoImportRows はリクエスト内で同期的にインポートする最大行数（超える場合はジョブとして非同期に実行する）
+const MaxSyncTodoImportRows = 500
+
+type TodoImportOptions struct {
+	// DryRun の場合は検証のみ行い、何も登録しない
+	DryRun bool
+	// AfterBatch はバッチを登録したトランザクション内で、登録済みの行数を渡して呼び出される（ジョブの進捗の記録に使う）
+	AfterBatch func(tx repository.DBTX, importedRows int) error
+}
+
+// ImportTodos は全ての行を作成時と同じく検証し、不備が無い場合のみ todoImportBatchSize 行ずつまとめて登録する
+// 不備のある行は行番号とともに結果の Errors に入れる（登録中のエラーはそれまでのバッチを確定したまま返す）
+// 大量の通知を避けるため、作成の通知は送信しない
+func (u *todoUsecase) ImportTodos(ctx context.Context, rows []models.TodoImportRow, opts TodoImportOptions) (*models.TodoImportResult, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || len(rows) > models.MaxTodoImportRows {
+		return nil, ErrInvalidInput
+	}
+
+	result := &models.TodoImportResult{TotalRows: len(rows), Errors: []models.TodoImportRowError{}}
+	todos := make([]*models.Todo, len(rows))
+	tagNames := make([][]string, len(rows))
+	for i, row := range rows {
+		todo := row.Todo()
+		names, err := u.prepareTodo(ctx, todo)
+		if err != nil {
+			rowErr, ok := todoImportRowError(row.Line, err)
+			if !ok {
+				return nil, err
+			}
+			result.Errors = append(result.Errors, rowErr)
+			continue
+		}
+		todos[i], tagNames[i] = todo, names
+	}
+	if len(result.Errors) > 0 || opts.DryRun {
+		return result, nil
+	}
+
+	for start := 0; start < len(todos); start += todoImportBatchSize {
+		end := min(start+todoImportBatchSize, len(todos))
+		err := u.txManager.WithinTx(func(tx repository.DBTX) error {
+			if err := u.withTx(tx).importBatch(ctx, todos[start:end], tagNames[start:end]); err != nil {
+				return err
+			}
+			if opts.AfterBatch != nil {
+				return opts.AfterBatch(tx, end)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to import todos after %d rows: %w", start, err)
+		}
+		result.ImportedRows = end
+	}
+	return result, nil
+}
+
+// importBatch は検証済みの todos を1回のクエリで作成し、タグと履歴を記録する
+func (u *todoUsecase) importBatch(ctx context.Context, todos []*models.Todo, tagNames [][]string) error {
+	created, err := u.todoRepo.CreateBatch(todos)
+	if err != nil {
+		return err
+	}
+
+	events := make([]models.TodoEvent, len(created))
+	for i := range created {
+		created[i].Tags = []models.Tag{}
+		if len(tagNames[i]) > 0 {
+			created[i].Tags, err = setTodoTags(u.tagRepo, created[i].ID, tagNames[i])
+			if err != nil {
+				return err
+			}
+		}
+		events[i] = newTodoEvent(ctx, created[i].ID, models.TodoEventCreated, createdChanges(&created[i]))
+	}
+	return u.eventRepo.Create(events)
+}
+
+// todoImportRowError は行の登録を妨げるエラーを、行番号・項目とメッセージに変換する
+// 行の内容によらないエラー（DBのエラーなど）の場合は false を返す
+func todoImportRowError(line int, err error) (models.TodoImportRowError, bool) {
+	rowErr := models.TodoImportRowError{Line: line}
+	switch {
+	case errors.Is(err, ErrParentTodoNotFound):
+		rowErr.Field, rowErr.Message = "ParentID", "親Todoが見つかりません"
+	case errors.Is(err, ErrProjectNotFound):
+		rowErr.Field, rowErr.Message = "ProjectID", "指定されたプロジェクトが見つかりません"
+	case errors.Is(err, ErrProjectArchived):
+		rowErr.Field, rowErr.Message = "ProjectID", "アーカイブされたプロジェクトにはTodoを追加できません"
+	case errors.Is(err, ErrForbidden):
+		rowErr.Message = "親Todoまたはプロジェクトへのアクセス権限がありません"
+	case errors.Is(err, ErrRecurrenceRequiresDueAt):
+		rowErr.Field, rowErr.Message = "DueAt", "繰り返し設定には期限を指定してください"
+	case errors.Is(err, ErrInvalidRecurrence):
+		rowErr.Field, rowErr.Message = "RecurrenceRule", "繰り返し設定またはタイムゾーンが無効です"
+	case errors.Is(err, ErrInvalidInput):
+		rowErr.Message = "入力データが無効です"
+	default:
+		return rowErr, false
+	}
+	return rowErr, true
+}
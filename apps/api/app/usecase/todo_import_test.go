@@ -0,0 +1,200 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoUsecase_ImportTodos(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	missingParentID := 99999
+	rows := []models.TodoImportRow{
+		{Line: 2, Title: "imported first", Priority: models.PriorityHigh, Tags: []string{"work"}},
+		{Line: 3, Title: "imported second"},
+	}
+
+	t.Run("Dry run writes nothing", func(t *testing.T) {
+		result, err := todoUsecase.ImportTodos(ctx, rows, usecase.TodoImportOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.TotalRows)
+		assert.Equal(t, 0, result.ImportedRows)
+		assert.Empty(t, result.Errors)
+
+		results, err := todoUsecase.SearchTodos(ctx, models.TodoSearchQuery{Q: "imported", Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Invalid row is reported with its line and nothing is imported", func(t *testing.T) {
+		invalid := append(rows, models.TodoImportRow{Line: 4, Title: "orphan", ParentID: &missingParentID})
+		result, err := todoUsecase.ImportTodos(ctx, invalid, usecase.TodoImportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.ImportedRows)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, 4, result.Errors[0].Line)
+		assert.Equal(t, "ParentID", result.Errors[0].Field)
+
+		results, err := todoUsecase.SearchTodos(ctx, models.TodoSearchQuery{Q: "imported", Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Import all rows in batches", func(t *testing.T) {
+		var progress []int
+		result, err := todoUsecase.ImportTodos(ctx, rows, usecase.TodoImportOptions{
+			AfterBatch: func(tx repository.DBTX, importedRows int) error {
+				progress = append(progress, importedRows)
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.ImportedRows)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, []int{2}, progress)
+
+		results, err := todoUsecase.SearchTodos(ctx, models.TodoSearchQuery{Q: "imported", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		for _, r := range results {
+			if r.Todo.Title == "imported first" {
+				assert.Equal(t, models.PriorityHigh, r.Todo.Priority)
+				require.Len(t, r.Todo.Tags, 1)
+				assert.Equal(t, "work", r.Todo.Tags[0].Name)
+			}
+		}
+	})
+
+	t.Run("No rows", func(t *testing.T) {
+		_, err := todoUsecase.ImportTodos(ctx, nil, usecase.TodoImportOptions{})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+	})
+}
+
+func TestTodoImportUsecase_RunPendingJobs(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	notificationClient := external.NewHTTPNotificationClient("http://localhost:9999", "test-key")
+	todoUsecase := usecase.NewTodoUsecase(repository.NewTodoRepository(db), repository.NewTagRepository(db), repository.NewProjectRepository(db), repository.NewTodoEventRepository(db), repository.NewTxManager(db), notificationClient)
+	importUsecase := usecase.NewTodoImportUsecase(todoUsecase, repository.NewTodoImportJobRepository(db), repository.NewWorkspaceRepository(db))
+
+	ownerID := createTestUser(t, db, "owner@example.com")
+	otherID := createTestUser(t, db, "other@example.com")
+	workspaceCtx := auth.WithWorkspaceID(context.Background(), createTestWorkspace(t, db, "test", ownerID, otherID))
+	ctx := auth.WithUserID(workspaceCtx, ownerID)
+
+	missingParentID := 99999
+	succeeding, err := importUsecase.StartImport(ctx, []models.TodoImportRow{
+		{Line: 2, Title: "job first"},
+		{Line: 3, Title: "job second"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.TodoImportJobPending, succeeding.Status)
+	assert.Equal(t, 2, succeeding.TotalRows)
+	failing, err := importUsecase.StartImport(ctx, []models.TodoImportRow{
+		{Line: 2, Title: "job orphan", ParentID: &missingParentID},
+	})
+	require.NoError(t, err)
+
+	t.Run("Other users cannot see the job", func(t *testing.T) {
+		_, err := importUsecase.GetJob(auth.WithUserID(workspaceCtx, otherID), succeeding.ID)
+		assert.ErrorIs(t, err, usecase.ErrTodoImportJobNotFound)
+	})
+
+	processed, err := importUsecase.RunPendingJobs(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+
+	t.Run("Imported job succeeds", func(t *testing.T) {
+		job, err := importUsecase.GetJob(ctx, succeeding.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.TodoImportJobSucceeded, job.Status)
+		assert.Equal(t, 2, job.ImportedRows)
+		assert.Empty(t, job.Errors)
+		assert.NotNil(t, job.FinishedAt)
+
+		results, err := todoUsecase.SearchTodos(ctx, models.TodoSearchQuery{Q: "job", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		for _, r := range results {
+			require.NotNil(t, r.Todo.OwnerID)
+			assert.Equal(t, ownerID, *r.Todo.OwnerID)
+		}
+	})
+
+	t.Run("Job with invalid row fails with the line", func(t *testing.T) {
+		job, err := importUsecase.GetJob(ctx, failing.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.TodoImportJobFailed, job.Status)
+		assert.Equal(t, 0, job.ImportedRows)
+		require.Len(t, job.Errors, 1)
+		assert.Equal(t, 2, job.Errors[0].Line)
+	})
+
+	t.Run("Finished jobs are not run again", func(t *testing.T) {
+		processed, err := importUsecase.RunPendingJobs(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 0, processed)
+	})
+}
+
+func TestTodoImportJobRepository_ClaimToken(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	ownerID := createTestUser(t, db, "owner@example.com")
+	workspaceID := createTestWorkspace(t, db, "test", ownerID)
+	jobRepo := repository.NewTodoImportJobRepository(db).WithWorkspace(workspaceID)
+	created, err := jobRepo.Create(&models.TodoImportJob{UserID: &ownerID, Payload: []models.TodoImportRow{{Line: 2, Title: "job"}}})
+	require.NoError(t, err)
+
+	now := time.Now()
+	stale, err := jobRepo.ClaimNext(now, 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, stale)
+	require.NotNil(t, stale.ClaimToken)
+
+	// heartbeat_at が途絶えたジョブを別のワーカーが引き継ぐ
+	current, err := jobRepo.ClaimNext(now.Add(10*time.Minute), 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, current)
+	require.Equal(t, created.ID, current.ID)
+	require.NotNil(t, current.ClaimToken)
+	assert.NotEqual(t, *stale.ClaimToken, *current.ClaimToken)
+
+	t.Run("Previous worker cannot record progress or finish", func(t *testing.T) {
+		err := jobRepo.RecordProgress(created.ID, *stale.ClaimToken, 1, now.Add(11*time.Minute))
+		assert.ErrorIs(t, err, repository.ErrTodoImportJobClaimLost)
+		err = jobRepo.Finish(created.ID, *stale.ClaimToken, models.TodoImportJobFailed, nil, now.Add(11*time.Minute))
+		assert.ErrorIs(t, err, repository.ErrTodoImportJobClaimLost)
+
+		job, err := jobRepo.GetByID(created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.TodoImportJobRunning, job.Status)
+		assert.Equal(t, 0, job.ImportedRows)
+	})
+
+	t.Run("Current worker records progress and finishes once", func(t *testing.T) {
+		require.NoError(t, jobRepo.RecordProgress(created.ID, *current.ClaimToken, 1, now.Add(11*time.Minute)))
+		require.NoError(t, jobRepo.Finish(created.ID, *current.ClaimToken, models.TodoImportJobSucceeded, nil, now.Add(11*time.Minute)))
+		err := jobRepo.Finish(created.ID, *current.ClaimToken, models.TodoImportJobSucceeded, nil, now.Add(12*time.Minute))
+		assert.ErrorIs(t, err, repository.ErrTodoImportJobClaimLost)
+
+		job, err := jobRepo.GetByID(created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.TodoImportJobSucceeded, job.Status)
+		assert.Equal(t, 1, job.ImportedRows)
+	})
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrTodoImportJobNotFound = errors.New("todo import job not found")
+
+// todoImportStaleAfter は処理中のジョブが中断した（ワーカーが停止した）とみなすまでの時間
+// 処理中はバッチごとに heartbeat_at を更新するため、1バッチの登録にかかる時間より十分長くする
+const todoImportStaleAfter = 5 * time.Minute
+
+type TodoImportUsecase interface {
+	// StartImport は rows のインポートをジョブとして登録する（RunPendingJobs がバックグラウンドで処理する）
+	StartImport(ctx context.Context, rows []models.TodoImportRow) (*models.TodoImportJob, error)
+	// GetJob はインポートしたユーザー本人のジョブを返す
+	GetJob(ctx context.Context, id int) (*models.TodoImportJob, error)
+	// RunPendingJobs は全てのワークスペースの待機中のジョブを処理し、処理したジョブの件数を返す
+	RunPendingJobs(ctx context.Context, now time.Time) (int, error)
+}
+
+type todoImportUsecase struct {
+	todoUsecase   TodoUsecase
+	jobRepo       repository.TodoImportJobRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewTodoImportUsecase(todoUsecase TodoUsecase, jobRepo repository.TodoImportJobRepository, workspaceRepo repository.WorkspaceRepository) TodoImportUsecase {
+	return &todoImportUsecase{
+		todoUsecase:   todoUsecase,
+		jobRepo:       jobRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+func (u *todoImportUsecase) StartImport(ctx context.Context, rows []models.TodoImportRow) (*models.TodoImportJob, error) {
+	workspaceID, err := workspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || len(rows) > models.MaxTodoImportRows {
+		return nil, ErrInvalidInput
+	}
+
+	return u.jobRepo.WithWorkspace(workspaceID).Create(&models.TodoImportJob{
+		UserID:  userScope(ctx),
+		Payload: rows,
+	})
+}
+
+func (u *todoImportUsecase) GetJob(ctx context.Context, id int) (*models.TodoImportJob, error) {
+	workspaceID, err := workspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	job, err := u.jobRepo.WithWorkspace(workspaceID).GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	// 他のユーザーのジョブは存在しないものとして扱う
+	if job == nil || !sameUser(job.UserID, userScope(ctx)) {
+		return nil, ErrTodoImportJobNotFound
+	}
+	return job, nil
+}
+
+// RunPendingJobs はワークスペースごとに待機中のジョブが無くなるまで1件ずつ処理する（リクエストのワークスペースには依存しない）
+func (u *todoImportUsecase) RunPendingJobs(ctx context.Context, now time.Time) (int, error) {
+	workspaceIDs, err := u.workspaceRepo.GetAllIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, workspaceID := range workspaceIDs {
+		jobRepo := u.jobRepo.WithWorkspace(workspaceID)
+		for ctx.Err() == nil {
+			job, err := jobRepo.ClaimNext(now, todoImportStaleAfter)
+			if err != nil {
+				return processed, err
+			}
+			if job == nil {
+				break
+			}
+			if err := u.runJob(ctx, jobRepo, job); err != nil {
+				if !errors.Is(err, repository.ErrTodoImportJobClaimLost) {
+					return processed, err
+				}
+				// 処理が遅れている間に別のワーカーに引き継がれた場合は、そのワーカーに任せる
+				fmt.Printf("Todo import job %d was claimed by another worker\n", job.ID)
+			}
+			processed++
+		}
+	}
+	return processed, nil
+}
+
+// runJob はジョブをインポートしたユーザーとして実行し、結果を記録する
+// 中断したジョブは登録済みの行の続きから再開する
+// 別のワーカーに引き継がれた場合は、進捗を記録するバッチをロールバックして ErrTodoImportJobClaimLost を返す
+func (u *todoImportUsecase) runJob(ctx context.Context, jobRepo repository.TodoImportJobRepository, job *models.TodoImportJob) error {
+	if job.ClaimToken == nil {
+		return fmt.Errorf("todo import job %d has no claim token", job.ID)
+	}
+	claimToken := *job.ClaimToken
+	ctx = auth.WithWorkspaceID(ctx, job.WorkspaceID)
+	if job.UserID != nil {
+		ctx = auth.WithUserID(ctx, *job.UserID)
+	}
+
+	rows := job.Payload
+	if job.ImportedRows > 0 && job.ImportedRows <= len(rows) {
+		rows = rows[job.ImportedRows:]
+	}
+
+	status := models.TodoImportJobSucceeded
+	var rowErrors []models.TodoImportRowError
+	if len(rows) > 0 {
+		result, err := u.todoUsecase.ImportTodos(ctx, rows, TodoImportOptions{
+			AfterBatch: func(tx repository.DBTX, importedRows int) error {
+				return jobRepo.WithTx(tx).RecordProgress(job.ID, claimToken, job.ImportedRows+importedRows, time.Now())
+			},
+		})
+		switch {
+		case errors.Is(err, repository.ErrTodoImportJobClaimLost):
+			return err
+		case err != nil:
+			// 行の内容によらないエラーは行番号のないエラーとして記録する（登録済みのバッチは確定したまま）
+			fmt.Printf("Failed to import todos (job %d): %v\n", job.ID, err)
+			status = models.TodoImportJobFailed
+			rowErrors = []models.TodoImportRowError{{Message: "インポート中にエラーが発生しました"}}
+		case len(result.Errors) > 0:
+			status = models.TodoImportJobFailed
+			rowErrors = result.Errors
+		}
+	}
+
+	return jobRepo.Finish(job.ID, claimToken, status, rowErrors, time.Now())
+}
+
+func sameUser(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,111 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	extMock "api/app/external/mock"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoUsecase_Ownership(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	todoRepo := repository.NewTodoRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	eventRepo := repository.NewTodoEventRepository(db)
+	txManager := repository.NewTxManager(db)
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		Return(&external.NotificationResponse{Status: "sent"}, nil).
+		Maybe()
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, tagRepo, projectRepo, eventRepo, txManager, mockNotificationClient)
+	trashUsecase := usecase.NewTrashUsecase(todoRepo, tagRepo, projectRepo, eventRepo, repository.NewWorkspaceRepository(db), txManager, 30*24*time.Hour)
+	userUsecase := usecase.NewUserUsecase(repository.NewUserRepository(db))
+
+	aliceID := createTestUser(t, db, "alice@example.com")
+	bobID := createTestUser(t, db, "bob@example.com")
+	workspaceID := createTestWorkspace(t, db, "test", aliceID, bobID)
+	workspace := auth.WithWorkspaceID(context.Background(), workspaceID)
+	alice := auth.WithUserID(workspace, aliceID)
+	bob := auth.WithUserID(workspace, bobID)
+
+	todo, err := todoUsecase.CreateTodo(alice, &models.Todo{Title: "買い物リスト"})
+	require.NoError(t, err)
+	require.NotNil(t, todo.OwnerID)
+	assert.Equal(t, aliceID, *todo.OwnerID)
+
+	t.Run("Lists are scoped to the owner", func(t *testing.T) {
+		page, err := todoUsecase.GetAllTodos(alice, models.TodoListQuery{})
+		require.NoError(t, err)
+		require.Len(t, page.Todos, 1)
+
+		page, err = todoUsecase.GetAllTodos(bob, models.TodoListQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Todos)
+
+		results, err := todoUsecase.SearchTodos(bob, models.TodoSearchQuery{Q: "買い物"})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Other users cannot access the todo", func(t *testing.T) {
+		_, err := todoUsecase.GetTodoByID(bob, todo.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = todoUsecase.UpdateTodo(bob, todo.ID, &models.Todo{Title: "奪取"}, usecase.UpdateTodoOptions{})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = todoUsecase.CreateTodo(bob, &models.Todo{Title: "子Todo", ParentID: &todo.ID})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = todoUsecase.GetTodoHistory(bob, todo.ID, models.TodoEventQuery{})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, todoUsecase.DeleteTodo(bob, todo.ID, usecase.DeleteTodoOptions{}), usecase.ErrForbidden)
+
+		saved, err := todoUsecase.GetTodoByID(alice, todo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "買い物リスト", saved.Title)
+	})
+
+	t.Run("Trash is scoped to the owner", func(t *testing.T) {
+		require.NoError(t, todoUsecase.DeleteTodo(alice, todo.ID, usecase.DeleteTodoOptions{}))
+
+		page, err := trashUsecase.GetTrash(bob, models.TrashListQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Todos)
+		_, err = trashUsecase.RestoreTodo(bob, todo.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, trashUsecase.PurgeTodo(bob, todo.ID), usecase.ErrForbidden)
+
+		_, err = trashUsecase.RestoreTodo(alice, todo.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Todos without an owner are not accessible to users", func(t *testing.T) {
+		legacy, err := todoRepo.WithWorkspace(workspaceID).Create(&models.Todo{Title: "所有者なし"})
+		require.NoError(t, err)
+
+		_, err = todoUsecase.GetTodoByID(alice, legacy.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+	})
+
+	t.Run("Users can only modify themselves", func(t *testing.T) {
+		_, err := userUsecase.UpdateUser(bob, aliceID, &models.User{Name: "奪取"})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		assert.ErrorIs(t, userUsecase.DeleteUser(bob, aliceID), usecase.ErrForbidden)
+
+		updated, err := userUsecase.UpdateUser(bob, bobID, &models.User{Name: "Bob"})
+		require.NoError(t, err)
+		assert.Equal(t, "Bob", updated.Name)
+	})
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"api/app/models"
+	"api/app/recurrence"
+	"api/repository"
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRecurrence       = errors.New("invalid recurrence")
+	ErrRecurrenceRequiresDueAt = errors.New("recurrence requires due date")
+	ErrTodoNotRecurring        = errors.New("todo is not recurring")
+)
+
+func (u *todoUsecase) PreviewOccurrences(ctx context.Context, id int, query models.OccurrenceQuery) ([]models.Occurrence, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id <= 0 || query.Limit < 0 || query.Limit > models.MaxOccurrenceLimit {
+		return nil, ErrInvalidInput
+	}
+	if query.Limit == 0 {
+		query.Limit = models.DefaultOccurrenceLimit
+	}
+	if query.After.IsZero() {
+		query.After = time.Now()
+	}
+
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if todo == nil {
+		return nil, ErrTodoNotFound
+	}
+	if err := authorizeTodo(ctx, u.projectRepo, todo, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	if todo.RecurrenceRule == nil || todo.RecurrenceStart == nil {
+		return nil, ErrTodoNotRecurring
+	}
+
+	rule, err := recurrence.Parse(*todo.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	timezone := query.Timezone
+	if timezone == "" {
+		timezone = stringOrEmpty(todo.RecurrenceTimezone)
+	}
+	loc, err := recurrence.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidRecurrence
+	}
+
+	dueTimes := rule.Occurrences(todo.RecurrenceStart.In(loc), query.After, query.Limit)
+	occurrences := make([]models.Occurrence, len(dueTimes))
+	for i, dueAt := range dueTimes {
+		occurrences[i] = models.Occurrence{
+			DueAt:    dueAt,
+			RemindAt: shiftReminder(todo, dueAt),
+		}
+	}
+	return occurrences, nil
+}
+
+// applyRecurrence は繰り返し設定を検証・正規化し、繰り返しの起点を期限に合わせる
+// existing は更新前のTodo（作成時は nil）
+// RecurrenceRule が空文字の場合は繰り返しを解除する
+func applyRecurrence(todo *models.Todo, existing *models.Todo) error {
+	rule, timezone, start := todo.RecurrenceRule, todo.RecurrenceTimezone, todo.DueAt
+	if existing != nil {
+		if timezone == nil {
+			timezone = existing.RecurrenceTimezone
+		}
+		if start == nil {
+			start = existing.DueAt
+		}
+		// タイムゾーンのみ変更する場合は既存の繰り返し設定と起点を引き継ぐ
+		if rule == nil && todo.RecurrenceTimezone != nil && existing.RecurrenceRule != nil {
+			rule, start = existing.RecurrenceRule, existing.RecurrenceStart
+		}
+	}
+
+	if rule == nil {
+		todo.RecurrenceTimezone, todo.RecurrenceStart = nil, nil
+		return nil
+	}
+	if *rule == "" {
+		todo.RecurrenceRule, todo.RecurrenceTimezone, todo.RecurrenceStart = rule, nil, nil
+		return nil
+	}
+
+	parsed, err := recurrence.Parse(*rule)
+	if err != nil {
+		return ErrInvalidRecurrence
+	}
+	name := stringOrEmpty(timezone)
+	if name == "" {
+		name = "UTC"
+	}
+	if _, err := recurrence.LoadLocation(name); err != nil {
+		return ErrInvalidRecurrence
+	}
+	// 期限を起点に繰り返すため、期限の無いTodoは繰り返せない
+	if start == nil {
+		return ErrRecurrenceRequiresDueAt
+	}
+
+	normalized := parsed.String()
+	todo.RecurrenceRule, todo.RecurrenceTimezone, todo.RecurrenceStart = &normalized, &name, start
+	return nil
+}
+
+// createNextOccurrence は完了した繰り返しTodoの次回分を作成する（繰り返しが終了している場合は nil）
+// タイトル・説明・優先度・親Todo・所有者・プロジェクト・タグを引き継ぎ、リマインドは期限との間隔を保つ
+func createNextOccurrence(todoRepo repository.TodoRepository, tagRepo repository.TagRepository, todo *models.Todo) (*models.Todo, error) {
+	if todo.RecurrenceRule == nil || todo.RecurrenceStart == nil || todo.DueAt == nil {
+		return nil, nil
+	}
+
+	rule, err := recurrence.Parse(*todo.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := recurrence.LoadLocation(stringOrEmpty(todo.RecurrenceTimezone))
+	if err != nil {
+		return nil, err
+	}
+
+	dueAt, ok := rule.Next(todo.RecurrenceStart.In(loc), *todo.DueAt)
+	if !ok {
+		return nil, nil
+	}
+
+	next, err := todoRepo.Create(&models.Todo{
+		Title:              todo.Title,
+		Description:        todo.Description,
+		Priority:           todo.Priority,
+		ParentID:           todo.ParentID,
+		OwnerID:            todo.OwnerID,
+		ProjectID:          todo.ProjectID,
+		DueAt:              &dueAt,
+		RemindAt:           shiftReminder(todo, dueAt),
+		RecurrenceRule:     todo.RecurrenceRule,
+		RecurrenceTimezone: todo.RecurrenceTimezone,
+		RecurrenceStart:    todo.RecurrenceStart,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if next.Tags, err = setTodoTags(tagRepo, next.ID, tagNamesOf(todo.Tags)); err != nil {
+		return nil, err
+	}
+	if err := todoRepo.SetNextOccurrence(todo.ID, next.ID); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// shiftReminder は期限が dueAt の回のリマインド日時を、元のTodoの期限との間隔から計算する
+func shiftReminder(todo *models.Todo, dueAt time.Time) *time.Time {
+	if todo.RemindAt == nil || todo.DueAt == nil {
+		return nil
+	}
+	remindAt := dueAt.Add(todo.RemindAt.Sub(*todo.DueAt))
+	return &remindAt
+}
+
+func stringOrEmpty(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
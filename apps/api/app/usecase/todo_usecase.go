@@ -1,52 +1,205 @@
 package usecase
 
 import (
+	"api/app/auth"
 	"api/app/external"
 	"api/app/models"
 	"api/repository"
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 var (
-	ErrTodoNotFound = errors.New("todo not found")
-	ErrInvalidInput = errors.New("invalid input")
+	ErrTodoNotFound       = errors.New("todo not found")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrParentTodoNotFound = errors.New("parent todo not found")
+	ErrTodoHierarchyCycle = errors.New("todo hierarchy cycle")
+	ErrOpenChildTodos     = errors.New("todo has open child todos")
+	// ErrTodoVersionMismatch は指定されたバージョンが現在のTodoと一致しない（他の更新と競合した）ことを表す
+	ErrTodoVersionMismatch = errors.New("todo version mismatch")
 )
 
 type TodoUsecase interface {
-	GetAllTodos() ([]models.Todo, error)
-	GetTodoByID(id int) (*models.Todo, error)
-	CreateTodo(todo *models.Todo) (*models.Todo, error)
-	UpdateTodo(id int, todo *models.Todo) (*models.Todo, error)
-	DeleteTodo(id int) error
+	GetAllTodos(ctx context.Context, query models.TodoListQuery) (*models.TodoPage, error)
+	// ExportTodos は一覧と同じ条件に一致するTodoを全件、1件ずつ fn に渡す
+	ExportTodos(ctx context.Context, query models.TodoListQuery, fn func(todo models.Todo) error) error
+	// ImportTodos は rows を検証し、不備が無い場合はまとめて登録する（DryRun の場合は検証のみ）
+	ImportTodos(ctx context.Context, rows []models.TodoImportRow, opts TodoImportOptions) (*models.TodoImportResult, error)
+	GetTodoByID(ctx context.Context, id int) (*models.Todo, error)
+	GetChildTodos(ctx context.Context, id int, tree bool) ([]models.Todo, error)
+	SearchTodos(ctx context.Context, query models.TodoSearchQuery) ([]models.TodoSearchResult, error)
+	CreateTodo(ctx context.Context, todo *models.Todo) (*models.Todo, error)
+	UpdateTodo(ctx context.Context, id int, todo *models.Todo, opts UpdateTodoOptions) (*models.Todo, error)
+	// PatchTodo は JSON Merge Patch（RFC 7396）の意味でTodoを部分更新する（null を指定した項目は値を消す）
+	PatchTodo(ctx context.Context, id int, patch models.TodoPatch, opts UpdateTodoOptions) (*models.Todo, error)
+	DeleteTodo(ctx context.Context, id int, opts DeleteTodoOptions) error
+	// GetTodoHistory はTodoの変更履歴を新しい順に取得する（ゴミ箱内のTodoも対象）
+	GetTodoHistory(ctx context.Context, id int, query models.TodoEventQuery) (*models.TodoEventPage, error)
+	// PreviewOccurrences は繰り返しTodoの今後の期限を取得する
+	PreviewOccurrences(ctx context.Context, id int, query models.OccurrenceQuery) ([]models.Occurrence, error)
+	// BulkTodos は複数の作成・更新・完了・削除を1つのトランザクションで実行する
+	BulkTodos(ctx context.Context, mode BulkMode, operations []BulkTodoOperation) (*BulkTodoResult, error)
+}
+
+// UpdateTodoOptions はTodo更新時の振る舞いを指定する
+type UpdateTodoOptions struct {
+	// CascadeCompletion が true の場合、親Todoの完了時に未完了の子孫Todoもまとめて完了にする
+	// false の場合、未完了の子孫Todoがあると完了にできない（ErrOpenChildTodos）
+	CascadeCompletion bool
+	// ExpectedVersion を指定した場合、Todoの version が一致しなければ更新せず ErrTodoVersionMismatch を返す
+	ExpectedVersion *int
+}
+
+// DeleteTodoOptions はTodo削除時の振る舞いを指定する
+type DeleteTodoOptions struct {
+	// ExpectedVersion を指定した場合、Todoの version が一致しなければ削除せず ErrTodoVersionMismatch を返す
+	ExpectedVersion *int
 }
 
 type todoUsecase struct {
 	todoRepo           repository.TodoRepository
+	tagRepo            repository.TagRepository
+	projectRepo        repository.ProjectRepository
+	eventRepo          repository.TodoEventRepository
+	txManager          repository.TxManager
 	notificationClient external.NotificationClient
 }
 
-func NewTodoUsecase(todoRepo repository.TodoRepository, notificationClient external.NotificationClient) TodoUsecase {
+func NewTodoUsecase(todoRepo repository.TodoRepository, tagRepo repository.TagRepository, projectRepo repository.ProjectRepository, eventRepo repository.TodoEventRepository, txManager repository.TxManager, notificationClient external.NotificationClient) TodoUsecase {
 	return &todoUsecase{
 		todoRepo:           todoRepo,
+		tagRepo:            tagRepo,
+		projectRepo:        projectRepo,
+		eventRepo:          eventRepo,
+		txManager:          txManager,
 		notificationClient: notificationClient,
 	}
 }
 
-func (u *todoUsecase) GetAllTodos() ([]models.Todo, error) {
-	todos, err := u.todoRepo.GetAll()
+// inWorkspace は ctx のワークスペースのTodo・プロジェクト・タグのみを扱う todoUsecase を返す
+func (u *todoUsecase) inWorkspace(ctx context.Context) (*todoUsecase, error) {
+	workspaceID, err := workspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &todoUsecase{
+		todoRepo:           u.todoRepo.WithWorkspace(workspaceID),
+		tagRepo:            u.tagRepo.WithWorkspace(workspaceID),
+		projectRepo:        u.projectRepo.WithWorkspace(workspaceID),
+		eventRepo:          u.eventRepo,
+		txManager:          u.txManager,
+		notificationClient: u.notificationClient,
+	}, nil
+}
+
+func (u *todoUsecase) GetAllTodos(ctx context.Context, query models.TodoListQuery) (*models.TodoPage, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if query.Limit < 0 || query.Limit > models.MaxTodoListLimit {
+		return nil, ErrInvalidInput
+	}
+	query, err = u.listQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := u.todoRepo.GetAll(query)
 	if err != nil {
 		return nil, err
 	}
 	// Return empty slice instead of nil for consistency
-	if todos == nil {
-		todos = []models.Todo{}
+	if page.Todos == nil {
+		page.Todos = []models.Todo{}
+	}
+
+	// ツリー表示では各ルートTodoの子孫をまとめて読み込む
+	if query.RootsOnly {
+		if err := u.attachDescendants(page.Todos); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := loadTags(u.tagRepo, page.Todos); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// todoExportBatchSize はエクスポート時にカーソルから一度に読み込む件数
+const todoExportBatchSize = 500
+
+// ExportTodos は一覧と同じ条件・並び順に一致するTodoを全件、タグを読み込んで1件ずつ fn に渡す
+// 件数・カーソル・ツリー表示の指定は無視する
+func (u *todoUsecase) ExportTodos(ctx context.Context, query models.TodoListQuery, fn func(todo models.Todo) error) error {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return err
+	}
+	query.Limit = 0
+	query.Cursor = nil
+	query.RootsOnly = false
+	query, err = u.listQuery(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	// タグはカーソルと同じトランザクションで読み込み、1件のエクスポートで接続を2本使わないようにする
+	return u.todoRepo.Export(ctx, query, todoExportBatchSize, func(tx repository.DBTX, todos []models.Todo) error {
+		if err := loadTags(u.tagRepo.WithTx(tx), todos); err != nil {
+			return err
+		}
+		for _, todo := range todos {
+			if err := fn(todo); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// listQuery は一覧・エクスポートの条件を検証し、タグ名の正規化と閲覧範囲の絞り込みを行う
+func (u *todoUsecase) listQuery(ctx context.Context, query models.TodoListQuery) (models.TodoListQuery, error) {
+	for _, priority := range query.Priorities {
+		if !isValidPriority(priority) {
+			return query, ErrInvalidInput
+		}
 	}
-	return todos, nil
+	for _, sort := range query.Sort {
+		if !models.IsValidTodoSortField(sort.Field) {
+			return query, ErrInvalidInput
+		}
+	}
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && !query.CreatedBefore.After(*query.CreatedAfter) {
+		return query, ErrInvalidInput
+	}
+	tags, ok := normalizeTagNames(query.Tags)
+	if !ok {
+		return query, ErrInvalidInput
+	}
+	query.Tags = tags
+	// カーソルは発行時と同じ並び順でのみ有効
+	if query.Cursor != nil && query.Cursor.Sort != models.FormatTodoSort(query.SortOrDefault()) {
+		return query, ErrInvalidInput
+	}
+	if query.ProjectID != nil {
+		if _, err := findProject(ctx, u.projectRepo, *query.ProjectID, models.ProjectRoleViewer); err != nil {
+			return query, err
+		}
+	}
+	query.UserID = userScope(ctx)
+	return query, nil
 }
 
-func (u *todoUsecase) GetTodoByID(id int) (*models.Todo, error) {
+func (u *todoUsecase) GetTodoByID(ctx context.Context, id int) (*models.Todo, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if id <= 0 {
 		return nil, ErrInvalidInput
 	}
@@ -59,17 +212,173 @@ func (u *todoUsecase) GetTodoByID(id int) (*models.Todo, error) {
 	if todo == nil {
 		return nil, ErrTodoNotFound
 	}
+	if err := authorizeTodo(ctx, u.projectRepo, todo, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
 
-	return todo, nil
+	todos := []models.Todo{*todo}
+	if err := loadTags(u.tagRepo, todos); err != nil {
+		return nil, err
+	}
+
+	return &todos[0], nil
 }
 
-func (u *todoUsecase) CreateTodo(todo *models.Todo) (*models.Todo, error) {
+func (u *todoUsecase) GetChildTodos(ctx context.Context, id int, tree bool) ([]models.Todo, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.GetTodoByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	children, err := u.todoRepo.GetChildren(id)
+	if err != nil {
+		return nil, err
+	}
+	if children == nil {
+		children = []models.Todo{}
+	}
+
+	if tree {
+		if err := u.attachDescendants(children); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := loadTags(u.tagRepo, children); err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+// attachDescendants は todos の子孫を1回のクエリで取得し、Children に階層構造で設定する
+func (u *todoUsecase) attachDescendants(todos []models.Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	descendants, err := u.todoRepo.GetDescendants(ids)
+	if err != nil {
+		return err
+	}
+
+	childrenOf := map[int][]models.Todo{}
+	for _, todo := range descendants {
+		if todo.ParentID != nil {
+			childrenOf[*todo.ParentID] = append(childrenOf[*todo.ParentID], todo)
+		}
+	}
+	attachChildren(todos, childrenOf)
+	return nil
+}
+
+func attachChildren(todos []models.Todo, childrenOf map[int][]models.Todo) {
+	for i := range todos {
+		children := childrenOf[todos[i].ID]
+		attachChildren(children, childrenOf)
+		todos[i].Children = children
+	}
+}
+
+func (u *todoUsecase) SearchTodos(ctx context.Context, query models.TodoSearchQuery) ([]models.TodoSearchResult, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query.Q = strings.TrimSpace(query.Q)
+	if query.Q == "" || query.Limit < 0 || query.Limit > models.MaxTodoListLimit {
+		return nil, ErrInvalidInput
+	}
+	query.UserID = userScope(ctx)
+
+	results, err := u.todoRepo.Search(query)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []models.TodoSearchResult{}
+	}
+
+	ids := make([]int, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	tagsByTodo, err := u.tagRepo.GetByTodoIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// 検索語の一致箇所を強調表示した抜粋を付与する
+	terms := searchTerms(query.Q)
+	for i := range results {
+		results[i].Tags = tagsOrEmpty(tagsByTodo[results[i].ID])
+		results[i].TitleHighlight = highlight(results[i].Title, terms)
+		results[i].DescriptionSnippet = snippet(results[i].Description, terms)
+	}
+
+	return results, nil
+}
+
+func (u *todoUsecase) CreateTodo(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	createdTodo, err := u.createTodo(ctx, todo)
+	if err != nil {
+		return nil, err
+	}
+
+	u.notifyCreated(ctx, createdTodo)
+	return createdTodo, nil
+}
+
+// createTodo はTodoとタグ・履歴を作成する（通知は送信しない）
+func (u *todoUsecase) createTodo(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
+	tagNames, err := u.prepareTodo(ctx, todo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create todo, its tags and the history event in database
+	var createdTodo *models.Todo
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		var err error
+		createdTodo, err = u.todoRepo.WithTx(tx).Create(todo)
+		if err != nil {
+			return err
+		}
+
+		createdTodo.Tags, err = setTodoTags(u.tagRepo.WithTx(tx), createdTodo.ID, tagNames)
+		if err != nil {
+			return err
+		}
+
+		return u.eventRepo.WithTx(tx).Create([]models.TodoEvent{
+			newTodoEvent(ctx, createdTodo.ID, models.TodoEventCreated, createdChanges(createdTodo)),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdTodo, nil
+}
+
+// prepareTodo は作成するTodoを検証して既定値と所有者を設定し、正規化したタグ名を返す
+func (u *todoUsecase) prepareTodo(ctx context.Context, todo *models.Todo) ([]string, error) {
 	if todo.Title == "" {
 		return nil, ErrInvalidInput
 	}
 
 	// Validate priority
-	if todo.Priority != "" && todo.Priority != models.PriorityLow && todo.Priority != models.PriorityMedium && todo.Priority != models.PriorityHigh {
+	if todo.Priority != "" && !isValidPriority(todo.Priority) {
 		return nil, ErrInvalidInput
 	}
 
@@ -78,16 +387,56 @@ func (u *todoUsecase) CreateTodo(todo *models.Todo) (*models.Todo, error) {
 		todo.Priority = models.PriorityMedium
 	}
 
-	// Create todo in database
-	createdTodo, err := u.todoRepo.Create(todo.Title, todo.Description, todo.Priority)
-	if err != nil {
+	if !isValidReminder(todo.DueAt, todo.RemindAt) {
+		return nil, ErrInvalidInput
+	}
+
+	if err := applyRecurrence(todo, nil); err != nil {
 		return nil, err
 	}
 
+	if todo.ParentID != nil {
+		parent, err := u.todoRepo.GetByID(*todo.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, ErrParentTodoNotFound
+		}
+		if err := authorizeTodo(ctx, u.projectRepo, parent, models.ProjectRoleEditor); err != nil {
+			return nil, err
+		}
+		// プロジェクト未指定のサブタスクは親と同じプロジェクトに入れる
+		if todo.ProjectID == nil {
+			todo.ProjectID = parent.ProjectID
+		}
+	}
+	if todo.ProjectID != nil {
+		if err := u.validateProject(ctx, *todo.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	// 作成したユーザーを所有者にする
+	todo.OwnerID = userScope(ctx)
+
+	tagNames, ok := normalizeTagNames(tagNamesOf(todo.Tags))
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+	return tagNames, nil
+}
+
+// notifyCreated はTodoの作成を作成したユーザーにプッシュ通知する
+func (u *todoUsecase) notifyCreated(ctx context.Context, createdTodo *models.Todo) {
+	// 未認証（バックグラウンドジョブなど）の場合は通知先がいないため送信しない
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
 	// Send notification (外部API呼び出し)
-	ctx := context.Background()
 	notificationReq := &external.NotificationRequest{
-		UserID:  1, // 固定値（実際は認証ユーザーIDを使用）
+		UserID:  userID,
 		Title:   "新しいTodoが作成されました",
 		Message: fmt.Sprintf("「%s」が作成されました。優先度: %s", createdTodo.Title, createdTodo.Priority),
 		Type:    "push",
@@ -99,15 +448,64 @@ func (u *todoUsecase) CreateTodo(todo *models.Todo) (*models.Todo, error) {
 		// ログに記録するだけで、エラーは返さない
 		fmt.Printf("Failed to send notification: %v\n", notifErr)
 	}
+}
+
+func (u *todoUsecase) UpdateTodo(ctx context.Context, id int, todo *models.Todo, opts UpdateTodoOptions) (*models.Todo, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
 
-	return createdTodo, nil
+	return u.patchTodo(ctx, id, updatePatch(todo), opts)
 }
 
-func (u *todoUsecase) UpdateTodo(id int, todo *models.Todo) (*models.Todo, error) {
+func (u *todoUsecase) PatchTodo(ctx context.Context, id int, patch models.TodoPatch, opts UpdateTodoOptions) (*models.Todo, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if id <= 0 {
 		return nil, ErrInvalidInput
 	}
 
+	return u.patchTodo(ctx, id, patch, opts)
+}
+
+// updatePatch は UpdateTodo（PUT）の更新内容を TodoPatch に変換する
+// 空文字・nil の項目は変更せず、project_id の 0 はインボックスへの移動として扱う
+func updatePatch(todo *models.Todo) models.TodoPatch {
+	patch := models.TodoPatch{
+		Completed:          models.NewNullable(todo.Completed),
+		ParentID:           models.NullableFromPtr(todo.ParentID),
+		ProjectID:          models.NullableFromPtr(todo.ProjectID),
+		DueAt:              models.NullableFromPtr(todo.DueAt),
+		RemindAt:           models.NullableFromPtr(todo.RemindAt),
+		RecurrenceRule:     models.NullableFromPtr(todo.RecurrenceRule),
+		RecurrenceTimezone: models.NullableFromPtr(todo.RecurrenceTimezone),
+	}
+	if todo.Title != "" {
+		patch.Title = models.NewNullable(todo.Title)
+	}
+	if todo.Description != "" {
+		patch.Description = models.NewNullable(todo.Description)
+	}
+	if todo.Priority != "" {
+		patch.Priority = models.NewNullable(todo.Priority)
+	}
+	if todo.ProjectID != nil && *todo.ProjectID == 0 {
+		patch.ProjectID = models.NullOf[int]()
+	}
+	if todo.Tags != nil {
+		patch.Tags = models.NewNullable(tagNamesOf(todo.Tags))
+	}
+	return patch
+}
+
+// patchTodo は patch で指定された項目のみを更新する（u はワークスペースに紐付け済み）
+func (u *todoUsecase) patchTodo(ctx context.Context, id int, patch models.TodoPatch, opts UpdateTodoOptions) (*models.Todo, error) {
 	// Check if todo exists
 	existingTodo, err := u.todoRepo.GetByID(id)
 	if err != nil {
@@ -117,37 +515,398 @@ func (u *todoUsecase) UpdateTodo(id int, todo *models.Todo) (*models.Todo, error
 	if existingTodo == nil {
 		return nil, ErrTodoNotFound
 	}
+	if err := authorizeTodo(ctx, u.projectRepo, existingTodo, models.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+
+	// タイトル・優先度・完了状態は消せない
+	if patch.Title.IsNull() || patch.Priority.IsNull() || patch.Completed.IsNull() {
+		return nil, ErrInvalidInput
+	}
+	if patch.Title.HasValue() && patch.Title.Value == "" {
+		return nil, ErrInvalidInput
+	}
 
 	// Validate priority
-	if todo.Priority != "" && todo.Priority != models.PriorityLow && todo.Priority != models.PriorityMedium && todo.Priority != models.PriorityHigh {
+	if patch.Priority.HasValue() && !isValidPriority(patch.Priority.Value) {
 		return nil, ErrInvalidInput
 	}
 
-	// Update with provided values
-	updatedTodo, err := u.todoRepo.Update(id, todo.Title, todo.Description, todo.Priority, &todo.Completed)
+	// 片方のみ更新する場合は既存の値と組み合わせて検証する
+	dueAt, remindAt := existingTodo.DueAt, existingTodo.RemindAt
+	if patch.DueAt.Set {
+		dueAt = patch.DueAt.Ptr()
+	}
+	if patch.RemindAt.Set {
+		remindAt = patch.RemindAt.Ptr()
+	}
+	if !isValidReminder(dueAt, remindAt) {
+		return nil, ErrInvalidInput
+	}
+
+	// null の繰り返し設定は空文字（解除）、null のタイムゾーンは空文字（UTC）として扱う
+	recurring := &models.Todo{
+		DueAt:              patch.DueAt.Ptr(),
+		RecurrenceRule:     patch.RecurrenceRule.OrZero(),
+		RecurrenceTimezone: patch.RecurrenceTimezone.OrZero(),
+	}
+	if err := applyRecurrence(recurring, existingTodo); err != nil {
+		return nil, err
+	}
+	// 期限を消す場合は繰り返しも解除しなければならない
+	if patch.DueAt.IsNull() {
+		stillRecurring := existingTodo.RecurrenceRule != nil
+		if recurring.RecurrenceRule != nil {
+			stillRecurring = *recurring.RecurrenceRule != ""
+		}
+		if stillRecurring {
+			return nil, ErrRecurrenceRequiresDueAt
+		}
+	}
+
+	// null の場合はルートTodoにするため検証しない
+	if patch.ParentID.HasValue() {
+		if err := u.validateParent(ctx, id, patch.ParentID.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	// null の場合はインボックスに移動するため検証しない
+	if patch.ProjectID.HasValue() {
+		if err := u.validateProject(ctx, patch.ProjectID.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	// Tags が未指定の場合はタグを変更しない（null の場合は全て外す）
+	var tagNames []string
+	if patch.Tags.Set {
+		var ok bool
+		if tagNames, ok = normalizeTagNames(patch.Tags.Value); !ok {
+			return nil, ErrInvalidInput
+		}
+	}
+
+	var updatedTodo *models.Todo
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		todoRepo := u.todoRepo.WithTx(tx)
+		tagRepo := u.tagRepo.WithTx(tx)
+
+		// 変更前の状態を行ロックして取得し、履歴の差分に使う
+		before, err := todoRepo.GetByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return ErrTodoNotFound
+		}
+		if opts.ExpectedVersion != nil && before.Version != *opts.ExpectedVersion {
+			return ErrTodoVersionMismatch
+		}
+		beforeTodos := []models.Todo{*before}
+		if err := loadTags(tagRepo, beforeTodos); err != nil {
+			return err
+		}
+		before = &beforeTodos[0]
+
+		var events []models.TodoEvent
+
+		// 完了にする場合、未完了の子孫Todoがあればオプションに応じて拒否またはまとめて完了にする
+		if patch.Completed.HasValue() && patch.Completed.Value && !before.Completed {
+			openCount, err := todoRepo.CountOpenDescendants(id)
+			if err != nil {
+				return err
+			}
+			if openCount > 0 {
+				if !opts.CascadeCompletion {
+					return ErrOpenChildTodos
+				}
+				// 別のプロジェクトにある子孫Todoも完了にするため、それぞれのプロジェクトのロールを確認する
+				if err := u.authorizeDescendants(ctx, todoRepo, id); err != nil {
+					return err
+				}
+				completedIDs, err := todoRepo.CompleteDescendants(id)
+				if err != nil {
+					return err
+				}
+				for _, completedID := range completedIDs {
+					events = append(events, newTodoEvent(ctx, completedID, models.TodoEventCompleted, models.TodoChanges{
+						"completed": {Before: false, After: true},
+					}))
+				}
+			}
+		}
+
+		// Update with provided values
+		updatedTodo, err = todoRepo.Update(id, models.TodoUpdate{
+			Title:       patch.Title.Value,
+			Description: patch.Description.OrZero(),
+			Priority:    patch.Priority.Value,
+			Completed:   patch.Completed.Ptr(),
+			ParentID:    patch.ParentID,
+			ProjectID:   patch.ProjectID,
+			DueAt:       patch.DueAt,
+			RemindAt:    patch.RemindAt,
+
+			RecurrenceRule:     recurring.RecurrenceRule,
+			RecurrenceTimezone: recurring.RecurrenceTimezone,
+			RecurrenceStart:    recurring.RecurrenceStart,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updatedTodo == nil {
+			return ErrTodoNotFound
+		}
+
+		// Tags が未指定の場合は変更前のタグのまま
+		updatedTodo.Tags = before.Tags
+		if patch.Tags.Set {
+			if updatedTodo.Tags, err = setTodoTags(tagRepo, id, tagNames); err != nil {
+				return err
+			}
+		}
+
+		// 値が変わった項目がある場合のみ履歴を残す
+		if changes := diffTodo(before, updatedTodo); len(changes) > 0 {
+			events = append(events, newTodoEvent(ctx, id, updateEventType(changes), changes))
+		}
+
+		// 繰り返しTodoを完了にした場合は次回分を作成する（完了し直しても二重に作成しない）
+		if !before.Completed && updatedTodo.Completed && updatedTodo.NextOccurrenceID == nil {
+			next, err := createNextOccurrence(todoRepo, tagRepo, updatedTodo)
+			if err != nil {
+				return err
+			}
+			if next != nil {
+				updatedTodo.NextOccurrenceID = &next.ID
+				events = append(events, newTodoEvent(ctx, next.ID, models.TodoEventCreated, createdChanges(next)))
+			}
+		}
+		return u.eventRepo.WithTx(tx).Create(events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedTodo, nil
+}
+
+func (u *todoUsecase) GetTodoHistory(ctx context.Context, id int, query models.TodoEventQuery) (*models.TodoEventPage, error) {
+	u, err := u.inWorkspace(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if id <= 0 || query.Limit < 0 || query.Limit > models.MaxTodoListLimit {
+		return nil, ErrInvalidInput
+	}
 
-	if updatedTodo == nil {
+	// 削除の履歴も確認できるよう、ゴミ箱内のTodoも対象にする
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if todo == nil {
+		if todo, err = u.todoRepo.GetDeletedByID(id); err != nil {
+			return nil, err
+		}
+	}
+	if todo == nil {
 		return nil, ErrTodoNotFound
 	}
+	if err := authorizeTodo(ctx, u.projectRepo, todo, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
 
-	return updatedTodo, nil
+	page, err := u.eventRepo.GetByTodoID(id, query)
+	if err != nil {
+		return nil, err
+	}
+	// Return empty slice instead of nil for consistency
+	if page.Events == nil {
+		page.Events = []models.TodoEvent{}
+	}
+	return page, nil
 }
 
-func (u *todoUsecase) DeleteTodo(id int) error {
+// setTodoTags はTodoのタグを names で置き換え、付与したタグを返す（存在しないタグは作成する）
+func setTodoTags(tagRepo repository.TagRepository, todoID int, names []string) ([]models.Tag, error) {
+	tags, err := tagRepo.EnsureByNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]int, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+	if err := tagRepo.SetTodoTags(todoID, tagIDs); err != nil {
+		return nil, err
+	}
+	return tagsOrEmpty(tags), nil
+}
+
+// loadTags は todos（入れ子の Children を含む）のタグを1回のクエリでまとめて読み込む
+func loadTags(tagRepo repository.TagRepository, todos []models.Todo) error {
+	var ids []int
+	collectTodoIDs(todos, &ids)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tagsByTodo, err := tagRepo.GetByTodoIDs(ids)
+	if err != nil {
+		return err
+	}
+	assignTags(todos, tagsByTodo)
+	return nil
+}
+
+func collectTodoIDs(todos []models.Todo, ids *[]int) {
+	for _, todo := range todos {
+		*ids = append(*ids, todo.ID)
+		collectTodoIDs(todo.Children, ids)
+	}
+}
+
+func assignTags(todos []models.Todo, tagsByTodo map[int][]models.Tag) {
+	for i := range todos {
+		todos[i].Tags = tagsOrEmpty(tagsByTodo[todos[i].ID])
+		assignTags(todos[i].Children, tagsByTodo)
+	}
+}
+
+func tagNamesOf(tags []models.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// Return empty slice instead of nil for consistency
+func tagsOrEmpty(tags []models.Tag) []models.Tag {
+	if tags == nil {
+		return []models.Tag{}
+	}
+	return tags
+}
+
+// validateParent は id のTodoを parentID の子にできるか（親が存在してアクセスでき、循環しないか）を検証する
+func (u *todoUsecase) validateParent(ctx context.Context, id int, parentID int) error {
+	if parentID == id {
+		return ErrTodoHierarchyCycle
+	}
+
+	parent, err := u.todoRepo.GetByID(parentID)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return ErrParentTodoNotFound
+	}
+	if err := authorizeTodo(ctx, u.projectRepo, parent, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	// 自分の子孫を親にすると循環する
+	isDescendant, err := u.todoRepo.IsDescendant(id, parentID)
+	if err != nil {
+		return err
+	}
+	if isDescendant {
+		return ErrTodoHierarchyCycle
+	}
+
+	return nil
+}
+
+// validateProject はTodoを projectID のプロジェクトに入れられるか（編集でき、アーカイブされていないか）を検証する
+func (u *todoUsecase) validateProject(ctx context.Context, projectID int) error {
+	project, err := findProject(ctx, u.projectRepo, projectID, models.ProjectRoleEditor)
+	if err != nil {
+		return err
+	}
+	if project.Archived {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+func (u *todoUsecase) DeleteTodo(ctx context.Context, id int, opts DeleteTodoOptions) error {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return err
+	}
 	if id <= 0 {
 		return ErrInvalidInput
 	}
 
-	err := u.todoRepo.Delete(id)
+	todo, err := u.todoRepo.GetByID(id)
 	if err != nil {
-		if errors.Is(err, repository.ErrNoRows) {
-			return ErrTodoNotFound
+		return err
+	}
+	if todo == nil {
+		return ErrTodoNotFound
+	}
+	if err := authorizeTodo(ctx, u.projectRepo, todo, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	return u.txManager.WithinTx(func(tx repository.DBTX) error {
+		todoRepo := u.todoRepo.WithTx(tx)
+
+		if opts.ExpectedVersion != nil {
+			current, err := todoRepo.GetByIDForUpdate(id)
+			if err != nil {
+				return err
+			}
+			if current == nil {
+				return ErrTodoNotFound
+			}
+			if current.Version != *opts.ExpectedVersion {
+				return ErrTodoVersionMismatch
+			}
+		}
+
+		// 別のプロジェクトにある子孫Todoも一緒にゴミ箱に移動するため、それぞれのプロジェクトのロールを確認する
+		if err := u.authorizeDescendants(ctx, todoRepo, id); err != nil {
+			return err
 		}
+		deleted, err := todoRepo.Delete(id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNoRows) {
+				return ErrTodoNotFound
+			}
+			return err
+		}
+
+		// 一緒にゴミ箱に移動した子孫Todoにも履歴を残す
+		events := make([]models.TodoEvent, len(deleted))
+		for i, todo := range deleted {
+			events[i] = newTodoEvent(ctx, todo.ID, models.TodoEventDeleted, models.TodoChanges{
+				"deleted_at": {Before: nil, After: timeOrNil(todo.DeletedAt)},
+			})
+		}
+		return u.eventRepo.WithTx(tx).Create(events)
+	})
+}
+
+// authorizeDescendants は id の子孫Todo（ゴミ箱内を除く）を全て編集できることを検証する
+func (u *todoUsecase) authorizeDescendants(ctx context.Context, todoRepo repository.TodoRepository, id int) error {
+	descendants, err := todoRepo.GetDescendants([]int{id})
+	if err != nil {
 		return err
 	}
+	return authorizeTodos(ctx, u.projectRepo, descendants, models.ProjectRoleEditor)
+}
 
-	return nil
+func isValidPriority(priority models.TodoPriority) bool {
+	return priority == models.PriorityLow || priority == models.PriorityMedium || priority == models.PriorityHigh
+}
+
+// isValidReminder はリマインド日時が期限より後になっていないかを検証する
+func isValidReminder(dueAt, remindAt *time.Time) bool {
+	return dueAt == nil || remindAt == nil || !remindAt.After(*dueAt)
 }
@@ -1,6 +1,7 @@
 package usecase_test
 
 import (
+	"api/app/auth"
 	"api/app/external"
 	extMock "api/app/external/mock"
 	"api/app/models"
@@ -19,6 +20,9 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Mock(t *testing.T) {
 	// 実DBセットアップ（Repository部分は実データベース使用）
 	db, cleanup := test.SetupTestDB()
 	defer cleanup()
+	userID := createTestUser(t, db, "notify@example.com")
+	workspaceID := createTestWorkspace(t, db, "test", userID)
+	ctx := auth.WithUserID(auth.WithWorkspaceID(context.Background(), workspaceID), userID)
 
 	// 実Repository作成
 	todoRepo := repository.NewTodoRepository(db)
@@ -27,7 +31,7 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Mock(t *testing.T) {
 	mockNotificationClient := extMock.NewMockNotificationClient(t)
 
 	// UseCase作成（Repository=実DB, 外部API=Mock）
-	todoUsecase := usecase.NewTodoUsecase(todoRepo, mockNotificationClient)
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, repository.NewTagRepository(db), repository.NewProjectRepository(db), repository.NewTodoEventRepository(db), repository.NewTxManager(db), mockNotificationClient)
 
 	// テストデータ
 	todo := &models.Todo{
@@ -41,7 +45,7 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Mock(t *testing.T) {
 
 	// Mock外部APIの期待値設定
 	expectedNotificationReq := &external.NotificationRequest{
-		UserID:  1,
+		UserID:  userID,
 		Title:   "新しいTodoが作成されました",
 		Message: "「外部API統合テスト」が作成されました。優先度: high",
 		Type:    "push",
@@ -55,12 +59,12 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Mock(t *testing.T) {
 
 	// 外部API呼び出しのMock設定
 	mockNotificationClient.EXPECT().
-		SendNotification(context.Background(), expectedNotificationReq).
+		SendNotification(ctx, expectedNotificationReq).
 		Return(expectedNotificationResp, nil).
 		Once()
 
 	// テスト実行
-	result, err := todoUsecase.CreateTodo(todo)
+	result, err := todoUsecase.CreateTodo(ctx, todo)
 
 	// 結果検証
 	require.NoError(t, err)
@@ -72,7 +76,7 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Mock(t *testing.T) {
 	assert.False(t, result.Completed)
 
 	// 実DBに保存されていることを確認
-	savedTodo, err := todoRepo.GetByID(result.ID)
+	savedTodo, err := todoRepo.WithWorkspace(workspaceID).GetByID(result.ID)
 	require.NoError(t, err)
 	assert.NotNil(t, savedTodo)
 	assert.Equal(t, "外部API統合テスト", savedTodo.Title)
@@ -84,6 +88,9 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Error(t *testing.T) {
 	// 実DBセットアップ
 	db, cleanup := test.SetupTestDB()
 	defer cleanup()
+	userID := createTestUser(t, db, "notify@example.com")
+	workspaceID := createTestWorkspace(t, db, "test", userID)
+	ctx := auth.WithUserID(auth.WithWorkspaceID(context.Background(), workspaceID), userID)
 
 	// 実Repository作成
 	todoRepo := repository.NewTodoRepository(db)
@@ -92,7 +99,7 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Error(t *testing.T) {
 	mockNotificationClient := extMock.NewMockNotificationClient(t)
 
 	// UseCase作成
-	todoUsecase := usecase.NewTodoUsecase(todoRepo, mockNotificationClient)
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, repository.NewTagRepository(db), repository.NewProjectRepository(db), repository.NewTodoEventRepository(db), repository.NewTxManager(db), mockNotificationClient)
 
 	// テストデータ
 	todo := &models.Todo{
@@ -104,7 +111,7 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Error(t *testing.T) {
 
 	// Mock外部APIの期待値設定（エラーを返す）
 	expectedNotificationReq := &external.NotificationRequest{
-		UserID:  1,
+		UserID:  userID,
 		Title:   "新しいTodoが作成されました",
 		Message: "「通知エラーテスト」が作成されました。優先度: medium",
 		Type:    "push",
@@ -112,12 +119,12 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Error(t *testing.T) {
 
 	// 外部APIが失敗する場合のMock設定
 	mockNotificationClient.EXPECT().
-		SendNotification(context.Background(), expectedNotificationReq).
+		SendNotification(ctx, expectedNotificationReq).
 		Return(nil, assert.AnError). // エラーを返す
 		Once()
 
 	// テスト実行
-	result, err := todoUsecase.CreateTodo(todo)
+	result, err := todoUsecase.CreateTodo(ctx, todo)
 
 	// 結果検証：外部APIが失敗してもTodo作成は成功する
 	require.NoError(t, err)
@@ -125,8 +132,22 @@ func TestTodoUsecase_CreateTodo_WithExternalAPI_Error(t *testing.T) {
 	assert.Equal(t, "通知エラーテスト", result.Title)
 
 	// DBには正常に保存されていることを確認
-	savedTodo, err := todoRepo.GetByID(result.ID)
+	savedTodo, err := todoRepo.WithWorkspace(workspaceID).GetByID(result.ID)
 	require.NoError(t, err)
 	assert.NotNil(t, savedTodo)
 	assert.Equal(t, "通知エラーテスト", savedTodo.Title)
 }
+
+func TestTodoUsecase_CreateTodo_WithoutAuthenticatedUser(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	// 通知が送信されないこと（EXPECT 未設定の呼び出しはテスト失敗になる）
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	todoUsecase := usecase.NewTodoUsecase(repository.NewTodoRepository(db), repository.NewTagRepository(db), repository.NewProjectRepository(db), repository.NewTodoEventRepository(db), repository.NewTxManager(db), mockNotificationClient)
+
+	ctx := auth.WithWorkspaceID(context.Background(), createTestWorkspace(t, db, "test"))
+	result, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "未認証", Priority: models.PriorityLow})
+	require.NoError(t, err)
+	assert.NotZero(t, result.ID)
+}
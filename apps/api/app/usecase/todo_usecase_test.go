@@ -1,13 +1,16 @@
 package usecase_test
 
 import (
+	"api/app/auth"
 	"api/app/external"
 	"api/app/models"
 	"api/app/usecase"
 	"api/repository"
 	"api/test"
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,23 +21,29 @@ func TestMain(m *testing.M) {
 	m.Run()
 }
 
-func setupTest(_ *testing.T) (usecase.TodoUsecase, func()) {
+// setupTest は実DBの TodoUsecase と、テスト用のワークスペースを設定した context を返す
+func setupTest(t *testing.T) (usecase.TodoUsecase, context.Context, func()) {
 	// Get a test database connection with transaction isolation
 	db, cleanup := test.SetupTestDB()
+	ctx := auth.WithWorkspaceID(context.Background(), createTestWorkspace(t, db, "test"))
 
 	// Create repository and usecase with real database
 	todoRepo := repository.NewTodoRepository(db)
 	// 統合テストなので外部APIも実際のHTTPクライアント使用（ただし設定はテスト用）
 	notificationClient := external.NewHTTPNotificationClient("http://localhost:9999", "test-key")
-	todoUsecase := usecase.NewTodoUsecase(todoRepo, notificationClient)
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, repository.NewTagRepository(db), repository.NewProjectRepository(db), repository.NewTodoEventRepository(db), repository.NewTxManager(db), notificationClient)
 
-	return todoUsecase, cleanup
+	return todoUsecase, ctx, cleanup
 }
 
 func TestTodoUsecase_CreateTodo(t *testing.T) {
-	todoUsecase, cleanup := setupTest(t)
+	todoUsecase, ctx, cleanup := setupTest(t)
 	defer cleanup()
 
+	dueAt := time.Now().Add(24 * time.Hour)
+	remindBeforeDue := dueAt.Add(-time.Hour)
+	remindAfterDue := dueAt.Add(time.Hour)
+
 	tests := []struct {
 		name    string
 		req     *models.Todo
@@ -85,13 +94,33 @@ func TestTodoUsecase_CreateTodo(t *testing.T) {
 			},
 			wantErr: usecase.ErrInvalidInput,
 		},
+		{
+			name: "Todo with due date and reminder",
+			req: &models.Todo{
+				Title:    "Due Todo",
+				Priority: "medium",
+				DueAt:    &dueAt,
+				RemindAt: &remindBeforeDue,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Reminder after due date should fail",
+			req: &models.Todo{
+				Title:    "Invalid Reminder",
+				Priority: "medium",
+				DueAt:    &dueAt,
+				RemindAt: &remindAfterDue,
+			},
+			wantErr: usecase.ErrInvalidInput,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// No need to truncate tables - each test runs in its own transaction
 
-			todo, err := todoUsecase.CreateTodo(tt.req)
+			todo, err := todoUsecase.CreateTodo(ctx, tt.req)
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -106,17 +135,27 @@ func TestTodoUsecase_CreateTodo(t *testing.T) {
 
 				// Check priority
 				assert.Equal(t, tt.req.Priority, todo.Priority)
+
+				// Check due date and reminder
+				if tt.req.DueAt != nil {
+					require.NotNil(t, todo.DueAt)
+					assert.True(t, tt.req.DueAt.Equal(*todo.DueAt))
+				}
+				if tt.req.RemindAt != nil {
+					require.NotNil(t, todo.RemindAt)
+					assert.True(t, tt.req.RemindAt.Equal(*todo.RemindAt))
+				}
 			}
 		})
 	}
 }
 
 func TestTodoUsecase_GetTodoByID(t *testing.T) {
-	todoUsecase, cleanup := setupTest(t)
+	todoUsecase, ctx, cleanup := setupTest(t)
 	defer cleanup()
 
 	// Create a test todo first
-	createdTodo, err := todoUsecase.CreateTodo(&models.Todo{
+	createdTodo, err := todoUsecase.CreateTodo(ctx, &models.Todo{
 		Title:       "Test Todo",
 		Description: "Test Description",
 		Priority:    "medium",
@@ -152,7 +191,7 @@ func TestTodoUsecase_GetTodoByID(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			todo, err := todoUsecase.GetTodoByID(tt.id)
+			todo, err := todoUsecase.GetTodoByID(ctx, tt.id)
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -168,17 +207,19 @@ func TestTodoUsecase_GetTodoByID(t *testing.T) {
 }
 
 func TestTodoUsecase_GetAllTodos(t *testing.T) {
-	todoUsecase, cleanup := setupTest(t)
+	todoUsecase, ctx, cleanup := setupTest(t)
 	defer cleanup()
 
 	// Test empty list
-	todos, err := todoUsecase.GetAllTodos()
+	page, err := todoUsecase.GetAllTodos(ctx, models.TodoListQuery{})
 	require.NoError(t, err)
-	assert.Empty(t, todos)
+	assert.Empty(t, page.Todos)
+	assert.False(t, page.HasMore)
+	assert.Nil(t, page.NextCursor)
 
 	// Create some todos
 	for i := 1; i <= 3; i++ {
-		_, err := todoUsecase.CreateTodo(&models.Todo{
+		_, err := todoUsecase.CreateTodo(ctx, &models.Todo{
 			Title:       fmt.Sprintf("Todo %d", i),
 			Description: fmt.Sprintf("Description %d", i),
 			Priority:    "medium",
@@ -187,8 +228,9 @@ func TestTodoUsecase_GetAllTodos(t *testing.T) {
 	}
 
 	// Test with todos
-	todos, err = todoUsecase.GetAllTodos()
+	page, err = todoUsecase.GetAllTodos(ctx, models.TodoListQuery{})
 	require.NoError(t, err)
+	todos := page.Todos
 	assert.Len(t, todos, 3)
 
 	// Verify that all todos are present (order may vary due to same timestamp)
@@ -209,12 +251,550 @@ func TestTodoUsecase_GetAllTodos(t *testing.T) {
 	}
 }
 
+func TestTodoUsecase_GetAllTodos_Pagination(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	// 同一トランザクション内では created_at が同じになるため、id によるタイブレークも検証される
+	for i := 1; i <= 5; i++ {
+		_, err := todoUsecase.CreateTodo(ctx, &models.Todo{
+			Title:    fmt.Sprintf("Todo %d", i),
+			Priority: "medium",
+		})
+		require.NoError(t, err)
+	}
+
+	seen := map[int]bool{}
+	query := models.TodoListQuery{Limit: 2}
+	pages := 0
+	for {
+		page, err := todoUsecase.GetAllTodos(ctx, query)
+		require.NoError(t, err)
+		pages++
+
+		for _, todo := range page.Todos {
+			assert.False(t, seen[todo.ID], "todo %d returned twice", todo.ID)
+			seen[todo.ID] = true
+		}
+
+		if !page.HasMore {
+			assert.Nil(t, page.NextCursor)
+			break
+		}
+		require.NotNil(t, page.NextCursor)
+		assert.Len(t, page.Todos, 2)
+
+		// クライアントと同じくエンコード済みのカーソルを経由する
+		cursor, err := models.DecodeTodoCursor(page.NextCursor.Encode())
+		require.NoError(t, err)
+		query.Cursor = cursor
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Len(t, seen, 5)
+
+	// Limit outside the allowed range should fail
+	_, err := todoUsecase.GetAllTodos(ctx, models.TodoListQuery{Limit: models.MaxTodoListLimit + 1})
+	assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+}
+
+func TestTodoUsecase_GetAllTodos_FilterAndSort(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	seeds := []struct {
+		title     string
+		priority  models.TodoPriority
+		completed bool
+	}{
+		{"A", models.PriorityLow, false},
+		{"B", models.PriorityHigh, true},
+		{"C", models.PriorityMedium, false},
+		{"D", models.PriorityHigh, false},
+	}
+	for _, seed := range seeds {
+		created, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: seed.title, Priority: seed.priority})
+		require.NoError(t, err)
+		if seed.completed {
+			_, err = todoUsecase.UpdateTodo(ctx, created.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+			require.NoError(t, err)
+		}
+	}
+
+	titles := func(todos []models.Todo) []string {
+		result := make([]string, len(todos))
+		for i, todo := range todos {
+			result[i] = todo.Title
+		}
+		return result
+	}
+
+	completed := false
+	tests := []struct {
+		name  string
+		query models.TodoListQuery
+		want  []string
+	}{
+		{
+			name:  "Filter by completed",
+			query: models.TodoListQuery{Completed: &completed, Sort: []models.TodoSort{{Field: models.TodoSortTitle}}},
+			want:  []string{"A", "C", "D"},
+		},
+		{
+			name:  "Filter by multiple priorities",
+			query: models.TodoListQuery{Priorities: []models.TodoPriority{models.PriorityLow, models.PriorityMedium}, Sort: []models.TodoSort{{Field: models.TodoSortTitle}}},
+			want:  []string{"A", "C"},
+		},
+		{
+			name: "Sort by priority desc then title",
+			query: models.TodoListQuery{Sort: []models.TodoSort{
+				{Field: models.TodoSortPriority, Desc: true},
+				{Field: models.TodoSortTitle},
+			}},
+			want: []string{"B", "D", "C", "A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := todoUsecase.GetAllTodos(ctx, tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, titles(page.Todos))
+		})
+	}
+
+	t.Run("Paginate with custom sort", func(t *testing.T) {
+		query := models.TodoListQuery{
+			Limit: 1,
+			Sort:  []models.TodoSort{{Field: models.TodoSortPriority, Desc: true}, {Field: models.TodoSortTitle}},
+		}
+		var got []string
+		for {
+			page, err := todoUsecase.GetAllTodos(ctx, query)
+			require.NoError(t, err)
+			got = append(got, titles(page.Todos)...)
+			if !page.HasMore {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		assert.Equal(t, []string{"B", "D", "C", "A"}, got)
+	})
+
+	t.Run("Cursor from a different sort should fail", func(t *testing.T) {
+		page, err := todoUsecase.GetAllTodos(ctx, models.TodoListQuery{Limit: 1})
+		require.NoError(t, err)
+		require.NotNil(t, page.NextCursor)
+
+		_, err = todoUsecase.GetAllTodos(ctx, models.TodoListQuery{
+			Limit:  1,
+			Cursor: page.NextCursor,
+			Sort:   []models.TodoSort{{Field: models.TodoSortTitle}},
+		})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+	})
+
+	t.Run("Invalid filters should fail", func(t *testing.T) {
+		_, err := todoUsecase.GetAllTodos(ctx, models.TodoListQuery{Priorities: []models.TodoPriority{"urgent"}})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+
+		_, err = todoUsecase.GetAllTodos(ctx, models.TodoListQuery{Sort: []models.TodoSort{{Field: "description"}}})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+	})
+}
+
+func TestTodoUsecase_ExportTodos(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "A", Priority: models.PriorityHigh, Tags: []models.Tag{{Name: "仕事"}}})
+	require.NoError(t, err)
+	_, err = todoUsecase.CreateTodo(ctx, &models.Todo{Title: "B", Priority: models.PriorityHigh, ParentID: &parent.ID})
+	require.NoError(t, err)
+	_, err = todoUsecase.CreateTodo(ctx, &models.Todo{Title: "C", Priority: models.PriorityLow})
+	require.NoError(t, err)
+
+	export := func(query models.TodoListQuery) ([]models.Todo, error) {
+		var todos []models.Todo
+		err := todoUsecase.ExportTodos(ctx, query, func(todo models.Todo) error {
+			todos = append(todos, todo)
+			return nil
+		})
+		return todos, err
+	}
+
+	t.Run("Export uses list filters and sort but ignores limit and tree", func(t *testing.T) {
+		todos, err := export(models.TodoListQuery{
+			Limit:      1,
+			RootsOnly:  true,
+			Priorities: []models.TodoPriority{models.PriorityHigh},
+			Sort:       []models.TodoSort{{Field: models.TodoSortTitle}},
+		})
+		require.NoError(t, err)
+		require.Len(t, todos, 2)
+		assert.Equal(t, "A", todos[0].Title)
+		assert.Equal(t, "B", todos[1].Title)
+		require.Len(t, todos[0].Tags, 1)
+		assert.Equal(t, "仕事", todos[0].Tags[0].Name)
+	})
+
+	t.Run("Error from callback stops the export", func(t *testing.T) {
+		stop := fmt.Errorf("stop")
+		calls := 0
+		err := todoUsecase.ExportTodos(ctx, models.TodoListQuery{}, func(todo models.Todo) error {
+			calls++
+			return stop
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Invalid filters should fail", func(t *testing.T) {
+		_, err := export(models.TodoListQuery{Priorities: []models.TodoPriority{"urgent"}})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+	})
+
+	t.Run("Canceled context stops fetching the next batch", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		err := todoUsecase.ExportTodos(cancelCtx, models.TodoListQuery{}, func(todo models.Todo) error {
+			cancel()
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestTodoUsecase_SearchTodos(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	for _, todo := range []*models.Todo{
+		{Title: "牛乳を買う", Description: "帰りにスーパーで牛乳を2本買う", Priority: "medium"},
+		{Title: "コーヒー豆を注文する", Description: "いつもの店で注文", Priority: "low"},
+		{Title: "Write weekly report", Description: "牛乳の在庫も確認する", Priority: "high"},
+	} {
+		_, err := todoUsecase.CreateTodo(ctx, todo)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name       string
+		q          string
+		wantTitles []string
+	}{
+		{
+			name:       "Japanese keyword matches title and description, title ranked first",
+			q:          "牛乳",
+			wantTitles: []string{"牛乳を買う", "Write weekly report"},
+		},
+		{
+			name:       "Katakana keyword",
+			q:          "コーヒー",
+			wantTitles: []string{"コーヒー豆を注文する"},
+		},
+		{
+			name:       "English keyword is case insensitive",
+			q:          "REPORT",
+			wantTitles: []string{"Write weekly report"},
+		},
+		{
+			name:       "Multiple keywords are combined with AND",
+			q:          "牛乳 スーパー",
+			wantTitles: []string{"牛乳を買う"},
+		},
+		{
+			name:       "Single Japanese character matches at the end of a run",
+			q:          "う",
+			wantTitles: []string{"牛乳を買う"},
+		},
+		{
+			name:       "Single alphanumeric character is a word prefix",
+			q:          "w",
+			wantTitles: []string{"Write weekly report"},
+		},
+		{
+			name:       "No match",
+			q:          "洗濯",
+			wantTitles: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := todoUsecase.SearchTodos(ctx, models.TodoSearchQuery{Q: tt.q})
+			require.NoError(t, err)
+
+			titles := make([]string, len(results))
+			for i, result := range results {
+				titles[i] = result.Title
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+		})
+	}
+
+	t.Run("Highlights matched keyword", func(t *testing.T) {
+		results, err := todoUsecase.SearchTodos(ctx, models.TodoSearchQuery{Q: "牛乳"})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, "<mark>牛乳</mark>を買う", results[0].TitleHighlight)
+		assert.Equal(t, "帰りにスーパーで<mark>牛乳</mark>を2本買う", results[0].DescriptionSnippet)
+		assert.Greater(t, results[0].Rank, 0.0)
+	})
+
+	t.Run("Empty keyword should fail", func(t *testing.T) {
+		_, err := todoUsecase.SearchTodos(ctx, models.TodoSearchQuery{Q: "  "})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+	})
+}
+
+func TestTodoUsecase_Subtasks(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	// root > child > grandchild の3階層を作成
+	root, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "root"})
+	require.NoError(t, err)
+	child, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "child", ParentID: &root.ID})
+	require.NoError(t, err)
+	grandchild, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "grandchild", ParentID: &child.ID})
+	require.NoError(t, err)
+
+	t.Run("Create with missing parent", func(t *testing.T) {
+		missing := 999999
+		_, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "orphan", ParentID: &missing})
+		assert.ErrorIs(t, err, usecase.ErrParentTodoNotFound)
+	})
+
+	t.Run("Get direct children", func(t *testing.T) {
+		children, err := todoUsecase.GetChildTodos(ctx, root.ID, false)
+		require.NoError(t, err)
+		require.Len(t, children, 1)
+		assert.Equal(t, child.ID, children[0].ID)
+		assert.Empty(t, children[0].Children)
+	})
+
+	t.Run("Get children as tree", func(t *testing.T) {
+		children, err := todoUsecase.GetChildTodos(ctx, root.ID, true)
+		require.NoError(t, err)
+		require.Len(t, children, 1)
+		require.Len(t, children[0].Children, 1)
+		assert.Equal(t, grandchild.ID, children[0].Children[0].ID)
+	})
+
+	t.Run("List as tree returns roots only", func(t *testing.T) {
+		page, err := todoUsecase.GetAllTodos(ctx, models.TodoListQuery{RootsOnly: true})
+		require.NoError(t, err)
+		require.Len(t, page.Todos, 1)
+		assert.Equal(t, root.ID, page.Todos[0].ID)
+		require.Len(t, page.Todos[0].Children, 1)
+		assert.Len(t, page.Todos[0].Children[0].Children, 1)
+	})
+
+	t.Run("Reject self as parent", func(t *testing.T) {
+		_, err := todoUsecase.UpdateTodo(ctx, root.ID, &models.Todo{ParentID: &root.ID}, usecase.UpdateTodoOptions{})
+		assert.ErrorIs(t, err, usecase.ErrTodoHierarchyCycle)
+	})
+
+	t.Run("Reject descendant as parent", func(t *testing.T) {
+		_, err := todoUsecase.UpdateTodo(ctx, root.ID, &models.Todo{ParentID: &grandchild.ID}, usecase.UpdateTodoOptions{})
+		assert.ErrorIs(t, err, usecase.ErrTodoHierarchyCycle)
+	})
+
+	t.Run("Block completion with open children", func(t *testing.T) {
+		_, err := todoUsecase.UpdateTodo(ctx, root.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+		assert.ErrorIs(t, err, usecase.ErrOpenChildTodos)
+
+		got, err := todoUsecase.GetTodoByID(ctx, root.ID)
+		require.NoError(t, err)
+		assert.False(t, got.Completed)
+	})
+
+	t.Run("Cascade completion to descendants", func(t *testing.T) {
+		updated, err := todoUsecase.UpdateTodo(ctx, root.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{CascadeCompletion: true})
+		require.NoError(t, err)
+		assert.True(t, updated.Completed)
+
+		for _, id := range []int{child.ID, grandchild.ID} {
+			got, err := todoUsecase.GetTodoByID(ctx, id)
+			require.NoError(t, err)
+			assert.True(t, got.Completed)
+		}
+	})
+}
+
+func TestTodoUsecase_Tags(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	tagsOf := func(todo *models.Todo) []string {
+		names := make([]string, len(todo.Tags))
+		for i, tag := range todo.Tags {
+			names[i] = tag.Name
+		}
+		return names
+	}
+
+	// 存在しないタグは作成され、重複は除かれる
+	both, err := todoUsecase.CreateTodo(ctx, &models.Todo{
+		Title: "both",
+		Tags:  []models.Tag{{Name: "work"}, {Name: "urgent"}, {Name: "work"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urgent", "work"}, tagsOf(both))
+
+	workOnly, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "work only", Tags: []models.Tag{{Name: "work"}}})
+	require.NoError(t, err)
+	_, err = todoUsecase.CreateTodo(ctx, &models.Todo{Title: "untagged"})
+	require.NoError(t, err)
+
+	t.Run("Filter by tags", func(t *testing.T) {
+		page, err := todoUsecase.GetAllTodos(ctx, models.TodoListQuery{Tags: []string{"work"}, Sort: []models.TodoSort{{Field: models.TodoSortTitle}}})
+		require.NoError(t, err)
+		require.Len(t, page.Todos, 2)
+		assert.Equal(t, "both", page.Todos[0].Title)
+		assert.Equal(t, []string{"urgent", "work"}, tagsOf(&page.Todos[0]))
+		assert.Equal(t, []string{"work"}, tagsOf(&page.Todos[1]))
+
+		page, err = todoUsecase.GetAllTodos(ctx, models.TodoListQuery{Tags: []string{"work", "urgent"}})
+		require.NoError(t, err)
+		require.Len(t, page.Todos, 1)
+		assert.Equal(t, both.ID, page.Todos[0].ID)
+	})
+
+	t.Run("Update without tags keeps them", func(t *testing.T) {
+		updated, err := todoUsecase.UpdateTodo(ctx, workOnly.ID, &models.Todo{Title: "renamed"}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"work"}, tagsOf(updated))
+	})
+
+	t.Run("Update replaces and clears tags", func(t *testing.T) {
+		updated, err := todoUsecase.UpdateTodo(ctx, workOnly.ID, &models.Todo{Tags: []models.Tag{{Name: "home"}}}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"home"}, tagsOf(updated))
+
+		updated, err = todoUsecase.UpdateTodo(ctx, workOnly.ID, &models.Todo{Tags: []models.Tag{}}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, updated.Tags)
+
+		got, err := todoUsecase.GetTodoByID(ctx, workOnly.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got.Tags)
+		assert.Empty(t, got.Tags)
+	})
+}
+
+func TestTodoUsecase_Recurrence(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	strPtr := func(s string) *string { return &s }
+
+	// 2026-10-16 は金曜日
+	dueAt := time.Date(2026, 10, 16, 9, 0, 0, 0, tokyo)
+	remindAt := dueAt.Add(-30 * time.Minute)
+	weekdays, err := todoUsecase.CreateTodo(ctx, &models.Todo{
+		Title:              "朝会",
+		DueAt:              &dueAt,
+		RemindAt:           &remindAt,
+		Tags:               []models.Tag{{Name: "work"}},
+		RecurrenceRule:     strPtr("rrule:freq=weekly;byday=MO,TU,WE,TH,FR"),
+		RecurrenceTimezone: strPtr("Asia/Tokyo"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, weekdays.RecurrenceRule)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", *weekdays.RecurrenceRule)
+
+	t.Run("Completing creates the next occurrence", func(t *testing.T) {
+		completed, err := todoUsecase.UpdateTodo(ctx, weekdays.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, completed.NextOccurrenceID)
+
+		next, err := todoUsecase.GetTodoByID(ctx, *completed.NextOccurrenceID)
+		require.NoError(t, err)
+		assert.Equal(t, "朝会", next.Title)
+		assert.False(t, next.Completed)
+		// 金曜日の次は土日を飛ばして月曜日の9時（東京）
+		require.NotNil(t, next.DueAt)
+		assert.True(t, time.Date(2026, 10, 19, 9, 0, 0, 0, tokyo).Equal(*next.DueAt))
+		require.NotNil(t, next.RemindAt)
+		assert.True(t, next.DueAt.Add(-30*time.Minute).Equal(*next.RemindAt))
+		require.Len(t, next.Tags, 1)
+		assert.Equal(t, "work", next.Tags[0].Name)
+
+		// 未完了に戻して再度完了にしても次回分は増えない
+		_, err = todoUsecase.UpdateTodo(ctx, weekdays.ID, &models.Todo{Completed: false}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		again, err := todoUsecase.UpdateTodo(ctx, weekdays.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, *completed.NextOccurrenceID, *again.NextOccurrenceID)
+	})
+
+	t.Run("Recurrence ends after COUNT", func(t *testing.T) {
+		first, err := todoUsecase.CreateTodo(ctx, &models.Todo{
+			Title:          "twice",
+			DueAt:          &dueAt,
+			RecurrenceRule: strPtr("FREQ=DAILY;COUNT=2"),
+		})
+		require.NoError(t, err)
+
+		first, err = todoUsecase.UpdateTodo(ctx, first.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, first.NextOccurrenceID)
+
+		second, err := todoUsecase.UpdateTodo(ctx, *first.NextOccurrenceID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, second.NextOccurrenceID)
+	})
+
+	t.Run("Preview occurrences in the requested time zone", func(t *testing.T) {
+		monthly, err := todoUsecase.CreateTodo(ctx, &models.Todo{
+			Title:              "月初の振り返り",
+			DueAt:              &dueAt,
+			RecurrenceRule:     strPtr("FREQ=MONTHLY;BYDAY=1MO"),
+			RecurrenceTimezone: strPtr("Asia/Tokyo"),
+		})
+		require.NoError(t, err)
+
+		occurrences, err := todoUsecase.PreviewOccurrences(ctx, monthly.ID, models.OccurrenceQuery{Limit: 3, After: dueAt})
+		require.NoError(t, err)
+		require.Len(t, occurrences, 3)
+		assert.Equal(t, "2026-11-02T09:00:00+09:00", occurrences[0].DueAt.Format(time.RFC3339))
+		assert.Equal(t, "2026-12-07T09:00:00+09:00", occurrences[1].DueAt.Format(time.RFC3339))
+		assert.Equal(t, "2027-01-04T09:00:00+09:00", occurrences[2].DueAt.Format(time.RFC3339))
+
+		// ニューヨークでは起点が木曜日の20時になるため、第1月曜日の20時（現地時刻）として計算される
+		occurrences, err = todoUsecase.PreviewOccurrences(ctx, monthly.ID, models.OccurrenceQuery{Limit: 1, After: dueAt, Timezone: "America/New_York"})
+		require.NoError(t, err)
+		require.Len(t, occurrences, 1)
+		assert.Equal(t, "2026-11-02T20:00:00-05:00", occurrences[0].DueAt.Format(time.RFC3339))
+	})
+
+	t.Run("Invalid recurrence", func(t *testing.T) {
+		_, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "no due", RecurrenceRule: strPtr("FREQ=DAILY")})
+		assert.ErrorIs(t, err, usecase.ErrRecurrenceRequiresDueAt)
+
+		_, err = todoUsecase.CreateTodo(ctx, &models.Todo{Title: "bad rule", DueAt: &dueAt, RecurrenceRule: strPtr("FREQ=HOURLY")})
+		assert.ErrorIs(t, err, usecase.ErrInvalidRecurrence)
+
+		_, err = todoUsecase.CreateTodo(ctx, &models.Todo{Title: "bad tz", DueAt: &dueAt, RecurrenceRule: strPtr("FREQ=DAILY"), RecurrenceTimezone: strPtr("Mars/Olympus")})
+		assert.ErrorIs(t, err, usecase.ErrInvalidRecurrence)
+
+		plain, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "plain"})
+		require.NoError(t, err)
+		_, err = todoUsecase.PreviewOccurrences(ctx, plain.ID, models.OccurrenceQuery{})
+		assert.ErrorIs(t, err, usecase.ErrTodoNotRecurring)
+	})
+}
+
 func TestTodoUsecase_UpdateTodo(t *testing.T) {
-	todoUsecase, cleanup := setupTest(t)
+	todoUsecase, ctx, cleanup := setupTest(t)
 	defer cleanup()
 
 	// Create a test todo first
-	createdTodo, err := todoUsecase.CreateTodo(&models.Todo{
+	createdTodo, err := todoUsecase.CreateTodo(ctx, &models.Todo{
 		Title:       "Original Title",
 		Description: "Original Description",
 		Priority:    "medium",
@@ -301,7 +881,7 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			todo, err := todoUsecase.UpdateTodo(tt.id, tt.req)
+			todo, err := todoUsecase.UpdateTodo(ctx, tt.id, tt.req, usecase.UpdateTodoOptions{})
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -324,12 +904,146 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 	}
 }
 
+func TestTodoUsecase_PatchTodo(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	dueAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	remindAt := dueAt.Add(-time.Hour)
+	parent, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "親"})
+	require.NoError(t, err)
+	todo, err := todoUsecase.CreateTodo(ctx, &models.Todo{
+		Title:       "子",
+		Description: "説明",
+		Priority:    models.PriorityHigh,
+		ParentID:    &parent.ID,
+		DueAt:       &dueAt,
+		RemindAt:    &remindAt,
+		Tags:        []models.Tag{{Name: "work"}},
+	})
+	require.NoError(t, err)
+	_, err = todoUsecase.UpdateTodo(ctx, todo.ID, &models.Todo{Completed: true}, usecase.UpdateTodoOptions{})
+	require.NoError(t, err)
+
+	t.Run("Omitted fields are left unchanged", func(t *testing.T) {
+		patched, err := todoUsecase.PatchTodo(ctx, todo.ID, models.TodoPatch{
+			Title: models.NewNullable("子（変更）"),
+		}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "子（変更）", patched.Title)
+		assert.Equal(t, "説明", patched.Description)
+		assert.Equal(t, models.PriorityHigh, patched.Priority)
+		assert.True(t, patched.Completed)
+		require.NotNil(t, patched.ParentID)
+		require.NotNil(t, patched.DueAt)
+		require.Len(t, patched.Tags, 1)
+	})
+
+	t.Run("Null clears fields", func(t *testing.T) {
+		patched, err := todoUsecase.PatchTodo(ctx, todo.ID, models.TodoPatch{
+			Description: models.NullOf[string](),
+			ParentID:    models.NullOf[int](),
+			DueAt:       models.NullOf[time.Time](),
+			RemindAt:    models.NullOf[time.Time](),
+			Tags:        models.NullOf[[]string](),
+		}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "", patched.Description)
+		assert.Nil(t, patched.ParentID)
+		assert.Nil(t, patched.DueAt)
+		assert.Nil(t, patched.RemindAt)
+		assert.Empty(t, patched.Tags)
+		assert.True(t, patched.Completed)
+
+		saved, err := todoUsecase.GetTodoByID(ctx, todo.ID)
+		require.NoError(t, err)
+		assert.Nil(t, saved.ParentID)
+		assert.Nil(t, saved.DueAt)
+	})
+
+	t.Run("Required fields cannot be null", func(t *testing.T) {
+		for _, patch := range []models.TodoPatch{
+			{Title: models.NullOf[string]()},
+			{Priority: models.NullOf[models.TodoPriority]()},
+			{Completed: models.NullOf[bool]()},
+		} {
+			_, err := todoUsecase.PatchTodo(ctx, todo.ID, patch, usecase.UpdateTodoOptions{})
+			assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+		}
+	})
+
+	t.Run("Clearing the due date of a recurring todo", func(t *testing.T) {
+		rule := "FREQ=WEEKLY"
+		recurring, err := todoUsecase.CreateTodo(ctx, &models.Todo{
+			Title:          "週次",
+			DueAt:          &dueAt,
+			RecurrenceRule: &rule,
+		})
+		require.NoError(t, err)
+
+		_, err = todoUsecase.PatchTodo(ctx, recurring.ID, models.TodoPatch{
+			DueAt: models.NullOf[time.Time](),
+		}, usecase.UpdateTodoOptions{})
+		assert.ErrorIs(t, err, usecase.ErrRecurrenceRequiresDueAt)
+
+		patched, err := todoUsecase.PatchTodo(ctx, recurring.ID, models.TodoPatch{
+			DueAt:          models.NullOf[time.Time](),
+			RecurrenceRule: models.NullOf[string](),
+		}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, patched.DueAt)
+		assert.Nil(t, patched.RecurrenceRule)
+	})
+}
+
+func TestTodoUsecase_Version(t *testing.T) {
+	todoUsecase, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	todo, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "version"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, todo.Version)
+
+	t.Run("Every update increments the version", func(t *testing.T) {
+		updated, err := todoUsecase.UpdateTodo(ctx, todo.ID, &models.Todo{Title: "v2"}, usecase.UpdateTodoOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.Version)
+
+		patched, err := todoUsecase.PatchTodo(ctx, todo.ID, models.TodoPatch{
+			Description: models.NewNullable("v3"),
+		}, usecase.UpdateTodoOptions{ExpectedVersion: &updated.Version})
+		require.NoError(t, err)
+		assert.Equal(t, 3, patched.Version)
+	})
+
+	t.Run("Stale versions are rejected", func(t *testing.T) {
+		stale := 1
+		_, err := todoUsecase.UpdateTodo(ctx, todo.ID, &models.Todo{Title: "上書き"}, usecase.UpdateTodoOptions{ExpectedVersion: &stale})
+		assert.ErrorIs(t, err, usecase.ErrTodoVersionMismatch)
+		_, err = todoUsecase.PatchTodo(ctx, todo.ID, models.TodoPatch{Title: models.NewNullable("上書き")}, usecase.UpdateTodoOptions{ExpectedVersion: &stale})
+		assert.ErrorIs(t, err, usecase.ErrTodoVersionMismatch)
+		assert.ErrorIs(t, todoUsecase.DeleteTodo(ctx, todo.ID, usecase.DeleteTodoOptions{ExpectedVersion: &stale}), usecase.ErrTodoVersionMismatch)
+
+		saved, err := todoUsecase.GetTodoByID(ctx, todo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "v2", saved.Title)
+		assert.Equal(t, 3, saved.Version)
+	})
+
+	t.Run("Delete with the current version", func(t *testing.T) {
+		current := 3
+		require.NoError(t, todoUsecase.DeleteTodo(ctx, todo.ID, usecase.DeleteTodoOptions{ExpectedVersion: &current}))
+		_, err := todoUsecase.GetTodoByID(ctx, todo.ID)
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+	})
+}
+
 func TestTodoUsecase_DeleteTodo(t *testing.T) {
-	todoUsecase, cleanup := setupTest(t)
+	todoUsecase, ctx, cleanup := setupTest(t)
 	defer cleanup()
 
 	// Create a test todo first
-	createdTodo, err := todoUsecase.CreateTodo(&models.Todo{
+	createdTodo, err := todoUsecase.CreateTodo(ctx, &models.Todo{
 		Title:       "To Be Deleted",
 		Description: "This will be deleted",
 		Priority:    "medium",
@@ -360,7 +1074,7 @@ func TestTodoUsecase_DeleteTodo(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := todoUsecase.DeleteTodo(tt.id)
+			err := todoUsecase.DeleteTodo(ctx, tt.id, usecase.DeleteTodoOptions{})
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -368,7 +1082,7 @@ func TestTodoUsecase_DeleteTodo(t *testing.T) {
 				require.NoError(t, err)
 
 				// Verify deletion
-				_, err := todoUsecase.GetTodoByID(tt.id)
+				_, err := todoUsecase.GetTodoByID(ctx, tt.id)
 				assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
 			}
 		})
@@ -376,7 +1090,7 @@ func TestTodoUsecase_DeleteTodo(t *testing.T) {
 }
 
 func TestTodoUsecase_PriorityFeature(t *testing.T) {
-	todoUsecase, cleanup := setupTest(t)
+	todoUsecase, ctx, cleanup := setupTest(t)
 	defer cleanup()
 
 	// Test creating todos with different priorities
@@ -384,7 +1098,7 @@ func TestTodoUsecase_PriorityFeature(t *testing.T) {
 	createdTodos := make([]*models.Todo, len(priorities))
 
 	for i, priority := range priorities {
-		todo, err := todoUsecase.CreateTodo(&models.Todo{
+		todo, err := todoUsecase.CreateTodo(ctx, &models.Todo{
 			Title:       fmt.Sprintf("Todo with %s priority", priority),
 			Description: fmt.Sprintf("Testing %s priority", priority),
 			Priority:    priority,
@@ -395,16 +1109,17 @@ func TestTodoUsecase_PriorityFeature(t *testing.T) {
 	}
 
 	// Test updating priority
-	updatedTodo, err := todoUsecase.UpdateTodo(createdTodos[0].ID, &models.Todo{
+	updatedTodo, err := todoUsecase.UpdateTodo(ctx, createdTodos[0].ID, &models.Todo{
 		Priority: models.PriorityHigh,
-	})
+	}, usecase.UpdateTodoOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, models.PriorityHigh, updatedTodo.Priority)
 	assert.Equal(t, createdTodos[0].Title, updatedTodo.Title) // Other fields should remain unchanged
 
 	// Test GetAll returns todos with priority
-	allTodos, err := todoUsecase.GetAllTodos()
+	page, err := todoUsecase.GetAllTodos(ctx, models.TodoListQuery{})
 	require.NoError(t, err)
+	allTodos := page.Todos
 	assert.Len(t, allTodos, len(priorities))
 
 	// Verify all todos have priority field
@@ -414,7 +1129,7 @@ func TestTodoUsecase_PriorityFeature(t *testing.T) {
 	}
 
 	// Test GetByID returns todo with priority
-	retrievedTodo, err := todoUsecase.GetTodoByID(createdTodos[1].ID)
+	retrievedTodo, err := todoUsecase.GetTodoByID(ctx, createdTodos[1].ID)
 	require.NoError(t, err)
 	assert.Equal(t, models.PriorityMedium, retrievedTodo.Priority)
 }
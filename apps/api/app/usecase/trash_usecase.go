@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrParentTodoInTrash = errors.New("parent todo is in trash")
+
+type TrashUsecase interface {
+	GetTrash(ctx context.Context, query models.TrashListQuery) (*models.TrashPage, error)
+	// RestoreTodo はゴミ箱内のTodoを、一緒に削除された子孫とともに元に戻す
+	RestoreTodo(ctx context.Context, id int) (*models.Todo, error)
+	// PurgeTodo はゴミ箱内のTodoを完全に削除する
+	PurgeTodo(ctx context.Context, id int) error
+	// PurgeExpired は保存期間を過ぎたゴミ箱内のTodoを完全に削除し、削除件数を返す
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type trashUsecase struct {
+	todoRepo      repository.TodoRepository
+	tagRepo       repository.TagRepository
+	projectRepo   repository.ProjectRepository
+	eventRepo     repository.TodoEventRepository
+	workspaceRepo repository.WorkspaceRepository
+	txManager     repository.TxManager
+	retention     time.Duration
+}
+
+// NewTrashUsecase はゴミ箱に移動してから retention 経過したTodoを自動削除する TrashUsecase を作成する
+func NewTrashUsecase(todoRepo repository.TodoRepository, tagRepo repository.TagRepository, projectRepo repository.ProjectRepository, eventRepo repository.TodoEventRepository, workspaceRepo repository.WorkspaceRepository, txManager repository.TxManager, retention time.Duration) TrashUsecase {
+	return &trashUsecase{
+		todoRepo:      todoRepo,
+		tagRepo:       tagRepo,
+		projectRepo:   projectRepo,
+		eventRepo:     eventRepo,
+		workspaceRepo: workspaceRepo,
+		txManager:     txManager,
+		retention:     retention,
+	}
+}
+
+// inWorkspace は ctx のワークスペースのTodoのみを扱う trashUsecase を返す
+func (u *trashUsecase) inWorkspace(ctx context.Context) (*trashUsecase, error) {
+	workspaceID, err := workspaceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &trashUsecase{
+		todoRepo:      u.todoRepo.WithWorkspace(workspaceID),
+		tagRepo:       u.tagRepo.WithWorkspace(workspaceID),
+		projectRepo:   u.projectRepo.WithWorkspace(workspaceID),
+		eventRepo:     u.eventRepo,
+		workspaceRepo: u.workspaceRepo,
+		txManager:     u.txManager,
+		retention:     u.retention,
+	}, nil
+}
+
+func (u *trashUsecase) GetTrash(ctx context.Context, query models.TrashListQuery) (*models.TrashPage, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if query.Limit < 0 || query.Limit > models.MaxTodoListLimit {
+		return nil, ErrInvalidInput
+	}
+	query.UserID = userScope(ctx)
+
+	page, err := u.todoRepo.GetTrash(query)
+	if err != nil {
+		return nil, err
+	}
+	// Return empty slice instead of nil for consistency
+	if page.Todos == nil {
+		page.Todos = []models.Todo{}
+	}
+
+	if err := loadTags(u.tagRepo, page.Todos); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (u *trashUsecase) RestoreTodo(ctx context.Context, id int) (*models.Todo, error) {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	deleted, err := u.todoRepo.GetDeletedByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if deleted == nil {
+		return nil, ErrTodoNotFound
+	}
+	if err := authorizeTodo(ctx, u.projectRepo, deleted, models.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+
+	// 一緒に削除された子孫Todoも戻すため、別のプロジェクトにある子孫のロールも確認する
+	descendants, err := u.todoRepo.GetDescendantsWithDeleted(id)
+	if err != nil {
+		return nil, err
+	}
+	var restoring []models.Todo
+	for _, descendant := range descendants {
+		if descendant.DeletedAt != nil && deleted.DeletedAt != nil && descendant.DeletedAt.Equal(*deleted.DeletedAt) {
+			restoring = append(restoring, descendant)
+		}
+	}
+	if err := authorizeTodos(ctx, u.projectRepo, restoring, models.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+
+	// 親がゴミ箱内にある場合は親を戻さないと表示できないため拒否する
+	if deleted.ParentID != nil {
+		parent, err := u.todoRepo.GetDeletedByID(*deleted.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			return nil, ErrParentTodoInTrash
+		}
+	}
+
+	err = u.txManager.WithinTx(func(tx repository.DBTX) error {
+		restored, err := u.todoRepo.WithTx(tx).Restore(id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNoRows) {
+				return ErrTodoNotFound
+			}
+			return err
+		}
+
+		// 一緒に元に戻した子孫Todoにも履歴を残す（削除日時は全て同じ）
+		events := make([]models.TodoEvent, len(restored))
+		for i, todo := range restored {
+			events[i] = newTodoEvent(ctx, todo.ID, models.TodoEventRestored, models.TodoChanges{
+				"deleted_at": {Before: timeOrNil(deleted.DeletedAt), After: nil},
+			})
+		}
+		return u.eventRepo.WithTx(tx).Create(events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if restored == nil {
+		return nil, ErrTodoNotFound
+	}
+
+	todos := []models.Todo{*restored}
+	if err := loadTags(u.tagRepo, todos); err != nil {
+		return nil, err
+	}
+	return &todos[0], nil
+}
+
+func (u *trashUsecase) PurgeTodo(ctx context.Context, id int) error {
+	u, err := u.inWorkspace(ctx)
+	if err != nil {
+		return err
+	}
+	if id <= 0 {
+		return ErrInvalidInput
+	}
+
+	deleted, err := u.todoRepo.GetDeletedByID(id)
+	if err != nil {
+		return err
+	}
+	if deleted == nil {
+		return ErrTodoNotFound
+	}
+	if err := authorizeTodo(ctx, u.projectRepo, deleted, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+	// 子孫Todoは ON DELETE CASCADE で一緒に削除されるため、別のプロジェクトにある子孫のロールも確認する
+	descendants, err := u.todoRepo.GetDescendantsWithDeleted(id)
+	if err != nil {
+		return err
+	}
+	if err := authorizeTodos(ctx, u.projectRepo, descendants, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	err = u.todoRepo.Purge(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoRows) {
+			return ErrTodoNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// PurgeExpired は全てのワークスペースを順に処理する（リクエストのワークスペースには依存しない）
+func (u *trashUsecase) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	workspaceIDs, err := u.workspaceRepo.GetAllIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for _, workspaceID := range workspaceIDs {
+		count, err := u.todoRepo.WithWorkspace(workspaceID).PurgeDeletedBefore(now.Add(-u.retention))
+		if err != nil {
+			return purged, err
+		}
+		purged += count
+	}
+	return purged, nil
+}
@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashUsecase(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	ctx := auth.WithWorkspaceID(context.Background(), createTestWorkspace(t, db, "test"))
+
+	todoRepo := repository.NewTodoRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	eventRepo := repository.NewTodoEventRepository(db)
+	txManager := repository.NewTxManager(db)
+	notificationClient := external.NewHTTPNotificationClient("http://localhost:9999", "test-key")
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, tagRepo, projectRepo, eventRepo, txManager, notificationClient)
+	trashUsecase := usecase.NewTrashUsecase(todoRepo, tagRepo, projectRepo, eventRepo, repository.NewWorkspaceRepository(db), txManager, 30*24*time.Hour)
+
+	parent, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "parent"})
+	require.NoError(t, err)
+	child, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "child", ParentID: &parent.ID})
+	require.NoError(t, err)
+
+	// 親を削除すると子も一緒にゴミ箱に移動する
+	require.NoError(t, todoUsecase.DeleteTodo(ctx, parent.ID, usecase.DeleteTodoOptions{}))
+	_, err = todoUsecase.GetTodoByID(ctx, child.ID)
+	assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+
+	page, err := todoUsecase.GetAllTodos(ctx, models.TodoListQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, page.Todos)
+
+	t.Run("Trash lists only the deleted parent", func(t *testing.T) {
+		trash, err := trashUsecase.GetTrash(ctx, models.TrashListQuery{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, trash.Todos, 1)
+		assert.Equal(t, parent.ID, trash.Todos[0].ID)
+		assert.NotNil(t, trash.Todos[0].DeletedAt)
+	})
+
+	t.Run("Child cannot be restored before its parent", func(t *testing.T) {
+		_, err := trashUsecase.RestoreTodo(ctx, child.ID)
+		assert.ErrorIs(t, err, usecase.ErrParentTodoInTrash)
+	})
+
+	t.Run("Restore parent together with child", func(t *testing.T) {
+		restored, err := trashUsecase.RestoreTodo(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+
+		_, err = todoUsecase.GetTodoByID(ctx, child.ID)
+		require.NoError(t, err)
+
+		_, err = trashUsecase.RestoreTodo(ctx, parent.ID)
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+	})
+
+	t.Run("Purge only todos in trash", func(t *testing.T) {
+		assert.ErrorIs(t, trashUsecase.PurgeTodo(ctx, parent.ID), usecase.ErrTodoNotFound)
+
+		require.NoError(t, todoUsecase.DeleteTodo(ctx, parent.ID, usecase.DeleteTodoOptions{}))
+		require.NoError(t, trashUsecase.PurgeTodo(ctx, parent.ID))
+
+		_, err := trashUsecase.RestoreTodo(ctx, child.ID)
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+	})
+
+	t.Run("Purge expired todos", func(t *testing.T) {
+		todo, err := todoUsecase.CreateTodo(ctx, &models.Todo{Title: "old"})
+		require.NoError(t, err)
+		require.NoError(t, todoUsecase.DeleteTodo(ctx, todo.ID, usecase.DeleteTodoOptions{}))
+
+		purged, err := trashUsecase.PurgeExpired(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), purged)
+
+		purged, err = trashUsecase.PurgeExpired(context.Background(), time.Now().Add(31*24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+	})
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrEmailAlreadyTaken = errors.New("email already taken")
+)
+
+type UserUsecase interface {
+	GetAllUsers(ctx context.Context) ([]models.User, error)
+	GetUserByID(ctx context.Context, id int) (*models.User, error)
+	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
+	UpdateUser(ctx context.Context, id int, user *models.User) (*models.User, error)
+	DeleteUser(ctx context.Context, id int) error
+}
+
+type userUsecase struct {
+	userRepo repository.UserRepository
+}
+
+func NewUserUsecase(userRepo repository.UserRepository) UserUsecase {
+	return &userUsecase{
+		userRepo: userRepo,
+	}
+}
+
+func (u *userUsecase) GetAllUsers(ctx context.Context) ([]models.User, error) {
+	users, err := u.userRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return empty slice instead of nil for consistency
+	if users == nil {
+		return []models.User{}, nil
+	}
+	return users, nil
+}
+
+func (u *userUsecase) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	user, err := u.userRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (u *userUsecase) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	name, ok := normalizeUserName(user.Name)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+	email, ok := normalizeEmail(user.Email)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+
+	// 他のユーザーが作成したアカウントにはパスワードを設定しない
+	// 本人がパスワードリセットでメールアドレスを確認してから設定するまでログインできない
+	created, err := u.userRepo.Create(&models.User{Name: name, Email: email})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateEmail) {
+			return nil, ErrEmailAlreadyTaken
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (u *userUsecase) UpdateUser(ctx context.Context, id int, user *models.User) (*models.User, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+	if err := authorizeUser(ctx, id); err != nil {
+		return nil, err
+	}
+
+	// 空文字の項目は変更しない
+	var update models.UserUpdate
+	if user.Name != "" {
+		name, ok := normalizeUserName(user.Name)
+		if !ok {
+			return nil, ErrInvalidInput
+		}
+		update.Name = name
+	}
+	if user.Email != "" {
+		email, ok := normalizeEmail(user.Email)
+		if !ok {
+			return nil, ErrInvalidInput
+		}
+		update.Email = email
+	}
+
+	updated, err := u.userRepo.Update(id, update)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateEmail) {
+			return nil, ErrEmailAlreadyTaken
+		}
+		return nil, err
+	}
+
+	if updated == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return updated, nil
+}
+
+func (u *userUsecase) DeleteUser(ctx context.Context, id int) error {
+	if id <= 0 {
+		return ErrInvalidInput
+	}
+	if err := authorizeUser(ctx, id); err != nil {
+		return err
+	}
+
+	err := u.userRepo.Delete(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// authorizeUser は認証済みユーザーが自分自身のみ更新・削除できることを検証する
+func authorizeUser(ctx context.Context, id int) error {
+	if userID, ok := auth.UserIDFromContext(ctx); ok && userID != id {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// normalizeUserName は前後の空白を除いたユーザー名を返す（空または長すぎる場合は false）
+func normalizeUserName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > models.MaxUserNameLength {
+		return "", false
+	}
+	return name, true
+}
+
+// isValidPassword はパスワードの長さが許容範囲内かを返す
+func isValidPassword(password string) bool {
+	n := utf8.RuneCountInString(password)
+	return n >= models.MinPasswordLength && n <= models.MaxPasswordLength
+}
+
+// normalizeEmail はメールアドレスを小文字に正規化する（形式が正しくない場合は false）
+func normalizeEmail(email string) (string, bool) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || len(email) > models.MaxUserEmailLength {
+		return "", false
+	}
+	// "Name <addr>" 形式は受け付けない
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return "", false
+	}
+	return email, true
+}
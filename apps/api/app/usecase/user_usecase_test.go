@@ -0,0 +1,69 @@
+package usecase_test
+
+import (
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUsecase_CRUD(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+	ctx := context.Background()
+
+	userRepo := repository.NewUserRepository(db)
+	userUsecase := usecase.NewUserUsecase(userRepo)
+
+	created, err := userUsecase.CreateUser(ctx, &models.User{Name: "  Alice  ", Email: "Alice@Example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", created.Name)
+	assert.Equal(t, "alice@example.com", created.Email)
+	// 作成したユーザーはパスワードリセットで本人が設定するまでログインできない
+	hash, err := userRepo.GetPasswordHash(created.ID)
+	require.NoError(t, err)
+	assert.Empty(t, hash)
+
+	bob, err := userUsecase.CreateUser(ctx, &models.User{Name: "Bob", Email: "bob@example.com"})
+	require.NoError(t, err)
+
+	users, err := userUsecase.GetAllUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	_, err = userUsecase.CreateUser(ctx, &models.User{Name: "Alice2", Email: "ALICE@example.com"})
+	assert.ErrorIs(t, err, usecase.ErrEmailAlreadyTaken)
+
+	updated, err := userUsecase.UpdateUser(ctx, created.ID, &models.User{Name: "Alice Smith"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Smith", updated.Name)
+	assert.Equal(t, "alice@example.com", updated.Email)
+
+	_, err = userUsecase.UpdateUser(ctx, bob.ID, &models.User{Email: "alice@example.com"})
+	assert.ErrorIs(t, err, usecase.ErrEmailAlreadyTaken)
+
+	_, err = userUsecase.CreateUser(ctx, &models.User{Name: "Carol", Email: "not-an-email"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+
+	require.NoError(t, userUsecase.DeleteUser(ctx, created.ID))
+	_, err = userUsecase.GetUserByID(ctx, created.ID)
+	assert.ErrorIs(t, err, usecase.ErrUserNotFound)
+	assert.ErrorIs(t, userUsecase.DeleteUser(ctx, created.ID), usecase.ErrUserNotFound)
+	_, err = userUsecase.UpdateUser(ctx, created.ID, &models.User{Name: "ghost"})
+	assert.ErrorIs(t, err, usecase.ErrUserNotFound)
+}
+
+// createTestUser はテスト用のユーザーを作成し、IDを返す
+func createTestUser(t *testing.T, db *sqlx.DB, email string) int {
+	t.Helper()
+	user, err := repository.NewUserRepository(db).Create(&models.User{Name: email, Email: email})
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	return user.ID
+}
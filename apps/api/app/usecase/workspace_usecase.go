@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"api/app/auth"
+	"api/app/models"
+	"api/repository"
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrWorkspaceNotFound      = errors.New("workspace not found")
+	ErrAlreadyWorkspaceMember = errors.New("user is already a workspace member")
+)
+
+type WorkspaceUsecase interface {
+	// GetWorkspaces は認証済みユーザーが所属するワークスペースを返す
+	GetWorkspaces(ctx context.Context) ([]models.Workspace, error)
+	// CreateWorkspace はワークスペースを作成し、作成したユーザーをオーナーにする
+	CreateWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error)
+	GetMembers(ctx context.Context, workspaceID int) ([]models.WorkspaceMember, error)
+	// AddMember は email のユーザーを role のメンバーとして追加する（オーナーのみ）
+	AddMember(ctx context.Context, workspaceID int, email string, role models.WorkspaceRole) (*models.WorkspaceMember, error)
+	// ResolveWorkspace はリクエストで操作するワークスペースを決める
+	// requestedID を指定した場合はメンバーであることを検証し、指定しない場合は所属するワークスペースが1つだけのときにそれを返す
+	ResolveWorkspace(ctx context.Context, requestedID *int) (int, error)
+}
+
+type workspaceUsecase struct {
+	workspaceRepo repository.WorkspaceRepository
+	userRepo      repository.UserRepository
+	txManager     repository.TxManager
+}
+
+func NewWorkspaceUsecase(workspaceRepo repository.WorkspaceRepository, userRepo repository.UserRepository, txManager repository.TxManager) WorkspaceUsecase {
+	return &workspaceUsecase{
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+		txManager:     txManager,
+	}
+}
+
+func (u *workspaceUsecase) GetWorkspaces(ctx context.Context) ([]models.Workspace, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+
+	workspaces, err := u.workspaceRepo.GetByMemberID(userID)
+	if err != nil {
+		return nil, err
+	}
+	// Return empty slice instead of nil for consistency
+	if workspaces == nil {
+		workspaces = []models.Workspace{}
+	}
+	return workspaces, nil
+}
+
+func (u *workspaceUsecase) CreateWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+
+	name := strings.TrimSpace(workspace.Name)
+	if name == "" || utf8.RuneCountInString(name) > models.MaxWorkspaceNameLength {
+		return nil, ErrInvalidInput
+	}
+
+	var created *models.Workspace
+	err := u.txManager.WithinTx(func(tx repository.DBTX) error {
+		workspaceRepo := u.workspaceRepo.WithTx(tx)
+
+		var err error
+		created, err = workspaceRepo.Create(&models.Workspace{Name: name})
+		if err != nil {
+			return err
+		}
+		_, err = workspaceRepo.AddMember(created.ID, userID, models.WorkspaceRoleOwner)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created.Role = models.WorkspaceRoleOwner
+	return created, nil
+}
+
+func (u *workspaceUsecase) GetMembers(ctx context.Context, workspaceID int) ([]models.WorkspaceMember, error) {
+	if _, err := u.requireRole(ctx, workspaceID, models.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+
+	members, err := u.workspaceRepo.GetMembers(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if members == nil {
+		members = []models.WorkspaceMember{}
+	}
+	return members, nil
+}
+
+func (u *workspaceUsecase) AddMember(ctx context.Context, workspaceID int, email string, role models.WorkspaceRole) (*models.WorkspaceMember, error) {
+	email, ok := normalizeEmail(email)
+	if !ok || !role.IsValid() {
+		return nil, ErrInvalidInput
+	}
+	if _, err := u.requireRole(ctx, workspaceID, models.WorkspaceRoleOwner); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	member, err := u.workspaceRepo.AddMember(workspaceID, user.ID, role)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateWorkspaceMember) {
+			return nil, ErrAlreadyWorkspaceMember
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+func (u *workspaceUsecase) ResolveWorkspace(ctx context.Context, requestedID *int) (int, error) {
+	// ユーザーが分からない場合はワークスペースを決められないため、どのデータにもアクセスさせない
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return 0, ErrWorkspaceRequired
+	}
+
+	if requestedID != nil {
+		if *requestedID <= 0 {
+			return 0, ErrInvalidInput
+		}
+		role, err := u.workspaceRepo.GetMemberRole(*requestedID, userID)
+		if err != nil {
+			return 0, err
+		}
+		if role == "" {
+			return 0, ErrForbidden
+		}
+		return *requestedID, nil
+	}
+
+	workspaces, err := u.workspaceRepo.GetByMemberID(userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(workspaces) != 1 {
+		return 0, ErrWorkspaceRequired
+	}
+	return workspaces[0].ID, nil
+}
+
+// requireRole は認証済みユーザーがワークスペースのメンバーで、required のロール（owner は member を含む）を持つことを検証する
+// メンバーでない場合はワークスペースの存在を明かさないよう ErrWorkspaceNotFound を返す
+func (u *workspaceUsecase) requireRole(ctx context.Context, workspaceID int, required models.WorkspaceRole) (models.WorkspaceRole, error) {
+	if workspaceID <= 0 {
+		return "", ErrInvalidInput
+	}
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return "", ErrForbidden
+	}
+
+	role, err := u.workspaceRepo.GetMemberRole(workspaceID, userID)
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		return "", ErrWorkspaceNotFound
+	}
+	if required == models.WorkspaceRoleOwner && role != models.WorkspaceRoleOwner {
+		return "", ErrForbidden
+	}
+	return role, nil
+}
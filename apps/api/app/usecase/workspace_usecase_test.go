@@ -0,0 +1,282 @@
+package usecase_test
+
+import (
+	"api/app/auth"
+	"api/app/external"
+	extMock "api/app/external/mock"
+	"api/app/models"
+	"api/app/usecase"
+	"api/repository"
+	"api/test"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestWorkspace はワークスペースを作成し、memberIDs のユーザーを追加する（最初のユーザーはオーナー）
+func createTestWorkspace(t *testing.T, db *sqlx.DB, name string, memberIDs ...int) int {
+	t.Helper()
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	workspace, err := workspaceRepo.Create(&models.Workspace{Name: name})
+	require.NoError(t, err)
+	for i, userID := range memberIDs {
+		role := models.WorkspaceRoleMember
+		if i == 0 {
+			role = models.WorkspaceRoleOwner
+		}
+		_, err := workspaceRepo.AddMember(workspace.ID, userID, role)
+		require.NoError(t, err)
+	}
+	return workspace.ID
+}
+
+func TestWorkspaceUsecase(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	workspaceUsecase := usecase.NewWorkspaceUsecase(repository.NewWorkspaceRepository(db), repository.NewUserRepository(db), repository.NewTxManager(db))
+
+	aliceID := createTestUser(t, db, "alice@example.com")
+	bobID := createTestUser(t, db, "bob@example.com")
+	createTestUser(t, db, "carol@example.com")
+	alice := auth.WithUserID(context.Background(), aliceID)
+	bob := auth.WithUserID(context.Background(), bobID)
+
+	team, err := workspaceUsecase.CreateWorkspace(alice, &models.Workspace{Name: " 開発チーム "})
+	require.NoError(t, err)
+	assert.Equal(t, "開発チーム", team.Name)
+	assert.Equal(t, models.WorkspaceRoleOwner, team.Role)
+
+	t.Run("Workspace is resolved from membership", func(t *testing.T) {
+		// 所属するワークスペースが1つだけなら指定を省略できる
+		resolved, err := workspaceUsecase.ResolveWorkspace(alice, nil)
+		require.NoError(t, err)
+		assert.Equal(t, team.ID, resolved)
+
+		// 所属していないワークスペースは指定できない
+		_, err = workspaceUsecase.ResolveWorkspace(bob, &team.ID)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = workspaceUsecase.ResolveWorkspace(bob, nil)
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceRequired)
+		// ユーザーが分からない場合はどのワークスペースにも決めない
+		_, err = workspaceUsecase.ResolveWorkspace(context.Background(), &team.ID)
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceRequired)
+
+		// 複数のワークスペースに所属する場合は指定が必要
+		personal, err := workspaceUsecase.CreateWorkspace(alice, &models.Workspace{Name: "個人"})
+		require.NoError(t, err)
+		_, err = workspaceUsecase.ResolveWorkspace(alice, nil)
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceRequired)
+		resolved, err = workspaceUsecase.ResolveWorkspace(alice, &personal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, personal.ID, resolved)
+
+		workspaces, err := workspaceUsecase.GetWorkspaces(alice)
+		require.NoError(t, err)
+		assert.Len(t, workspaces, 2)
+	})
+
+	t.Run("Owners add members", func(t *testing.T) {
+		_, err := workspaceUsecase.AddMember(bob, team.ID, "carol@example.com", models.WorkspaceRoleMember)
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceNotFound)
+
+		member, err := workspaceUsecase.AddMember(alice, team.ID, " Bob@Example.com ", models.WorkspaceRoleMember)
+		require.NoError(t, err)
+		assert.Equal(t, bobID, member.UserID)
+		assert.Equal(t, "bob@example.com", member.UserEmail)
+
+		_, err = workspaceUsecase.AddMember(alice, team.ID, "bob@example.com", models.WorkspaceRoleOwner)
+		assert.ErrorIs(t, err, usecase.ErrAlreadyWorkspaceMember)
+		_, err = workspaceUsecase.AddMember(alice, team.ID, "nobody@example.com", models.WorkspaceRoleMember)
+		assert.ErrorIs(t, err, usecase.ErrUserNotFound)
+		// メンバーはメンバーを追加できない
+		_, err = workspaceUsecase.AddMember(bob, team.ID, "carol@example.com", models.WorkspaceRoleMember)
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+
+		members, err := workspaceUsecase.GetMembers(bob, team.ID)
+		require.NoError(t, err)
+		assert.Len(t, members, 2)
+
+		resolved, err := workspaceUsecase.ResolveWorkspace(bob, nil)
+		require.NoError(t, err)
+		assert.Equal(t, team.ID, resolved)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := workspaceUsecase.CreateWorkspace(alice, &models.Workspace{Name: "  "})
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+		_, err = workspaceUsecase.CreateWorkspace(context.Background(), &models.Workspace{Name: "所有者なし"})
+		assert.ErrorIs(t, err, usecase.ErrForbidden)
+		_, err = workspaceUsecase.AddMember(alice, team.ID, "carol@example.com", "admin")
+		assert.ErrorIs(t, err, usecase.ErrInvalidInput)
+	})
+}
+
+func TestWorkspaceIsolation(t *testing.T) {
+	db, cleanup := test.SetupTestDB()
+	defer cleanup()
+
+	todoRepo := repository.NewTodoRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	eventRepo := repository.NewTodoEventRepository(db)
+	txManager := repository.NewTxManager(db)
+	mockNotificationClient := extMock.NewMockNotificationClient(t)
+	mockNotificationClient.EXPECT().
+		SendNotification(mock.Anything, mock.Anything).
+		Return(&external.NotificationResponse{Status: "sent"}, nil).
+		Maybe()
+	todoUsecase := usecase.NewTodoUsecase(todoRepo, tagRepo, projectRepo, eventRepo, txManager, mockNotificationClient)
+	trashUsecase := usecase.NewTrashUsecase(todoRepo, tagRepo, projectRepo, eventRepo, repository.NewWorkspaceRepository(db), txManager, 0)
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, todoRepo, eventRepo, txManager)
+	memberUsecase := usecase.NewProjectMemberUsecase(projectRepo, repository.NewProjectInvitationRepository(db), repository.NewUserRepository(db), repository.NewWorkspaceRepository(db), txManager, mockNotificationClient)
+	tagUsecase := usecase.NewTagUsecase(tagRepo, todoRepo, txManager)
+
+	// alice は両方のワークスペースに所属し、bob はどちらにも所属しない
+	aliceID := createTestUser(t, db, "alice@example.com")
+	createTestUser(t, db, "bob@example.com")
+	workspaceA := createTestWorkspace(t, db, "A", aliceID)
+	workspaceB := createTestWorkspace(t, db, "B", aliceID)
+	aliceInA := auth.WithWorkspaceID(auth.WithUserID(context.Background(), aliceID), workspaceA)
+	aliceInB := auth.WithWorkspaceID(auth.WithUserID(context.Background(), aliceID), workspaceB)
+
+	project, err := projectUsecase.CreateProject(aliceInA, &models.Project{Name: "仕事"})
+	require.NoError(t, err)
+	todo, err := todoUsecase.CreateTodo(aliceInA, &models.Todo{Title: "A のTodo", ProjectID: &project.ID, Tags: []models.Tag{{Name: "重要"}}})
+	require.NoError(t, err)
+
+	t.Run("Todos are not visible from another workspace", func(t *testing.T) {
+		_, err := todoUsecase.GetTodoByID(aliceInB, todo.ID)
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+		_, err = todoUsecase.UpdateTodo(aliceInB, todo.ID, &models.Todo{Title: "上書き"}, usecase.UpdateTodoOptions{})
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+		assert.ErrorIs(t, todoUsecase.DeleteTodo(aliceInB, todo.ID, usecase.DeleteTodoOptions{}), usecase.ErrTodoNotFound)
+		_, err = todoUsecase.CreateTodo(aliceInB, &models.Todo{Title: "子", ParentID: &todo.ID})
+		assert.ErrorIs(t, err, usecase.ErrParentTodoNotFound)
+
+		page, err := todoUsecase.GetAllTodos(aliceInB, models.TodoListQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Todos)
+		results, err := todoUsecase.SearchTodos(aliceInB, models.TodoSearchQuery{Q: "Todo"})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		page, err = todoUsecase.GetAllTodos(aliceInA, models.TodoListQuery{})
+		require.NoError(t, err)
+		require.Len(t, page.Todos, 1)
+		assert.Equal(t, todo.ID, page.Todos[0].ID)
+	})
+
+	t.Run("Projects are not visible from another workspace", func(t *testing.T) {
+		_, err := projectUsecase.GetProjectByID(aliceInB, project.ID)
+		assert.ErrorIs(t, err, usecase.ErrProjectNotFound)
+		_, err = todoUsecase.CreateTodo(aliceInB, &models.Todo{Title: "B のTodo", ProjectID: &project.ID})
+		assert.ErrorIs(t, err, usecase.ErrProjectNotFound)
+
+		projects, err := projectUsecase.GetProjects(aliceInB, models.ProjectListQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+	})
+
+	t.Run("Tag names are unique per workspace", func(t *testing.T) {
+		tagsInB, err := tagUsecase.GetAllTags(aliceInB)
+		require.NoError(t, err)
+		assert.Empty(t, tagsInB)
+
+		tag, err := tagUsecase.CreateTag(aliceInB, &models.Tag{Name: "重要"})
+		require.NoError(t, err)
+		_, err = tagUsecase.CreateTag(aliceInB, &models.Tag{Name: "重要"})
+		assert.ErrorIs(t, err, usecase.ErrTagAlreadyExists)
+
+		tagsInA, err := tagUsecase.GetAllTags(aliceInA)
+		require.NoError(t, err)
+		require.Len(t, tagsInA, 1)
+		assert.NotEqual(t, tag.ID, tagsInA[0].ID)
+		_, err = tagUsecase.GetTagByID(aliceInA, tag.ID)
+		assert.ErrorIs(t, err, usecase.ErrTagNotFound)
+	})
+
+	t.Run("Trash is scoped to the workspace", func(t *testing.T) {
+		doomed, err := todoUsecase.CreateTodo(aliceInB, &models.Todo{Title: "削除"})
+		require.NoError(t, err)
+		require.NoError(t, todoUsecase.DeleteTodo(aliceInB, doomed.ID, usecase.DeleteTodoOptions{}))
+
+		trash, err := trashUsecase.GetTrash(aliceInA, models.TrashListQuery{Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, trash.Todos)
+		_, err = trashUsecase.RestoreTodo(aliceInA, doomed.ID)
+		assert.ErrorIs(t, err, usecase.ErrTodoNotFound)
+		assert.ErrorIs(t, trashUsecase.PurgeTodo(aliceInA, doomed.ID), usecase.ErrTodoNotFound)
+	})
+
+	t.Run("Only workspace members can be invited to a project", func(t *testing.T) {
+		_, err := memberUsecase.InviteMember(aliceInA, project.ID, "bob@example.com", models.ProjectRoleEditor)
+		assert.ErrorIs(t, err, usecase.ErrNotWorkspaceMember)
+	})
+
+	t.Run("Missing workspace fails closed", func(t *testing.T) {
+		alice := auth.WithUserID(context.Background(), aliceID)
+
+		_, err := todoUsecase.GetAllTodos(alice, models.TodoListQuery{})
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceRequired)
+		_, err = todoUsecase.CreateTodo(alice, &models.Todo{Title: "どこにも属さない"})
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceRequired)
+		_, err = projectUsecase.GetProjects(alice, models.ProjectListQuery{})
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceRequired)
+		_, err = tagUsecase.GetAllTags(alice)
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceRequired)
+		_, err = trashUsecase.GetTrash(alice, models.TrashListQuery{})
+		assert.ErrorIs(t, err, usecase.ErrWorkspaceRequired)
+
+		// ワークスペースを指定していない Repository もクエリを実行しない
+		_, err = todoRepo.GetByID(todo.ID)
+		assert.ErrorIs(t, err, repository.ErrWorkspaceRequired)
+		_, err = projectRepo.GetAll(models.ProjectListQuery{})
+		assert.ErrorIs(t, err, repository.ErrWorkspaceRequired)
+		_, err = tagRepo.GetAll()
+		assert.ErrorIs(t, err, repository.ErrWorkspaceRequired)
+	})
+
+	t.Run("Row-level security applies to raw queries", func(t *testing.T) {
+		// API と同じ権限のロールで、Repository の絞り込みを通さずにクエリを実行する
+		_, err := db.Exec(`SET LOCAL ROLE todo_api`)
+		require.NoError(t, err)
+		defer db.Exec(`RESET ROLE`)
+		var bypassesRLS bool
+		require.NoError(t, db.Get(&bypassesRLS, `SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`))
+		require.False(t, bypassesRLS)
+
+		setWorkspace := func(t *testing.T, workspaceID string) {
+			t.Helper()
+			_, err := db.Exec(`SELECT set_config('app.workspace_id', $1, true)`, workspaceID)
+			require.NoError(t, err)
+		}
+		countTodo := func(t *testing.T) int {
+			t.Helper()
+			var count int
+			require.NoError(t, db.Get(&count, `SELECT COUNT(*) FROM todos WHERE id = $1`, todo.ID))
+			return count
+		}
+
+		setWorkspace(t, strconv.Itoa(workspaceA))
+		assert.Equal(t, 1, countTodo(t))
+		setWorkspace(t, strconv.Itoa(workspaceB))
+		assert.Equal(t, 0, countTodo(t))
+		setWorkspace(t, "")
+		assert.Equal(t, 0, countTodo(t))
+
+		// 設定したワークスペース以外の行は WITH CHECK で書き込めない
+		setWorkspace(t, strconv.Itoa(workspaceB))
+		_, err = db.Exec(`SAVEPOINT rls_with_check`)
+		require.NoError(t, err)
+		_, err = db.Exec(`INSERT INTO todos (title, owner_id, workspace_id) VALUES ('越境', $1, $2)`, aliceID, workspaceA)
+		assert.ErrorContains(t, err, "row-level security")
+		_, err = db.Exec(`ROLLBACK TO SAVEPOINT rls_with_check`)
+		require.NoError(t, err)
+	})
+}
@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
@@ -26,6 +29,72 @@ type Config struct {
 
 	// CORS settings
 	DashboardClientURL string `envconfig:"DASHBOARD_CLIENT_URL" default:"http://localhost:5173"`
+
+	// X-Forwarded-For からクライアントのIPアドレスを読み取るプロキシ（IPアドレス・CIDR、未設定の場合は接続元のIPアドレスを使う）
+	// レート制限はクライアントのIPアドレスで数えるため、信頼できないプロキシを指定すると偽装したヘッダーで回避できる
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
+
+	// Reminder scheduler settings
+	ReminderEnabled  bool          `envconfig:"REMINDER_ENABLED" default:"true"`
+	ReminderInterval time.Duration `envconfig:"REMINDER_INTERVAL" default:"1m"`
+	// remind_at 未設定のTodoを due_at のどれだけ前にリマインドするか
+	ReminderLeadTime time.Duration `envconfig:"REMINDER_LEAD_TIME" default:"1h"`
+
+	// JWT authentication settings
+	// 検証鍵は kid:値 のカンマ区切りで複数指定できる（鍵のローテーション用）
+	JWTHS256Secrets        map[string]string `envconfig:"JWT_HS256_SECRETS"`
+	JWTRS256PublicKeyFiles map[string]string `envconfig:"JWT_RS256_PUBLIC_KEY_FILES"`
+	JWTIssuer              string            `envconfig:"JWT_ISSUER"`
+	JWTAudience            string            `envconfig:"JWT_AUDIENCE"`
+	// exp / nbf の検証で許容する時計のずれ
+	JWTLeeway time.Duration `envconfig:"JWT_LEEWAY" default:"30s"`
+
+	// Password login settings
+	// ログインで発行するアクセストークンの署名に使う鍵（JWT_HS256_SECRETS の kid、未設定の場合はログインできない）
+	JWTSigningKeyID string        `envconfig:"JWT_SIGNING_KEY_ID"`
+	AccessTokenTTL  time.Duration `envconfig:"ACCESS_TOKEN_TTL" default:"15m"`
+	RefreshTokenTTL time.Duration `envconfig:"REFRESH_TOKEN_TTL" default:"720h"`
+	// パスワードリセットのトークンの有効期間
+	PasswordResetTTL time.Duration `envconfig:"PASSWORD_RESET_TTL" default:"30m"`
+	// 同時に実行するパスワードのハッシュ計算（argon2id、1回あたり64MiB）の上限
+	PasswordHashConcurrency int `envconfig:"PASSWORD_HASH_CONCURRENCY" default:"4"`
+
+	// OpenID Connect login settings
+	// IdP の issuer（未設定の場合は OpenID Connect でログインできない）
+	OIDCIssuer       string `envconfig:"OIDC_ISSUER"`
+	OIDCClientID     string `envconfig:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string `envconfig:"OIDC_CLIENT_SECRET"`
+	// IdP から認可コードを受け取るダッシュボードのURL（未設定の場合は DASHBOARD_CLIENT_URL + /auth/callback）
+	OIDCRedirectURL string   `envconfig:"OIDC_REDIRECT_URL"`
+	OIDCScopes      []string `envconfig:"OIDC_SCOPES" default:"openid,email,profile"`
+
+	// Trash settings
+	TrashPurgeEnabled  bool          `envconfig:"TRASH_PURGE_ENABLED" default:"true"`
+	TrashPurgeInterval time.Duration `envconfig:"TRASH_PURGE_INTERVAL" default:"1h"`
+	// ゴミ箱に移動したTodoを完全に削除するまでの保存期間
+	TrashRetention time.Duration `envconfig:"TRASH_RETENTION" default:"720h"`
+
+	// Todo import settings
+	// 大きなファイルのインポートをバックグラウンドで実行するジョブ
+	TodoImportEnabled  bool          `envconfig:"TODO_IMPORT_ENABLED" default:"true"`
+	TodoImportInterval time.Duration `envconfig:"TODO_IMPORT_INTERVAL" default:"5s"`
+
+	// Rate limit settings
+	RateLimitEnabled bool `envconfig:"RATE_LIMIT_ENABLED" default:"true"`
+	// memory（単一インスタンス用）または postgres（複数レプリカで共有）
+	RateLimitStore   string    `envconfig:"RATE_LIMIT_STORE" default:"memory"`
+	RateLimitDefault RateLimit `envconfig:"RATE_LIMIT_DEFAULT" default:"120/1m"`
+	// ルートごとの上限は "GET /api/v1/todos=300/1m;POST /api/v1/todos/bulk=10/1m" の形式で指定する
+	RateLimitRoutes RateLimitRoutes `envconfig:"RATE_LIMIT_ROUTES"`
+	// 認証の前にIPアドレスごとに全てのルートを合わせて制限する（不正な認証情報での大量のリクエストを防ぐ）
+	RateLimitPerIP RateLimit `envconfig:"RATE_LIMIT_PER_IP" default:"600/1m"`
+	// ログイン・パスワードの再設定は総当たりや再設定メールの大量送信を防ぐため、ルートごとにIPアドレスごと・メールアドレスごとにより厳しく制限する
+	RateLimitLoginPerIP    RateLimit `envconfig:"RATE_LIMIT_LOGIN_PER_IP" default:"10/1m"`
+	RateLimitLoginPerEmail RateLimit `envconfig:"RATE_LIMIT_LOGIN_PER_EMAIL" default:"5/5m"`
+
+	// Idempotency-Key settings
+	// 同じ Idempotency-Key で再送されたリクエストに保存したレスポンスを返す期間
+	IdempotencyKeyTTL time.Duration `envconfig:"IDEMPOTENCY_KEY_TTL" default:"24h"`
 }
 
 func Load() (*Config, error) {
@@ -48,3 +117,60 @@ func (c *Config) GetDSN() string {
 		c.DBSSLMode,
 	)
 }
+
+// RateLimit はトークンバケットによるレート制限の設定
+// Period あたり Requests 回まで呼び出せ、使ったトークンは Period をかけて一定の速度で回復する
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Decode は "120/1m" 形式の文字列を RateLimit に変換する（envconfig.Decoder）
+func (r *RateLimit) Decode(value string) error {
+	requests, period, found := strings.Cut(strings.TrimSpace(value), "/")
+	if !found {
+		return fmt.Errorf("invalid rate limit %q: expected <requests>/<period>", value)
+	}
+
+	n, err := strconv.Atoi(requests)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("invalid rate limit %q: requests must be a positive integer", value)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid rate limit %q: period must be a positive duration", value)
+	}
+
+	*r = RateLimit{Requests: n, Period: d}
+	return nil
+}
+
+// RateLimitRoutes は "メソッド ルート"（例: "POST /api/v1/todos/bulk"）ごとのレート制限
+type RateLimitRoutes map[string]RateLimit
+
+// Decode は "GET /api/v1/todos=300/1m;POST /api/v1/todos/bulk=10/1m" 形式の文字列を RateLimitRoutes に変換する（envconfig.Decoder）
+// ルートに ":id" などのパラメータを含められるよう、区切りにはセミコロンと = を使う
+func (r *RateLimitRoutes) Decode(value string) error {
+	routes := RateLimitRoutes{}
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, limit, found := strings.Cut(entry, "=")
+		method, path, hasPath := strings.Cut(strings.TrimSpace(route), " ")
+		if !found || !hasPath {
+			return fmt.Errorf("invalid rate limit route %q: expected <METHOD> <path>=<requests>/<period>", entry)
+		}
+
+		var rateLimit RateLimit
+		if err := rateLimit.Decode(limit); err != nil {
+			return err
+		}
+		routes[strings.ToUpper(method)+" "+strings.TrimSpace(path)] = rateLimit
+	}
+
+	*r = routes
+	return nil
+}
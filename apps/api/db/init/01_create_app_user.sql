@@ -0,0 +1,5 @@
+-- ローカル開発用の API の接続ユーザー（docker-compose の初回起動時に実行される）
+-- マイグレーションを実行する apiuser はスーパーユーザーのため、API・テストはこのユーザーで接続して RLS を適用する
+-- 権限は migrations/000024_create_app_role で todo_api ロールに付与する
+CREATE ROLE todo_api NOLOGIN NOSUPERUSER NOBYPASSRLS;
+CREATE ROLE apiapp LOGIN PASSWORD 'apiapppassword' NOSUPERUSER NOBYPASSRLS IN ROLE todo_api;
\ No newline at end of file
@@ -11,6 +11,14 @@ import (
 // @host localhost:8080
 // @BasePath /
 // @schemes http https
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description "Bearer <JWT>" 形式で指定
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description APIキー（tdk_... 形式、Todo関連のエンドポイントのみ）
 func main() {
 	// Initialize server dependencies
 	if err := server.Initialize(); err != nil {
@@ -0,0 +1 @@
+CREATE INDEX idx_todos_created_at_id ON todos(created_at DESC, id DESC);
\ No newline at end of file
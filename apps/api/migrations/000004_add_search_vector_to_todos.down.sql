@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_todos_search_vector;
+ALTER TABLE todos DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS todo_search_query(TEXT);
+DROP FUNCTION IF EXISTS todo_search_tokens(TEXT);
\ No newline at end of file
@@ -0,0 +1,44 @@
+-- 日本語は空白で分かち書きされないため、PostgreSQL標準のパーサーでは単語に分割できない。
+-- 英数字は単語単位、それ以外の文字の連続は2文字ずつ（bigram）に分割したトークン列に変換する。
+-- 例: 'コーヒー豆を注文 ASAP' -> 'コー ーヒ ヒー ー豆 豆を を注 注文 asap'
+CREATE OR REPLACE FUNCTION todo_search_tokens(input TEXT) RETURNS TEXT AS $$
+DECLARE
+    run TEXT;
+    tokens TEXT[] := ARRAY[]::TEXT[];
+    i INTEGER;
+BEGIN
+    FOR run IN
+        SELECT m[1]
+        FROM regexp_matches(
+            lower(normalize(coalesce(input, ''), NFKC)),
+            '([0-9a-z]+|[^\x01-\x7f[:space:]、。，．・：；？！「」『』（）［］【】〈〉《》〔〕｛｝〜…]+)',
+            'g'
+        ) AS m
+    LOOP
+        IF run ~ '^[0-9a-z]+$' OR char_length(run) = 1 THEN
+            tokens := tokens || run;
+        ELSE
+            FOR i IN 1..char_length(run) - 1 LOOP
+                tokens := tokens || substr(run, i, 2);
+            END LOOP;
+        END IF;
+    END LOOP;
+
+    RETURN array_to_string(tokens, ' ');
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- 検索語を todo_search_tokens と同じ規則で分割し、全トークンのAND（前方一致）の tsquery に変換する
+CREATE OR REPLACE FUNCTION todo_search_query(input TEXT) RETURNS tsquery AS $$
+    SELECT to_tsquery('simple', coalesce(string_agg(quote_literal(token) || ':*', ' & '), ''))
+    FROM unnest(string_to_array(todo_search_tokens(input), ' ')) AS token
+    WHERE token <> '';
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE todos
+ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', todo_search_tokens(title)), 'A') ||
+    setweight(to_tsvector('simple', todo_search_tokens(description)), 'B')
+) STORED;
+
+CREATE INDEX idx_todos_search_vector ON todos USING GIN (search_vector);
\ No newline at end of file
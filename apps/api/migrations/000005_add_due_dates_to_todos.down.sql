@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_todos_pending_reminder;
+DROP INDEX IF EXISTS idx_todos_due_at;
+ALTER TABLE todos
+DROP COLUMN IF EXISTS reminded_at,
+DROP COLUMN IF EXISTS remind_at,
+DROP COLUMN IF EXISTS due_at;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE todos
+ADD COLUMN due_at TIMESTAMPTZ,
+ADD COLUMN remind_at TIMESTAMPTZ,
+-- リマインド送信済み日時（送信前に記録し、再起動時の二重送信を防ぐ）
+ADD COLUMN reminded_at TIMESTAMPTZ;
+
+CREATE INDEX idx_todos_due_at ON todos(due_at);
+CREATE INDEX idx_todos_pending_reminder ON todos(remind_at, due_at)
+WHERE reminded_at IS NULL AND completed = false;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE todos
+-- 親Todoを削除した場合は子Todoも削除する
+ADD COLUMN parent_id INTEGER REFERENCES todos(id) ON DELETE CASCADE,
+ADD CONSTRAINT chk_todos_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_todos_parent_id ON todos(parent_id);
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE todos
+-- ゴミ箱に移動した日時（NULL の場合は通常のTodo）
+ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- ゴミ箱一覧・保存期間切れの削除用
+CREATE INDEX idx_todos_deleted_at ON todos(deleted_at) WHERE deleted_at IS NOT NULL;
\ No newline at end of file
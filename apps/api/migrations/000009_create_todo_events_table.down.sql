@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_todo_events_todo_id;
+DROP TABLE IF EXISTS todo_events;
\ No newline at end of file
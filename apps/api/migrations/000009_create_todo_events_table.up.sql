@@ -0,0 +1,11 @@
+-- Todoの変更履歴（changes は項目ごとの変更前後の値）
+CREATE TABLE IF NOT EXISTS todo_events (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    event_type VARCHAR(20) NOT NULL,
+    actor_id INTEGER,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_todo_events_todo_id ON todo_events(todo_id, id DESC);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE todos
+DROP COLUMN IF EXISTS next_occurrence_id,
+DROP COLUMN IF EXISTS recurrence_start,
+DROP COLUMN IF EXISTS recurrence_timezone,
+DROP COLUMN IF EXISTS recurrence_rule;
\ No newline at end of file
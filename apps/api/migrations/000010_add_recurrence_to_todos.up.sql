@@ -0,0 +1,7 @@
+ALTER TABLE todos
+ADD COLUMN recurrence_rule VARCHAR(255),
+ADD COLUMN recurrence_timezone VARCHAR(64),
+-- 繰り返しの起点（COUNT・UNTIL の計算に使う最初の期限）
+ADD COLUMN recurrence_start TIMESTAMPTZ,
+-- 完了時に生成した次回のTodo（同じTodoを完了し直しても二重に生成しない）
+ADD COLUMN next_occurrence_id INTEGER REFERENCES todos(id) ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_todos_owner_id;
+
+ALTER TABLE todos
+DROP COLUMN IF EXISTS owner_id;
\ No newline at end of file
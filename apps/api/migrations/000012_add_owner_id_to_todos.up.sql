@@ -0,0 +1,5 @@
+-- 既存のTodoは所有者なし（NULL）のまま残し、認証済みユーザーからはアクセスできない
+ALTER TABLE todos
+ADD COLUMN owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_todos_owner_id ON todos(owner_id);
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    -- #rrggbb 形式（小文字に正規化して保存する）
+    color VARCHAR(7) NOT NULL DEFAULT '#808080',
+    archived BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_projects_owner_id ON projects(owner_id);
+
+-- project_id が NULL のTodoはインボックス（プロジェクト未所属）
+-- ゴミ箱内のTodoはプロジェクトを削除するとインボックスに戻る
+ALTER TABLE todos
+ADD COLUMN project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_todos_project_id ON todos(project_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS project_invitations;
+
+DROP TABLE IF EXISTS project_members;
\ No newline at end of file
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS project_members (
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- owner: メンバー・プロジェクトの管理、editor: Todoの作成・更新・削除、viewer: 閲覧のみ
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX idx_project_members_user_id ON project_members(user_id);
+
+-- 既存のプロジェクトは作成したユーザーをオーナーにする
+INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+SELECT id, owner_id, 'owner', created_at, created_at FROM projects;
+
+CREATE TABLE IF NOT EXISTS project_invitations (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    inviter_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    invitee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'revoked')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP
+);
+
+-- 同じユーザーへの保留中の招待は1件のみ
+CREATE UNIQUE INDEX idx_project_invitations_pending ON project_invitations(project_id, invitee_id) WHERE status = 'pending';
+CREATE INDEX idx_project_invitations_invitee_id ON project_invitations(invitee_id);
\ No newline at end of file
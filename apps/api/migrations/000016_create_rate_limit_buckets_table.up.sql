@@ -0,0 +1,8 @@
+-- レート制限のトークンバケット（RATE_LIMIT_STORE=postgres の場合に複数のレプリカで共有する）
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key VARCHAR(255) PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
\ No newline at end of file
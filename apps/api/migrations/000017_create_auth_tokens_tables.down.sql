@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS password_hash;
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- パスワードは argon2id のハッシュ（PHC 形式）のみ保存する
+-- NULL のユーザーはパスワードでログインできない（パスワードリセットで設定する）
+ALTER TABLE users
+ADD COLUMN password_hash TEXT;
+
+-- リフレッシュトークンはローテーションごとに1行追加し、同じログインから発行したトークンを family_id でまとめる
+-- 使用済みのトークンが再利用された場合は漏洩とみなし、family ごと失効させる
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL,
+    -- トークン本体は保存せず、SHA-256 のハッシュのみ保存する
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+-- パスワードリセットのトークン（1回のみ使用できる）
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
\ No newline at end of file
@@ -0,0 +1,27 @@
+-- 外部の IdP（OpenID Connect）のアカウントとユーザーの紐付け
+-- IdP のユーザーは issuer と sub の組で識別する（メールアドレスは変更されうるため識別には使わない）
+CREATE TABLE IF NOT EXISTS user_identities (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    -- 最後にログインした時点の IdP のメールアドレス
+    email VARCHAR(255),
+    last_login_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (issuer, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+-- 認可リクエストの state と、コールバックで検証する nonce・PKCE の code_verifier
+-- コールバックで1回だけ取り出して削除する
+CREATE TABLE IF NOT EXISTS oidc_auth_requests (
+    state_hash CHAR(64) PRIMARY KEY,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_oidc_auth_requests_expires_at ON oidc_auth_requests(expires_at);
\ No newline at end of file
@@ -0,0 +1,38 @@
+DROP POLICY IF EXISTS tags_workspace_isolation ON tags;
+ALTER TABLE tags NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE tags DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS projects_workspace_isolation ON projects;
+ALTER TABLE projects NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE projects DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS todos_workspace_isolation ON todos;
+ALTER TABLE todos NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE todos DISABLE ROW LEVEL SECURITY;
+
+-- 別のワークスペースに同名のタグがある場合は一意制約を戻せないため、IDが最小のタグに統合する
+INSERT INTO todo_tags (todo_id, tag_id)
+SELECT tt.todo_id, keep.id
+FROM todo_tags tt
+JOIN tags t ON t.id = tt.tag_id
+JOIN (SELECT name, MIN(id) AS id FROM tags GROUP BY name) keep ON keep.name = t.name
+WHERE t.id <> keep.id
+ON CONFLICT DO NOTHING;
+
+-- 統合したタグの todo_tags は ON DELETE CASCADE で削除される
+DELETE FROM tags t
+USING (SELECT name, MIN(id) AS id FROM tags GROUP BY name) keep
+WHERE t.name = keep.name AND t.id <> keep.id;
+
+ALTER TABLE tags DROP CONSTRAINT IF EXISTS tags_workspace_id_name_key;
+ALTER TABLE tags ADD CONSTRAINT tags_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_projects_workspace_id;
+DROP INDEX IF EXISTS idx_todos_workspace_id;
+
+ALTER TABLE tags DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS workspace_id;
+
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
\ No newline at end of file
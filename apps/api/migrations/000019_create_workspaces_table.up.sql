@@ -0,0 +1,72 @@
+-- ワークスペース（チーム）ごとにTodo・プロジェクト・タグを分離する
+CREATE TABLE IF NOT EXISTS workspaces (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- owner: メンバーの追加、member: ワークスペース内のTodo・プロジェクト・タグの利用
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'member')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX idx_workspace_members_user_id ON workspace_members(user_id);
+
+-- 既存のデータは既定のワークスペースに移し、既存のユーザーを全てオーナーにする
+INSERT INTO workspaces (name)
+SELECT 'Default'
+WHERE EXISTS (SELECT 1 FROM users) OR EXISTS (SELECT 1 FROM todos) OR EXISTS (SELECT 1 FROM projects) OR EXISTS (SELECT 1 FROM tags);
+
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT w.id, u.id, 'owner' FROM workspaces w CROSS JOIN users u;
+
+ALTER TABLE todos ADD COLUMN workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE projects ADD COLUMN workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE tags ADD COLUMN workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+
+UPDATE todos SET workspace_id = (SELECT MIN(id) FROM workspaces);
+UPDATE projects SET workspace_id = (SELECT MIN(id) FROM workspaces);
+UPDATE tags SET workspace_id = (SELECT MIN(id) FROM workspaces);
+
+ALTER TABLE todos ALTER COLUMN workspace_id SET NOT NULL;
+ALTER TABLE projects ALTER COLUMN workspace_id SET NOT NULL;
+ALTER TABLE tags ALTER COLUMN workspace_id SET NOT NULL;
+
+CREATE INDEX idx_todos_workspace_id ON todos(workspace_id);
+CREATE INDEX idx_projects_workspace_id ON projects(workspace_id);
+
+-- タグ名はワークスペースごとに一意
+ALTER TABLE tags DROP CONSTRAINT tags_name_key;
+ALTER TABLE tags ADD CONSTRAINT tags_workspace_id_name_key UNIQUE (workspace_id, name);
+
+-- 行レベルセキュリティ（RLS）
+-- API はトランザクションごとに set_config('app.workspace_id', ..., true) でワークスペースを設定する
+-- 設定されていない場合はどの行にも一致しない（読み取りは0件、書き込みはエラー）
+-- テーブルの所有者にも適用するため FORCE する（スーパーユーザーと BYPASSRLS のロールには適用されない）
+-- RLS は workspace_id を持つ todos・projects・tags のみに意図的に限定する。それ以外はアプリケーションで絞り込む
+--   todo_tags・todo_events・project_members・project_invitations: Todo・プロジェクトを経由してのみ参照する
+--     （招待一覧などワークスペースを指定せずに参照するクエリがある）
+--   todo_import_jobs（000022）: ワーカーが全てのワークスペースのジョブを取得する
+--   idempotency_keys（000021）・rate_limit_buckets: ワークスペースではなく呼び出し元ごとのデータ
+ALTER TABLE todos ENABLE ROW LEVEL SECURITY;
+ALTER TABLE todos FORCE ROW LEVEL SECURITY;
+CREATE POLICY todos_workspace_isolation ON todos
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::INTEGER)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::INTEGER);
+
+ALTER TABLE projects ENABLE ROW LEVEL SECURITY;
+ALTER TABLE projects FORCE ROW LEVEL SECURITY;
+CREATE POLICY projects_workspace_isolation ON projects
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::INTEGER)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::INTEGER);
+
+ALTER TABLE tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tags FORCE ROW LEVEL SECURITY;
+CREATE POLICY tags_workspace_isolation ON tags
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::INTEGER)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::INTEGER);
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- 楽観的排他制御用のバージョン（更新のたびに1ずつ増やし、ETag として返す）
+ALTER TABLE todos
+ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
\ No newline at end of file
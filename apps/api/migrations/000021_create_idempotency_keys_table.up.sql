@@ -0,0 +1,17 @@
+-- POST リクエストの Idempotency-Key（同じキーで再送されたリクエストには保存したレスポンスを返す）
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    -- キーの持ち主（APIキー・ユーザー・IPアドレス）。別の呼び出し元とはキーが重複してもよい
+    scope VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    -- メソッド・パス・ワークスペース・本文の SHA-256（同じキーで別の内容のリクエストを検出する）
+    request_hash CHAR(64) NOT NULL,
+    -- レスポンスは処理が終わるまで NULL
+    response_status INTEGER,
+    response_content_type VARCHAR(255),
+    response_body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
\ No newline at end of file
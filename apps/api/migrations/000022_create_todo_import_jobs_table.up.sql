@@ -0,0 +1,28 @@
+-- Todoのインポート（行数の多いファイルは非同期のジョブとして TodoImportScheduler が処理する）
+CREATE TABLE IF NOT EXISTS todo_import_jobs (
+    id SERIAL PRIMARY KEY,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    -- インポートしたユーザー（作成したTodoの所有者になり、ジョブの状態はこのユーザーのみ参照できる）
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+    -- 検証済みのインポートする行（完了したジョブでは削除する）
+    payload JSONB,
+    total_rows INTEGER NOT NULL,
+    -- 登録済みの行数（処理が中断した場合は続きから再開する）
+    imported_rows INTEGER NOT NULL DEFAULT 0,
+    -- 登録できなかった行のエラー
+    errors JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMPTZ,
+    -- 処理中のワーカーがバッチごとに更新する（途絶えたジョブは別のワーカーが引き継ぐ）
+    heartbeat_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_todo_import_jobs_unfinished ON todo_import_jobs(workspace_id, id) WHERE status IN ('pending', 'running');
+
+ALTER TABLE todo_import_jobs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE todo_import_jobs FORCE ROW LEVEL SECURITY;
+CREATE POLICY todo_import_jobs_workspace_isolation ON todo_import_jobs
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::INTEGER)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::INTEGER);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE oidc_auth_requests
+DROP COLUMN IF EXISTS link_user_id;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS email_verified_at;
\ No newline at end of file
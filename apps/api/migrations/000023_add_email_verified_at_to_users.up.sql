@@ -0,0 +1,8 @@
+-- メールアドレスの所有を確認した日時（IdP で確認済みのアドレスでの作成、またはパスワードの再設定で確認する）
+-- 確認されていないユーザーには IdP のアカウントを自動で紐付けない
+ALTER TABLE users
+ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+-- ログイン済みのユーザーが IdP のアカウントを紐付ける場合の認可リクエストのユーザー
+ALTER TABLE oidc_auth_requests
+ADD COLUMN link_user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE ALL ON SEQUENCES FROM todo_api;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE ALL ON TABLES FROM todo_api;
+REVOKE ALL ON ALL SEQUENCES IN SCHEMA public FROM todo_api;
+REVOKE ALL ON ALL TABLES IN SCHEMA public FROM todo_api;
+REVOKE USAGE ON SCHEMA public FROM todo_api;
+
+-- ロールはクラスタ全体で共有されるため削除しない（ログインするユーザーがメンバーになっている）
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- API が接続するロール（スーパーユーザー・BYPASSRLS ではないため RLS が適用される）
+-- ログインするユーザーは環境ごとに作成し、このロールのメンバーにする（db/init/01_create_app_user.sql 参照）
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'todo_api') THEN
+        CREATE ROLE todo_api NOLOGIN NOSUPERUSER NOBYPASSRLS;
+    END IF;
+END
+$$;
+
+-- データの参照・更新のみ許可する（TRUNCATE は RLS を適用しないため許可しない）
+GRANT USAGE ON SCHEMA public TO todo_api;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO todo_api;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO todo_api;
+REVOKE ALL ON schema_migrations FROM todo_api;
+
+-- 以降のマイグレーションで作成するテーブルにも同じ権限を付与する
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO todo_api;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO todo_api;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE todo_import_jobs
+DROP COLUMN IF EXISTS claim_token;
\ No newline at end of file
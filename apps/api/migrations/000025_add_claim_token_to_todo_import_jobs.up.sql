@@ -0,0 +1,3 @@
+-- ジョブを取得したワーカーごとに発行する（別のワーカーに引き継がれたジョブを古いワーカーが更新しないようにする）
+ALTER TABLE todo_import_jobs
+ADD COLUMN claim_token UUID;
\ No newline at end of file
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type APIKeyRepository interface {
+	// GetByUserID はユーザーのAPIキーを作成日時の新しい順に取得する（失効済みを含む）
+	GetByUserID(userID int) ([]models.APIKey, error)
+	GetByID(id int) (*models.APIKey, error)
+	GetByPrefix(prefix string) (*models.APIKey, error)
+	Create(key *models.APIKey) (*models.APIKey, error)
+	// Revoke はAPIキーを失効させる（失効済みの場合は最初の失効日時のまま）
+	Revoke(id int, now time.Time) (*models.APIKey, error)
+	// TouchLastUsed は最終利用日時を記録する
+	TouchLastUsed(id int, now time.Time) error
+
+	// WithTx はトランザクション内でクエリを実行する APIKeyRepository を返す
+	WithTx(tx DBTX) APIKeyRepository
+}
+
+// apiKeyColumns は SELECT / RETURNING で取得する api_keys のカラム
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at`
+
+type apiKeyRepository struct {
+	db DBTX
+}
+
+func NewAPIKeyRepository(db DBTX) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) WithTx(tx DBTX) APIKeyRepository {
+	return &apiKeyRepository{db: tx}
+}
+
+func (r *apiKeyRepository) GetByUserID(userID int) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+	if err := r.db.Select(&keys, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) GetByID(id int) (*models.APIKey, error) {
+	return r.getOne(`SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1`, id)
+}
+
+func (r *apiKeyRepository) GetByPrefix(prefix string) (*models.APIKey, error) {
+	return r.getOne(`SELECT `+apiKeyColumns+` FROM api_keys WHERE prefix = $1`, prefix)
+}
+
+func (r *apiKeyRepository) getOne(query string, args ...interface{}) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.Get(&key, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch api key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) Create(key *models.APIKey) (*models.APIKey, error) {
+	var created models.APIKey
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+		RETURNING ` + apiKeyColumns
+
+	err := r.db.QueryRowx(query, key.UserID, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.ExpiresAt).StructScan(&created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *apiKeyRepository) Revoke(id int, now time.Time) (*models.APIKey, error) {
+	var revoked models.APIKey
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $1) WHERE id = $2 RETURNING ` + apiKeyColumns
+	if err := r.db.QueryRowx(query, now, id).StructScan(&revoked); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return &revoked, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(id int, now time.Time) error {
+	if _, err := r.db.Exec(`UPDATE api_keys SET last_used_at = $1 WHERE id = $2`, now, id); err != nil {
+		return fmt.Errorf("failed to record api key usage: %w", err)
+	}
+	return nil
+}
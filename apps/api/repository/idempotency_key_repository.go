@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type IdempotencyKeyRepository interface {
+	// Claim は scope の key を処理中として登録し、登録できたかどうかを返す
+	// 有効期限内の登録が既にある場合は登録せず、その内容を返す（期限切れの登録は置き換える）
+	Claim(scope string, key string, requestHash string, now time.Time, expiresAt time.Time) (*models.IdempotencyKey, bool, error)
+	// Complete は処理したリクエストのレスポンスを保存する
+	Complete(scope string, key string, status int, contentType string, body []byte) error
+	// Release は処理中の登録を削除し、同じキーで再実行できるようにする
+	Release(scope string, key string) error
+	// DeleteExpired は now までに有効期限が切れた登録を削除する
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+const idempotencyKeyColumns = `scope, key, request_hash, response_status, response_content_type, response_body, created_at, expires_at`
+
+type idempotencyKeyRepository struct {
+	db DBTX
+}
+
+func NewIdempotencyKeyRepository(db DBTX) IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db}
+}
+
+func (r *idempotencyKeyRepository) Claim(scope string, key string, requestHash string, now time.Time, expiresAt time.Time) (*models.IdempotencyKey, bool, error) {
+	// 同時に同じキーで送られた場合も、主キーの一意制約で1件だけが登録できる
+	query := `
+		INSERT INTO idempotency_keys AS k (scope, key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (scope, key) DO UPDATE
+			SET request_hash = EXCLUDED.request_hash, response_status = NULL, response_content_type = NULL,
+				response_body = NULL, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE k.expires_at <= $4
+		RETURNING key`
+
+	var claimed string
+	err := r.db.Get(&claimed, query, scope, key, requestHash, now, expiresAt)
+	if err == nil {
+		return nil, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	var existing models.IdempotencyKey
+	query = `SELECT ` + idempotencyKeyColumns + ` FROM idempotency_keys WHERE scope = $1 AND key = $2`
+	if err := r.db.Get(&existing, query, scope, key); err != nil {
+		if err == sql.ErrNoRows {
+			// 取得する前に削除された（処理に失敗して解放された）
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to fetch idempotency key: %w", err)
+	}
+	return &existing, false, nil
+}
+
+func (r *idempotencyKeyRepository) Complete(scope string, key string, status int, contentType string, body []byte) error {
+	query := `
+		UPDATE idempotency_keys SET response_status = $3, response_content_type = $4, response_body = $5
+		WHERE scope = $1 AND key = $2`
+	if _, err := r.db.Exec(query, scope, key, status, contentType, body); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyKeyRepository) Release(scope string, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND response_status IS NULL`
+	if _, err := r.db.Exec(query, scope, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyKeyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type OIDCAuthRequestRepository interface {
+	Create(request *models.OIDCAuthRequest) error
+	// Consume は state のハッシュが一致する認可リクエストを削除して返す（同じ state は1回しか使えない）
+	Consume(stateHash string) (*models.OIDCAuthRequest, error)
+	// DeleteExpired は before より前に期限切れになった認可リクエストを削除する
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+// oidcAuthRequestColumns は SELECT / RETURNING で取得する oidc_auth_requests のカラム
+const oidcAuthRequestColumns = `state_hash, nonce, code_verifier, expires_at, created_at, link_user_id`
+
+type oidcAuthRequestRepository struct {
+	db DBTX
+}
+
+func NewOIDCAuthRequestRepository(db DBTX) OIDCAuthRequestRepository {
+	return &oidcAuthRequestRepository{db: db}
+}
+
+func (r *oidcAuthRequestRepository) Create(request *models.OIDCAuthRequest) error {
+	query := `
+		INSERT INTO oidc_auth_requests (state_hash, nonce, code_verifier, expires_at, link_user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)`
+	if _, err := r.db.Exec(query, request.StateHash, request.Nonce, request.CodeVerifier, request.ExpiresAt, request.LinkUserID); err != nil {
+		return fmt.Errorf("failed to create oidc auth request: %w", err)
+	}
+	return nil
+}
+
+func (r *oidcAuthRequestRepository) Consume(stateHash string) (*models.OIDCAuthRequest, error) {
+	var request models.OIDCAuthRequest
+	query := `DELETE FROM oidc_auth_requests WHERE state_hash = $1 RETURNING ` + oidcAuthRequestColumns
+	if err := r.db.QueryRowx(query, stateHash).StructScan(&request); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to consume oidc auth request: %w", err)
+	}
+	return &request, nil
+}
+
+func (r *oidcAuthRequestRepository) DeleteExpired(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM oidc_auth_requests WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired oidc auth requests: %w", err)
+	}
+	return result.RowsAffected()
+}
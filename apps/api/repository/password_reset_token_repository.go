@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type PasswordResetTokenRepository interface {
+	// GetByHashForUpdate はハッシュが一致するトークンを行ロックして取得する（同じトークンの同時使用を防ぐ）
+	GetByHashForUpdate(tokenHash string) (*models.PasswordResetToken, error)
+	Create(token *models.PasswordResetToken) (*models.PasswordResetToken, error)
+	// InvalidateByUserID はユーザーの未使用のトークンを全て使用済みにする（最新のトークンのみ有効にするため）
+	InvalidateByUserID(userID int, now time.Time) error
+
+	// WithTx はトランザクション内でクエリを実行する PasswordResetTokenRepository を返す
+	WithTx(tx DBTX) PasswordResetTokenRepository
+}
+
+// passwordResetTokenColumns は SELECT / RETURNING で取得する password_reset_tokens のカラム
+const passwordResetTokenColumns = `id, user_id, token_hash, expires_at, used_at, created_at`
+
+type passwordResetTokenRepository struct {
+	db DBTX
+}
+
+func NewPasswordResetTokenRepository(db DBTX) PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{db: db}
+}
+
+func (r *passwordResetTokenRepository) WithTx(tx DBTX) PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{db: tx}
+}
+
+func (r *passwordResetTokenRepository) GetByHashForUpdate(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	query := `SELECT ` + passwordResetTokenColumns + ` FROM password_reset_tokens WHERE token_hash = $1 FOR UPDATE`
+	if err := r.db.Get(&token, query, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch password reset token: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *passwordResetTokenRepository) Create(token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	var created models.PasswordResetToken
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		RETURNING ` + passwordResetTokenColumns
+
+	err := r.db.QueryRowx(query, token.UserID, token.TokenHash, token.ExpiresAt).StructScan(&created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password reset token: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *passwordResetTokenRepository) InvalidateByUserID(userID int, now time.Time) error {
+	query := `UPDATE password_reset_tokens SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL`
+	if _, err := r.db.Exec(query, now, userID); err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrDuplicateInvitation は同じユーザーへの未回答の招待が既に存在する場合のエラー
+var ErrDuplicateInvitation = errors.New("duplicate invitation")
+
+type ProjectInvitationRepository interface {
+	GetByID(id int) (*models.ProjectInvitation, error)
+	// GetPendingByProjectID はプロジェクトの未回答の招待を作成日時の新しい順に取得する
+	GetPendingByProjectID(projectID int) ([]models.ProjectInvitation, error)
+	// GetPendingByInviteeID はユーザーが受け取った未回答の招待を作成日時の新しい順に取得する
+	GetPendingByInviteeID(inviteeID int) ([]models.ProjectInvitation, error)
+	Create(invitation *models.ProjectInvitation) (*models.ProjectInvitation, error)
+	// Respond は未回答の招待の状態を status にする（存在しないか回答済みの場合は nil）
+	Respond(id int, status models.InvitationStatus, now time.Time) (*models.ProjectInvitation, error)
+
+	// WithTx はトランザクション内でクエリを実行する ProjectInvitationRepository を返す
+	WithTx(tx DBTX) ProjectInvitationRepository
+	// WithWorkspace は workspaceID のワークスペースのプロジェクトへの招待のみを対象にする ProjectInvitationRepository を返す
+	WithWorkspace(workspaceID int) ProjectInvitationRepository
+}
+
+// projectInvitationColumns は SELECT で取得する project_invitations のカラム（projects を p として JOIN する）
+const projectInvitationColumns = `i.id, i.project_id, i.inviter_id, i.invitee_id, i.role, i.status, i.created_at, i.responded_at, p.name AS project_name`
+
+type projectInvitationRepository struct {
+	db workspaceDB
+}
+
+func NewProjectInvitationRepository(db DBTX) ProjectInvitationRepository {
+	return &projectInvitationRepository{db: workspaceDB{db: db}}
+}
+
+func (r *projectInvitationRepository) WithTx(tx DBTX) ProjectInvitationRepository {
+	return &projectInvitationRepository{db: workspaceDB{db: tx, workspaceID: r.db.workspaceID}}
+}
+
+func (r *projectInvitationRepository) WithWorkspace(workspaceID int) ProjectInvitationRepository {
+	return &projectInvitationRepository{db: workspaceDB{db: r.db.db, workspaceID: workspaceID}}
+}
+
+func (r *projectInvitationRepository) GetByID(id int) (*models.ProjectInvitation, error) {
+	query := `
+		SELECT ` + projectInvitationColumns + `
+		FROM project_invitations i JOIN projects p ON p.id = i.project_id
+		WHERE i.id = $1 AND p.workspace_id = $2`
+	return r.getOne(query, id, r.db.workspaceID)
+}
+
+func (r *projectInvitationRepository) GetPendingByProjectID(projectID int) ([]models.ProjectInvitation, error) {
+	return r.getPending(`i.project_id = $2`, projectID)
+}
+
+func (r *projectInvitationRepository) GetPendingByInviteeID(inviteeID int) ([]models.ProjectInvitation, error) {
+	return r.getPending(`i.invitee_id = $2`, inviteeID)
+}
+
+// getPending は condition に一致する未回答の招待を取得する（condition のプレースホルダは $2 から）
+func (r *projectInvitationRepository) getPending(condition string, args ...interface{}) ([]models.ProjectInvitation, error) {
+	var invitations []models.ProjectInvitation
+	query := `
+		SELECT ` + projectInvitationColumns + `
+		FROM project_invitations i JOIN projects p ON p.id = i.project_id
+		WHERE p.workspace_id = $1 AND i.status = 'pending' AND ` + condition + `
+		ORDER BY i.created_at DESC, i.id DESC`
+	if err := r.db.Select(&invitations, query, append([]interface{}{r.db.workspaceID}, args...)...); err != nil {
+		return nil, fmt.Errorf("failed to fetch project invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (r *projectInvitationRepository) getOne(query string, args ...interface{}) (*models.ProjectInvitation, error) {
+	var invitation models.ProjectInvitation
+	if err := r.db.Get(&invitation, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch project invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+func (r *projectInvitationRepository) Create(invitation *models.ProjectInvitation) (*models.ProjectInvitation, error) {
+	var id int
+	query := `
+		INSERT INTO project_invitations (project_id, inviter_id, invitee_id, role, status, created_at)
+		SELECT $1, $2, $3, $4, 'pending', CURRENT_TIMESTAMP
+		WHERE EXISTS (SELECT 1 FROM projects WHERE id = $1 AND workspace_id = $5)
+		RETURNING id`
+
+	err := r.db.Get(&id, query, invitation.ProjectID, invitation.InviterID, invitation.InviteeID, invitation.Role, r.db.workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to create project invitation: project %d not found in workspace", invitation.ProjectID)
+		}
+		// 未回答の招待は idx_project_invitations_pending で1件に制限している
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateInvitation
+		}
+		return nil, fmt.Errorf("failed to create project invitation: %w", err)
+	}
+	return r.GetByID(id)
+}
+
+func (r *projectInvitationRepository) Respond(id int, status models.InvitationStatus, now time.Time) (*models.ProjectInvitation, error) {
+	var respondedID int
+	query := `
+		UPDATE project_invitations SET status = $1, responded_at = $2
+		WHERE id = $3 AND status = 'pending'
+			AND EXISTS (SELECT 1 FROM projects p WHERE p.id = project_id AND p.workspace_id = $4)
+		RETURNING id`
+	if err := r.db.Get(&respondedID, query, status, now, id, r.db.workspaceID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to respond to project invitation: %w", err)
+	}
+	return r.GetByID(respondedID)
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type ProjectRepository interface {
+	// GetAll はプロジェクトをTodoの件数・メンバーのロールとともに名前順に取得する
+	GetAll(query models.ProjectListQuery) ([]models.Project, error)
+	GetByID(id int) (*models.Project, error)
+	Create(project *models.Project) (*models.Project, error)
+	Update(id int, update models.ProjectUpdate) (*models.Project, error)
+	// Delete はプロジェクトを削除する（ゴミ箱内のTodoは ON DELETE SET NULL でインボックスに戻る）
+	Delete(id int) error
+
+	// メンバー
+	GetMembers(projectID int) ([]models.ProjectMember, error)
+	// GetMemberRole はユーザーのロールを返す（メンバーでない場合は空文字）
+	GetMemberRole(projectID int, userID int) (models.ProjectRole, error)
+	// AddMember はメンバーを追加する（既にメンバーの場合はロールを変更しない）
+	AddMember(projectID int, userID int, role models.ProjectRole) error
+	UpdateMemberRole(projectID int, userID int, role models.ProjectRole) (*models.ProjectMember, error)
+	RemoveMember(projectID int, userID int) error
+	// CountOwnersForUpdate はオーナーのロールを持つメンバーを行ロックして数える
+	CountOwnersForUpdate(projectID int) (int, error)
+
+	// WithTx はトランザクション内でクエリを実行する ProjectRepository を返す
+	WithTx(tx DBTX) ProjectRepository
+	// WithWorkspace は workspaceID のワークスペースのプロジェクトのみを対象にする ProjectRepository を返す
+	WithWorkspace(workspaceID int) ProjectRepository
+}
+
+// projectColumns はプロジェクトのカラムと、プロジェクト内の未完了・完了済みのTodo（ゴミ箱内を除く）の件数
+// todos を t として LEFT JOIN し、p.id で GROUP BY して使う
+const projectColumns = `p.id, p.owner_id, p.name, p.color, p.archived, p.created_at, p.updated_at,
+	COUNT(t.id) FILTER (WHERE NOT t.completed) AS open_todo_count,
+	COUNT(t.id) FILTER (WHERE t.completed) AS completed_todo_count`
+
+const projectSelect = `
+	SELECT ` + projectColumns + `
+	FROM projects p
+	LEFT JOIN todos t ON t.project_id = p.id AND t.deleted_at IS NULL`
+
+// projectMemberColumns は SELECT / RETURNING で取得する project_members のカラム（users を u として JOIN する）
+const projectMemberColumns = `m.project_id, m.user_id, m.role, m.created_at, m.updated_at, u.name AS user_name, u.email AS user_email`
+
+// projectInWorkspace は project_members に RLS がないため、$1 のプロジェクトが $2 のワークスペースにあることを確認する条件
+const projectInWorkspace = `EXISTS (SELECT 1 FROM projects WHERE id = $1 AND workspace_id = $2)`
+
+type projectRepository struct {
+	db workspaceDB
+}
+
+func NewProjectRepository(db DBTX) ProjectRepository {
+	return &projectRepository{db: workspaceDB{db: db}}
+}
+
+func (r *projectRepository) WithTx(tx DBTX) ProjectRepository {
+	return &projectRepository{db: workspaceDB{db: tx, workspaceID: r.db.workspaceID}}
+}
+
+func (r *projectRepository) WithWorkspace(workspaceID int) ProjectRepository {
+	return &projectRepository{db: workspaceDB{db: r.db.db, workspaceID: workspaceID}}
+}
+
+func (r *projectRepository) GetAll(query models.ProjectListQuery) ([]models.Project, error) {
+	var args queryArgs
+	// MemberID のプレースホルダを $1 にするため、ワークスペースの条件は後で追加する
+	memberID := args.add(query.MemberID)
+	conditions := []string{"p.workspace_id = " + args.add(r.db.workspaceID)}
+	if query.MemberID != nil {
+		conditions = append(conditions, "m.user_id IS NOT NULL")
+	}
+	if !query.IncludeArchived {
+		conditions = append(conditions, "p.archived = false")
+	}
+
+	// MemberID が nil の場合はどの行とも一致しないため、ロールは空文字になる
+	sqlQuery := `
+		SELECT ` + projectColumns + `, COALESCE(m.role, '') AS role
+		FROM projects p
+		LEFT JOIN project_members m ON m.project_id = p.id AND m.user_id = ` + memberID + `
+		LEFT JOIN todos t ON t.project_id = p.id AND t.deleted_at IS NULL
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		GROUP BY p.id, m.role
+		ORDER BY p.name ASC, p.id ASC`
+
+	var projects []models.Project
+	if err := r.db.Select(&projects, sqlQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (r *projectRepository) GetByID(id int) (*models.Project, error) {
+	var project models.Project
+	query := projectSelect + ` WHERE p.id = $1 AND p.workspace_id = $2 GROUP BY p.id`
+
+	err := r.db.Get(&project, query, id, r.db.workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch project: %w", err)
+	}
+
+	return &project, nil
+}
+
+func (r *projectRepository) Create(project *models.Project) (*models.Project, error) {
+	var created models.Project
+	query := `
+		INSERT INTO projects (workspace_id, owner_id, name, color, archived, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, false, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id, owner_id, name, color, archived, created_at, updated_at`
+
+	err := r.db.Get(&created, query, r.db.workspaceID, project.OwnerID, project.Name, project.Color)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return &created, nil
+}
+
+// Update はプロジェクトを更新し、Todoの件数とともに返す（存在しない場合は nil）
+func (r *projectRepository) Update(id int, update models.ProjectUpdate) (*models.Project, error) {
+	var args queryArgs
+	sets := []string{"updated_at = CURRENT_TIMESTAMP"}
+	if update.Name != nil {
+		sets = append(sets, "name = "+args.add(*update.Name))
+	}
+	if update.Color != nil {
+		sets = append(sets, "color = "+args.add(*update.Color))
+	}
+	if update.Archived != nil {
+		sets = append(sets, "archived = "+args.add(*update.Archived))
+	}
+
+	query := `UPDATE projects SET ` + strings.Join(sets, ", ") +
+		` WHERE id = ` + args.add(id) + ` AND workspace_id = ` + args.add(r.db.workspaceID) + ` RETURNING id`
+
+	var updatedID int
+	if err := r.db.Get(&updatedID, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return r.GetByID(updatedID)
+}
+
+func (r *projectRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM projects WHERE id = $1 AND workspace_id = $2`, id, r.db.workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *projectRepository) GetMembers(projectID int) ([]models.ProjectMember, error) {
+	var members []models.ProjectMember
+	query := `
+		SELECT ` + projectMemberColumns + `
+		FROM project_members m JOIN users u ON u.id = m.user_id
+		WHERE m.project_id = $1 AND ` + projectInWorkspace + `
+		ORDER BY m.created_at ASC, m.user_id ASC`
+	if err := r.db.Select(&members, query, projectID, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to fetch project members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *projectRepository) GetMemberRole(projectID int, userID int) (models.ProjectRole, error) {
+	var role models.ProjectRole
+	query := `SELECT role FROM project_members WHERE project_id = $1 AND user_id = $3 AND ` + projectInWorkspace
+	if err := r.db.Get(&role, query, projectID, r.db.workspaceID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to fetch project member role: %w", err)
+	}
+	return role, nil
+}
+
+func (r *projectRepository) AddMember(projectID int, userID int, role models.ProjectRole) error {
+	query := `
+		INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+		SELECT $1, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		WHERE ` + projectInWorkspace + `
+		ON CONFLICT (project_id, user_id) DO NOTHING`
+	if _, err := r.db.Exec(query, projectID, r.db.workspaceID, userID, role); err != nil {
+		return fmt.Errorf("failed to add project member: %w", err)
+	}
+	return nil
+}
+
+// UpdateMemberRole はメンバーのロールを変更する（メンバーでない場合は nil）
+func (r *projectRepository) UpdateMemberRole(projectID int, userID int, role models.ProjectRole) (*models.ProjectMember, error) {
+	var member models.ProjectMember
+	query := `
+		WITH updated AS (
+			UPDATE project_members SET role = $4, updated_at = CURRENT_TIMESTAMP
+			WHERE project_id = $1 AND user_id = $3 AND ` + projectInWorkspace + `
+			RETURNING *
+		)
+		SELECT ` + projectMemberColumns + ` FROM updated m JOIN users u ON u.id = m.user_id`
+
+	if err := r.db.Get(&member, query, projectID, r.db.workspaceID, userID, role); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update project member: %w", err)
+	}
+	return &member, nil
+}
+
+func (r *projectRepository) RemoveMember(projectID int, userID int) error {
+	query := `DELETE FROM project_members WHERE project_id = $1 AND user_id = $3 AND ` + projectInWorkspace
+	result, err := r.db.Exec(query, projectID, r.db.workspaceID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove project member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *projectRepository) CountOwnersForUpdate(projectID int) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(*) FROM (
+			SELECT 1 FROM project_members WHERE project_id = $1 AND role = 'owner' AND ` + projectInWorkspace + ` FOR UPDATE
+		) owners`
+	if err := r.db.Get(&count, query, projectID, r.db.workspaceID); err != nil {
+		return 0, fmt.Errorf("failed to count project owners: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type RateLimitRepository interface {
+	// Take は key のバケットを now まで補充してトークンを1つ取り出し、取り出せたかどうかと残りのトークン数を返す
+	// バケットは capacity 個のトークンで始まり、1秒あたり refillRate 個回復する
+	Take(key string, capacity float64, refillRate float64, now time.Time) (bool, float64, error)
+	// DeleteIdle は before 以降に使われていないバケットを削除する
+	DeleteIdle(before time.Time) (int64, error)
+}
+
+// refilledTokens は now（$4）まで補充したトークン数（b は rate_limit_buckets、$2 は容量、$3 は1秒あたりの回復量）
+// 複数のレプリカの時計のずれで経過時間が負にならないようにする
+const refilledTokens = `LEAST($2::float8, b.tokens + GREATEST(EXTRACT(EPOCH FROM ($4::timestamptz - b.updated_at)), 0) * $3::float8)`
+
+type rateLimitRepository struct {
+	db DBTX
+}
+
+func NewRateLimitRepository(db DBTX) RateLimitRepository {
+	return &rateLimitRepository{db: db}
+}
+
+func (r *rateLimitRepository) Take(key string, capacity float64, refillRate float64, now time.Time) (bool, float64, error) {
+	// 補充後に1つ以上残っている場合のみ取り出す（行ロックで同時リクエストを直列化する）
+	var tokens float64
+	query := `
+		INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+		VALUES ($1, $2 - 1, $4)
+		ON CONFLICT (key) DO UPDATE
+			SET tokens = ` + refilledTokens + ` - 1, updated_at = GREATEST(b.updated_at, $4)
+			WHERE ` + refilledTokens + ` >= 1
+		RETURNING tokens`
+
+	err := r.db.QueryRowx(query, key, capacity, refillRate, now).Scan(&tokens)
+	if err == nil {
+		return true, tokens, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	// トークンが足りない場合は更新されないため、補充後の残りを取得する
+	query = `SELECT ` + refilledTokens + ` FROM rate_limit_buckets b WHERE b.key = $1`
+	if err := r.db.Get(&tokens, query, key, capacity, refillRate, now); err != nil {
+		return false, 0, fmt.Errorf("failed to fetch rate limit bucket: %w", err)
+	}
+	return false, tokens, nil
+}
+
+func (r *rateLimitRepository) DeleteIdle(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM rate_limit_buckets WHERE updated_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete idle rate limit buckets: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type RefreshTokenRepository interface {
+	// GetByHashForUpdate はハッシュが一致するトークンを行ロックして取得する（同じトークンの同時使用を防ぐ）
+	GetByHashForUpdate(tokenHash string) (*models.RefreshToken, error)
+	Create(token *models.RefreshToken) (*models.RefreshToken, error)
+	// MarkUsed はローテーションで使用済みになったトークンを記録する
+	MarkUsed(id int, now time.Time) error
+	// RevokeFamily は同じログインで発行したトークンを全て失効させる
+	RevokeFamily(familyID string, now time.Time) error
+	// RevokeByUserID はユーザーの全てのトークンを失効させる（パスワード変更時など）
+	RevokeByUserID(userID int, now time.Time) error
+
+	// WithTx はトランザクション内でクエリを実行する RefreshTokenRepository を返す
+	WithTx(tx DBTX) RefreshTokenRepository
+}
+
+// refreshTokenColumns は SELECT / RETURNING で取得する refresh_tokens のカラム
+const refreshTokenColumns = `id, user_id, family_id, token_hash, expires_at, used_at, revoked_at, created_at`
+
+type refreshTokenRepository struct {
+	db DBTX
+}
+
+func NewRefreshTokenRepository(db DBTX) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) WithTx(tx DBTX) RefreshTokenRepository {
+	return &refreshTokenRepository{db: tx}
+}
+
+func (r *refreshTokenRepository) GetByHashForUpdate(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	query := `SELECT ` + refreshTokenColumns + ` FROM refresh_tokens WHERE token_hash = $1 FOR UPDATE`
+	if err := r.db.Get(&token, query, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) Create(token *models.RefreshToken) (*models.RefreshToken, error) {
+	var created models.RefreshToken
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		RETURNING ` + refreshTokenColumns
+
+	err := r.db.QueryRowx(query, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).StructScan(&created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *refreshTokenRepository) MarkUsed(id int, now time.Time) error {
+	if _, err := r.db.Exec(`UPDATE refresh_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`, now, id); err != nil {
+		return fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(familyID string, now time.Time) error {
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL`
+	if _, err := r.db.Exec(query, now, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeByUserID(userID int, now time.Time) error {
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`
+	if _, err := r.db.Exec(query, now, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateTag は同名のタグが既に存在する場合のエラー
+var ErrDuplicateTag = errors.New("duplicate tag")
+
+// uniqueViolation は PostgreSQL の一意制約違反のエラーコード
+const uniqueViolation = "23505"
+
+type TagRepository interface {
+	GetAll() ([]models.Tag, error)
+	GetByID(id int) (*models.Tag, error)
+	Create(name string) (*models.Tag, error)
+	Update(id int, name string) (*models.Tag, error)
+	Delete(id int) error
+
+	// EnsureByNames は指定した名前のタグを（存在しなければ作成して）全て返す
+	EnsureByNames(names []string) ([]models.Tag, error)
+	// SetTodoTags はTodoに付与するタグを tagIDs で置き換える
+	SetTodoTags(todoID int, tagIDs []int) error
+	// GetByTodoIDs は複数Todoのタグを1回のクエリでまとめて取得する（キーはTodoのID）
+	GetByTodoIDs(todoIDs []int) (map[int][]models.Tag, error)
+	// IsUsedByInaccessibleTodos はタグが userID のユーザーのアクセスできないTodo（ゴミ箱内を含む）に付与されているかを返す
+	IsUsedByInaccessibleTodos(id int, userID int) (bool, error)
+
+	// WithTx はトランザクション内でクエリを実行する TagRepository を返す
+	WithTx(tx DBTX) TagRepository
+	// WithWorkspace は workspaceID のワークスペースのタグのみを対象にする TagRepository を返す
+	WithWorkspace(workspaceID int) TagRepository
+}
+
+// tagColumns は SELECT / RETURNING で取得する tags のカラム
+const tagColumns = `id, name, created_at, updated_at`
+
+type tagRepository struct {
+	db workspaceDB
+}
+
+func NewTagRepository(db DBTX) TagRepository {
+	return &tagRepository{db: workspaceDB{db: db}}
+}
+
+func (r *tagRepository) WithTx(tx DBTX) TagRepository {
+	return &tagRepository{db: workspaceDB{db: tx, workspaceID: r.db.workspaceID}}
+}
+
+func (r *tagRepository) WithWorkspace(workspaceID int) TagRepository {
+	return &tagRepository{db: workspaceDB{db: r.db.db, workspaceID: workspaceID}}
+}
+
+func (r *tagRepository) GetAll() ([]models.Tag, error) {
+	var tags []models.Tag
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE workspace_id = $1 ORDER BY name ASC, id ASC`
+	if err := r.db.Select(&tags, query, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) GetByID(id int) (*models.Tag, error) {
+	var tag models.Tag
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE id = $1 AND workspace_id = $2`
+
+	err := r.db.Get(&tag, query, id, r.db.workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch tag: %w", err)
+	}
+
+	return &tag, nil
+}
+
+func (r *tagRepository) Create(name string) (*models.Tag, error) {
+	var created models.Tag
+	// 一意制約違反でトランザクションが中断しないよう、重複時は挿入せずに判定する
+	query := `
+		INSERT INTO tags (workspace_id, name, created_at, updated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (workspace_id, name) DO NOTHING
+		RETURNING ` + tagColumns
+
+	if err := r.db.Get(&created, query, r.db.workspaceID, name); err != nil {
+		if err == sql.ErrNoRows || isUniqueViolation(err) {
+			return nil, ErrDuplicateTag
+		}
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *tagRepository) Update(id int, name string) (*models.Tag, error) {
+	var updated models.Tag
+	query := `
+		UPDATE tags SET name = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND workspace_id = $3
+			AND NOT EXISTS (SELECT 1 FROM tags WHERE workspace_id = $3 AND name = $1 AND id <> $2)
+		RETURNING ` + tagColumns
+
+	if err := r.db.Get(&updated, query, name, id, r.db.workspaceID); err != nil {
+		if err == sql.ErrNoRows {
+			// 対象が存在しないのか、同名のタグがあるのかを区別する
+			existing, getErr := r.GetByID(id)
+			if getErr != nil {
+				return nil, getErr
+			}
+			if existing != nil {
+				return nil, ErrDuplicateTag
+			}
+			return nil, nil
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateTag
+		}
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// Delete はタグを削除する（Todoとの関連は ON DELETE CASCADE で削除される）
+func (r *tagRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM tags WHERE id = $1 AND workspace_id = $2`, id, r.db.workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *tagRepository) IsUsedByInaccessibleTodos(id int, userID int) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM todo_tags tt
+			JOIN todos t ON t.id = tt.todo_id
+			WHERE tt.tag_id = $1 AND t.workspace_id = $2 AND NOT ` + accessibleTodoCondition("t", "$3") + `
+		)`
+
+	var used bool
+	if err := r.db.Get(&used, query, id, r.db.workspaceID, userID); err != nil {
+		return false, fmt.Errorf("failed to check tag usage: %w", err)
+	}
+	return used, nil
+}
+
+func (r *tagRepository) EnsureByNames(names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	// 既存のタグは更新せず、存在しないものだけ作成する
+	insert := `
+		INSERT INTO tags (workspace_id, name, created_at, updated_at)
+		SELECT $1, name, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP FROM UNNEST($2::text[]) AS name
+		ON CONFLICT (workspace_id, name) DO NOTHING`
+	if _, err := r.db.Exec(insert, r.db.workspaceID, pq.Array(names)); err != nil {
+		return nil, fmt.Errorf("failed to create tags: %w", err)
+	}
+
+	var tags []models.Tag
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE workspace_id = $1 AND name = ANY($2) ORDER BY name ASC, id ASC`
+	if err := r.db.Select(&tags, query, r.db.workspaceID, pq.Array(names)); err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+	return tags, nil
+}
+
+// SetTodoTags は todo_tags に RLS がないため、Todoとタグがワークスペース内にあることを確認して更新する
+func (r *tagRepository) SetTodoTags(todoID int, tagIDs []int) error {
+	remove := `
+		DELETE FROM todo_tags
+		WHERE todo_id = $1 AND NOT (tag_id = ANY($2))
+			AND EXISTS (SELECT 1 FROM todos WHERE id = $1 AND workspace_id = $3)`
+	if _, err := r.db.Exec(remove, todoID, pq.Array(tagIDs), r.db.workspaceID); err != nil {
+		return fmt.Errorf("failed to remove todo tags: %w", err)
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	insert := `
+		INSERT INTO todo_tags (todo_id, tag_id)
+		SELECT $1, t.id FROM tags t
+		WHERE t.id = ANY($2) AND t.workspace_id = $3
+			AND EXISTS (SELECT 1 FROM todos WHERE id = $1 AND workspace_id = $3)
+		ON CONFLICT DO NOTHING`
+	if _, err := r.db.Exec(insert, todoID, pq.Array(tagIDs), r.db.workspaceID); err != nil {
+		return fmt.Errorf("failed to add todo tags: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) GetByTodoIDs(todoIDs []int) (map[int][]models.Tag, error) {
+	result := map[int][]models.Tag{}
+	if len(todoIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		TodoID int `db:"todo_id"`
+		models.Tag
+	}
+	query := `
+		SELECT tt.todo_id, t.id, t.name, t.created_at, t.updated_at
+		FROM todo_tags tt JOIN tags t ON t.id = tt.tag_id
+		WHERE tt.todo_id = ANY($1) AND t.workspace_id = $2
+		ORDER BY t.name ASC, t.id ASC`
+	if err := r.db.Select(&rows, query, pq.Array(todoIDs), r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to fetch todo tags: %w", err)
+	}
+
+	for _, row := range rows {
+		result[row.TodoID] = append(result[row.TodoID], row.Tag)
+	}
+	return result, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
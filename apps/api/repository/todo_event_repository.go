@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"api/app/models"
+	"fmt"
+	"strings"
+)
+
+type TodoEventRepository interface {
+	// Create は変更履歴をまとめて記録する（変更と同じトランザクションで呼び出す）
+	Create(events []models.TodoEvent) error
+	// GetByTodoID はTodoの変更履歴を新しい順に取得する
+	GetByTodoID(todoID int, query models.TodoEventQuery) (*models.TodoEventPage, error)
+
+	// WithTx はトランザクション内でクエリを実行する TodoEventRepository を返す
+	WithTx(tx DBTX) TodoEventRepository
+}
+
+// todoEventColumns は SELECT で取得する todo_events のカラム
+const todoEventColumns = `id, todo_id, event_type, actor_id, changes, created_at`
+
+type todoEventRepository struct {
+	db DBTX
+}
+
+func NewTodoEventRepository(db DBTX) TodoEventRepository {
+	return &todoEventRepository{db: db}
+}
+
+func (r *todoEventRepository) WithTx(tx DBTX) TodoEventRepository {
+	return &todoEventRepository{db: tx}
+}
+
+func (r *todoEventRepository) Create(events []models.TodoEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var args queryArgs
+	values := make([]string, len(events))
+	for i, event := range events {
+		values[i] = "(" + strings.Join([]string{
+			args.add(event.TodoID),
+			args.add(event.Type),
+			args.add(event.ActorID),
+			args.add(event.Changes),
+		}, ", ") + ", CURRENT_TIMESTAMP)"
+	}
+
+	query := `INSERT INTO todo_events (todo_id, event_type, actor_id, changes, created_at) VALUES ` + strings.Join(values, ", ")
+	if _, err := r.db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to create todo events: %w", err)
+	}
+	return nil
+}
+
+func (r *todoEventRepository) GetByTodoID(todoID int, query models.TodoEventQuery) (*models.TodoEventPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultTodoListLimit
+	}
+
+	var args queryArgs
+	conditions := []string{"todo_id = " + args.add(todoID)}
+	if query.Cursor != nil {
+		conditions = append(conditions, "id < "+args.add(query.Cursor.ID))
+	}
+
+	sqlQuery := `SELECT ` + todoEventColumns + ` FROM todo_events
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY id DESC
+		LIMIT ` + args.add(limit+1)
+
+	var events []models.TodoEvent
+	if err := r.db.Select(&events, sqlQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to fetch todo events: %w", err)
+	}
+
+	page := &models.TodoEventPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.HasMore = true
+		page.NextCursor = &models.TodoEventCursor{ID: page.Events[limit-1].ID}
+	}
+	return page, nil
+}
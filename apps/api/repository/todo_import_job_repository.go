@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTodoImportJobClaimLost は別のワーカーに引き継がれた（または完了した）ジョブを更新しようとした場合のエラー
+var ErrTodoImportJobClaimLost = errors.New("todo import job claim lost")
+
+type TodoImportJobRepository interface {
+	Create(job *models.TodoImportJob) (*models.TodoImportJob, error)
+	// GetByID はジョブを取得する（payload は読み込まない）
+	GetByID(id int) (*models.TodoImportJob, error)
+	// ClaimNext は待機中のジョブ、または staleAfter 以上 heartbeat_at が更新されていない処理中のジョブを1件処理中にして返す
+	// 対象のジョブが無い場合は nil を返す
+	// 取得するたびに新しい claim_token を発行し、RecordProgress・Finish はその値で取得したワーカーを確認する
+	ClaimNext(now time.Time, staleAfter time.Duration) (*models.TodoImportJob, error)
+	// RecordProgress は登録済みの行数を記録する（登録と同じトランザクションで呼び出す）
+	// claimToken のワーカーが処理中でない場合は ErrTodoImportJobClaimLost を返す（トランザクションをロールバックする）
+	RecordProgress(id int, claimToken string, importedRows int, now time.Time) error
+	// Finish はジョブを完了にし、payload を削除する
+	// claimToken のワーカーが処理中でない場合は ErrTodoImportJobClaimLost を返す
+	Finish(id int, claimToken string, status models.TodoImportJobStatus, errors []models.TodoImportRowError, now time.Time) error
+
+	// WithTx はトランザクション内でクエリを実行する TodoImportJobRepository を返す
+	WithTx(tx DBTX) TodoImportJobRepository
+	// WithWorkspace は workspaceID のワークスペースのジョブのみを対象にする TodoImportJobRepository を返す
+	WithWorkspace(workspaceID int) TodoImportJobRepository
+}
+
+// todoImportJobColumns は SELECT / RETURNING で取得する todo_import_jobs のカラム（payload を除く）
+const todoImportJobColumns = `id, workspace_id, user_id, status, total_rows, imported_rows, errors, created_at, started_at, heartbeat_at, finished_at`
+
+type todoImportJobRepository struct {
+	db workspaceDB
+}
+
+func NewTodoImportJobRepository(db DBTX) TodoImportJobRepository {
+	return &todoImportJobRepository{db: workspaceDB{db: db}}
+}
+
+func (r *todoImportJobRepository) WithTx(tx DBTX) TodoImportJobRepository {
+	return &todoImportJobRepository{db: workspaceDB{db: tx, workspaceID: r.db.workspaceID}}
+}
+
+func (r *todoImportJobRepository) WithWorkspace(workspaceID int) TodoImportJobRepository {
+	return &todoImportJobRepository{db: workspaceDB{db: r.db.db, workspaceID: workspaceID}}
+}
+
+func (r *todoImportJobRepository) Create(job *models.TodoImportJob) (*models.TodoImportJob, error) {
+	var created models.TodoImportJob
+	query := `
+		INSERT INTO todo_import_jobs (workspace_id, user_id, status, payload, total_rows)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + todoImportJobColumns
+
+	err := r.db.Get(&created, query, r.db.workspaceID, job.UserID, models.TodoImportJobPending, job.Payload, len(job.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create todo import job: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *todoImportJobRepository) GetByID(id int) (*models.TodoImportJob, error) {
+	var job models.TodoImportJob
+	query := `SELECT ` + todoImportJobColumns + ` FROM todo_import_jobs WHERE id = $1 AND workspace_id = $2`
+
+	err := r.db.Get(&job, query, id, r.db.workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch todo import job: %w", err)
+	}
+	return &job, nil
+}
+
+// ClaimNext は FOR UPDATE SKIP LOCKED により、複数レプリカで同時に実行しても同じジョブを二重に取得しない
+func (r *todoImportJobRepository) ClaimNext(now time.Time, staleAfter time.Duration) (*models.TodoImportJob, error) {
+	var job models.TodoImportJob
+	query := `
+		UPDATE todo_import_jobs SET status = 'running', started_at = COALESCE(started_at, $1), heartbeat_at = $1, claim_token = gen_random_uuid()
+		WHERE id = (
+			SELECT id FROM todo_import_jobs
+			WHERE workspace_id = $3
+				AND (status = 'pending' OR (status = 'running' AND heartbeat_at <= $1 - make_interval(secs => $2)))
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + todoImportJobColumns + `, payload, claim_token`
+
+	err := r.db.Get(&job, query, now, staleAfter.Seconds(), r.db.workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim todo import job: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *todoImportJobRepository) RecordProgress(id int, claimToken string, importedRows int, now time.Time) error {
+	query := `
+		UPDATE todo_import_jobs SET imported_rows = $1, heartbeat_at = $2
+		WHERE id = $3 AND workspace_id = $4 AND status = 'running' AND claim_token = $5`
+	result, err := r.db.Exec(query, importedRows, now, id, r.db.workspaceID, claimToken)
+	if err != nil {
+		return fmt.Errorf("failed to record todo import progress: %w", err)
+	}
+	return claimedRowAffected(result)
+}
+
+func (r *todoImportJobRepository) Finish(id int, claimToken string, status models.TodoImportJobStatus, errors []models.TodoImportRowError, now time.Time) error {
+	query := `
+		UPDATE todo_import_jobs SET status = $1, errors = $2, payload = NULL, heartbeat_at = $3, finished_at = $3, claim_token = NULL
+		WHERE id = $4 AND workspace_id = $5 AND status = 'running' AND claim_token = $6`
+	result, err := r.db.Exec(query, status, models.TodoImportRowErrors(errors), now, id, r.db.workspaceID, claimToken)
+	if err != nil {
+		return fmt.Errorf("failed to finish todo import job: %w", err)
+	}
+	return claimedRowAffected(result)
+}
+
+// claimedRowAffected は更新した行が無い（別のワーカーに引き継がれた）場合に ErrTodoImportJobClaimLost を返す
+func claimedRowAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrTodoImportJobClaimLost
+	}
+	return nil
+}
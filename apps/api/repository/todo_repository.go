@@ -2,46 +2,311 @@ package repository
 
 import (
 	"api/app/models"
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 var ErrNoRows = sql.ErrNoRows
 
 type TodoRepository interface {
-	GetAll() ([]models.Todo, error)
+	GetAll(query models.TodoListQuery) (*models.TodoPage, error)
+	// Export は query の条件・並び順に一致するTodoを全件、batchSize 件ずつ fn に渡す（ページネーションの Limit は無視する）
+	// fn にはカーソルを開いているトランザクションを渡すため、タグなどの追加の読み込みは tx 上で行う。ctx が終了すると次の読み込みの前に中断する
+	Export(ctx context.Context, query models.TodoListQuery, batchSize int, fn func(tx DBTX, todos []models.Todo) error) error
 	GetByID(id int) (*models.Todo, error)
-	Create(title string, description string, priority models.TodoPriority) (*models.Todo, error)
-	Update(id int, title string, description string, priority models.TodoPriority, completed *bool) (*models.Todo, error)
-	Delete(id int) error
+	// GetByIDForUpdate はトランザクション内で行ロックを取得してTodoを返す
+	GetByIDForUpdate(id int) (*models.Todo, error)
+	Search(query models.TodoSearchQuery) ([]models.TodoSearchResult, error)
+	Create(todo *models.Todo) (*models.Todo, error)
+	// CreateBatch は todos を1回のクエリでまとめて作成し、作成したTodoを todos と同じ順で返す
+	CreateBatch(todos []*models.Todo) ([]models.Todo, error)
+	Update(id int, update models.TodoUpdate) (*models.Todo, error)
+	// Delete はTodoを子孫ごとゴミ箱に移動し、移動したTodoを返す
+	Delete(id int) ([]models.Todo, error)
+	// DeleteByProject はプロジェクトのTodoを子孫ごとゴミ箱に移動し、移動したTodoを返す
+	DeleteByProject(projectID int) ([]models.Todo, error)
+	// MoveProjectTodosToInbox はプロジェクトのTodo（ゴミ箱内を除く）をインボックスに移動し、移動したTodoのIDを返す
+	MoveProjectTodosToInbox(projectID int) ([]int, error)
+	// BumpVersionByTag はタグが付与されたTodo（ゴミ箱内を含む）の version を上げる（タグの名前の変更・削除でTodoの表現が変わるため）
+	BumpVersionByTag(tagID int) error
+	ClaimDueReminders(now time.Time, leadTime time.Duration, limit int) ([]models.Todo, error)
+	ReleaseReminder(id int) error
+	// SetNextOccurrence は繰り返しTodoに、完了時に生成した次回のTodoを記録する
+	SetNextOccurrence(id int, nextID int) error
+
+	// 親子関係（サブタスク）
+	GetChildren(parentID int) ([]models.Todo, error)
+	GetDescendants(rootIDs []int) ([]models.Todo, error)
+	// GetDescendantsWithDeleted はゴミ箱内を含む子孫Todoを取得する（Purge で一緒に削除されるTodo）
+	GetDescendantsWithDeleted(id int) ([]models.Todo, error)
+	// GetProjectDescendants はプロジェクトのTodoの子孫のうち、別のプロジェクト・インボックスにあるもの（ゴミ箱内を除く）を取得する
+	GetProjectDescendants(projectID int) ([]models.Todo, error)
+	IsDescendant(ancestorID int, id int) (bool, error)
+	CountOpenDescendants(id int) (int, error)
+	// CompleteDescendants は未完了の子孫Todoを完了にし、完了にしたTodoのIDを返す
+	CompleteDescendants(id int) ([]int, error)
+
+	// ゴミ箱（論理削除したTodo）
+	GetTrash(query models.TrashListQuery) (*models.TrashPage, error)
+	GetDeletedByID(id int) (*models.Todo, error)
+	// Restore はゴミ箱内のTodoを一緒に削除された子孫とともに戻し、戻したTodoを返す
+	Restore(id int) ([]models.Todo, error)
+	Purge(id int) error
+	PurgeDeletedBefore(cutoff time.Time) (int64, error)
+
+	// WithTx はトランザクション内でクエリを実行する TodoRepository を返す
+	WithTx(tx DBTX) TodoRepository
+	// WithWorkspace は workspaceID のワークスペースのTodoのみを対象にする TodoRepository を返す
+	// ワークスペースを指定していない TodoRepository のメソッドは ErrWorkspaceRequired を返す
+	WithWorkspace(workspaceID int) TodoRepository
 }
 
+// todoColumns は SELECT / RETURNING で取得する todos のカラム
+const todoColumns = `id, title, description, completed, priority, parent_id, owner_id, project_id, due_at, remind_at, reminded_at, created_at, updated_at, deleted_at,
+	recurrence_rule, recurrence_timezone, recurrence_start, next_occurrence_id, version`
+
+// descendantsCTE は $1 の子孫Todo（ゴミ箱内を除く）のIDを、$2 のワークスペース内で再帰的に列挙する
+// UNION により既存データに循環があっても無限ループしない
+const descendantsCTE = `
+	WITH RECURSIVE descendants AS (
+		SELECT id FROM todos WHERE parent_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+		UNION
+		SELECT t.id FROM todos t JOIN descendants d ON t.parent_id = d.id WHERE t.workspace_id = $2 AND t.deleted_at IS NULL
+	)`
+
 type todoRepository struct {
-	db *sqlx.DB
+	db workspaceDB
+}
+
+func NewTodoRepository(db DBTX) TodoRepository {
+	return &todoRepository{db: workspaceDB{db: db}}
+}
+
+func (r *todoRepository) WithTx(tx DBTX) TodoRepository {
+	return &todoRepository{db: workspaceDB{db: tx, workspaceID: r.db.workspaceID}}
+}
+
+func (r *todoRepository) WithWorkspace(workspaceID int) TodoRepository {
+	return &todoRepository{db: workspaceDB{db: r.db.db, workspaceID: workspaceID}}
+}
+
+// sortColumn は並び替え可能な項目に対応するSQL式とカーソル上の値
+type sortColumn struct {
+	expr  string
+	value func(cursor *models.TodoCursor) interface{}
+}
+
+// todoSortColumns は並び替え可能なカラムのホワイトリスト
+// ORDER BY にはここに定義されたSQL式以外を埋め込まない
+var todoSortColumns = map[models.TodoSortField]sortColumn{
+	models.TodoSortCreatedAt: {
+		expr:  "created_at",
+		value: func(c *models.TodoCursor) interface{} { return c.CreatedAt },
+	},
+	models.TodoSortUpdatedAt: {
+		expr:  "updated_at",
+		value: func(c *models.TodoCursor) interface{} { return c.UpdatedAt },
+	},
+	models.TodoSortPriority: {
+		expr:  "CASE priority WHEN 'low' THEN 1 WHEN 'high' THEN 3 ELSE 2 END",
+		value: func(c *models.TodoCursor) interface{} { return models.PriorityRank(c.Priority) },
+	},
+	models.TodoSortTitle: {
+		expr:  "title",
+		value: func(c *models.TodoCursor) interface{} { return c.Title },
+	},
 }
 
-func NewTodoRepository(db *sqlx.DB) TodoRepository {
-	return &todoRepository{db: db}
+// idSortColumn は同値のレコードの順序を安定させるためのタイブレーク
+var idSortColumn = sortColumn{
+	expr:  "id",
+	value: func(c *models.TodoCursor) interface{} { return c.ID },
 }
 
-func (r *todoRepository) GetAll() ([]models.Todo, error) {
+// queryArgs はプレースホルダ番号を採番しながら引数を積み上げる
+type queryArgs []interface{}
+
+func (a *queryArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+func (r *todoRepository) GetAll(query models.TodoListQuery) (*models.TodoPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultTodoListLimit
+	}
+
+	sqlQuery, args, err := listTodosQuery(query, r.db.workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	// 次ページの有無を判定するため1件多く取得する
+	sqlQuery += " LIMIT " + args.add(limit+1)
+
 	var todos []models.Todo
-	query := `SELECT id, title, description, completed, priority, created_at, updated_at FROM todos ORDER BY created_at DESC`
-	err := r.db.Select(&todos, query)
+	err = r.db.Select(&todos, sqlQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch todos: %w", err)
 	}
 
-	return todos, nil
+	page := &models.TodoPage{Todos: todos}
+	if len(todos) > limit {
+		page.Todos = todos[:limit]
+		page.HasMore = true
+		page.NextCursor = models.NewTodoCursor(page.Todos[limit-1], query.SortOrDefault())
+	}
+
+	return page, nil
+}
+
+// todoExportStatementTimeout はエクスポート中の1回の読み込み（FETCH・タグの読み込み）に許す時間
+const todoExportStatementTimeout = 30 * time.Second
+
+// todoExportIdleTimeout はエクスポート中に fn（クライアントへの書き出し）を待つ間、トランザクションを開いたままにできる時間
+// 受信の止まったクライアントが接続を idle in transaction のまま占有し続けないよう、超えた場合は PostgreSQL がセッションを切る
+const todoExportIdleTimeout = time.Minute
+
+// Export は query の条件・並び順に一致するTodoを全件（Limit は無視する）、サーバー側カーソルで batchSize 件ずつ fn に渡す
+// 全件をメモリに読み込まずに出力できるよう、fn の呼び出し中もトランザクションとカーソルを開いたままにする
+// 接続を長く占有しないよう、ctx の終了・読み込みの時間・fn の待ち時間のいずれかで打ち切る
+func (r *todoRepository) Export(ctx context.Context, query models.TodoListQuery, batchSize int, fn func(tx DBTX, todos []models.Todo) error) error {
+	sqlQuery, args, err := listTodosQuery(query, r.db.workspaceID)
+	if err != nil {
+		return err
+	}
+
+	return r.db.run(func(db DBTX) error {
+		if _, err := db.Exec(fmt.Sprintf(`SET LOCAL statement_timeout = %d`, todoExportStatementTimeout.Milliseconds())); err != nil {
+			return fmt.Errorf("failed to set todo export statement timeout: %w", err)
+		}
+		if _, err := db.Exec(fmt.Sprintf(`SET LOCAL idle_in_transaction_session_timeout = %d`, todoExportIdleTimeout.Milliseconds())); err != nil {
+			return fmt.Errorf("failed to set todo export idle timeout: %w", err)
+		}
+		if _, err := db.Exec(`DECLARE todo_export NO SCROLL CURSOR FOR `+sqlQuery, args...); err != nil {
+			return fmt.Errorf("failed to declare todo export cursor: %w", err)
+		}
+		fetch := fmt.Sprintf(`FETCH FORWARD %d FROM todo_export`, batchSize)
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var todos []models.Todo
+			if err := db.Select(&todos, fetch); err != nil {
+				return fmt.Errorf("failed to fetch todos for export: %w", err)
+			}
+			if len(todos) == 0 {
+				break
+			}
+			if err := fn(db, todos); err != nil {
+				return err
+			}
+		}
+		if _, err := db.Exec(`CLOSE todo_export`); err != nil {
+			return fmt.Errorf("failed to close todo export cursor: %w", err)
+		}
+		return nil
+	})
+}
+
+// listTodosQuery は一覧の条件・カーソル・並び順から workspaceID のワークスペースのTodoを取得するSQLを組み立てる（LIMIT は呼び出し元で付ける）
+func listTodosQuery(query models.TodoListQuery, workspaceID int) (string, queryArgs, error) {
+	sorts := query.SortOrDefault()
+	columns := make([]sortColumn, 0, len(sorts)+1)
+	directions := make([]bool, 0, len(sorts)+1)
+	for _, sort := range sorts {
+		column, ok := todoSortColumns[sort.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported sort field: %s", sort.Field)
+		}
+		columns = append(columns, column)
+		directions = append(directions, sort.Desc)
+	}
+	// id は最後の並び替えキーと同じ向きでタイブレークする
+	columns = append(columns, idSortColumn)
+	directions = append(directions, directions[len(directions)-1])
+
+	var args queryArgs
+	// ゴミ箱内のTodoは除外する
+	conditions := []string{"workspace_id = " + args.add(workspaceID), "deleted_at IS NULL"}
+
+	// ツリー表示ではルート（親を持たない）Todoのみをページングする
+	if query.RootsOnly {
+		conditions = append(conditions, "parent_id IS NULL")
+	}
+	if query.UserID != nil {
+		conditions = append(conditions, accessibleTodoCondition("", args.add(*query.UserID)))
+	}
+	if query.ProjectID != nil {
+		conditions = append(conditions, "project_id = "+args.add(*query.ProjectID))
+	}
+	if query.Completed != nil {
+		conditions = append(conditions, "completed = "+args.add(*query.Completed))
+	}
+	if len(query.Priorities) > 0 {
+		priorities := make([]string, len(query.Priorities))
+		for i, p := range query.Priorities {
+			priorities[i] = string(p)
+		}
+		conditions = append(conditions, "priority = ANY("+args.add(pq.Array(priorities))+")")
+	}
+	if query.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= "+args.add(*query.CreatedAfter))
+	}
+	if query.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < "+args.add(*query.CreatedBefore))
+	}
+	if len(query.Tags) > 0 {
+		// 指定した全てのタグが付いたTodoに絞り込む
+		conditions = append(conditions, `id IN (
+			SELECT tt.todo_id FROM todo_tags tt JOIN tags t ON t.id = tt.tag_id
+			WHERE t.name = ANY(`+args.add(pq.Array(query.Tags))+`)
+			GROUP BY tt.todo_id
+			HAVING COUNT(DISTINCT t.id) = `+args.add(len(query.Tags))+`)`)
+	}
+
+	// カーソル以降のみ取得する
+	// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... の形で並び順ごとの向きに対応する
+	if query.Cursor != nil {
+		var keyset []string
+		for i, column := range columns {
+			var terms []string
+			for _, prev := range columns[:i] {
+				terms = append(terms, prev.expr+" = "+args.add(prev.value(query.Cursor)))
+			}
+			op := ">"
+			if directions[i] {
+				op = "<"
+			}
+			terms = append(terms, column.expr+" "+op+" "+args.add(column.value(query.Cursor)))
+			keyset = append(keyset, "("+strings.Join(terms, " AND ")+")")
+		}
+		conditions = append(conditions, "("+strings.Join(keyset, " OR ")+")")
+	}
+
+	orderBy := make([]string, len(columns))
+	for i, column := range columns {
+		orderBy[i] = column.expr + " ASC"
+		if directions[i] {
+			orderBy[i] = column.expr + " DESC"
+		}
+	}
+
+	sqlQuery := `SELECT ` + todoColumns + ` FROM todos WHERE ` + strings.Join(conditions, " AND ")
+	sqlQuery += " ORDER BY " + strings.Join(orderBy, ", ")
+	return sqlQuery, args, nil
 }
 
 func (r *todoRepository) GetByID(id int) (*models.Todo, error) {
 	var todo models.Todo
-	query := `SELECT id, title, description, completed, priority, created_at, updated_at FROM todos WHERE id = $1`
+	query := `SELECT ` + todoColumns + ` FROM todos WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL`
 
-	err := r.db.Get(&todo, query, id)
+	err := r.db.Get(&todo, query, id, r.db.workspaceID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -52,62 +317,196 @@ func (r *todoRepository) GetByID(id int) (*models.Todo, error) {
 	return &todo, nil
 }
 
-func (r *todoRepository) Create(title, description string, priority models.TodoPriority) (*models.Todo, error) {
+func (r *todoRepository) GetByIDForUpdate(id int) (*models.Todo, error) {
 	var todo models.Todo
+	query := `SELECT ` + todoColumns + ` FROM todos WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL FOR UPDATE`
+
+	err := r.db.Get(&todo, query, id, r.db.workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch todo: %w", err)
+	}
+
+	return &todo, nil
+}
+
+func (r *todoRepository) Search(query models.TodoSearchQuery) ([]models.TodoSearchResult, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultTodoListLimit
+	}
+
+	// todo_search_query / search_vector は日本語をbigramに分割して照合する（migrations/000004 参照）
+	// bigramの前方一致では、検索語が日本語などの1文字の場合に文字の連続の末尾にある文字（「牛乳を買う」の「う」）が見つからない。
+	// その場合に限り、search_vector と同じ正規化をしたタイトル・説明の部分一致でも照合する（インデックスを使わないため英数字や2文字以上には使わない）
+	sqlQuery := `
+		SELECT ` + todoColumns + `,
+			ts_rank_cd(search_vector, q) AS rank
+		FROM todos, todo_search_query($1) AS q,
+			(SELECT CASE WHEN char_length(token) = 1 AND token !~ '^[0-9a-z]$' THEN '%' || token || '%' END AS pattern
+				FROM todo_search_tokens($1) AS token) AS single_char
+		WHERE (search_vector @@ q
+				OR lower(normalize(title, NFKC)) LIKE single_char.pattern
+				OR lower(normalize(description, NFKC)) LIKE single_char.pattern)
+			AND workspace_id = $4 AND deleted_at IS NULL
+			AND ($3::int IS NULL OR ` + accessibleTodoCondition("", "$3") + `)
+		ORDER BY rank DESC, id DESC
+		LIMIT $2`
+
+	var results []models.TodoSearchResult
+	err := r.db.Select(&results, sqlQuery, query.Q, limit, query.UserID, r.db.workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search todos: %w", err)
+	}
+
+	return results, nil
+}
+
+func (r *todoRepository) Create(todo *models.Todo) (*models.Todo, error) {
+	var created models.Todo
 
 	// Validate priority
+	priority := todo.Priority
 	if priority == "" {
 		priority = models.PriorityMedium
 	}
 
 	query := `
-		INSERT INTO todos (title, description, completed, priority, created_at, updated_at) 
-		VALUES ($1, $2, false, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) 
-		RETURNING id, title, description, completed, priority, created_at, updated_at`
+		INSERT INTO todos (title, description, completed, priority, parent_id, owner_id, project_id, due_at, remind_at,
+			recurrence_rule, recurrence_timezone, recurrence_start, workspace_id, created_at, updated_at) 
+		VALUES ($1, $2, false, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) 
+		RETURNING ` + todoColumns
 
-	err := r.db.QueryRowx(query, title, description, priority).StructScan(&todo)
+	err := r.db.Get(&created, query, todo.Title, todo.Description, priority, todo.ParentID, todo.OwnerID, todo.ProjectID, todo.DueAt, todo.RemindAt,
+		todo.RecurrenceRule, todo.RecurrenceTimezone, todo.RecurrenceStart, r.db.workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create todo: %w", err)
 	}
 
-	return &todo, nil
+	return &created, nil
 }
 
-func (r *todoRepository) Update(id int, title, description string, priority models.TodoPriority, completed *bool) (*models.Todo, error) {
+func (r *todoRepository) CreateBatch(todos []*models.Todo) ([]models.Todo, error) {
+	if len(todos) == 0 {
+		return []models.Todo{}, nil
+	}
+
+	var args queryArgs
+	workspaceID := args.add(r.db.workspaceID)
+	values := make([]string, len(todos))
+	for i, todo := range todos {
+		priority := todo.Priority
+		if priority == "" {
+			priority = models.PriorityMedium
+		}
+		values[i] = "(" + strings.Join([]string{
+			args.add(todo.Title),
+			args.add(todo.Description),
+			"false",
+			args.add(priority),
+			args.add(todo.ParentID),
+			args.add(todo.OwnerID),
+			args.add(todo.ProjectID),
+			args.add(todo.DueAt),
+			args.add(todo.RemindAt),
+			args.add(todo.RecurrenceRule),
+			args.add(todo.RecurrenceTimezone),
+			args.add(todo.RecurrenceStart),
+			workspaceID,
+		}, ", ") + ", CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)"
+	}
+
+	// id は VALUES の順に採番されるため、id 順に並べて todos と対応させる
+	query := `
+		WITH inserted AS (
+			INSERT INTO todos (title, description, completed, priority, parent_id, owner_id, project_id, due_at, remind_at,
+				recurrence_rule, recurrence_timezone, recurrence_start, workspace_id, created_at, updated_at)
+			VALUES ` + strings.Join(values, ", ") + `
+			RETURNING ` + todoColumns + `
+		)
+		SELECT * FROM inserted ORDER BY id`
+
+	var created []models.Todo
+	if err := r.db.Select(&created, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to create todos: %w", err)
+	}
+	return created, nil
+}
+
+func (r *todoRepository) Update(id int, update models.TodoUpdate) (*models.Todo, error) {
 	// Build dynamic update query
-	query := `UPDATE todos SET updated_at = CURRENT_TIMESTAMP`
+	query := `UPDATE todos SET updated_at = CURRENT_TIMESTAMP, version = version + 1`
 	args := []interface{}{}
 	argCount := 1
 
-	if title != "" {
+	if update.Title != "" {
 		query += fmt.Sprintf(`, title = $%d`, argCount)
-		args = append(args, title)
+		args = append(args, update.Title)
 		argCount++
 	}
 
-	if description != "" {
+	if update.Description != nil {
 		query += fmt.Sprintf(`, description = $%d`, argCount)
-		args = append(args, description)
+		args = append(args, *update.Description)
 		argCount++
 	}
 
-	if priority != "" {
+	if update.Priority != "" {
 		query += fmt.Sprintf(`, priority = $%d`, argCount)
-		args = append(args, priority)
+		args = append(args, update.Priority)
 		argCount++
 	}
 
-	if completed != nil {
+	if update.Completed != nil {
 		query += fmt.Sprintf(`, completed = $%d`, argCount)
-		args = append(args, *completed)
+		args = append(args, *update.Completed)
+		argCount++
+	}
+
+	// null が指定された項目は NULL にする
+	if update.ParentID.Set {
+		query += fmt.Sprintf(`, parent_id = $%d`, argCount)
+		args = append(args, update.ParentID.Ptr())
+		argCount++
+	}
+
+	if update.ProjectID.Set {
+		query += fmt.Sprintf(`, project_id = $%d`, argCount)
+		args = append(args, update.ProjectID.Ptr())
+		argCount++
+	}
+
+	if update.DueAt.Set {
+		query += fmt.Sprintf(`, due_at = $%d`, argCount)
+		args = append(args, update.DueAt.Ptr())
+		argCount++
+	}
+
+	if update.RemindAt.Set {
+		query += fmt.Sprintf(`, remind_at = $%d`, argCount)
+		args = append(args, update.RemindAt.Ptr())
 		argCount++
 	}
 
-	query += fmt.Sprintf(` WHERE id = $%d RETURNING id, title, description, completed, priority, created_at, updated_at`, argCount)
-	args = append(args, id)
+	if update.RecurrenceRule != nil {
+		// 空文字の場合は繰り返しを解除する
+		query += fmt.Sprintf(`, recurrence_rule = NULLIF($%d, ''), recurrence_timezone = $%d, recurrence_start = $%d`, argCount, argCount+1, argCount+2)
+		args = append(args, *update.RecurrenceRule, update.RecurrenceTimezone, update.RecurrenceStart)
+		argCount += 3
+	}
+
+	// 期限・リマインド日時が変わったら改めてリマインドする
+	if update.DueAt.Set || update.RemindAt.Set {
+		query += `, reminded_at = NULL`
+	}
+
+	query += fmt.Sprintf(` WHERE id = $%d AND workspace_id = $%d AND deleted_at IS NULL RETURNING `+todoColumns, argCount, argCount+1)
+	args = append(args, id, r.db.workspaceID)
 
 	var todo models.Todo
-	err := r.db.QueryRowx(query, args...).StructScan(&todo)
+	err := r.db.Get(&todo, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -118,11 +517,311 @@ func (r *todoRepository) Update(id int, title, description string, priority mode
 	return &todo, nil
 }
 
-func (r *todoRepository) Delete(id int) error {
-	query := `DELETE FROM todos WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+// Delete はTodoを子孫ごとゴミ箱に移動する（論理削除）
+// 一緒に削除した子孫を Restore でまとめて戻せるよう、同じ deleted_at を記録する
+func (r *todoRepository) Delete(id int) ([]models.Todo, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM todos WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+			UNION
+			SELECT t.id FROM todos t JOIN subtree s ON t.parent_id = s.id WHERE t.workspace_id = $2 AND t.deleted_at IS NULL
+		)
+		UPDATE todos SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id IN (SELECT id FROM subtree)
+		RETURNING ` + todoColumns
+
+	var deleted []models.Todo
+	if err := r.db.Select(&deleted, query, id, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to delete todo: %w", err)
+	}
+
+	if len(deleted) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return deleted, nil
+}
+
+// DeleteByProject はプロジェクトのTodoを子孫ごとゴミ箱に移動する（論理削除）
+// 別のプロジェクトに属するサブタスクも、親と一緒にゴミ箱に移動する
+func (r *todoRepository) DeleteByProject(projectID int) ([]models.Todo, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM todos WHERE project_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+			UNION
+			SELECT t.id FROM todos t JOIN subtree s ON t.parent_id = s.id WHERE t.workspace_id = $2 AND t.deleted_at IS NULL
+		)
+		UPDATE todos SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id IN (SELECT id FROM subtree)
+		RETURNING ` + todoColumns
+
+	var deleted []models.Todo
+	if err := r.db.Select(&deleted, query, projectID, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to delete project todos: %w", err)
+	}
+	return deleted, nil
+}
+
+func (r *todoRepository) MoveProjectTodosToInbox(projectID int) ([]int, error) {
+	query := `
+		UPDATE todos SET project_id = NULL, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE project_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+		RETURNING id`
+
+	var ids []int
+	if err := r.db.Select(&ids, query, projectID, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to move project todos to inbox: %w", err)
+	}
+	return ids, nil
+}
+
+// BumpVersionByTag はタグが付与されたTodoの version を上げ、ETag を変更する
+// Todo自体は変更していないため updated_at は更新しない
+func (r *todoRepository) BumpVersionByTag(tagID int) error {
+	query := `
+		UPDATE todos SET version = version + 1
+		WHERE workspace_id = $2 AND id IN (SELECT todo_id FROM todo_tags WHERE tag_id = $1)`
+
+	if _, err := r.db.Exec(query, tagID, r.db.workspaceID); err != nil {
+		return fmt.Errorf("failed to bump todo versions: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueReminders はリマインド時刻を過ぎた未完了のTodoに reminded_at を記録し、記録したTodoを返す
+// リマインド時刻は remind_at、未設定なら due_at の leadTime 前とする
+// 送信前に記録をコミットするため、再起動や複数レプリカでも同じリマインドは二重に送信されない
+// 通知先がいない所有者なしのTodoは対象外
+func (r *todoRepository) ClaimDueReminders(now time.Time, leadTime time.Duration, limit int) ([]models.Todo, error) {
+	query := `
+		UPDATE todos SET reminded_at = $1
+		WHERE id IN (
+			SELECT id FROM todos
+			WHERE workspace_id = $4 AND completed = false AND deleted_at IS NULL
+				AND owner_id IS NOT NULL
+				AND reminded_at IS NULL
+				AND COALESCE(remind_at, due_at - make_interval(secs => $2)) <= $1
+			ORDER BY COALESCE(remind_at, due_at - make_interval(secs => $2)), id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + todoColumns
+
+	var todos []models.Todo
+	err := r.db.Select(&todos, query, now, leadTime.Seconds(), limit, r.db.workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due reminders: %w", err)
+	}
+
+	return todos, nil
+}
+
+// ReleaseReminder は送信に失敗したリマインドを次回の実行で再送できるよう未送信に戻す
+func (r *todoRepository) ReleaseReminder(id int) error {
+	query := `UPDATE todos SET reminded_at = NULL WHERE id = $1 AND workspace_id = $2`
+	if _, err := r.db.Exec(query, id, r.db.workspaceID); err != nil {
+		return fmt.Errorf("failed to release reminder: %w", err)
+	}
+	return nil
+}
+
+// SetNextOccurrence は完了と同じ更新の一部として記録するため version は増やさない
+func (r *todoRepository) SetNextOccurrence(id int, nextID int) error {
+	query := `UPDATE todos SET next_occurrence_id = $1 WHERE id = $2 AND workspace_id = $3`
+	if _, err := r.db.Exec(query, nextID, id, r.db.workspaceID); err != nil {
+		return fmt.Errorf("failed to set next occurrence: %w", err)
+	}
+	return nil
+}
+
+func (r *todoRepository) GetChildren(parentID int) ([]models.Todo, error) {
+	var todos []models.Todo
+	query := `SELECT ` + todoColumns + ` FROM todos WHERE parent_id = $1 AND workspace_id = $2 AND deleted_at IS NULL ORDER BY created_at ASC, id ASC`
+	if err := r.db.Select(&todos, query, parentID, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to fetch child todos: %w", err)
+	}
+	return todos, nil
+}
+
+// GetDescendants は rootIDs の子孫Todoを全階層分まとめて取得する（ルート自身は含まない）
+func (r *todoRepository) GetDescendants(rootIDs []int) ([]models.Todo, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		WITH RECURSIVE descendants AS (
+			SELECT id FROM todos WHERE parent_id = ANY($1) AND workspace_id = $2 AND deleted_at IS NULL
+			UNION
+			SELECT t.id FROM todos t JOIN descendants d ON t.parent_id = d.id WHERE t.workspace_id = $2 AND t.deleted_at IS NULL
+		)
+		SELECT ` + todoColumns + ` FROM todos
+		WHERE id IN (SELECT id FROM descendants)
+		ORDER BY created_at ASC, id ASC`
+
+	var todos []models.Todo
+	if err := r.db.Select(&todos, query, pq.Array(rootIDs), r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to fetch descendant todos: %w", err)
+	}
+	return todos, nil
+}
+
+func (r *todoRepository) GetDescendantsWithDeleted(id int) ([]models.Todo, error) {
+	query := `
+		WITH RECURSIVE descendants AS (
+			SELECT id FROM todos WHERE parent_id = $1 AND workspace_id = $2
+			UNION
+			SELECT t.id FROM todos t JOIN descendants d ON t.parent_id = d.id WHERE t.workspace_id = $2
+		)
+		SELECT ` + todoColumns + ` FROM todos
+		WHERE id IN (SELECT id FROM descendants)
+		ORDER BY created_at ASC, id ASC`
+
+	var todos []models.Todo
+	if err := r.db.Select(&todos, query, id, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to fetch descendant todos: %w", err)
+	}
+	return todos, nil
+}
+
+func (r *todoRepository) GetProjectDescendants(projectID int) ([]models.Todo, error) {
+	query := `
+		WITH RECURSIVE descendants AS (
+			SELECT t.id FROM todos t JOIN todos p ON t.parent_id = p.id
+			WHERE p.project_id = $1 AND p.workspace_id = $2 AND p.deleted_at IS NULL AND t.workspace_id = $2 AND t.deleted_at IS NULL
+			UNION
+			SELECT t.id FROM todos t JOIN descendants d ON t.parent_id = d.id WHERE t.workspace_id = $2 AND t.deleted_at IS NULL
+		)
+		SELECT ` + todoColumns + ` FROM todos
+		WHERE id IN (SELECT id FROM descendants) AND project_id IS DISTINCT FROM $1
+		ORDER BY created_at ASC, id ASC`
+
+	var todos []models.Todo
+	if err := r.db.Select(&todos, query, projectID, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to fetch descendant todos: %w", err)
+	}
+	return todos, nil
+}
+
+// IsDescendant は id が ancestorID の子孫かどうかを返す
+func (r *todoRepository) IsDescendant(ancestorID int, id int) (bool, error) {
+	var exists bool
+	query := descendantsCTE + ` SELECT EXISTS (SELECT 1 FROM descendants WHERE id = $3)`
+	if err := r.db.Get(&exists, query, ancestorID, r.db.workspaceID, id); err != nil {
+		return false, fmt.Errorf("failed to check todo hierarchy: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *todoRepository) CountOpenDescendants(id int) (int, error) {
+	var count int
+	query := descendantsCTE + ` SELECT COUNT(*) FROM todos WHERE id IN (SELECT id FROM descendants) AND completed = false`
+	if err := r.db.Get(&count, query, id, r.db.workspaceID); err != nil {
+		return 0, fmt.Errorf("failed to count open child todos: %w", err)
+	}
+	return count, nil
+}
+
+func (r *todoRepository) CompleteDescendants(id int) ([]int, error) {
+	query := descendantsCTE + `
+		UPDATE todos SET completed = true, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id IN (SELECT id FROM descendants) AND completed = false
+		RETURNING id`
+	var ids []int
+	if err := r.db.Select(&ids, query, id, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to complete child todos: %w", err)
+	}
+	return ids, nil
+}
+
+// GetTrash はゴミ箱内のTodoを削除日時の新しい順に取得する
+// 親と一緒に削除された子孫は親の下にあるため、親がゴミ箱内にあるTodoは含めない
+func (r *todoRepository) GetTrash(query models.TrashListQuery) (*models.TrashPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultTodoListLimit
+	}
+
+	var args queryArgs
+	conditions := []string{
+		"t.workspace_id = " + args.add(r.db.workspaceID),
+		"t.deleted_at IS NOT NULL",
+		"NOT EXISTS (SELECT 1 FROM todos p WHERE p.id = t.parent_id AND p.deleted_at IS NOT NULL)",
+	}
+	if query.UserID != nil {
+		conditions = append(conditions, accessibleTodoCondition("t", args.add(*query.UserID)))
+	}
+	if query.Cursor != nil {
+		deletedAt := args.add(query.Cursor.DeletedAt)
+		conditions = append(conditions, "(t.deleted_at < "+deletedAt+" OR (t.deleted_at = "+deletedAt+" AND t.id < "+args.add(query.Cursor.ID)+"))")
+	}
+
+	sqlQuery := `SELECT ` + prefixColumns("t", todoColumns) + ` FROM todos t
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY t.deleted_at DESC, t.id DESC
+		LIMIT ` + args.add(limit+1)
+
+	var todos []models.Todo
+	if err := r.db.Select(&todos, sqlQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to fetch trash: %w", err)
+	}
+
+	page := &models.TrashPage{Todos: todos}
+	if len(todos) > limit {
+		page.Todos = todos[:limit]
+		page.HasMore = true
+		page.NextCursor = models.NewTrashCursor(page.Todos[limit-1])
+	}
+	return page, nil
+}
+
+func (r *todoRepository) GetDeletedByID(id int) (*models.Todo, error) {
+	var todo models.Todo
+	query := `SELECT ` + todoColumns + ` FROM todos WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL`
+
+	err := r.db.Get(&todo, query, id, r.db.workspaceID)
 	if err != nil {
-		return fmt.Errorf("failed to delete todo: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch deleted todo: %w", err)
+	}
+
+	return &todo, nil
+}
+
+// Restore はゴミ箱内のTodoを、一緒に削除された子孫とともに元に戻す
+func (r *todoRepository) Restore(id int) ([]models.Todo, error) {
+	query := `
+		WITH RECURSIVE target AS (
+			SELECT id, deleted_at FROM todos WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL
+		), subtree AS (
+			SELECT id FROM target
+			UNION
+			SELECT t.id FROM todos t JOIN subtree s ON t.parent_id = s.id
+			WHERE t.workspace_id = $2 AND t.deleted_at = (SELECT deleted_at FROM target)
+		)
+		UPDATE todos SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id IN (SELECT id FROM subtree)
+		RETURNING ` + todoColumns
+
+	var restored []models.Todo
+	if err := r.db.Select(&restored, query, id, r.db.workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to restore todo: %w", err)
+	}
+
+	if len(restored) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return restored, nil
+}
+
+// Purge はゴミ箱内のTodoを完全に削除する（子孫は ON DELETE CASCADE で削除される）
+func (r *todoRepository) Purge(id int) error {
+	result, err := r.db.Exec(`DELETE FROM todos WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL`, id, r.db.workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to purge todo: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -136,3 +835,37 @@ func (r *todoRepository) Delete(id int) error {
 
 	return nil
 }
+
+// PurgeDeletedBefore は cutoff より前にゴミ箱に移動したTodoを完全に削除し、削除件数を返す
+func (r *todoRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM todos WHERE workspace_id = $1 AND deleted_at < $2`, r.db.workspaceID, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired todos: %w", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return purged, nil
+}
+
+// prefixColumns は "a, b" 形式のカラム一覧の各カラムにテーブル別名を付ける
+func prefixColumns(alias string, columns string) string {
+	parts := strings.Split(columns, ",")
+	for i, part := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// accessibleTodoCondition は userParam のユーザーがアクセスできるTodo（自分のインボックスのTodoと、メンバーになっているプロジェクトのTodo）の条件を返す
+// alias が空でない場合は todos のテーブル別名として使う
+func accessibleTodoCondition(alias string, userParam string) string {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+	return "((" + prefix + "project_id IS NULL AND " + prefix + "owner_id = " + userParam + ") OR " +
+		prefix + "project_id IN (SELECT project_id FROM project_members WHERE user_id = " + userParam + "))"
+}
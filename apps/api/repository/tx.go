@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DBTX は *sqlx.DB と *sqlx.Tx の両方が満たすクエリ実行インターフェース
+// Repository はこれを介してクエリを実行するため、トランザクション内外で同じ実装を使える
+type DBTX interface {
+	sqlx.Ext
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+}
+
+// TxManager はトランザクション境界を管理する
+type TxManager interface {
+	// WithinTx は fn をトランザクション内で実行する
+	// fn がエラーを返した場合はロールバックし、そのエラーを返す
+	WithinTx(fn func(tx DBTX) error) error
+}
+
+type txManager struct {
+	db *sqlx.DB
+}
+
+func NewTxManager(db *sqlx.DB) TxManager {
+	return &txManager{db: db}
+}
+
+func (m *txManager) WithinTx(fn func(tx DBTX) error) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("failed to rollback transaction: %v (original error: %w)", rbErr, err)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// savepointTxManager は既存のトランザクション内で WithinTx をセーブポイントとして実行する
+// 複数のUsecase呼び出しを1つのトランザクションにまとめる場合に使う
+type savepointTxManager struct {
+	tx  DBTX
+	seq *int
+}
+
+// NewSavepointTxManager は tx 内で入れ子のトランザクション境界を扱う TxManager を作成する
+// fn がエラーを返した場合はセーブポイントまで戻し、tx 自体は継続して使える
+func NewSavepointTxManager(tx DBTX) TxManager {
+	return &savepointTxManager{tx: tx, seq: new(int)}
+}
+
+func (m *savepointTxManager) WithinTx(fn func(tx DBTX) error) error {
+	*m.seq++
+	name := fmt.Sprintf("sp_%d", *m.seq)
+
+	if _, err := m.tx.Exec(`SAVEPOINT ` + name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(m.tx); err != nil {
+		if _, rbErr := m.tx.Exec(`ROLLBACK TO SAVEPOINT ` + name); rbErr != nil {
+			return fmt.Errorf("failed to rollback to savepoint: %v (original error: %w)", rbErr, err)
+		}
+		return err
+	}
+
+	if _, err := m.tx.Exec(`RELEASE SAVEPOINT ` + name); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return nil
+}
+
+// ErrWorkspaceRequired はワークスペースを指定せずにワークスペース単位のデータ（Todo・プロジェクト・タグ）にアクセスした場合のエラー
+var ErrWorkspaceRequired = errors.New("workspace is required")
+
+// workspaceDB は workspaceID のワークスペースに限定してクエリを実行する
+// 各クエリの前に RLS のポリシー（migrations/000019 参照）が参照する app.workspace_id をトランザクション内で設定する
+// トランザクション外ではクエリごとにトランザクションを開始し、設定がプールのコネクションに残らないようにする
+// workspaceID が設定されていない場合はクエリを実行せずに ErrWorkspaceRequired を返す
+type workspaceDB struct {
+	db          DBTX
+	workspaceID int
+}
+
+func (w workspaceDB) Get(dest interface{}, query string, args ...interface{}) error {
+	return w.run(func(db DBTX) error {
+		return db.Get(dest, query, args...)
+	})
+}
+
+func (w workspaceDB) Select(dest interface{}, query string, args ...interface{}) error {
+	return w.run(func(db DBTX) error {
+		return db.Select(dest, query, args...)
+	})
+}
+
+func (w workspaceDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := w.run(func(db DBTX) error {
+		var err error
+		result, err = db.Exec(query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (w workspaceDB) run(fn func(db DBTX) error) error {
+	if w.workspaceID <= 0 {
+		return ErrWorkspaceRequired
+	}
+	if db, ok := w.db.(*sqlx.DB); ok {
+		return NewTxManager(db).WithinTx(func(tx DBTX) error {
+			return w.withWorkspace(tx, fn)
+		})
+	}
+	return w.withWorkspace(w.db, fn)
+}
+
+func (w workspaceDB) withWorkspace(tx DBTX, fn func(db DBTX) error) error {
+	if _, err := tx.Exec(`SELECT set_config('app.workspace_id', $1, true)`, strconv.Itoa(w.workspaceID)); err != nil {
+		return fmt.Errorf("failed to set workspace: %w", err)
+	}
+	return fn(tx)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrDuplicateIdentity は同じ IdP のアカウントが既に紐付けられている場合のエラー
+var ErrDuplicateIdentity = errors.New("duplicate identity")
+
+type UserIdentityRepository interface {
+	GetByIssuerAndSubject(issuer, subject string) (*models.UserIdentity, error)
+	Create(identity *models.UserIdentity) (*models.UserIdentity, error)
+	// TouchLastLogin は最終ログイン日時と IdP のメールアドレスを記録する
+	TouchLastLogin(id int, email *string, now time.Time) error
+
+	// WithTx はトランザクション内でクエリを実行する UserIdentityRepository を返す
+	WithTx(tx DBTX) UserIdentityRepository
+}
+
+// userIdentityColumns は SELECT / RETURNING で取得する user_identities のカラム
+const userIdentityColumns = `id, user_id, issuer, subject, email, last_login_at, created_at`
+
+type userIdentityRepository struct {
+	db DBTX
+}
+
+func NewUserIdentityRepository(db DBTX) UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+func (r *userIdentityRepository) WithTx(tx DBTX) UserIdentityRepository {
+	return &userIdentityRepository{db: tx}
+}
+
+func (r *userIdentityRepository) GetByIssuerAndSubject(issuer, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	query := `SELECT ` + userIdentityColumns + ` FROM user_identities WHERE issuer = $1 AND subject = $2`
+	if err := r.db.Get(&identity, query, issuer, subject); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch user identity: %w", err)
+	}
+	return &identity, nil
+}
+
+func (r *userIdentityRepository) Create(identity *models.UserIdentity) (*models.UserIdentity, error) {
+	var created models.UserIdentity
+	// 一意制約違反でトランザクションが中断しないよう、重複時は挿入せずに判定する
+	query := `
+		INSERT INTO user_identities (user_id, issuer, subject, email, last_login_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+		ON CONFLICT (issuer, subject) DO NOTHING
+		RETURNING ` + userIdentityColumns
+
+	err := r.db.QueryRowx(query, identity.UserID, identity.Issuer, identity.Subject, identity.Email, identity.LastLoginAt).StructScan(&created)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDuplicateIdentity
+		}
+		return nil, fmt.Errorf("failed to create user identity: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *userIdentityRepository) TouchLastLogin(id int, email *string, now time.Time) error {
+	query := `UPDATE user_identities SET last_login_at = $1, email = COALESCE($2, email) WHERE id = $3`
+	if _, err := r.db.Exec(query, now, email, id); err != nil {
+		return fmt.Errorf("failed to update user identity: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrDuplicateEmail は同じメールアドレスのユーザーが既に存在する場合のエラー
+var ErrDuplicateEmail = errors.New("duplicate email")
+
+type UserRepository interface {
+	GetAll() ([]models.User, error)
+	GetByID(id int) (*models.User, error)
+	GetByEmail(email string) (*models.User, error)
+	Create(user *models.User) (*models.User, error)
+	// Update はユーザーを更新する（メールアドレスを変更した場合は確認済みの日時を消す）
+	Update(id int, update models.UserUpdate) (*models.User, error)
+	Delete(id int) error
+	// GetPasswordHash はパスワードのハッシュを返す（パスワード未設定の場合は空文字）
+	GetPasswordHash(id int) (string, error)
+	// SetPasswordHash はパスワードのハッシュを保存する（ユーザーが存在しない場合は sql.ErrNoRows）
+	SetPasswordHash(id int, hash string) error
+	// MarkEmailVerified はメールアドレスを確認済みにする（確認済みの場合は最初に確認した日時のまま）
+	MarkEmailVerified(id int, now time.Time) error
+
+	// WithTx はトランザクション内でクエリを実行する UserRepository を返す
+	WithTx(tx DBTX) UserRepository
+}
+
+// userColumns は SELECT / RETURNING で取得する users のカラム
+const userColumns = `id, name, email, email_verified_at, created_at, updated_at`
+
+type userRepository struct {
+	db DBTX
+}
+
+func NewUserRepository(db DBTX) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) WithTx(tx DBTX) UserRepository {
+	return &userRepository{db: tx}
+}
+
+func (r *userRepository) GetAll() ([]models.User, error) {
+	var users []models.User
+	query := `SELECT ` + userColumns + ` FROM users ORDER BY id ASC`
+	if err := r.db.Select(&users, query); err != nil {
+		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	}
+	return users, nil
+}
+
+func (r *userRepository) GetByID(id int) (*models.User, error) {
+	return r.getOne(`SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+}
+
+func (r *userRepository) GetByEmail(email string) (*models.User, error) {
+	return r.getOne(`SELECT `+userColumns+` FROM users WHERE email = $1`, email)
+}
+
+func (r *userRepository) getOne(query string, args ...interface{}) (*models.User, error) {
+	var user models.User
+	if err := r.db.Get(&user, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Create(user *models.User) (*models.User, error) {
+	var created models.User
+	// 一意制約違反でトランザクションが中断しないよう、重複時は挿入せずに判定する
+	query := `
+		INSERT INTO users (name, email, email_verified_at, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (email) DO NOTHING
+		RETURNING ` + userColumns
+
+	if err := r.db.QueryRowx(query, user.Name, user.Email, user.EmailVerifiedAt).StructScan(&created); err != nil {
+		if err == sql.ErrNoRows || isUniqueViolation(err) {
+			return nil, ErrDuplicateEmail
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *userRepository) Update(id int, update models.UserUpdate) (*models.User, error) {
+	query := `UPDATE users SET updated_at = CURRENT_TIMESTAMP`
+	args := []interface{}{}
+	argCount := 1
+
+	if update.Name != "" {
+		query += fmt.Sprintf(`, name = $%d`, argCount)
+		args = append(args, update.Name)
+		argCount++
+	}
+
+	if update.Email != "" {
+		// 変更後のメールアドレスは確認されていないため、確認済みの日時を消す（変わらない場合は残す）
+		query += fmt.Sprintf(`, email = $%d, email_verified_at = CASE WHEN email = $%d THEN email_verified_at END`, argCount, argCount)
+		args = append(args, update.Email)
+		argCount++
+	}
+
+	query += fmt.Sprintf(` WHERE id = $%d`, argCount)
+	args = append(args, id)
+	if update.Email != "" {
+		query += fmt.Sprintf(` AND NOT EXISTS (SELECT 1 FROM users WHERE email = $%d AND id <> $%d)`, argCount-1, argCount)
+	}
+	query += ` RETURNING ` + userColumns
+
+	var updated models.User
+	if err := r.db.QueryRowx(query, args...).StructScan(&updated); err != nil {
+		if err == sql.ErrNoRows {
+			// 対象が存在しないのか、メールアドレスが重複しているのかを区別する
+			existing, getErr := r.GetByID(id)
+			if getErr != nil {
+				return nil, getErr
+			}
+			if existing != nil {
+				return nil, ErrDuplicateEmail
+			}
+			return nil, nil
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateEmail
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return &updated, nil
+}
+
+func (r *userRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *userRepository) GetPasswordHash(id int) (string, error) {
+	var hash sql.NullString
+	if err := r.db.Get(&hash, `SELECT password_hash FROM users WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to fetch password hash: %w", err)
+	}
+	return hash.String, nil
+}
+
+func (r *userRepository) SetPasswordHash(id int, hash string) error {
+	query := `UPDATE users SET password_hash = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+	result, err := r.db.Exec(query, hash, id)
+	if err != nil {
+		return fmt.Errorf("failed to set password hash: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *userRepository) MarkEmailVerified(id int, now time.Time) error {
+	query := `UPDATE users SET email_verified_at = COALESCE(email_verified_at, $1) WHERE id = $2`
+	if _, err := r.db.Exec(query, now, id); err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+	return nil
+}
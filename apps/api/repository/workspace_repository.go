@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"api/app/models"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateWorkspaceMember はユーザーが既にワークスペースのメンバーである場合のエラー
+var ErrDuplicateWorkspaceMember = errors.New("duplicate workspace member")
+
+type WorkspaceRepository interface {
+	// GetAllIDs は全てのワークスペースのIDを返す（ワークスペースごとに実行するバックグラウンド処理で使う）
+	GetAllIDs() ([]int, error)
+	// GetByMemberID はユーザーが所属するワークスペースをロールとともに作成順に取得する
+	GetByMemberID(userID int) ([]models.Workspace, error)
+	// GetMemberRole はユーザーのロールを返す（メンバーでない場合は空文字）
+	GetMemberRole(workspaceID int, userID int) (models.WorkspaceRole, error)
+	Create(workspace *models.Workspace) (*models.Workspace, error)
+
+	// メンバー
+	GetMembers(workspaceID int) ([]models.WorkspaceMember, error)
+	AddMember(workspaceID int, userID int, role models.WorkspaceRole) (*models.WorkspaceMember, error)
+
+	// WithTx はトランザクション内でクエリを実行する WorkspaceRepository を返す
+	WithTx(tx DBTX) WorkspaceRepository
+}
+
+// workspaceColumns は SELECT / RETURNING で取得する workspaces のカラム
+const workspaceColumns = `id, name, created_at, updated_at`
+
+// workspaceMemberColumns は SELECT で取得する workspace_members のカラム（users を u として JOIN する）
+const workspaceMemberColumns = `m.workspace_id, m.user_id, m.role, m.created_at, u.name AS user_name, u.email AS user_email`
+
+type workspaceRepository struct {
+	db DBTX
+}
+
+func NewWorkspaceRepository(db DBTX) WorkspaceRepository {
+	return &workspaceRepository{db: db}
+}
+
+func (r *workspaceRepository) WithTx(tx DBTX) WorkspaceRepository {
+	return &workspaceRepository{db: tx}
+}
+
+func (r *workspaceRepository) GetAllIDs() ([]int, error) {
+	var ids []int
+	if err := r.db.Select(&ids, `SELECT id FROM workspaces ORDER BY id ASC`); err != nil {
+		return nil, fmt.Errorf("failed to fetch workspaces: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *workspaceRepository) GetByMemberID(userID int) ([]models.Workspace, error) {
+	var workspaces []models.Workspace
+	query := `
+		SELECT w.id, w.name, w.created_at, w.updated_at, m.role
+		FROM workspaces w JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = $1
+		ORDER BY w.created_at ASC, w.id ASC`
+	if err := r.db.Select(&workspaces, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to fetch workspaces: %w", err)
+	}
+	return workspaces, nil
+}
+
+func (r *workspaceRepository) GetMemberRole(workspaceID int, userID int) (models.WorkspaceRole, error) {
+	var role models.WorkspaceRole
+	query := `SELECT role FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`
+	if err := r.db.Get(&role, query, workspaceID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to fetch workspace member role: %w", err)
+	}
+	return role, nil
+}
+
+func (r *workspaceRepository) Create(workspace *models.Workspace) (*models.Workspace, error) {
+	var created models.Workspace
+	query := `
+		INSERT INTO workspaces (name, created_at, updated_at)
+		VALUES ($1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING ` + workspaceColumns
+
+	if err := r.db.Get(&created, query, workspace.Name); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *workspaceRepository) GetMembers(workspaceID int) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	query := `
+		SELECT ` + workspaceMemberColumns + `
+		FROM workspace_members m JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = $1
+		ORDER BY m.created_at ASC, m.user_id ASC`
+	if err := r.db.Select(&members, query, workspaceID); err != nil {
+		return nil, fmt.Errorf("failed to fetch workspace members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *workspaceRepository) AddMember(workspaceID int, userID int, role models.WorkspaceRole) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+	// 一意制約違反でトランザクションが中断しないよう、重複時は挿入せずに判定する
+	query := `
+		WITH inserted AS (
+			INSERT INTO workspace_members (workspace_id, user_id, role, created_at)
+			VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+			ON CONFLICT (workspace_id, user_id) DO NOTHING
+			RETURNING *
+		)
+		SELECT ` + workspaceMemberColumns + ` FROM inserted m JOIN users u ON u.id = m.user_id`
+
+	if err := r.db.Get(&member, query, workspaceID, userID, role); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDuplicateWorkspaceMember
+		}
+		return nil, fmt.Errorf("failed to add workspace member: %w", err)
+	}
+	return &member, nil
+}
@@ -0,0 +1,234 @@
+package test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeOIDCUser は FakeOIDCProvider でログインするユーザー
+type FakeOIDCUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// FakeOIDCProvider はテスト用のプロセス内 OpenID Connect IdP
+// ディスカバリー・JWKS・トークンエンドポイントをローカルの httptest.Server で提供し、
+// 認可エンドポイントでのログインは Authorize で代わりに行う
+type FakeOIDCProvider struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	mu    sync.Mutex
+	key   *rsa.PrivateKey
+	keyID string
+	codes map[string]fakeAuthCode
+}
+
+type fakeAuthCode struct {
+	user          FakeOIDCUser
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// NewFakeOIDCProvider は IdP を起動する（テストの終了時に Close を呼ぶ）
+func NewFakeOIDCProvider(clientID, clientSecret string) *FakeOIDCProvider {
+	p := &FakeOIDCProvider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		codes:        map[string]fakeAuthCode{},
+	}
+	p.RotateKey()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("/jwks", p.handleJWKS)
+	mux.HandleFunc("/token", p.handleToken)
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+// Issuer は IdP の issuer（サーバーの URL）を返す
+func (p *FakeOIDCProvider) Issuer() string {
+	return p.Server.URL
+}
+
+func (p *FakeOIDCProvider) Close() {
+	p.Server.Close()
+}
+
+// RotateKey は署名鍵を新しい kid の鍵に置き換える
+func (p *FakeOIDCProvider) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to generate RSA key: %v", err))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.key = key
+	p.keyID = randomString()
+}
+
+// Authorize は authURL（認可エンドポイントの URL）で user がログインしたものとして、リダイレクト先に渡す state と code を返す
+func (p *FakeOIDCProvider) Authorize(authURL string, user FakeOIDCUser) (state string, code string, err error) {
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	query := parsed.Query()
+	if parsed.Path != "/authorize" || query.Get("response_type") != "code" || query.Get("client_id") != p.ClientID {
+		return "", "", fmt.Errorf("unexpected authorization request: %s", authURL)
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		return "", "", fmt.Errorf("PKCE S256 is required: %s", authURL)
+	}
+	if !strings.Contains(" "+query.Get("scope")+" ", " openid ") {
+		return "", "", fmt.Errorf("openid scope is required: %s", authURL)
+	}
+
+	code = randomString()
+	p.mu.Lock()
+	p.codes[code] = fakeAuthCode{
+		user:          user,
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	p.mu.Unlock()
+	return query.Get("state"), code, nil
+}
+
+func (p *FakeOIDCProvider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.Issuer(),
+		"authorization_endpoint":                p.Issuer() + "/authorize",
+		"token_endpoint":                        p.Issuer() + "/token",
+		"jwks_uri":                              p.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *FakeOIDCProvider) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	publicKey := p.key.PublicKey
+	keyID := p.keyID
+	p.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *FakeOIDCProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != url.QueryEscape(p.ClientID) || clientSecret != url.QueryEscape(p.ClientSecret) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	// 認可コードは1回のみ使える
+	p.mu.Lock()
+	authCode, found := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if r.PostForm.Get("grant_type") != "authorization_code" || !found ||
+		r.PostForm.Get("redirect_uri") != authCode.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != authCode.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken, err := p.sign(map[string]interface{}{
+		"iss":            p.Issuer(),
+		"sub":            authCode.user.Subject,
+		"aud":            p.ClientID,
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          authCode.nonce,
+		"email":          authCode.user.Email,
+		"email_verified": authCode.user.EmailVerified,
+		"name":           authCode.user.Name,
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+// sign は claims を RS256 で署名した ID トークンを返す
+func (p *FakeOIDCProvider) sign(claims map[string]interface{}) (string, error) {
+	p.mu.Lock()
+	key := p.key
+	keyID := p.keyID
+	p.mu.Unlock()
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("Failed to generate random string: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
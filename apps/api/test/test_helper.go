@@ -89,9 +89,6 @@ func SetupTestDB() (*sqlx.DB, func()) {
 		panic(fmt.Sprintf("Failed to ping test database: %v", err))
 	}
 
-	// Clean all test tables at the start of each test to ensure isolation
-	cleanupTables(db)
-
 	cleanup := func() {
 		if err := db.Close(); err != nil {
 			log.Printf("Failed to close test database: %v", err)
@@ -101,16 +98,6 @@ func SetupTestDB() (*sqlx.DB, func()) {
 	return db, cleanup
 }
 
-// cleanupTables removes all data from test tables
-func cleanupTables(db *sqlx.DB) {
-	tables := []string{"todos"}
-	for _, table := range tables {
-		_, err := db.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", table))
-		if err != nil {
-			log.Printf("Warning: Failed to truncate table %s: %v", table, err)
-		}
-	}
-}
-
-// Note: With txdb, we don't need TruncateTables because each test runs in an isolated transaction
-// that is automatically rolled back at the end of the test
+// Note: With txdb, we don't need to truncate tables because each test runs in an isolated transaction
+// that is automatically rolled back at the end of the test.
+// Tests connect as the API's role, which is not allowed to TRUNCATE (it would bypass row-level security).